@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -43,15 +44,32 @@ type TelemetrySpec struct {
 
 	// +optional
 	Metric *MetricSpec `json:"metric,omitempty"`
+
+	// +optional
+	Log *LogSpec `json:"log,omitempty"`
+
+	// +optional
+	SelfMonitor *SelfMonitorSpec `json:"selfMonitor,omitempty"`
 }
 
 // MetricSpec defines the behavior of the metric gateway
 type MetricSpec struct {
 	Gateway MetricGatewaySpec `json:"gateway,omitempty"`
+
+	// +optional
+	Agent *MetricAgentSpec `json:"agent,omitempty"`
 }
 
 type MetricGatewaySpec struct {
 	Scaling Scaling `json:"scaling,omitempty"`
+
+	// Resources overrides the CPU and memory requests and limits of the gateway.
+	// +optional
+	Resources *GatewayResources `json:"resources,omitempty"`
+
+	// PersistentQueue stores the sending queues of the gateway on a volume instead of in memory.
+	// +optional
+	PersistentQueue *PersistentQueue `json:"persistentQueue,omitempty"`
 }
 
 // TraceSpec defines the behavior of the trace gateway
@@ -61,6 +79,182 @@ type TraceSpec struct {
 
 type TraceGatewaySpec struct {
 	Scaling Scaling `json:"scaling,omitempty"`
+
+	// Resources overrides the CPU and memory requests and limits of the gateway.
+	// +optional
+	Resources *GatewayResources `json:"resources,omitempty"`
+
+	// PersistentQueue stores the sending queues of the gateway on a volume instead of in memory.
+	// +optional
+	PersistentQueue *PersistentQueue `json:"persistentQueue,omitempty"`
+}
+
+// MetricAgentSpec defines the behavior of the metric agent
+type MetricAgentSpec struct {
+	// Resources overrides the CPU and memory requests and limits of the agent.
+	// +optional
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+// LogSpec defines the behavior of the log agent
+type LogSpec struct {
+	// +optional
+	FluentBit *FluentBitSpec `json:"fluentBit,omitempty"`
+}
+
+// FluentBitSpec defines the behavior of the Fluent Bit agent
+type FluentBitSpec struct {
+	// Resources overrides the CPU and memory requests and limits of Fluent Bit.
+	// +optional
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+// SelfMonitorSpec defines the behavior of the self-monitor
+type SelfMonitorSpec struct {
+	// Alerting forwards the alerts of the self-monitor to an external Alertmanager.
+	// +optional
+	Alerting *SelfMonitorAlerting `json:"alerting,omitempty"`
+
+	// Rules overrides the thresholds and durations of the alerting rules of the self-monitor.
+	// +optional
+	Rules *SelfMonitorRules `json:"rules,omitempty"`
+}
+
+// SelfMonitorRules defines the thresholds and durations of the alerting rules of the self-monitor.
+type SelfMonitorRules struct {
+	// RateWindow is the time window over which the rates of exported, dropped, and refused data are computed. Default is 5m.
+	// +optional
+	RateWindow *metav1.Duration `json:"rateWindow,omitempty"`
+
+	// For is the time for which the condition of a rule must be met before the alert fires. Default is 0, so alerts fire at the first evaluation.
+	// +optional
+	For *metav1.Duration `json:"for,omitempty"`
+
+	// LogAgentBufferInUse defines when the filesystem buffer of the log agent is considered in use. The threshold is a percentage of the filesystem buffer limit. Default is 30.
+	// +optional
+	LogAgentBufferInUse *SelfMonitorThresholdRule `json:"logAgentBufferInUse,omitempty"`
+
+	// LogAgentBufferFull defines when the filesystem buffer of the log agent is considered full. The threshold is a percentage of the filesystem buffer limit. Default is 90.
+	// +optional
+	LogAgentBufferFull *SelfMonitorThresholdRule `json:"logAgentBufferFull,omitempty"`
+
+	// GatewayExporterQueueAlmostFull defines when the sending queue of a gateway exporter is considered almost full. The threshold is a percentage of the queue capacity. Default is 80.
+	// +optional
+	GatewayExporterQueueAlmostFull *SelfMonitorThresholdRule `json:"gatewayExporterQueueAlmostFull,omitempty"`
+}
+
+// SelfMonitorThresholdRule defines a rule that fires when a usage exceeds a percentage of the capacity.
+type SelfMonitorThresholdRule struct {
+	// ThresholdPercent is the usage, relative to the capacity, above which the rule fires. It must be lower than 100, because the usage can't exceed the capacity.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	ThresholdPercent int32 `json:"thresholdPercent,omitempty"`
+
+	// For overrides the time for which the condition of the rule must be met before the alert fires.
+	// +optional
+	For *metav1.Duration `json:"for,omitempty"`
+}
+
+// SelfMonitorAlerting defines an Alertmanager-compatible endpoint, to which the alerts of the self-monitor are forwarded.
+type SelfMonitorAlerting struct {
+	// Endpoint is the base URL of the Alertmanager. The alerts are sent to the path `/api/v2/alerts` of the endpoint.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// Labels are added to every forwarded alert. They override the labels of the alert with the same name.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// RepeatInterval is the minimum time after which a firing alert is forwarded again. Default is 15m.
+	// It also applies to the Kubernetes Events that are recorded on the affected pipelines.
+	// +optional
+	RepeatInterval *metav1.Duration `json:"repeatInterval,omitempty"`
+
+	// Authentication defines the credentials that are sent to the Alertmanager.
+	// +optional
+	Authentication *AlertingAuthentication `json:"authentication,omitempty"`
+
+	// TLS defines the certificates for the connection to the Alertmanager, if the endpoint uses HTTPS.
+	// +optional
+	TLS *AlertingTLS `json:"tls,omitempty"`
+}
+
+// AlertingAuthentication defines the credentials for the Alertmanager. Only one authentication method can be used.
+// +kubebuilder:validation:XValidation:rule="!(has(self.basic) && has(self.bearerToken))",message="Only one authentication method can be used"
+type AlertingAuthentication struct {
+	// Basic activates basic authentication with the username and password of the referenced Secrets.
+	// +optional
+	Basic *AlertingBasicAuth `json:"basic,omitempty"`
+
+	// BearerToken activates bearer authentication with the token of the referenced Secret.
+	// +optional
+	BearerToken *SecretKeyRef `json:"bearerToken,omitempty"`
+}
+
+// AlertingBasicAuth defines the username and password for basic authentication.
+type AlertingBasicAuth struct {
+	// +kubebuilder:validation:Required
+	User SecretKeyRef `json:"user"`
+
+	// +kubebuilder:validation:Required
+	Password SecretKeyRef `json:"password"`
+}
+
+// AlertingTLS defines the certificates for the connection to the Alertmanager. The certificates and the key must be provided in PEM format.
+// +kubebuilder:validation:XValidation:rule="has(self.cert) == has(self.key)",message="Client certificate and key must be set together"
+type AlertingTLS struct {
+	// CA is the CA certificate, with which the server certificate is verified. If not set, the system CAs are used.
+	// +optional
+	CA *SecretKeyRef `json:"ca,omitempty"`
+
+	// Cert is the client certificate.
+	// +optional
+	Cert *SecretKeyRef `json:"cert,omitempty"`
+
+	// Key is the client key.
+	// +optional
+	Key *SecretKeyRef `json:"key,omitempty"`
+}
+
+// SecretKeyRef refers to the value of a key in a Secret.
+type SecretKeyRef struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// GatewayResources defines the CPU and memory requests and limits of a gateway replica.
+// The resources of a replica are the base values plus the per-pipeline values multiplied by the number of pipelines.
+type GatewayResources struct {
+	ResourceRequirements `json:",inline"`
+
+	// PerPipeline defines the requests and limits that are added for every pipeline.
+	// +optional
+	PerPipeline *ResourceRequirements `json:"perPipeline,omitempty"`
+}
+
+// ResourceRequirements defines CPU and memory requests and limits. Values that are not set keep the defaults of Telemetry Manager.
+type ResourceRequirements struct {
+	// +optional
+	Limits *ComputeResources `json:"limits,omitempty"`
+
+	// +optional
+	Requests *ComputeResources `json:"requests,omitempty"`
+}
+
+type ComputeResources struct {
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
 }
 
 // Scaling defines which strategy is used for scaling the gateway, with detailed configuration options for each strategy type.
@@ -68,20 +262,44 @@ type Scaling struct {
 	// Type of scaling strategy. Default is none, using a fixed amount of replicas.
 	// +optional
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=Static
+	// +kubebuilder:validation:Enum=Static;Dynamic
 	Type ScalingStrategyType `json:"type,omitempty"`
 
 	// Static is a scaling strategy enabling you to define a custom amount of replicas to be used for the gateway. Present only if Type =
 	// StaticScalingStrategyType.
 	// +optional
 	Static *StaticScaling `json:"static,omitempty"`
+
+	// Dynamic is a scaling strategy that adjusts the amount of replicas to the load of the gateway using a HorizontalPodAutoscaler. Present only if Type =
+	// DynamicScalingStrategyType.
+	// +optional
+	Dynamic *DynamicScaling `json:"dynamic,omitempty"`
+}
+
+// PersistentQueue defines a sending queue that is stored on a PersistentVolume of each gateway replica, so that buffered data is not lost when the collector restarts or is rescheduled.
+// With a persistent queue, the gateway runs as a StatefulSet with a PersistentVolumeClaim per replica.
+type PersistentQueue struct {
+	// Enabled activates the persistent queue. Default is false.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Size defines the size of the PersistentVolumeClaim of each gateway replica. The sending queues use at most half of it, the rest is needed to compact them. Default is 1Gi.
+	// A larger size expands the existing volumes if the StorageClass allows it; volumes are never shrunk.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName defines the StorageClass of the PersistentVolumeClaims. If not set, the default StorageClass of the cluster is used.
+	// Changing the StorageClass applies only to volumes of new replicas; existing volumes keep their StorageClass.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
 }
 
 // +enum
 type ScalingStrategyType string
 
 const (
-	StaticScalingStrategyType ScalingStrategyType = "Static"
+	StaticScalingStrategyType  ScalingStrategyType = "Static"
+	DynamicScalingStrategyType ScalingStrategyType = "Dynamic"
 )
 
 type StaticScaling struct {
@@ -90,6 +308,32 @@ type StaticScaling struct {
 	Replicas int32 `json:"replicas,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || !has(self.maxReplicas) || self.minReplicas <= self.maxReplicas", message="minReplicas must not be greater than maxReplicas"
+type DynamicScaling struct {
+	// MinReplicas defines the lowest number of pods to run the gateway. Default is 2.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas defines the highest number of pods to run the gateway. Default is 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// TargetCPUUtilizationPercentage defines the average CPU utilization of the gateway pods, relative to their requested CPU, at which the gateway is scaled. Default is 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TargetCPUUtilizationPercentage int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage defines the average memory utilization of the gateway pods, relative to their requested memory, at which the gateway is scaled.
+	// If not set, memory utilization is not considered.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TargetMemoryUtilizationPercentage int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
 // TelemetryStatus defines the observed state of Telemetry
 type TelemetryStatus struct {
 	Status `json:",inline"`
@@ -101,9 +345,20 @@ type TelemetryStatus struct {
 	// endpoints for trace and metric gateway.
 	// +nullable
 	GatewayEndpoints GatewayEndpoints `json:"endpoints,omitempty"`
+
+	// resources contains the effective CPU and memory requests and limits of the deployed components.
+	// +optional
+	Resources *ComponentResources `json:"resources,omitempty"`
 	// add other fields to status subresource here
 }
 
+type ComponentResources struct {
+	TraceGateway  *ResourceRequirements `json:"traceGateway,omitempty"`
+	MetricGateway *ResourceRequirements `json:"metricGateway,omitempty"`
+	MetricAgent   *ResourceRequirements `json:"metricAgent,omitempty"`
+	FluentBit     *ResourceRequirements `json:"fluentBit,omitempty"`
+}
+
 type GatewayEndpoints struct {
 	//traces contains the endpoints for trace gateway supporting OTLP.
 	Traces *OTLPEndpoints `json:"traces,omitempty"`
@@ -25,6 +25,173 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingAuthentication) DeepCopyInto(out *AlertingAuthentication) {
+	*out = *in
+	if in.Basic != nil {
+		in, out := &in.Basic, &out.Basic
+		*out = new(AlertingBasicAuth)
+		**out = **in
+	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingAuthentication.
+func (in *AlertingAuthentication) DeepCopy() *AlertingAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingBasicAuth) DeepCopyInto(out *AlertingBasicAuth) {
+	*out = *in
+	out.User = in.User
+	out.Password = in.Password
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingBasicAuth.
+func (in *AlertingBasicAuth) DeepCopy() *AlertingBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingTLS) DeepCopyInto(out *AlertingTLS) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingTLS.
+func (in *AlertingTLS) DeepCopy() *AlertingTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentResources) DeepCopyInto(out *ComponentResources) {
+	*out = *in
+	if in.TraceGateway != nil {
+		in, out := &in.TraceGateway, &out.TraceGateway
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricGateway != nil {
+		in, out := &in.MetricGateway, &out.MetricGateway
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricAgent != nil {
+		in, out := &in.MetricAgent, &out.MetricAgent
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FluentBit != nil {
+		in, out := &in.FluentBit, &out.FluentBit
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentResources.
+func (in *ComponentResources) DeepCopy() *ComponentResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComputeResources) DeepCopyInto(out *ComputeResources) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComputeResources.
+func (in *ComputeResources) DeepCopy() *ComputeResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ComputeResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicScaling) DeepCopyInto(out *DynamicScaling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicScaling.
+func (in *DynamicScaling) DeepCopy() *DynamicScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentBitSpec) DeepCopyInto(out *FluentBitSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentBitSpec.
+func (in *FluentBitSpec) DeepCopy() *FluentBitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentBitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayEndpoints) DeepCopyInto(out *GatewayEndpoints) {
 	*out = *in
@@ -50,10 +217,81 @@ func (in *GatewayEndpoints) DeepCopy() *GatewayEndpoints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayResources) DeepCopyInto(out *GatewayResources) {
+	*out = *in
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.PerPipeline != nil {
+		in, out := &in.PerPipeline, &out.PerPipeline
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayResources.
+func (in *GatewayResources) DeepCopy() *GatewayResources {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSpec) DeepCopyInto(out *LogSpec) {
+	*out = *in
+	if in.FluentBit != nil {
+		in, out := &in.FluentBit, &out.FluentBit
+		*out = new(FluentBitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSpec.
+func (in *LogSpec) DeepCopy() *LogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricAgentSpec) DeepCopyInto(out *MetricAgentSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricAgentSpec.
+func (in *MetricAgentSpec) DeepCopy() *MetricAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricGatewaySpec) DeepCopyInto(out *MetricGatewaySpec) {
 	*out = *in
 	in.Scaling.DeepCopyInto(&out.Scaling)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(GatewayResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentQueue != nil {
+		in, out := &in.PersistentQueue, &out.PersistentQueue
+		*out = new(PersistentQueue)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricGatewaySpec.
@@ -70,6 +308,11 @@ func (in *MetricGatewaySpec) DeepCopy() *MetricGatewaySpec {
 func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
 	*out = *in
 	in.Gateway.DeepCopyInto(&out.Gateway)
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(MetricAgentSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSpec.
@@ -97,6 +340,56 @@ func (in *OTLPEndpoints) DeepCopy() *OTLPEndpoints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentQueue) DeepCopyInto(out *PersistentQueue) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentQueue.
+func (in *PersistentQueue) DeepCopy() *PersistentQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(ComputeResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = new(ComputeResources)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRequirements.
+func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scaling) DeepCopyInto(out *Scaling) {
 	*out = *in
@@ -105,6 +398,11 @@ func (in *Scaling) DeepCopyInto(out *Scaling) {
 		*out = new(StaticScaling)
 		**out = **in
 	}
+	if in.Dynamic != nil {
+		in, out := &in.Dynamic, &out.Dynamic
+		*out = new(DynamicScaling)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scaling.
@@ -117,6 +415,143 @@ func (in *Scaling) DeepCopy() *Scaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfMonitorAlerting) DeepCopyInto(out *SelfMonitorAlerting) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RepeatInterval != nil {
+		in, out := &in.RepeatInterval, &out.RepeatInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(AlertingAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(AlertingTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfMonitorAlerting.
+func (in *SelfMonitorAlerting) DeepCopy() *SelfMonitorAlerting {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfMonitorAlerting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfMonitorRules) DeepCopyInto(out *SelfMonitorRules) {
+	*out = *in
+	if in.RateWindow != nil {
+		in, out := &in.RateWindow, &out.RateWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LogAgentBufferInUse != nil {
+		in, out := &in.LogAgentBufferInUse, &out.LogAgentBufferInUse
+		*out = new(SelfMonitorThresholdRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogAgentBufferFull != nil {
+		in, out := &in.LogAgentBufferFull, &out.LogAgentBufferFull
+		*out = new(SelfMonitorThresholdRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GatewayExporterQueueAlmostFull != nil {
+		in, out := &in.GatewayExporterQueueAlmostFull, &out.GatewayExporterQueueAlmostFull
+		*out = new(SelfMonitorThresholdRule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfMonitorRules.
+func (in *SelfMonitorRules) DeepCopy() *SelfMonitorRules {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfMonitorRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfMonitorSpec) DeepCopyInto(out *SelfMonitorSpec) {
+	*out = *in
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(SelfMonitorAlerting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = new(SelfMonitorRules)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfMonitorSpec.
+func (in *SelfMonitorSpec) DeepCopy() *SelfMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelfMonitorThresholdRule) DeepCopyInto(out *SelfMonitorThresholdRule) {
+	*out = *in
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfMonitorThresholdRule.
+func (in *SelfMonitorThresholdRule) DeepCopy() *SelfMonitorThresholdRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SelfMonitorThresholdRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticScaling) DeepCopyInto(out *StaticScaling) {
 	*out = *in
@@ -219,6 +654,16 @@ func (in *TelemetrySpec) DeepCopyInto(out *TelemetrySpec) {
 		*out = new(MetricSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = new(LogSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelfMonitor != nil {
+		in, out := &in.SelfMonitor, &out.SelfMonitor
+		*out = new(SelfMonitorSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetrySpec.
@@ -243,6 +688,11 @@ func (in *TelemetryStatus) DeepCopyInto(out *TelemetryStatus) {
 		}
 	}
 	in.GatewayEndpoints.DeepCopyInto(&out.GatewayEndpoints)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ComponentResources)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetryStatus.
@@ -259,6 +709,16 @@ func (in *TelemetryStatus) DeepCopy() *TelemetryStatus {
 func (in *TraceGatewaySpec) DeepCopyInto(out *TraceGatewaySpec) {
 	*out = *in
 	in.Scaling.DeepCopyInto(&out.Scaling)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(GatewayResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentQueue != nil {
+		in, out := &in.PersistentQueue, &out.PersistentQueue
+		*out = new(PersistentQueue)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TraceGatewaySpec.
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"fmt"
 	"net/url"
+	"path"
 	"regexp"
 	"strings"
 
@@ -16,6 +17,9 @@ func (lp *LogPipeline) Validate(vc *LogPipelineValidationConfig) error {
 	if err := lp.validateFilters(vc.DeniedFilterPlugins); err != nil {
 		return err
 	}
+	if err := lp.ValidateRedaction(); err != nil {
+		return err
+	}
 	return lp.validateInput()
 }
 
@@ -37,6 +41,14 @@ func (lp *LogPipeline) validateOutput(deniedOutputPlugins []string) error {
 		}
 	}
 
+	if output.IsOtlpDefined() {
+		if err := lp.validateOtlpOutput(); err != nil {
+			return err
+		}
+	} else if len(lp.Spec.AdditionalOutputs) > 0 {
+		return fmt.Errorf("additional outputs are only supported for a LogPipeline with OTLP output")
+	}
+
 	return validateCustomOutput(deniedOutputPlugins, output.Custom)
 }
 
@@ -50,6 +62,38 @@ func checkSingleOutputPlugin(output Output) error {
 	return nil
 }
 
+func (lp *LogPipeline) validateOtlpOutput() error {
+	if len(lp.Spec.Filters) > 0 {
+		return fmt.Errorf("filters are not supported for a LogPipeline with OTLP output")
+	}
+	if len(lp.Spec.Files) > 0 {
+		return fmt.Errorf("files are not supported for a LogPipeline with OTLP output")
+	}
+	if len(lp.Spec.Variables) > 0 {
+		return fmt.Errorf("variables are not supported for a LogPipeline with OTLP output")
+	}
+	if lp.Spec.Redaction != nil {
+		return fmt.Errorf("redaction is not supported for a LogPipeline with OTLP output")
+	}
+	// The logs of an OTLP pipeline are pushed by the applications, so there are no container logs to select
+	if !lp.Spec.Input.Application.isEmpty() {
+		return fmt.Errorf("application input is not supported for a LogPipeline with OTLP output")
+	}
+	if lp.Spec.Input.KubernetesEvents != nil {
+		return fmt.Errorf("kubernetes events input is not supported for a LogPipeline with OTLP output")
+	}
+	if lp.Spec.Input.System != nil {
+		return fmt.Errorf("system input is not supported for a LogPipeline with OTLP output")
+	}
+	return nil
+}
+
+func (ai ApplicationInput) isEmpty() bool {
+	return len(ai.Namespaces.Include) == 0 && len(ai.Namespaces.Exclude) == 0 && !ai.Namespaces.System &&
+		len(ai.Containers.Include) == 0 && len(ai.Containers.Exclude) == 0 &&
+		!ai.KeepAnnotations && !ai.DropLabels
+}
+
 func validateLokiOutput(lokiOutput *LokiOutput) error {
 	if lokiOutput.URL.Value != "" && !validURL(lokiOutput.URL.Value) {
 		return fmt.Errorf("invalid hostname '%s'", lokiOutput.URL.Value)
@@ -185,6 +229,21 @@ func validateCustomFilter(content string, deniedFilterPlugins []string) error {
 	return nil
 }
 
+// ValidateRedaction returns an error if a custom redaction pattern cannot be translated into a Lua pattern.
+// Besides the webhook, the reconciler uses it to skip pipelines that were created before the webhook was able to reject them.
+func (lp *LogPipeline) ValidateRedaction() error {
+	if lp.Spec.Redaction == nil {
+		return nil
+	}
+
+	for _, regex := range lp.Spec.Redaction.Custom {
+		if _, err := config.ConvertRegexToLuaPattern(regex); err != nil {
+			return fmt.Errorf("invalid redaction pattern: %w", err)
+		}
+	}
+	return nil
+}
+
 func (lp *LogPipeline) validateInput() error {
 	input := lp.Spec.Input
 	if !input.IsDefined() {
@@ -203,5 +262,55 @@ func (lp *LogPipeline) validateInput() error {
 		return fmt.Errorf("invalid log pipeline definition: Can only define one 'input.application.namespaces' selector - either 'include', 'exclude', or 'system'")
 	}
 
+	if events := input.KubernetesEvents; events != nil {
+		namespaces = events.Namespaces
+		if (len(namespaces.Include) > 0 && len(namespaces.Exclude) > 0) ||
+			(len(namespaces.Include) > 0 && namespaces.System) ||
+			(len(namespaces.Exclude) > 0 && namespaces.System) {
+			return fmt.Errorf("invalid log pipeline definition: Can only define one 'input.kubernetesEvents.namespaces' selector - either 'include', 'exclude', or 'system'")
+		}
+	}
+
+	if input.System != nil {
+		return validateSystemInput(input.System)
+	}
+
+	return nil
+}
+
+var systemdUnitRegex = regexp.MustCompile(`^[A-Za-z0-9:_.@\\-]+$`)
+
+func validateSystemInput(system *SystemInput) error {
+	for _, unit := range system.Units {
+		if !systemdUnitRegex.MatchString(unit) {
+			return fmt.Errorf("invalid log pipeline definition: 'input.system.units' contains the invalid unit name '%s'", unit)
+		}
+	}
+
+	for _, file := range system.Files {
+		if !strings.HasPrefix(file, "/var/log/") || strings.Contains(file, "..") || strings.ContainsAny(file, ", \t\n\r") {
+			return fmt.Errorf("invalid log pipeline definition: 'input.system.files' contains '%s', which is not a path in the /var/log directory", file)
+		}
+		if selectsContainerLogs(file) {
+			return fmt.Errorf("invalid log pipeline definition: 'input.system.files' contains '%s', which selects container logs", file)
+		}
+	}
+
 	return nil
 }
+
+// selectsContainerLogs returns true if the glob pattern can match a file in the /var/log/containers or /var/log/pods directories,
+// for example, /var/log/*/*.log. Invalid patterns are treated as if they matched, because their expansion cannot be predicted.
+func selectsContainerLogs(file string) bool {
+	components := strings.Split(strings.TrimPrefix(file, "/var/log/"), "/")
+	if len(components) < 2 {
+		return false
+	}
+
+	for _, dir := range []string{"containers", "pods"} {
+		if matched, err := path.Match(components[0], dir); matched || err != nil {
+			return true
+		}
+	}
+	return false
+}
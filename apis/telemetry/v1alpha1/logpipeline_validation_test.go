@@ -39,6 +39,133 @@ func TestContainsMultipleOutputPlugins(t *testing.T) {
 	require.Contains(t, result.Error(), "multiple output plugins are defined, you must define only one output")
 }
 
+func TestContainsOtlpAndHTTPOutputPlugins(t *testing.T) {
+	logPipeline := &LogPipeline{
+		Spec: LogPipelineSpec{
+			Output: Output{
+				HTTP: &HTTPOutput{
+					Host: ValueType{
+						Value: "localhost",
+					},
+				},
+				Otlp: &OtlpOutput{
+					Endpoint: ValueType{
+						Value: "https://localhost:4317",
+					},
+				},
+			},
+		}}
+	vc := getLogPipelineValidationConfig()
+	result := logPipeline.validateOutput(vc.DeniedOutPutPlugins)
+
+	require.Error(t, result)
+	require.Contains(t, result.Error(), "multiple output plugins are defined, you must define only one output")
+}
+
+func TestValidateOtlpOutput(t *testing.T) {
+	otlpOutput := &OtlpOutput{
+		Endpoint: ValueType{
+			Value: "https://localhost:4317",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		spec          LogPipelineSpec
+		expectedError string
+	}{
+		{
+			name: "valid",
+			spec: LogPipelineSpec{Output: Output{Otlp: otlpOutput}},
+		},
+		{
+			name: "with filters",
+			spec: LogPipelineSpec{
+				Output:  Output{Otlp: otlpOutput},
+				Filters: []Filter{{Custom: "Name grep"}},
+			},
+			expectedError: "filters are not supported for a LogPipeline with OTLP output",
+		},
+		{
+			name: "with files",
+			spec: LogPipelineSpec{
+				Output: Output{Otlp: otlpOutput},
+				Files:  []FileMount{{Name: "file", Content: "content"}},
+			},
+			expectedError: "files are not supported for a LogPipeline with OTLP output",
+		},
+		{
+			name: "with redaction",
+			spec: LogPipelineSpec{
+				Output:    Output{Otlp: otlpOutput},
+				Redaction: &LogPipelineRedaction{Patterns: []RedactionPattern{RedactionPatternEmail}},
+			},
+			expectedError: "redaction is not supported for a LogPipeline with OTLP output",
+		},
+		{
+			name: "with application input",
+			spec: LogPipelineSpec{
+				Output: Output{Otlp: otlpOutput},
+				Input:  Input{Application: ApplicationInput{Namespaces: InputNamespaces{Include: []string{"default"}}}},
+			},
+			expectedError: "application input is not supported for a LogPipeline with OTLP output",
+		},
+		{
+			name: "with kubernetes events input",
+			spec: LogPipelineSpec{
+				Output: Output{Otlp: otlpOutput},
+				Input:  Input{KubernetesEvents: &KubernetesEventsInput{}},
+			},
+			expectedError: "kubernetes events input is not supported for a LogPipeline with OTLP output",
+		},
+		{
+			name: "with system input",
+			spec: LogPipelineSpec{
+				Output: Output{Otlp: otlpOutput},
+				Input:  Input{System: &SystemInput{Kernel: true}},
+			},
+			expectedError: "system input is not supported for a LogPipeline with OTLP output",
+		},
+		{
+			name: "with variables",
+			spec: LogPipelineSpec{
+				Output:    Output{Otlp: otlpOutput},
+				Variables: []VariableRef{{Name: "var"}},
+			},
+			expectedError: "variables are not supported for a LogPipeline with OTLP output",
+		},
+		{
+			name: "with additional outputs",
+			spec: LogPipelineSpec{
+				Output:            Output{Otlp: otlpOutput},
+				AdditionalOutputs: []AdditionalOtlpOutput{{Name: "audit", Otlp: otlpOutput}},
+			},
+		},
+		{
+			name: "additional outputs without otlp output",
+			spec: LogPipelineSpec{
+				Output:            Output{Custom: "Name stdout"},
+				AdditionalOutputs: []AdditionalOtlpOutput{{Name: "audit", Otlp: otlpOutput}},
+			},
+			expectedError: "additional outputs are only supported for a LogPipeline with OTLP output",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPipeline := &LogPipeline{Spec: tt.spec}
+			vc := getLogPipelineValidationConfig()
+			err := logPipeline.validateOutput(vc.DeniedOutPutPlugins)
+
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
 func TestDeniedOutputPlugins(t *testing.T) {
 	logPipeline := &LogPipeline{
 		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
@@ -228,6 +355,48 @@ func TestDeniedFilterPlugins(t *testing.T) {
 	require.Contains(t, err.Error(), "plugin 'lua' is forbidden. ")
 }
 
+func TestValidateRedaction(t *testing.T) {
+	tests := []struct {
+		name          string
+		redaction     *LogPipelineRedaction
+		expectedError string
+	}{
+		{
+			name: "no redaction",
+		},
+		{
+			name: "built-in patterns and custom regexes",
+			redaction: &LogPipelineRedaction{
+				Patterns: []RedactionPattern{RedactionPatternEmail, RedactionPatternJWT},
+				Custom:   []string{`token=\w+`, `apikey-[a-f0-9]+`},
+			},
+		},
+		{
+			name:          "unsupported regex",
+			redaction:     &LogPipelineRedaction{Custom: []string{`(user|password)=\S+`}},
+			expectedError: "invalid redaction pattern",
+		},
+		{
+			name:          "invalid regex",
+			redaction:     &LogPipelineRedaction{Custom: []string{`[a-`}},
+			expectedError: "invalid redaction pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPipeline := &LogPipeline{Spec: LogPipelineSpec{Redaction: tt.redaction}}
+
+			err := logPipeline.ValidateRedaction()
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
 func TestValidateWithValidInputIncludes(t *testing.T) {
 	logPipeline := &LogPipeline{
 		Spec: LogPipelineSpec{
@@ -378,3 +547,124 @@ func TestValidateWithInvalidContainerSelectors(t *testing.T) {
 	err := logPipeline.validateInput()
 	require.Error(t, err)
 }
+
+func TestValidateKubernetesEventsInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespaces    InputNamespaces
+		expectedError bool
+	}{
+		{
+			name: "no selector",
+		},
+		{
+			name:       "include",
+			namespaces: InputNamespaces{Include: []string{"namespace-1"}},
+		},
+		{
+			name:       "system",
+			namespaces: InputNamespaces{System: true},
+		},
+		{
+			name:          "include and exclude",
+			namespaces:    InputNamespaces{Include: []string{"namespace-1"}, Exclude: []string{"namespace-2"}},
+			expectedError: true,
+		},
+		{
+			name:          "exclude and system",
+			namespaces:    InputNamespaces{Exclude: []string{"namespace-2"}, System: true},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPipeline := &LogPipeline{
+				Spec: LogPipelineSpec{
+					Input: Input{
+						KubernetesEvents: &KubernetesEventsInput{
+							Namespaces: tt.namespaces,
+							Types:      []KubernetesEventType{KubernetesEventTypeWarning},
+						},
+					},
+				},
+			}
+
+			err := logPipeline.validateInput()
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateSystemInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		system        SystemInput
+		expectedError string
+	}{
+		{
+			name:   "valid",
+			system: SystemInput{Units: []string{"kubelet.service", "containerd.service"}, Kernel: true, Files: []string{"/var/log/syslog*", "/var/log/kube-proxy/*.log"}},
+		},
+		{
+			name:          "invalid unit",
+			system:        SystemInput{Units: []string{"kubelet.service\nName stdout"}},
+			expectedError: "invalid log pipeline definition: 'input.system.units' contains the invalid unit name 'kubelet.service\nName stdout'",
+		},
+		{
+			name:          "file outside of /var/log",
+			system:        SystemInput{Files: []string{"/etc/shadow"}},
+			expectedError: "invalid log pipeline definition: 'input.system.files' contains '/etc/shadow', which is not a path in the /var/log directory",
+		},
+		{
+			name:          "file with path traversal",
+			system:        SystemInput{Files: []string{"/var/log/../../etc/shadow"}},
+			expectedError: "invalid log pipeline definition: 'input.system.files' contains '/var/log/../../etc/shadow', which is not a path in the /var/log directory",
+		},
+		{
+			name:          "multiple files",
+			system:        SystemInput{Files: []string{"/var/log/syslog,/etc/shadow"}},
+			expectedError: "invalid log pipeline definition: 'input.system.files' contains '/var/log/syslog,/etc/shadow', which is not a path in the /var/log directory",
+		},
+		{
+			name:          "container logs",
+			system:        SystemInput{Files: []string{"/var/log/containers/*.log"}},
+			expectedError: "invalid log pipeline definition: 'input.system.files' contains '/var/log/containers/*.log', which selects container logs",
+		},
+		{
+			name:          "glob matching the container logs",
+			system:        SystemInput{Files: []string{"/var/log/*/*.log"}},
+			expectedError: "invalid log pipeline definition: 'input.system.files' contains '/var/log/*/*.log', which selects container logs",
+		},
+		{
+			name:          "glob matching the pod logs",
+			system:        SystemInput{Files: []string{"/var/log/p?ds/*/*/*.log"}},
+			expectedError: "invalid log pipeline definition: 'input.system.files' contains '/var/log/p?ds/*/*/*.log', which selects container logs",
+		},
+		{
+			name:   "glob not matching the container logs",
+			system: SystemInput{Files: []string{"/var/log/kube-*/*.log", "/var/log/*.log"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPipeline := &LogPipeline{
+				Spec: LogPipelineSpec{
+					Input: Input{System: &tt.system},
+				},
+			}
+
+			err := logPipeline.validateInput()
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
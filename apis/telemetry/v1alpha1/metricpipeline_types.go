@@ -40,6 +40,9 @@ type MetricPipelineList struct {
 //+kubebuilder:printcolumn:name="Configuration Generated",type=string,JSONPath=`.status.conditions[?(@.type=="ConfigurationGenerated")].status`
 //+kubebuilder:printcolumn:name="Gateway Healthy",type=string,JSONPath=`.status.conditions[?(@.type=="GatewayHealthy")].status`
 //+kubebuilder:printcolumn:name="Agent Healthy",type=string,JSONPath=`.status.conditions[?(@.type=="AgentHealthy")].status`
+//+kubebuilder:printcolumn:name="Flow Healthy",type=string,JSONPath=`.status.conditions[?(@.type=="TelemetryFlowHealthy")].status`
+//+kubebuilder:printcolumn:name="Sent/min",type=integer,JSONPath=`.status.dataFlow.sentPerMinute`
+//+kubebuilder:printcolumn:name="Dropped/min",type=integer,JSONPath=`.status.dataFlow.droppedPerMinute`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // MetricPipeline is the Schema for the metricpipelines API.
@@ -59,8 +62,52 @@ type MetricPipelineSpec struct {
 	// Configures different inputs to send additional metrics to the metric gateway.
 	Input MetricPipelineInput `json:"input,omitempty"`
 
+	// Defines which metrics and data points are shipped to the output. If not defined, all metrics selected by the inputs are shipped.
+	//+optional
+	Filters *MetricPipelineFilters `json:"filters,omitempty"`
+
+	// Modifies attributes of the metrics before they are shipped to the output, for example, to remove or hash sensitive data. The rules are applied in the given order.
+	//+optional
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:XValidation:rule="self.all(t, t.context != 'span')", message="The 'span' context is not available for metrics"
+	Transforms []TransformRule `json:"transforms,omitempty"`
+
 	// Configures the metric gateway.
 	Output MetricPipelineOutput `json:"output,omitempty"`
+
+	// Defines further OTLP backends that receive the same metrics as the output, for example, for auditing or archiving. All outputs share the resources of the pipeline.
+	//+optional
+	//+listType=map
+	//+listMapKey=name
+	//+kubebuilder:validation:MaxItems=3
+	AdditionalOutputs []AdditionalOtlpOutput `json:"additionalOutputs,omitempty"`
+}
+
+// MetricPipelineFilters defines which metrics and data points are shipped. The filters apply to the metrics of all inputs.
+type MetricPipelineFilters struct {
+	// Only metrics with a name matching at least one of the regular expressions are shipped. A regular expression must match the whole metric name, for example, `http_server_.*`.
+	//+optional
+	Include []string `json:"include,omitempty"`
+	// Metrics with a name matching at least one of the regular expressions are dropped. A regular expression must match the whole metric name, for example, `.*_bucket`.
+	//+optional
+	Exclude []string `json:"exclude,omitempty"`
+	// Drops data points with an attribute that matches at least one of the matchers, for example, to remove high-cardinality series of a metric.
+	//+optional
+	DataPoints []MetricPipelineAttributeFilter `json:"dataPoints,omitempty"`
+}
+
+// MetricPipelineAttributeFilter matches data points by the value of a data point attribute. Exactly one of `value` or `regex` must be defined.
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.regex)", message="Exactly one of 'value' or 'regex' must be defined"
+type MetricPipelineAttributeFilter struct {
+	// Key of the data point attribute, for example, `http.route`.
+	//+kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+	// Data points with the attribute set to exactly this value are dropped.
+	//+optional
+	Value string `json:"value,omitempty"`
+	// Data points with an attribute value matching this regular expression are dropped.
+	//+optional
+	Regex string `json:"regex,omitempty"`
 }
 
 // MetricPipelineInput defines the input configuration section.
@@ -74,6 +121,12 @@ type MetricPipelineInput struct {
 	// Configures istio-proxy metrics scraping.
 	//+optional
 	Istio *MetricPipelineIstioInput `json:"istio,omitempty"`
+	// Configures the collection of cluster-wide state metrics of Kubernetes objects, such as the readiness of Nodes or the replica counts of Deployments.
+	//+optional
+	Cluster *MetricPipelineClusterInput `json:"cluster,omitempty"`
+	// Configures the collection of span metrics and service graph metrics, which a TracePipeline with `spanMetrics` derives from the spans.
+	//+optional
+	SpanMetrics *MetricPipelineSpanMetricsInput `json:"spanMetrics,omitempty"`
 	// Configures the collection of push-based metrics that use the OpenTelemetry protocol.
 	//+optional
 	Otlp *MetricPipelineOtlpInput `json:"otlp,omitempty"`
@@ -90,6 +143,24 @@ type MetricPipelinePrometheusInput struct {
 	// Configures diagnostic metrics scraping
 	//+optional
 	DiagnosticMetrics *DiagnosticMetrics `json:"diagnosticMetrics,omitempty"`
+	// Configures the scraping of the targets defined by ServiceMonitor and PodMonitor resources of the Prometheus Operator.
+	//+optional
+	Monitors *MetricPipelinePrometheusInputMonitors `json:"monitors,omitempty"`
+	// Interval at which the targets are scraped, such as `10s` or `1m`. The default is `30s`. If pipelines request different intervals, the shortest one is used for all of them.
+	// A single target can override the interval with the `prometheus.io/scrape-interval` annotation.
+	//+optional
+	//+kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+	// Maximum number of samples accepted per scrape of a target. If a target exposes more samples, the scrape fails. The default is `50000`. If pipelines request different limits, the highest one is used for all of them.
+	//+optional
+	//+kubebuilder:validation:Minimum=1
+	SampleLimit int `json:"sampleLimit,omitempty"`
+}
+
+// MetricPipelinePrometheusInputMonitors defines the translation of Prometheus Operator resources into scrape configurations.
+type MetricPipelinePrometheusInputMonitors struct {
+	// If enabled, the targets defined by ServiceMonitor and PodMonitor resources (monitoring.coreos.com/v1) are scraped in addition to the annotated workloads. The default is `false`.
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 // MetricPipelineRuntimeInput defines the runtime scraping section.
@@ -100,6 +171,39 @@ type MetricPipelineRuntimeInput struct {
 	//+optional
 	//+kubebuilder:default={exclude: {kyma-system, kube-system, istio-system, compass-system}}
 	Namespaces *MetricPipelineInputNamespaceSelector `json:"namespaces,omitempty"`
+	// Describes the Kubernetes resources for which runtime metrics are collected.
+	//+optional
+	Resources *MetricPipelineRuntimeInputResources `json:"resources,omitempty"`
+}
+
+// MetricPipelineRuntimeInputResources describes the Kubernetes resources for which runtime metrics are collected.
+type MetricPipelineRuntimeInputResources struct {
+	// Configures Pod runtime metrics.
+	//+optional
+	Pod *MetricPipelineRuntimeInputResourceEnabledByDefault `json:"pod,omitempty"`
+	// Configures container runtime metrics.
+	//+optional
+	Container *MetricPipelineRuntimeInputResourceEnabledByDefault `json:"container,omitempty"`
+	// Configures Node runtime metrics, including the usage of the Node file systems.
+	//+optional
+	Node *MetricPipelineRuntimeInputResourceDisabledByDefault `json:"node,omitempty"`
+	// Configures Volume runtime metrics.
+	//+optional
+	Volume *MetricPipelineRuntimeInputResourceDisabledByDefault `json:"volume,omitempty"`
+}
+
+// MetricPipelineRuntimeInputResourceEnabledByDefault defines if the runtime metrics of a resource that is collected by default are collected.
+type MetricPipelineRuntimeInputResourceEnabledByDefault struct {
+	// If enabled, the runtime metrics for the resource are collected. The default is `true`.
+	//+optional
+	//+kubebuilder:default=true
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// MetricPipelineRuntimeInputResourceDisabledByDefault defines if the runtime metrics of a resource that is not collected by default are collected.
+type MetricPipelineRuntimeInputResourceDisabledByDefault struct {
+	// If enabled, the runtime metrics for the resource are collected. The default is `false`.
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 // MetricPipelineIstioInput defines the Istio scraping section.
@@ -114,6 +218,18 @@ type MetricPipelineIstioInput struct {
 	DiagnosticMetrics *DiagnosticMetrics `json:"diagnosticMetrics,omitempty"`
 }
 
+// MetricPipelineClusterInput defines the collection of cluster-wide state metrics.
+type MetricPipelineClusterInput struct {
+	// If enabled, the state of Kubernetes objects such as Nodes, Deployments, and Pods is collected once for the whole cluster. The default is `false`.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// MetricPipelineSpanMetricsInput defines the collection of metrics that the trace gateway derives from spans.
+type MetricPipelineSpanMetricsInput struct {
+	// If enabled, the span metrics and service graph metrics generated by the trace gateway are collected. The default is `false`.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
 // MetricPipelineOtlpInput defines the collection of push-based metrics that use the OpenTelemetry protocol.
 type MetricPipelineOtlpInput struct {
 	// If disabled, push-based OTLP metrics are not collected. The default is `false`.
@@ -148,4 +264,6 @@ type DiagnosticMetrics struct {
 type MetricPipelineStatus struct {
 	// An array of conditions describing the status of the pipeline.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Data flow statistics of the pipeline. They are only reported if self-monitoring is enabled.
+	DataFlow *DataFlowStatus `json:"dataFlow,omitempty"`
 }
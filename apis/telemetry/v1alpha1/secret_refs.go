@@ -12,6 +12,11 @@ func (lp *LogPipeline) GetSecretRefs() []SecretKeyRef {
 	refs = append(refs, lp.GetEnvSecretRefs()...)
 	refs = append(refs, lp.GetTLSSecretRefs()...)
 
+	if lp.Spec.Output.IsOtlpDefined() {
+		refs = append(refs, getRefsInOtlpOutput(lp.Spec.Output.Otlp)...)
+		refs = append(refs, getRefsInAdditionalOtlpOutputs(lp.Spec.AdditionalOutputs)...)
+	}
+
 	return refs
 }
 
@@ -53,11 +58,25 @@ func (lp *LogPipeline) GetTLSSecretRefs() []SecretKeyRef {
 }
 
 func (tp *TracePipeline) GetSecretRefs() []SecretKeyRef {
-	return getRefsInOtlpOutput(tp.Spec.Output.Otlp)
+	refs := getRefsInOtlpOutput(tp.Spec.Output.Otlp)
+	return append(refs, getRefsInAdditionalOtlpOutputs(tp.Spec.AdditionalOutputs)...)
 }
 
 func (mp *MetricPipeline) GetSecretRefs() []SecretKeyRef {
-	return getRefsInOtlpOutput(mp.Spec.Output.Otlp)
+	refs := getRefsInOtlpOutput(mp.Spec.Output.Otlp)
+	return append(refs, getRefsInAdditionalOtlpOutputs(mp.Spec.AdditionalOutputs)...)
+}
+
+func getRefsInAdditionalOtlpOutputs(outputs []AdditionalOtlpOutput) []SecretKeyRef {
+	var refs []SecretKeyRef
+
+	for i := range outputs {
+		if outputs[i].Otlp != nil {
+			refs = append(refs, getRefsInOtlpOutput(outputs[i].Otlp)...)
+		}
+	}
+
+	return refs
 }
 
 func getRefsInOtlpOutput(otlpOut *OtlpOutput) []SecretKeyRef {
@@ -70,6 +89,16 @@ func getRefsInOtlpOutput(otlpOut *OtlpOutput) []SecretKeyRef {
 		refs = appendIfSecretRef(refs, otlpOut.Authentication.Basic.Password)
 	}
 
+	if otlpOut.Authentication != nil && otlpOut.Authentication.OAuth2.IsDefined() {
+		refs = appendIfSecretRef(refs, otlpOut.Authentication.OAuth2.TokenURL)
+		refs = appendIfSecretRef(refs, otlpOut.Authentication.OAuth2.ClientID)
+		refs = appendIfSecretRef(refs, otlpOut.Authentication.OAuth2.ClientSecret)
+	}
+
+	if otlpOut.Authentication != nil && otlpOut.Authentication.BearerToken.IsDefined() {
+		refs = appendIfSecretRef(refs, *otlpOut.Authentication.BearerToken)
+	}
+
 	for _, header := range otlpOut.Headers {
 		refs = appendIfSecretRef(refs, header.ValueType)
 	}
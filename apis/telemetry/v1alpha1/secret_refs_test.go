@@ -143,6 +143,44 @@ func TestLogPipeline_GetSecretRefs(t *testing.T) {
 				{Name: "secret-2", Key: "password"},
 			},
 		},
+		{
+			name: "otlp output secret refs",
+			given: LogPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "otlp",
+				},
+				Spec: LogPipelineSpec{
+					Output: Output{
+						Otlp: &OtlpOutput{
+							Endpoint: ValueType{
+								ValueFrom: &ValueFromSource{
+									SecretKeyRef: &SecretKeyRef{Name: "creds", Namespace: "default", Key: "endpoint"},
+								},
+							},
+							Authentication: &AuthenticationOptions{
+								Basic: &BasicAuthOptions{
+									User: ValueType{
+										ValueFrom: &ValueFromSource{
+											SecretKeyRef: &SecretKeyRef{Name: "creds", Namespace: "default", Key: "user"},
+										},
+									},
+									Password: ValueType{
+										ValueFrom: &ValueFromSource{
+											SecretKeyRef: &SecretKeyRef{Name: "creds", Namespace: "default", Key: "password"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []SecretKeyRef{
+				{Name: "creds", Namespace: "default", Key: "endpoint"},
+				{Name: "creds", Namespace: "default", Key: "user"},
+				{Name: "creds", Namespace: "default", Key: "password"},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -226,6 +264,49 @@ func TestTracePipeline_GetSecretRefs(t *testing.T) {
 				{Name: "secret-3", Namespace: "default", Key: "myheader"},
 			},
 		},
+		{
+			name:         "oauth2",
+			pipelineName: "test-pipeline",
+			given: &OtlpOutput{
+				Authentication: &AuthenticationOptions{
+					OAuth2: &OAuth2Options{
+						TokenURL: ValueType{Value: "https://auth.example.com/token"},
+						ClientID: ValueType{
+							ValueFrom: &ValueFromSource{
+								SecretKeyRef: &SecretKeyRef{Name: "secret-1", Namespace: "default", Key: "client-id"},
+							},
+						},
+						ClientSecret: ValueType{
+							ValueFrom: &ValueFromSource{
+								SecretKeyRef: &SecretKeyRef{Name: "secret-1", Namespace: "default", Key: "client-secret"},
+							},
+						},
+					},
+				},
+			},
+
+			expected: []SecretKeyRef{
+				{Name: "secret-1", Namespace: "default", Key: "client-id"},
+				{Name: "secret-1", Namespace: "default", Key: "client-secret"},
+			},
+		},
+		{
+			name:         "bearer token",
+			pipelineName: "test-pipeline",
+			given: &OtlpOutput{
+				Authentication: &AuthenticationOptions{
+					BearerToken: &ValueType{
+						ValueFrom: &ValueFromSource{
+							SecretKeyRef: &SecretKeyRef{Name: "secret-1", Namespace: "default", Key: "token"},
+						},
+					},
+				},
+			},
+
+			expected: []SecretKeyRef{
+				{Name: "secret-1", Namespace: "default", Key: "token"},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -237,6 +318,36 @@ func TestTracePipeline_GetSecretRefs(t *testing.T) {
 	}
 }
 
+func TestTracePipeline_GetSecretRefsWithAdditionalOutputs(t *testing.T) {
+	sut := TracePipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline"},
+		Spec: TracePipelineSpec{
+			Output: TracePipelineOutput{Otlp: &OtlpOutput{
+				Endpoint: ValueType{ValueFrom: &ValueFromSource{SecretKeyRef: &SecretKeyRef{Name: "secret-1", Key: "endpoint"}}},
+			}},
+			AdditionalOutputs: []AdditionalOtlpOutput{
+				{
+					Name: "audit",
+					Otlp: &OtlpOutput{
+						Endpoint: ValueType{ValueFrom: &ValueFromSource{SecretKeyRef: &SecretKeyRef{Name: "secret-2", Key: "endpoint"}}},
+					},
+				},
+				{
+					Name: "archive",
+					Otlp: &OtlpOutput{
+						Endpoint: ValueType{Value: "https://archive:4317"},
+					},
+				},
+			},
+		},
+	}
+
+	require.ElementsMatch(t, []SecretKeyRef{
+		{Name: "secret-1", Key: "endpoint"},
+		{Name: "secret-2", Key: "endpoint"},
+	}, sut.GetSecretRefs())
+}
+
 func TestMetricPipeline_GetSecretRefs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -28,7 +29,7 @@ type ValueFromSource struct {
 }
 
 func (v *ValueFromSource) IsSecretKeyRef() bool {
-	return v.SecretKeyRef != nil && v.SecretKeyRef.Name != "" && v.SecretKeyRef.Key != ""
+	return v != nil && v.SecretKeyRef != nil && v.SecretKeyRef.Name != "" && v.SecretKeyRef.Key != ""
 }
 
 type SecretKeyRef struct {
@@ -94,11 +95,52 @@ type OtlpOutput struct {
 	Headers []Header `json:"headers,omitempty"`
 	// Defines TLS options for the OTLP output.
 	TLS *OtlpTLS `json:"tls,omitempty"`
+	// Defines how data is buffered and retried if the backend is not available.
+	Delivery *OtlpDelivery `json:"delivery,omitempty"`
 }
 
+// OtlpDelivery defines the buffering, retry, and request settings of an OTLP output.
+type OtlpDelivery struct {
+	// Defines the number of batches that the output buffers while the backend is not available. The gateway has a capacity of 256 batches for all outputs: the defined queue sizes are taken from it first, and the rest is shared equally by the outputs without a queue size. If the defined queue sizes exceed the capacity, they are reduced proportionally.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=256
+	QueueSize int `json:"queueSize,omitempty"`
+	// Defines the maximum time that a batch is retried before it is dropped, for example, `30m`. Must be between `10s` and `24h`. The default is `300s`.
+	// +kubebuilder:validation:MaxLength=16
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('10s') && duration(self) <= duration('24h')", message="Retry max elapsed time must be between 10s and 24h"
+	RetryMaxElapsedTime string `json:"retryMaxElapsedTime,omitempty"`
+	// Defines the timeout of a single request to the backend, for example, `10s`. Must be between `1s` and `5m`. The default is `5s`.
+	// +kubebuilder:validation:MaxLength=16
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s') && duration(self) <= duration('5m')", message="Timeout must be between 1s and 5m"
+	Timeout string `json:"timeout,omitempty"`
+	// Defines the compression of the requests to the backend (gzip, zstd, or none). The default is gzip.
+	// +kubebuilder:validation:Enum=gzip;zstd;none
+	Compression string `json:"compression,omitempty"`
+}
+
+// AdditionalOtlpOutput defines an additional OTLP backend that receives the same data as the primary output of a pipeline.
+type AdditionalOtlpOutput struct {
+	// Identifies the output within the pipeline. It is used in the names of the generated exporter and of its self-monitoring metrics.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// Configures the OTLP exporter of the output.
+	// +kubebuilder:validation:Required
+	Otlp *OtlpOutput `json:"otlp"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.basic) ? 1 : 0) + (has(self.oauth2) ? 1 : 0) + (has(self.bearerToken) ? 1 : 0) <= 1", message="Only one authentication method can be defined"
 type AuthenticationOptions struct {
 	// Activates `Basic` authentication for the destination providing relevant Secrets.
 	Basic *BasicAuthOptions `json:"basic,omitempty"`
+	// Activates `OAuth2` authentication with the client credentials flow. The access token is fetched from the token URL and refreshed before it expires.
+	OAuth2 *OAuth2Options `json:"oauth2,omitempty"`
+	// Activates `Bearer` authentication with the given token or a Secret reference. The token is passed to the gateway at startup; if the referenced Secret changes, the gateway is restarted to use the new token.
+	BearerToken *ValueType `json:"bearerToken,omitempty"`
 }
 
 type BasicAuthOptions struct {
@@ -111,5 +153,87 @@ type BasicAuthOptions struct {
 }
 
 func (b *BasicAuthOptions) IsDefined() bool {
-	return b.User.IsDefined() && b.Password.IsDefined()
+	return b != nil && b.User.IsDefined() && b.Password.IsDefined()
+}
+
+type OAuth2Options struct {
+	// Contains the URL of the OAuth2 token endpoint or a Secret reference.
+	// +kubebuilder:validation:Required
+	TokenURL ValueType `json:"tokenURL"`
+	// Contains the OAuth2 client ID or a Secret reference.
+	// +kubebuilder:validation:Required
+	ClientID ValueType `json:"clientID"`
+	// Contains the OAuth2 client secret or a Secret reference.
+	// +kubebuilder:validation:Required
+	ClientSecret ValueType `json:"clientSecret"`
+	// Defines the scopes to request with the access token.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+func (o *OAuth2Options) IsDefined() bool {
+	return o != nil && o.TokenURL.IsDefined() && o.ClientID.IsDefined() && o.ClientSecret.IsDefined()
+}
+
+// TransformRule modifies an attribute of the telemetry data before it is shipped to the outputs of a pipeline.
+// +kubebuilder:validation:XValidation:rule="self.action != 'set' || has(self.value)", message="The 'set' action requires a 'value'"
+// +kubebuilder:validation:XValidation:rule="self.action != 'rename' || (has(self.newKey) && size(self.newKey) > 0)", message="The 'rename' action requires a 'newKey'"
+// +kubebuilder:validation:XValidation:rule="self.action != 'truncate' || (has(self.limit) && self.limit > 0)", message="The 'truncate' action requires a positive 'limit'"
+type TransformRule struct {
+	// Defines the level at which the attribute is modified. The `datapoint` level is only available for metrics, the `span` level only for traces.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=resource;scope;datapoint;span
+	Context TransformContext `json:"context"`
+	// Defines how the attribute is modified. `set` assigns `value`, `delete` removes the attribute, `rename` moves it to `newKey`, `hash` replaces the value by its unsalted SHA-256 hash, and `truncate` shortens it to `limit` characters. Hashing is pseudonymization, not anonymization: values from a small set, such as email addresses or IP addresses, can be recovered by hashing candidate values, so delete attributes that must not be recoverable.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=set;delete;rename;hash;truncate
+	Action TransformAction `json:"action"`
+	// Key of the attribute, for example, `user.email`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+	// Value that is assigned by the `set` action.
+	//+optional
+	Value string `json:"value,omitempty"`
+	// Key that the attribute is moved to by the `rename` action.
+	//+optional
+	NewKey string `json:"newKey,omitempty"`
+	// Maximum number of characters that the `truncate` action keeps.
+	//+optional
+	Limit int64 `json:"limit,omitempty"`
+}
+
+type TransformContext string
+
+const (
+	TransformContextResource  TransformContext = "resource"
+	TransformContextScope     TransformContext = "scope"
+	TransformContextDataPoint TransformContext = "datapoint"
+	TransformContextSpan      TransformContext = "span"
+)
+
+type TransformAction string
+
+const (
+	TransformActionSet      TransformAction = "set"
+	TransformActionDelete   TransformAction = "delete"
+	TransformActionRename   TransformAction = "rename"
+	TransformActionHash     TransformAction = "hash"
+	TransformActionTruncate TransformAction = "truncate"
+)
+
+// DataFlowStatus reports the data flow of a pipeline as measured by the self-monitor.
+type DataFlowStatus struct {
+	// Number of items per minute that the pipeline sent, averaged over 5 minutes. Items are metric data points for a MetricPipeline, spans for a TracePipeline, and log records for a LogPipeline.
+	// If the pipeline has additional outputs, it is the number of the output that sent the most items, because every output receives the same items.
+	// It is not reported if the self-monitor has not collected the underlying metrics.
+	//+optional
+	SentPerMinute *int64 `json:"sentPerMinute,omitempty"`
+	// Number of items per minute that the pipeline dropped, averaged over 5 minutes. It is not reported if the self-monitor has not collected the underlying metrics.
+	//+optional
+	DroppedPerMinute *int64 `json:"droppedPerMinute,omitempty"`
+	// Utilization of the fullest export queue of the pipeline in percent. It is not reported for a LogPipeline.
+	//+optional
+	QueueUtilizationPercent *int32 `json:"queueUtilizationPercent,omitempty"`
+	// Time of the measurement.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
 }
@@ -22,8 +22,172 @@ import (
 
 // TracePipelineSpec defines the desired state of TracePipeline
 type TracePipelineSpec struct {
+	// Configures the inputs from which the pipeline selects trace data.
+	Input TracePipelineInput `json:"input,omitempty"`
+
+	// Drops spans that match at least one of the filters before they are shipped to the output.
+	//+optional
+	Filters []TracePipelineFilter `json:"filters,omitempty"`
+
+	// Configures the sampling of traces before they are shipped to the output. If not defined, all traces are shipped.
+	//+optional
+	Sampling *TracePipelineSampling `json:"sampling,omitempty"`
+
+	// Derives RED metrics (rate, errors, duration) and service graph metrics from the spans received by the trace gateway, and sends them to the metric gateway.
+	// To ship the metrics, enable the `spanMetrics` input in a MetricPipeline.
+	//+optional
+	SpanMetrics *TracePipelineSpanMetrics `json:"spanMetrics,omitempty"`
+
+	// Modifies attributes of the spans before they are shipped to the output, for example, to remove or hash sensitive data. The rules are applied in the given order.
+	//+optional
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:XValidation:rule="self.all(t, t.context != 'datapoint')", message="The 'datapoint' context is not available for traces"
+	Transforms []TransformRule `json:"transforms,omitempty"`
+
 	// Defines a destination for shipping trace data. Only one can be defined per pipeline.
 	Output TracePipelineOutput `json:"output"`
+
+	// Defines further OTLP backends that receive the same traces as the output, for example, for auditing or archiving. All outputs share the resources of the pipeline.
+	//+optional
+	//+listType=map
+	//+listMapKey=name
+	//+kubebuilder:validation:MaxItems=3
+	AdditionalOutputs []AdditionalOtlpOutput `json:"additionalOutputs,omitempty"`
+}
+
+// TracePipelineInput defines the input configuration section.
+type TracePipelineInput struct {
+	// Configures the collection of push-based traces that use the OpenTelemetry protocol.
+	//+optional
+	Otlp *TracePipelineOtlpInput `json:"otlp,omitempty"`
+}
+
+// TracePipelineOtlpInput defines the collection of push-based traces that use the OpenTelemetry protocol.
+type TracePipelineOtlpInput struct {
+	// Describes whether push-based OTLP traces from specific Namespaces are selected. System Namespaces are enabled by default.
+	//+optional
+	Namespaces *TracePipelineInputNamespaceSelector `json:"namespaces,omitempty"`
+}
+
+// TracePipelineInputNamespaceSelector describes whether traces from specific Namespaces are selected.
+// +kubebuilder:validation:XValidation:rule="!((has(self.include) && size(self.include) != 0) && (has(self.exclude) && size(self.exclude) != 0))", message="Can only define one namespace selector - either 'include' or 'exclude'"
+type TracePipelineInputNamespaceSelector struct {
+	// Include traces from the specified Namespace names only.
+	Include []string `json:"include,omitempty"`
+	// Exclude traces from the specified Namespace names only.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// TracePipelineFilter defines a condition for dropping spans. Exactly one of `condition` or `attribute` must be defined.
+// +kubebuilder:validation:XValidation:rule="has(self.condition) != has(self.attribute)", message="Exactly one of 'condition' or 'attribute' must be defined"
+type TracePipelineFilter struct {
+	// OTTL condition in the span context, for example, `attributes["http.route"] == "/healthz"`. Spans matching the condition are dropped.
+	//+optional
+	//+kubebuilder:validation:MinLength=1
+	Condition string `json:"condition,omitempty"`
+	// Drops spans with an attribute that matches the given value or regular expression.
+	//+optional
+	Attribute *TracePipelineAttributeFilter `json:"attribute,omitempty"`
+}
+
+// TracePipelineAttributeFilter matches spans by the value of a span attribute. Exactly one of `value` or `regex` must be defined.
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.regex)", message="Exactly one of 'value' or 'regex' must be defined"
+type TracePipelineAttributeFilter struct {
+	// Key of the span attribute, for example, `http.route`.
+	//+kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+	// Spans with the attribute set to exactly this value are dropped.
+	//+optional
+	Value string `json:"value,omitempty"`
+	// Spans with an attribute value matching this regular expression are dropped.
+	//+optional
+	Regex string `json:"regex,omitempty"`
+}
+
+// TracePipelineSampling defines the sampling configuration section.
+// +kubebuilder:validation:XValidation:rule="!(has(self.probabilistic) && has(self.tail))", message="Can only define one sampling mode - either 'probabilistic' or 'tail'"
+type TracePipelineSampling struct {
+	// Samples a fixed percentage of traces based on the trace ID. All spans of a sampled trace are kept.
+	//+optional
+	Probabilistic *ProbabilisticSampling `json:"probabilistic,omitempty"`
+	// Samples traces based on policies evaluated after all spans of a trace have been received. A trace is kept if at least one policy matches.
+	//+optional
+	Tail *TailSampling `json:"tail,omitempty"`
+}
+
+// ProbabilisticSampling defines the probabilistic sampling configuration section.
+type ProbabilisticSampling struct {
+	// Percentage of traces to be kept, for example, `10` or `0.5`. Must be between 0 and 100.
+	//+kubebuilder:validation:Pattern=`^(100(\.0+)?|[0-9]{1,2}(\.[0-9]+)?)$`
+	Percentage string `json:"percentage"`
+}
+
+// TailSampling defines the tail-based sampling configuration section.
+type TailSampling struct {
+	// Time to wait after the first span of a trace has been received before a sampling decision is made. The default is `10s`.
+	//+optional
+	//+kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m))+$`
+	DecisionWait string `json:"decisionWait,omitempty"`
+	// Policies that decide whether a trace is kept. A trace is kept if at least one policy matches. The policy names must be unique.
+	//+kubebuilder:validation:MinItems=1
+	//+listType=map
+	//+listMapKey=name
+	Policies []TailSamplingPolicy `json:"policies"`
+}
+
+// TailSamplingPolicyType is the type of a tail sampling policy.
+// +kubebuilder:validation:Enum=Error;Latency;Attribute
+type TailSamplingPolicyType string
+
+const (
+	// TailSamplingPolicyTypeError keeps traces containing at least one span with status code `ERROR`.
+	TailSamplingPolicyTypeError TailSamplingPolicyType = "Error"
+	// TailSamplingPolicyTypeLatency keeps traces with a duration above a threshold.
+	TailSamplingPolicyTypeLatency TailSamplingPolicyType = "Latency"
+	// TailSamplingPolicyTypeAttribute keeps traces containing at least one span with a matching attribute value.
+	TailSamplingPolicyTypeAttribute TailSamplingPolicyType = "Attribute"
+)
+
+// TailSamplingPolicy defines a policy for tail-based sampling.
+// +kubebuilder:validation:XValidation:rule="self.type != 'Latency' || has(self.latency)", message="A 'Latency' policy requires the 'latency' section"
+// +kubebuilder:validation:XValidation:rule="self.type != 'Attribute' || has(self.attribute)", message="An 'Attribute' policy requires the 'attribute' section"
+type TailSamplingPolicy struct {
+	// Unique name of the policy within the pipeline.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Type of the policy. Must be one of `Error`, `Latency`, or `Attribute`.
+	Type TailSamplingPolicyType `json:"type"`
+	// Configures a `Latency` policy.
+	//+optional
+	Latency *TailSamplingLatencyPolicy `json:"latency,omitempty"`
+	// Configures an `Attribute` policy.
+	//+optional
+	Attribute *TailSamplingAttributePolicy `json:"attribute,omitempty"`
+}
+
+// TailSamplingLatencyPolicy defines a policy that keeps slow traces.
+type TailSamplingLatencyPolicy struct {
+	// Traces with a duration of at least the threshold in milliseconds are kept.
+	//+kubebuilder:validation:Minimum=1
+	ThresholdMs int64 `json:"thresholdMs"`
+}
+
+// TailSamplingAttributePolicy defines a policy that keeps traces with a matching span attribute.
+type TailSamplingAttributePolicy struct {
+	// Key of the span or resource attribute.
+	//+kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+	// Traces that have the attribute set to one of the values are kept.
+	//+kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+// TracePipelineSpanMetrics defines the generation of metrics from spans.
+type TracePipelineSpanMetrics struct {
+	// Span attributes that are added as dimensions to the span metrics, in addition to the service name, span name, span kind, and status code, for example, `http.method`.
+	//+optional
+	//+kubebuilder:validation:MaxItems=10
+	Dimensions []string `json:"dimensions,omitempty"`
 }
 
 // TracePipelineOutput defines the output configuration section.
@@ -36,6 +200,8 @@ type TracePipelineOutput struct {
 type TracePipelineStatus struct {
 	// An array of conditions describing the status of the pipeline.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Data flow statistics of the pipeline. They are only reported if self-monitoring is enabled.
+	DataFlow *DataFlowStatus `json:"dataFlow,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -43,6 +209,9 @@ type TracePipelineStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Configuration Generated",type=string,JSONPath=`.status.conditions[?(@.type=="ConfigurationGenerated")].status`
 // +kubebuilder:printcolumn:name="Gateway Healthy",type=string,JSONPath=`.status.conditions[?(@.type=="GatewayHealthy")].status`
+// +kubebuilder:printcolumn:name="Flow Healthy",type=string,JSONPath=`.status.conditions[?(@.type=="TelemetryFlowHealthy")].status`
+// +kubebuilder:printcolumn:name="Sent/min",type=integer,JSONPath=`.status.dataFlow.sentPerMinute`
+// +kubebuilder:printcolumn:name="Dropped/min",type=integer,JSONPath=`.status.dataFlow.droppedPerMinute`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // TracePipeline is the Schema for the tracepipelines API
 type TracePipeline struct {
@@ -25,6 +25,26 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalOtlpOutput) DeepCopyInto(out *AdditionalOtlpOutput) {
+	*out = *in
+	if in.Otlp != nil {
+		in, out := &in.Otlp, &out.Otlp
+		*out = new(OtlpOutput)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalOtlpOutput.
+func (in *AdditionalOtlpOutput) DeepCopy() *AdditionalOtlpOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalOtlpOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationInput) DeepCopyInto(out *ApplicationInput) {
 	*out = *in
@@ -50,6 +70,16 @@ func (in *AuthenticationOptions) DeepCopyInto(out *AuthenticationOptions) {
 		*out = new(BasicAuthOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2Options)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(ValueType)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationOptions.
@@ -79,6 +109,37 @@ func (in *BasicAuthOptions) DeepCopy() *BasicAuthOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataFlowStatus) DeepCopyInto(out *DataFlowStatus) {
+	*out = *in
+	if in.SentPerMinute != nil {
+		in, out := &in.SentPerMinute, &out.SentPerMinute
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DroppedPerMinute != nil {
+		in, out := &in.DroppedPerMinute, &out.DroppedPerMinute
+		*out = new(int64)
+		**out = **in
+	}
+	if in.QueueUtilizationPercent != nil {
+		in, out := &in.QueueUtilizationPercent, &out.QueueUtilizationPercent
+		*out = new(int32)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataFlowStatus.
+func (in *DataFlowStatus) DeepCopy() *DataFlowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataFlowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiagnosticMetrics) DeepCopyInto(out *DiagnosticMetrics) {
 	*out = *in
@@ -163,6 +224,16 @@ func (in *Header) DeepCopy() *Header {
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
 	in.Application.DeepCopyInto(&out.Application)
+	if in.KubernetesEvents != nil {
+		in, out := &in.KubernetesEvents, &out.KubernetesEvents
+		*out = new(KubernetesEventsInput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.System != nil {
+		in, out := &in.System, &out.System
+		*out = new(SystemInput)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Input.
@@ -225,6 +296,27 @@ func (in *InputNamespaces) DeepCopy() *InputNamespaces {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesEventsInput) DeepCopyInto(out *KubernetesEventsInput) {
+	*out = *in
+	in.Namespaces.DeepCopyInto(&out.Namespaces)
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]KubernetesEventType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesEventsInput.
+func (in *KubernetesEventsInput) DeepCopy() *KubernetesEventsInput {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesEventsInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogParser) DeepCopyInto(out *LogParser) {
 	*out = *in
@@ -380,6 +472,36 @@ func (in *LogPipelineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogPipelineRedaction) DeepCopyInto(out *LogPipelineRedaction) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]RedactionPattern, len(*in))
+		copy(*out, *in)
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogPipelineRedaction.
+func (in *LogPipelineRedaction) DeepCopy() *LogPipelineRedaction {
+	if in == nil {
+		return nil
+	}
+	out := new(LogPipelineRedaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogPipelineSpec) DeepCopyInto(out *LogPipelineSpec) {
 	*out = *in
@@ -389,6 +511,11 @@ func (in *LogPipelineSpec) DeepCopyInto(out *LogPipelineSpec) {
 		*out = make([]Filter, len(*in))
 		copy(*out, *in)
 	}
+	if in.Redaction != nil {
+		in, out := &in.Redaction, &out.Redaction
+		*out = new(LogPipelineRedaction)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Output.DeepCopyInto(&out.Output)
 	if in.Files != nil {
 		in, out := &in.Files, &out.Files
@@ -402,6 +529,13 @@ func (in *LogPipelineSpec) DeepCopyInto(out *LogPipelineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AdditionalOutputs != nil {
+		in, out := &in.AdditionalOutputs, &out.AdditionalOutputs
+		*out = make([]AdditionalOtlpOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogPipelineSpec.
@@ -424,6 +558,11 @@ func (in *LogPipelineStatus) DeepCopyInto(out *LogPipelineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DataFlow != nil {
+		in, out := &in.DataFlow, &out.DataFlow
+		*out = new(DataFlowStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogPipelineStatus.
@@ -516,6 +655,66 @@ func (in *MetricPipeline) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineAttributeFilter) DeepCopyInto(out *MetricPipelineAttributeFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineAttributeFilter.
+func (in *MetricPipelineAttributeFilter) DeepCopy() *MetricPipelineAttributeFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineAttributeFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineClusterInput) DeepCopyInto(out *MetricPipelineClusterInput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineClusterInput.
+func (in *MetricPipelineClusterInput) DeepCopy() *MetricPipelineClusterInput {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineClusterInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineFilters) DeepCopyInto(out *MetricPipelineFilters) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataPoints != nil {
+		in, out := &in.DataPoints, &out.DataPoints
+		*out = make([]MetricPipelineAttributeFilter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineFilters.
+func (in *MetricPipelineFilters) DeepCopy() *MetricPipelineFilters {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineFilters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricPipelineInput) DeepCopyInto(out *MetricPipelineInput) {
 	*out = *in
@@ -534,6 +733,16 @@ func (in *MetricPipelineInput) DeepCopyInto(out *MetricPipelineInput) {
 		*out = new(MetricPipelineIstioInput)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(MetricPipelineClusterInput)
+		**out = **in
+	}
+	if in.SpanMetrics != nil {
+		in, out := &in.SpanMetrics, &out.SpanMetrics
+		*out = new(MetricPipelineSpanMetricsInput)
+		**out = **in
+	}
 	if in.Otlp != nil {
 		in, out := &in.Otlp, &out.Otlp
 		*out = new(MetricPipelineOtlpInput)
@@ -686,6 +895,11 @@ func (in *MetricPipelinePrometheusInput) DeepCopyInto(out *MetricPipelinePrometh
 		*out = new(DiagnosticMetrics)
 		**out = **in
 	}
+	if in.Monitors != nil {
+		in, out := &in.Monitors, &out.Monitors
+		*out = new(MetricPipelinePrometheusInputMonitors)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelinePrometheusInput.
@@ -698,6 +912,21 @@ func (in *MetricPipelinePrometheusInput) DeepCopy() *MetricPipelinePrometheusInp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelinePrometheusInputMonitors) DeepCopyInto(out *MetricPipelinePrometheusInputMonitors) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelinePrometheusInputMonitors.
+func (in *MetricPipelinePrometheusInputMonitors) DeepCopy() *MetricPipelinePrometheusInputMonitors {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelinePrometheusInputMonitors)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricPipelineRuntimeInput) DeepCopyInto(out *MetricPipelineRuntimeInput) {
 	*out = *in
@@ -706,6 +935,11 @@ func (in *MetricPipelineRuntimeInput) DeepCopyInto(out *MetricPipelineRuntimeInp
 		*out = new(MetricPipelineInputNamespaceSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(MetricPipelineRuntimeInputResources)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineRuntimeInput.
@@ -718,11 +952,113 @@ func (in *MetricPipelineRuntimeInput) DeepCopy() *MetricPipelineRuntimeInput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineRuntimeInputResourceDisabledByDefault) DeepCopyInto(out *MetricPipelineRuntimeInputResourceDisabledByDefault) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineRuntimeInputResourceDisabledByDefault.
+func (in *MetricPipelineRuntimeInputResourceDisabledByDefault) DeepCopy() *MetricPipelineRuntimeInputResourceDisabledByDefault {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineRuntimeInputResourceDisabledByDefault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineRuntimeInputResourceEnabledByDefault) DeepCopyInto(out *MetricPipelineRuntimeInputResourceEnabledByDefault) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineRuntimeInputResourceEnabledByDefault.
+func (in *MetricPipelineRuntimeInputResourceEnabledByDefault) DeepCopy() *MetricPipelineRuntimeInputResourceEnabledByDefault {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineRuntimeInputResourceEnabledByDefault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineRuntimeInputResources) DeepCopyInto(out *MetricPipelineRuntimeInputResources) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(MetricPipelineRuntimeInputResourceEnabledByDefault)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(MetricPipelineRuntimeInputResourceEnabledByDefault)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Node != nil {
+		in, out := &in.Node, &out.Node
+		*out = new(MetricPipelineRuntimeInputResourceDisabledByDefault)
+		**out = **in
+	}
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(MetricPipelineRuntimeInputResourceDisabledByDefault)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineRuntimeInputResources.
+func (in *MetricPipelineRuntimeInputResources) DeepCopy() *MetricPipelineRuntimeInputResources {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineRuntimeInputResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPipelineSpanMetricsInput) DeepCopyInto(out *MetricPipelineSpanMetricsInput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineSpanMetricsInput.
+func (in *MetricPipelineSpanMetricsInput) DeepCopy() *MetricPipelineSpanMetricsInput {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPipelineSpanMetricsInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricPipelineSpec) DeepCopyInto(out *MetricPipelineSpec) {
 	*out = *in
 	in.Input.DeepCopyInto(&out.Input)
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = new(MetricPipelineFilters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transforms != nil {
+		in, out := &in.Transforms, &out.Transforms
+		*out = make([]TransformRule, len(*in))
+		copy(*out, *in)
+	}
 	in.Output.DeepCopyInto(&out.Output)
+	if in.AdditionalOutputs != nil {
+		in, out := &in.AdditionalOutputs, &out.AdditionalOutputs
+		*out = make([]AdditionalOtlpOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineSpec.
@@ -745,6 +1081,11 @@ func (in *MetricPipelineStatus) DeepCopyInto(out *MetricPipelineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DataFlow != nil {
+		in, out := &in.DataFlow, &out.DataFlow
+		*out = new(DataFlowStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPipelineStatus.
@@ -757,6 +1098,44 @@ func (in *MetricPipelineStatus) DeepCopy() *MetricPipelineStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2Options) DeepCopyInto(out *OAuth2Options) {
+	*out = *in
+	in.TokenURL.DeepCopyInto(&out.TokenURL)
+	in.ClientID.DeepCopyInto(&out.ClientID)
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2Options.
+func (in *OAuth2Options) DeepCopy() *OAuth2Options {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2Options)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OtlpDelivery) DeepCopyInto(out *OtlpDelivery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtlpDelivery.
+func (in *OtlpDelivery) DeepCopy() *OtlpDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(OtlpDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OtlpOutput) DeepCopyInto(out *OtlpOutput) {
 	*out = *in
@@ -778,6 +1157,11 @@ func (in *OtlpOutput) DeepCopyInto(out *OtlpOutput) {
 		*out = new(OtlpTLS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(OtlpDelivery)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OtlpOutput.
@@ -833,6 +1217,11 @@ func (in *Output) DeepCopyInto(out *Output) {
 		*out = new(LokiOutput)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Otlp != nil {
+		in, out := &in.Otlp, &out.Otlp
+		*out = new(OtlpOutput)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Output.
@@ -845,6 +1234,21 @@ func (in *Output) DeepCopy() *Output {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbabilisticSampling) DeepCopyInto(out *ProbabilisticSampling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbabilisticSampling.
+func (in *ProbabilisticSampling) DeepCopy() *ProbabilisticSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbabilisticSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
 	*out = *in
@@ -860,6 +1264,31 @@ func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemInput) DeepCopyInto(out *SystemInput) {
+	*out = *in
+	if in.Units != nil {
+		in, out := &in.Units, &out.Units
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemInput.
+func (in *SystemInput) DeepCopy() *SystemInput {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -890,6 +1319,88 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TailSampling) DeepCopyInto(out *TailSampling) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]TailSamplingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TailSampling.
+func (in *TailSampling) DeepCopy() *TailSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(TailSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TailSamplingAttributePolicy) DeepCopyInto(out *TailSamplingAttributePolicy) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TailSamplingAttributePolicy.
+func (in *TailSamplingAttributePolicy) DeepCopy() *TailSamplingAttributePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TailSamplingAttributePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TailSamplingLatencyPolicy) DeepCopyInto(out *TailSamplingLatencyPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TailSamplingLatencyPolicy.
+func (in *TailSamplingLatencyPolicy) DeepCopy() *TailSamplingLatencyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TailSamplingLatencyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TailSamplingPolicy) DeepCopyInto(out *TailSamplingPolicy) {
+	*out = *in
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(TailSamplingLatencyPolicy)
+		**out = **in
+	}
+	if in.Attribute != nil {
+		in, out := &in.Attribute, &out.Attribute
+		*out = new(TailSamplingAttributePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TailSamplingPolicy.
+func (in *TailSamplingPolicy) DeepCopy() *TailSamplingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TailSamplingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracePipeline) DeepCopyInto(out *TracePipeline) {
 	*out = *in
@@ -917,6 +1428,86 @@ func (in *TracePipeline) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineAttributeFilter) DeepCopyInto(out *TracePipelineAttributeFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineAttributeFilter.
+func (in *TracePipelineAttributeFilter) DeepCopy() *TracePipelineAttributeFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineAttributeFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineFilter) DeepCopyInto(out *TracePipelineFilter) {
+	*out = *in
+	if in.Attribute != nil {
+		in, out := &in.Attribute, &out.Attribute
+		*out = new(TracePipelineAttributeFilter)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineFilter.
+func (in *TracePipelineFilter) DeepCopy() *TracePipelineFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineInput) DeepCopyInto(out *TracePipelineInput) {
+	*out = *in
+	if in.Otlp != nil {
+		in, out := &in.Otlp, &out.Otlp
+		*out = new(TracePipelineOtlpInput)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineInput.
+func (in *TracePipelineInput) DeepCopy() *TracePipelineInput {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineInputNamespaceSelector) DeepCopyInto(out *TracePipelineInputNamespaceSelector) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineInputNamespaceSelector.
+func (in *TracePipelineInputNamespaceSelector) DeepCopy() *TracePipelineInputNamespaceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineInputNamespaceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracePipelineList) DeepCopyInto(out *TracePipelineList) {
 	*out = *in
@@ -949,6 +1540,26 @@ func (in *TracePipelineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineOtlpInput) DeepCopyInto(out *TracePipelineOtlpInput) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(TracePipelineInputNamespaceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineOtlpInput.
+func (in *TracePipelineOtlpInput) DeepCopy() *TracePipelineOtlpInput {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineOtlpInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracePipelineOutput) DeepCopyInto(out *TracePipelineOutput) {
 	*out = *in
@@ -969,10 +1580,85 @@ func (in *TracePipelineOutput) DeepCopy() *TracePipelineOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineSampling) DeepCopyInto(out *TracePipelineSampling) {
+	*out = *in
+	if in.Probabilistic != nil {
+		in, out := &in.Probabilistic, &out.Probabilistic
+		*out = new(ProbabilisticSampling)
+		**out = **in
+	}
+	if in.Tail != nil {
+		in, out := &in.Tail, &out.Tail
+		*out = new(TailSampling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineSampling.
+func (in *TracePipelineSampling) DeepCopy() *TracePipelineSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracePipelineSpanMetrics) DeepCopyInto(out *TracePipelineSpanMetrics) {
+	*out = *in
+	if in.Dimensions != nil {
+		in, out := &in.Dimensions, &out.Dimensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineSpanMetrics.
+func (in *TracePipelineSpanMetrics) DeepCopy() *TracePipelineSpanMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(TracePipelineSpanMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracePipelineSpec) DeepCopyInto(out *TracePipelineSpec) {
 	*out = *in
+	in.Input.DeepCopyInto(&out.Input)
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]TracePipelineFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(TracePipelineSampling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpanMetrics != nil {
+		in, out := &in.SpanMetrics, &out.SpanMetrics
+		*out = new(TracePipelineSpanMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transforms != nil {
+		in, out := &in.Transforms, &out.Transforms
+		*out = make([]TransformRule, len(*in))
+		copy(*out, *in)
+	}
 	in.Output.DeepCopyInto(&out.Output)
+	if in.AdditionalOutputs != nil {
+		in, out := &in.AdditionalOutputs, &out.AdditionalOutputs
+		*out = make([]AdditionalOtlpOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineSpec.
@@ -995,6 +1681,11 @@ func (in *TracePipelineStatus) DeepCopyInto(out *TracePipelineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DataFlow != nil {
+		in, out := &in.DataFlow, &out.DataFlow
+		*out = new(DataFlowStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracePipelineStatus.
@@ -1007,6 +1698,21 @@ func (in *TracePipelineStatus) DeepCopy() *TracePipelineStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformRule) DeepCopyInto(out *TransformRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformRule.
+func (in *TransformRule) DeepCopy() *TransformRule {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromSource) DeepCopyInto(out *ValueFromSource) {
 	*out = *in
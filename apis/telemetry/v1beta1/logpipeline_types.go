@@ -48,19 +48,67 @@ type LogPipelineSpec struct {
 	// Defines where to collect logs, including selector mechanisms.
 	Input   Input    `json:"input,omitempty"`
 	Filters []Filter `json:"filters,omitempty"`
+	// Masks sensitive data, such as email addresses or tokens, in the log records before they are shipped to the output.
+	//+optional
+	Redaction *LogPipelineRedaction `json:"redaction,omitempty"`
 	// [Fluent Bit output](https://docs.fluentbit.io/manual/pipeline/outputs) where you want to push the logs. Only one output can be specified.
 	Output Output      `json:"output,omitempty"`
 	Files  []FileMount `json:"files,omitempty"`
 	// A list of mappings from Kubernetes Secret keys to environment variables. Mapped keys are mounted as environment variables, so that they are available as [Variables](https://docs.fluentbit.io/manual/administration/configuring-fluent-bit/classic-mode/variables) in the sections.
 	Variables []VariableRef `json:"variables,omitempty"`
+
+	// Defines further OTLP backends that receive the same logs as the output, if the output is of type `otlp`, for example, for auditing or archiving. All outputs share the resources of the pipeline.
+	//+optional
+	//+listType=map
+	//+listMapKey=name
+	//+kubebuilder:validation:MaxItems=3
+	AdditionalOutputs []AdditionalOTLPOutput `json:"additionalOutputs,omitempty"`
 }
 
 // Input describes a log input for a LogPipeline.
 type Input struct {
 	// Configures in more detail from which containers application logs are enabled as input.
 	Application ApplicationInput `json:"application,omitempty"`
+	// Configures the collection of Kubernetes events as input, in addition to the application logs. The events are collected once for the whole cluster, independent of the nodes.
+	//+optional
+	KubernetesEvents *KubernetesEventsInput `json:"kubernetesEvents,omitempty"`
+	// Configures the collection of the logs of the Nodes, such as the logs of the kubelet, the container runtime, and the kernel, in addition to the application logs. System logs are not collected by default.
+	//+optional
+	System *SystemInput `json:"system,omitempty"`
+}
+
+// SystemInput configures which logs of the Nodes are selected as input. At least one of `units`, `kernel`, or `files` must be defined.
+// +kubebuilder:validation:XValidation:rule="(has(self.units) && size(self.units) > 0) || (has(self.kernel) && self.kernel) || (has(self.files) && size(self.files) > 0)", message="At least one of 'units', 'kernel', or 'files' must be defined"
+type SystemInput struct {
+	// Selects the systemd units whose journald logs are collected, for example, `kubelet.service` or `containerd.service`.
+	//+optional
+	Units []string `json:"units,omitempty"`
+	// Defines whether the kernel logs are collected from journald. The default is `false`.
+	//+optional
+	Kernel bool `json:"kernel,omitempty"`
+	// Glob patterns of the log files on the Node that are collected, for example, `/var/log/syslog*`. The files must be located in the `/var/log` directory, but not in the `/var/log/containers` or `/var/log/pods` directories of the container logs.
+	//+optional
+	Files []string `json:"files,omitempty"`
+}
+
+// KubernetesEventsInput configures in more detail which Kubernetes events are selected as input.
+type KubernetesEventsInput struct {
+	// Describes whether events from specific Namespaces are selected. The options are mutually exclusive. System Namespaces are excluded by default from the collection.
+	Namespaces InputNamespaces `json:"namespaces,omitempty"`
+	// Selects only the events of the given types. If not set, events of all types are selected.
+	//+optional
+	Types []KubernetesEventType `json:"types,omitempty"`
 }
 
+// KubernetesEventType is the type of a Kubernetes event.
+// +kubebuilder:validation:Enum=Normal;Warning
+type KubernetesEventType string
+
+const (
+	KubernetesEventTypeNormal  KubernetesEventType = "Normal"
+	KubernetesEventTypeWarning KubernetesEventType = "Warning"
+)
+
 // ApplicationInput specifies the default type of Input that handles application logs from runtime containers. It configures in more detail from which containers logs are selected as input.
 type ApplicationInput struct {
 	// Describes whether application logs from specific Namespaces are selected. The options are mutually exclusive. System Namespaces are excluded by default from the collection.
@@ -97,6 +145,35 @@ type Filter struct {
 	Custom string `json:"custom,omitempty"`
 }
 
+// LogPipelineRedaction masks sensitive data in the log records of a pipeline. At least one of `patterns` or `custom` must be defined.
+// +kubebuilder:validation:XValidation:rule="(has(self.patterns) && size(self.patterns) > 0) || (has(self.custom) && size(self.custom) > 0)", message="At least one of 'patterns' or 'custom' must be defined"
+type LogPipelineRedaction struct {
+	// Built-in patterns of sensitive data that are masked.
+	//+optional
+	Patterns []RedactionPattern `json:"patterns,omitempty"`
+	// Regular expressions whose matches are masked, for example, `token=\w+`. Supported are literals, the wildcard `.`, character classes, the escapes `\d`, `\s`, and `\w`, the quantifiers `*`, `+`, and `?`, and the anchors `^` and `$`. Groups, alternations, and counted repetitions are not supported.
+	//+optional
+	Custom []string `json:"custom,omitempty"`
+	// Top-level keys of the log record whose string values are redacted. The default is `log`, `message`, and `msg`.
+	//+optional
+	Keys []string `json:"keys,omitempty"`
+	// Text that replaces each match. The default is `[REDACTED]`.
+	//+optional
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// RedactionPattern is a built-in pattern of sensitive data. `CreditCard` only masks numbers with a valid checksum.
+// +kubebuilder:validation:Enum=Email;IPv4;IPv6;JWT;CreditCard
+type RedactionPattern string
+
+const (
+	RedactionPatternEmail      RedactionPattern = "Email"
+	RedactionPatternIPv4       RedactionPattern = "IPv4"
+	RedactionPatternIPv6       RedactionPattern = "IPv6"
+	RedactionPatternJWT        RedactionPattern = "JWT"
+	RedactionPatternCreditCard RedactionPattern = "CreditCard"
+)
+
 // Output describes a Fluent Bit output configuration section.
 type Output struct {
 	// Defines a custom output in the Fluent Bit syntax. Note: If you use a `custom` output, you put the LogPipeline in unsupported mode.
@@ -105,6 +182,8 @@ type Output struct {
 	HTTP *HTTPOutput `json:"http,omitempty"`
 	// The grafana-loki output is not supported anymore. For integration with a custom Loki installation, use the `custom` output and follow [Installing a custom Loki stack in Kyma](https://kyma-project.io/#/telemetry-manager/user/integration/loki/README ).
 	Loki *LokiOutput `json:"grafana-loki,omitempty"`
+	// Configures an OTLP output. Logs are not collected by Fluent Bit, but pushed by the workloads through OTLP to the log gateway, which ships them to the given backend. Cannot be combined with `filters`, `files`, or `variables`.
+	OTLP *OTLPOutput `json:"otlp,omitempty"`
 }
 
 // HTTPOutput configures an HTTP-based output compatible with the Fluent Bit HTTP output plugin.
@@ -190,6 +269,11 @@ func (i *Input) IsDefined() bool {
 	return i != nil
 }
 
+// IsJournaldDefined returns true if logs are collected from journald.
+func (si *SystemInput) IsJournaldDefined() bool {
+	return si != nil && (len(si.Units) > 0 || si.Kernel)
+}
+
 func (o *Output) IsCustomDefined() bool {
 	return o.Custom != ""
 }
@@ -202,6 +286,10 @@ func (o *Output) IsLokiDefined() bool {
 	return o.Loki != nil && o.Loki.URL.IsDefined()
 }
 
+func (o *Output) IsOTLPDefined() bool {
+	return o.OTLP != nil
+}
+
 func (o *Output) IsAnyDefined() bool {
 	return o.pluginCount() > 0
 }
@@ -221,6 +309,9 @@ func (o *Output) pluginCount() int {
 	if o.IsLokiDefined() {
 		plugins++
 	}
+	if o.IsOTLPDefined() {
+		plugins++
+	}
 	return plugins
 }
 
@@ -53,11 +53,25 @@ func (lp *LogPipeline) GetTLSSecretRefs() []SecretKeyRef {
 }
 
 func (tp *TracePipeline) GetSecretRefs() []SecretKeyRef {
-	return getRefsInOTLPOutput(tp.Spec.Output.OTLP)
+	refs := getRefsInOTLPOutput(tp.Spec.Output.OTLP)
+	return append(refs, getRefsInAdditionalOTLPOutputs(tp.Spec.AdditionalOutputs)...)
 }
 
 func (mp *MetricPipeline) GetSecretRefs() []SecretKeyRef {
-	return getRefsInOTLPOutput(mp.Spec.Output.OTLP)
+	refs := getRefsInOTLPOutput(mp.Spec.Output.OTLP)
+	return append(refs, getRefsInAdditionalOTLPOutputs(mp.Spec.AdditionalOutputs)...)
+}
+
+func getRefsInAdditionalOTLPOutputs(outputs []AdditionalOTLPOutput) []SecretKeyRef {
+	var refs []SecretKeyRef
+
+	for i := range outputs {
+		if outputs[i].OTLP != nil {
+			refs = append(refs, getRefsInOTLPOutput(outputs[i].OTLP)...)
+		}
+	}
+
+	return refs
 }
 
 func getRefsInOTLPOutput(OTLPOut *OTLPOutput) []SecretKeyRef {
@@ -70,6 +84,16 @@ func getRefsInOTLPOutput(OTLPOut *OTLPOutput) []SecretKeyRef {
 		refs = appendIfSecretRef(refs, OTLPOut.Authentication.Basic.Password)
 	}
 
+	if OTLPOut.Authentication != nil && OTLPOut.Authentication.OAuth2.IsDefined() {
+		refs = appendIfSecretRef(refs, OTLPOut.Authentication.OAuth2.TokenURL)
+		refs = appendIfSecretRef(refs, OTLPOut.Authentication.OAuth2.ClientID)
+		refs = appendIfSecretRef(refs, OTLPOut.Authentication.OAuth2.ClientSecret)
+	}
+
+	if OTLPOut.Authentication != nil && OTLPOut.Authentication.BearerToken.IsDefined() {
+		refs = appendIfSecretRef(refs, *OTLPOut.Authentication.BearerToken)
+	}
+
 	for _, header := range OTLPOut.Headers {
 		refs = appendIfSecretRef(refs, header.ValueType)
 	}
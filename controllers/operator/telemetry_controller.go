@@ -18,9 +18,12 @@ package operator
 
 import (
 	"context"
+	"slices"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -75,6 +78,18 @@ func (r *TelemetryController) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&telemetryv1alpha1.MetricPipeline{},
 			handler.EnqueueRequestsFromMapFunc(r.mapMetricPipeline),
+			builder.WithPredicates(predicate.CreateOrUpdateOrDelete())).
+		Watches(
+			&appsv1.Deployment{},
+			handler.EnqueueRequestsFromMapFunc(r.mapComponentWorkload),
+			builder.WithPredicates(predicate.CreateOrUpdateOrDelete())).
+		Watches(
+			&appsv1.DaemonSet{},
+			handler.EnqueueRequestsFromMapFunc(r.mapComponentWorkload),
+			builder.WithPredicates(predicate.CreateOrUpdateOrDelete())).
+		Watches(
+			&appsv1.StatefulSet{},
+			handler.EnqueueRequestsFromMapFunc(r.mapComponentWorkload),
 			builder.WithPredicates(predicate.CreateOrUpdateOrDelete()))
 
 	return b.Complete(r)
@@ -132,6 +147,21 @@ func (r *TelemetryController) mapMetricPipeline(ctx context.Context, object clie
 	return r.createTelemetryRequests(ctx)
 }
 
+// mapComponentWorkload triggers a reconciliation if a workload changes whose resources are reported in the Telemetry status.
+func (r *TelemetryController) mapComponentWorkload(ctx context.Context, object client.Object) []reconcile.Request {
+	componentWorkloads := []types.NamespacedName{
+		{Namespace: r.config.Traces.Namespace, Name: r.config.Traces.GatewayName},
+		{Namespace: r.config.Metrics.Namespace, Name: r.config.Metrics.GatewayName},
+		{Namespace: r.config.Metrics.Namespace, Name: r.config.Metrics.AgentName},
+		{Namespace: r.config.Logs.Namespace, Name: r.config.Logs.FluentBitName},
+	}
+	if !slices.Contains(componentWorkloads, client.ObjectKeyFromObject(object)) {
+		return nil
+	}
+
+	return r.createTelemetryRequests(ctx)
+}
+
 func (r *TelemetryController) createTelemetryRequests(ctx context.Context) []reconcile.Request {
 	var telemetries operatorv1alpha1.TelemetryList
 	err := r.List(ctx, &telemetries)
@@ -19,16 +19,23 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -38,6 +45,7 @@ import (
 	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/predicate"
+	"github.com/kyma-project/telemetry-manager/internal/prometheusmonitor"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/metricpipeline"
 )
 
@@ -46,6 +54,13 @@ type MetricPipelineController struct {
 	client.Client
 	reconcileTriggerChan <-chan event.GenericEvent
 	reconciler           *metricpipeline.Reconciler
+
+	// The ServiceMonitor and PodMonitor CRDs can be installed after the controller is set up,
+	// so their watches are added when the CRDs are created.
+	controller              controller.Controller
+	cache                   cache.Cache
+	prometheusMonitorsMutex sync.Mutex
+	watchedMonitors         map[schema.GroupVersionKind]bool
 }
 
 func NewMetricPipelineController(client client.Client, reconcileTriggerChan <-chan event.GenericEvent, reconciler *metricpipeline.Reconciler) *MetricPipelineController {
@@ -53,6 +68,7 @@ func NewMetricPipelineController(client client.Client, reconcileTriggerChan <-ch
 		Client:               client,
 		reconcileTriggerChan: reconcileTriggerChan,
 		reconciler:           reconciler,
+		watchedMonitors:      make(map[schema.GroupVersionKind]bool),
 	}
 }
 
@@ -70,6 +86,8 @@ func (r *MetricPipelineController) SetupWithManager(mgr ctrl.Manager) error {
 
 	ownedResourceTypesToWatch := []client.Object{
 		&appsv1.Deployment{},
+		&appsv1.StatefulSet{},
+		&autoscalingv2.HorizontalPodAutoscaler{},
 		&appsv1.DaemonSet{},
 		&corev1.ConfigMap{},
 		&corev1.Secret{},
@@ -91,7 +109,7 @@ func (r *MetricPipelineController) SetupWithManager(mgr ctrl.Manager) error {
 		)
 	}
 
-	return b.Watches(
+	c, err := b.Watches(
 		&apiextensionsv1.CustomResourceDefinition{},
 		handler.EnqueueRequestsFromMapFunc(r.mapCRDChanges),
 		builder.WithPredicates(predicate.CreateOrDelete()),
@@ -99,16 +117,79 @@ func (r *MetricPipelineController) SetupWithManager(mgr ctrl.Manager) error {
 		&operatorv1alpha1.Telemetry{},
 		handler.EnqueueRequestsFromMapFunc(r.mapTelemetryChanges),
 		builder.WithPredicates(predicate.CreateOrUpdateOrDelete()),
-	).Complete(r)
+	).Build(r)
+	if err != nil {
+		return err
+	}
+
+	r.controller = c
+	r.cache = mgr.GetCache()
+
+	// A watch for a kind that is not served would block the start of the controller,
+	// so ServiceMonitors and PodMonitors are only watched once their CRDs are installed
+	for _, gvk := range []schema.GroupVersionKind{prometheusmonitor.ServiceMonitorGVK, prometheusmonitor.PodMonitorGVK} {
+		if _, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if !meta.IsNoMatchError(err) {
+				return fmt.Errorf("failed to check if %s is served: %w", gvk.Kind, err)
+			}
+			continue
+		}
+
+		if err := r.watchPrometheusMonitor(gvk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// watchPrometheusMonitor watches the monitors of the given kind, unless they are already watched.
+func (r *MetricPipelineController) watchPrometheusMonitor(gvk schema.GroupVersionKind) error {
+	r.prometheusMonitorsMutex.Lock()
+	defer r.prometheusMonitorsMutex.Unlock()
+
+	if r.watchedMonitors[gvk] {
+		return nil
+	}
+
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(gvk)
+	if err := r.controller.Watch(source.Kind[client.Object](
+		r.cache,
+		monitor,
+		handler.EnqueueRequestsFromMapFunc(r.mapPrometheusMonitorChanges),
+		predicate.CreateOrUpdateOrDelete(),
+	)); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", gvk.Kind, err)
+	}
+
+	r.watchedMonitors[gvk] = true
+	return nil
 }
 
 func (r *MetricPipelineController) mapCRDChanges(ctx context.Context, object client.Object) []reconcile.Request {
-	_, ok := object.(*apiextensionsv1.CustomResourceDefinition)
+	crd, ok := object.(*apiextensionsv1.CustomResourceDefinition)
 	if !ok {
 		logf.FromContext(ctx).V(1).Error(nil, "Unexpected type: expected CRD")
 		return nil
 	}
 
+	for _, gvk := range []schema.GroupVersionKind{prometheusmonitor.ServiceMonitorGVK, prometheusmonitor.PodMonitorGVK} {
+		if crd.Spec.Group == gvk.Group && crd.Spec.Names.Kind == gvk.Kind && crd.DeletionTimestamp.IsZero() {
+			if err := r.watchPrometheusMonitor(gvk); err != nil {
+				logf.FromContext(ctx).Error(err, "Unable to watch Prometheus monitors")
+			}
+		}
+	}
+
+	requests, err := r.createRequestsForAllPipelines(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Unable to create reconcile requests")
+	}
+	return requests
+}
+
+func (r *MetricPipelineController) mapPrometheusMonitorChanges(ctx context.Context, _ client.Object) []reconcile.Request {
 	requests, err := r.createRequestsForAllPipelines(ctx)
 	if err != nil {
 		logf.FromContext(ctx).Error(err, "Unable to create reconcile requests")
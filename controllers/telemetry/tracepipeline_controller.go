@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -31,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlpredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -68,6 +70,8 @@ func (r *TracePipelineController) SetupWithManager(mgr ctrl.Manager) error {
 
 	ownedResourceTypesToWatch := []client.Object{
 		&appsv1.Deployment{},
+		&appsv1.StatefulSet{},
+		&autoscalingv2.HorizontalPodAutoscaler{},
 		&corev1.ConfigMap{},
 		&corev1.Secret{},
 		&corev1.Service{},
@@ -92,9 +96,28 @@ func (r *TracePipelineController) SetupWithManager(mgr ctrl.Manager) error {
 		&operatorv1alpha1.Telemetry{},
 		handler.EnqueueRequestsFromMapFunc(r.mapTelemetryChanges),
 		builder.WithPredicates(predicate.CreateOrUpdateOrDelete()),
+	).Watches(
+		// Span metrics are only generated if a MetricPipeline collects them
+		&telemetryv1alpha1.MetricPipeline{},
+		handler.EnqueueRequestsFromMapFunc(r.mapMetricPipelineChanges),
+		builder.WithPredicates(predicate.CreateOrUpdateOrDelete(), ctrlpredicate.GenerationChangedPredicate{}),
 	).Complete(r)
 }
 
+func (r *TracePipelineController) mapMetricPipelineChanges(ctx context.Context, object client.Object) []reconcile.Request {
+	_, ok := object.(*telemetryv1alpha1.MetricPipeline)
+	if !ok {
+		logf.FromContext(ctx).V(1).Error(nil, "Unexpected type: expected MetricPipeline")
+		return nil
+	}
+
+	requests, err := r.createRequestsForAllPipelines(ctx)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Unable to create reconcile requests")
+	}
+	return requests
+}
+
 func (r *TracePipelineController) mapTelemetryChanges(ctx context.Context, object client.Object) []reconcile.Request {
 	_, ok := object.(*operatorv1alpha1.Telemetry)
 	if !ok {
@@ -1,5 +1,11 @@
 package conditions
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
 const (
 	TypeAgentHealthy            = "AgentHealthy"
 	TypeConfigurationGenerated  = "ConfigurationGenerated"
@@ -34,6 +40,7 @@ const (
 	ReasonGatewayReady                = "GatewayReady"
 	ReasonMaxPipelinesExceeded        = "MaxPipelinesExceeded"
 	ReasonReferencedSecretMissing     = "ReferencedSecretMissing"
+	ReasonResourceRequirementsInvalid = "ResourceRequirementsInvalid"
 	ReasonSelfMonAllDataDropped       = "AllTelemetryDataDropped"
 	ReasonSelfMonBufferFillingUp      = "BufferFillingUp"
 	ReasonSelfMonFlowHealthy          = "Healthy"
@@ -44,11 +51,21 @@ const (
 	ReasonTLSCertificateInvalid       = "TLSCertificateInvalid"
 
 	// LogPipeline reasons
+	ReasonRedactionInvalid       = "RedactionInvalid"
 	ReasonSelfMonNoLogsDelivered = "NoLogsDelivered"
 	ReasonUnsupportedLokiOutput  = "UnsupportedLokiOutput"
 
 	// MetricPipeline reasons
-	ReasonMetricAgentNotRequired = "AgentNotRequired"
+	ReasonMetricAgentNotRequired           = "AgentNotRequired"
+	ReasonPrometheusMonitorsSkipped        = "PrometheusMonitorsSkipped"
+	ReasonScrapeSettingsOverridden         = "ScrapeSettingsOverridden"
+	ReasonSelfMonAgentAllDataDropped       = "AgentAllTelemetryDataDropped"
+	ReasonSelfMonAgentSomeDataDropped      = "AgentSomeTelemetryDataDropped"
+	ReasonSelfMonScrapeSampleLimitExceeded = "ScrapeSampleLimitExceeded"
+	ReasonSelfMonScrapeTargetsDown         = "ScrapeTargetsDown"
+
+	// TracePipeline reasons
+	ReasonFilterInvalid = "FilterInvalid"
 
 	// NOTE: The "FluentBitDaemonSetNotReady", "FluentBitDaemonSetReady", "TraceGatewayDeploymentNotReady" and "TraceGatewayDeploymentReady" reasons are deprecated.
 	// They will be removed when the "Running" and "Pending" types are removed
@@ -63,27 +80,33 @@ var commonMessages = map[string]string{
 	ReasonMaxPipelinesExceeded:        "Maximum pipeline count limit exceeded",
 	ReasonNoPipelineDeployed:          "No pipelines have been deployed",
 	ReasonReferencedSecretMissing:     "One or more referenced Secrets are missing",
+	ReasonResourceRequirementsInvalid: "Resource requests in the Telemetry resource exceed their limits: the default resources are used",
 	ReasonTLSCertificateAboutToExpire: "TLS certificate is about to expire, configured certificate is valid until %s",
 	ReasonTLSCertificateExpired:       "TLS certificate expired on %s",
 	ReasonTLSCertificateInvalid:       "TLS certificate invalid: %s",
 }
 
 var logPipelineMessages = map[string]string{
-	ReasonAgentNotReady:          "Fluent Bit agent DaemonSet is not ready",
-	ReasonAgentReady:             "Fluent Bit agent DaemonSet is ready",
-	ReasonComponentsRunning:      "All log components are running",
-	ReasonFluentBitDSNotReady:    "Fluent Bit DaemonSet is not ready",
-	ReasonFluentBitDSReady:       "Fluent Bit DaemonSet is ready",
-	ReasonSelfMonAllDataDropped:  "All logs dropped: backend unreachable or rejecting",
-	ReasonSelfMonBufferFillingUp: "Buffer nearing capacity: incoming log rate exceeds export rate",
-	ReasonSelfMonFlowHealthy:     "No problems detected in the log flow",
-	ReasonSelfMonNoLogsDelivered: "No logs delivered to backend",
-	ReasonSelfMonSomeDataDropped: "Some logs dropped: backend unreachable or rejecting",
-	ReasonUnsupportedLokiOutput:  "grafana-loki output is not supported anymore. For integration with a custom Loki installation, use the `custom` output and follow https://kyma-project.io/#/telemetry-manager/user/integration/loki/README",
+	ReasonAgentNotReady:            "Fluent Bit agent DaemonSet is not ready",
+	ReasonAgentReady:               "Fluent Bit agent DaemonSet is ready",
+	ReasonComponentsRunning:        "All log components are running",
+	ReasonFluentBitDSNotReady:      "Fluent Bit DaemonSet is not ready",
+	ReasonFluentBitDSReady:         "Fluent Bit DaemonSet is ready",
+	ReasonGatewayNotReady:          "Log gateway Deployment is not ready",
+	ReasonGatewayReady:             "Log gateway Deployment is ready",
+	ReasonRedactionInvalid:         "Redaction contains a pattern that is not supported: the pipeline is not deployed",
+	ReasonSelfMonAllDataDropped:    "All logs dropped: backend unreachable or rejecting",
+	ReasonSelfMonBufferFillingUp:   "Buffer nearing capacity: incoming log rate exceeds export rate",
+	ReasonSelfMonFlowHealthy:       "No problems detected in the log flow",
+	ReasonSelfMonGatewayThrottling: "Log gateway experiencing high influx: unable to receive logs at current rate",
+	ReasonSelfMonNoLogsDelivered:   "No logs delivered to backend",
+	ReasonSelfMonSomeDataDropped:   "Some logs dropped: backend unreachable or rejecting",
+	ReasonUnsupportedLokiOutput:    "grafana-loki output is not supported anymore. For integration with a custom Loki installation, use the `custom` output and follow https://kyma-project.io/#/telemetry-manager/user/integration/loki/README",
 }
 
 var tracePipelineMessages = map[string]string{
 	ReasonComponentsRunning:              "All trace components are running",
+	ReasonFilterInvalid:                  "Filter cannot be parsed: the pipeline is not deployed",
 	ReasonGatewayNotReady:                "Trace gateway Deployment is not ready",
 	ReasonGatewayReady:                   "Trace gateway Deployment is ready",
 	ReasonSelfMonAllDataDropped:          "All traces dropped: backend unreachable or rejecting",
@@ -96,16 +119,22 @@ var tracePipelineMessages = map[string]string{
 }
 
 var metricPipelineMessages = map[string]string{
-	ReasonAgentNotReady:            "Metric agent DaemonSet is not ready",
-	ReasonAgentReady:               "Metric agent DaemonSet is ready",
-	ReasonComponentsRunning:        "All metric components are running",
-	ReasonGatewayNotReady:          "Metric gateway Deployment is not ready",
-	ReasonGatewayReady:             "Metric gateway Deployment is ready",
-	ReasonSelfMonAllDataDropped:    "All metrics dropped: backend unreachable or rejecting",
-	ReasonSelfMonBufferFillingUp:   "Buffer nearing capacity: incoming metric rate exceeds export rate",
-	ReasonSelfMonFlowHealthy:       "No problems detected in the metric flow",
-	ReasonSelfMonGatewayThrottling: "Metric gateway experiencing high influx: unable to receive metrics at current rate",
-	ReasonSelfMonSomeDataDropped:   "Some metrics dropped: backend unreachable or rejecting",
+	ReasonAgentNotReady:                    "Metric agent DaemonSet is not ready",
+	ReasonAgentReady:                       "Metric agent DaemonSet is ready",
+	ReasonComponentsRunning:                "All metric components are running",
+	ReasonGatewayNotReady:                  "Metric gateway Deployment is not ready",
+	ReasonGatewayReady:                     "Metric gateway Deployment is ready",
+	ReasonSelfMonAgentAllDataDropped:       "All metrics dropped by the metric agent: metric gateway unreachable or rejecting",
+	ReasonSelfMonAgentSomeDataDropped:      "Some metrics dropped by the metric agent: metric gateway unreachable or rejecting",
+	ReasonSelfMonAllDataDropped:            "All metrics dropped: backend unreachable or rejecting",
+	ReasonSelfMonBufferFillingUp:           "Buffer nearing capacity: incoming metric rate exceeds export rate",
+	ReasonSelfMonFlowHealthy:               "No problems detected in the metric flow",
+	ReasonSelfMonGatewayThrottling:         "Metric gateway experiencing high influx: unable to receive metrics at current rate",
+	ReasonSelfMonScrapeSampleLimitExceeded: "Prometheus scrape targets exceed the sample limit: their metrics are dropped",
+	ReasonSelfMonScrapeTargetsDown:         "Prometheus scrape targets are down: their metrics are not collected",
+	ReasonSelfMonSomeDataDropped:           "Some metrics dropped: backend unreachable or rejecting",
+	ReasonScrapeSettingsOverridden:         "Prometheus scrape settings are overridden by other pipelines: %s",
+	ReasonPrometheusMonitorsSkipped:        "Some ServiceMonitors or PodMonitors are not scraped: %s",
 }
 
 func MessageForLogPipeline(reason string) string {
@@ -129,3 +158,28 @@ func message(reason string, specializedMessages map[string]string) string {
 	}
 	return ""
 }
+
+// MessageForResourceRequirementsInvalid returns the message for invalid resource requirements including the validation error.
+// The Telemetry resource only shows the message without the error, because it does not refer to a single workload.
+func MessageForResourceRequirementsInvalid(errValidation error) string {
+	return fmt.Sprintf("%s (%s)", commonMessages[ReasonResourceRequirementsInvalid], errValidation)
+}
+
+// WithAffectedOutputs appends the names of the affected outputs of a pipeline to a condition message.
+// The primary output has an empty name. A pipeline without additional outputs has only one output that can be affected, so the message is only extended if an additional output is affected.
+func WithAffectedOutputs(message string, outputNames []string) string {
+	if !slices.ContainsFunc(outputNames, func(name string) bool { return name != "" }) {
+		return message
+	}
+
+	outputs := make([]string, 0, len(outputNames))
+	for _, name := range outputNames {
+		if name == "" {
+			outputs = append(outputs, "output")
+		} else {
+			outputs = append(outputs, fmt.Sprintf("additionalOutputs[%s]", name))
+		}
+	}
+
+	return fmt.Sprintf("%s (affected outputs: %s)", message, strings.Join(outputs, ", "))
+}
@@ -1,6 +1,7 @@
 package conditions
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -28,3 +29,18 @@ func TestMessageFor(t *testing.T) {
 		require.Equal(t, "", metricsAgentNotRequiredMessage)
 	})
 }
+
+func TestWithAffectedOutputs(t *testing.T) {
+	message := MessageForTracePipeline(ReasonSelfMonSomeDataDropped)
+
+	require.Equal(t, message, WithAffectedOutputs(message, nil))
+	require.Equal(t, message, WithAffectedOutputs(message, []string{""}))
+	require.Equal(t, message+" (affected outputs: additionalOutputs[audit])", WithAffectedOutputs(message, []string{"audit"}))
+	require.Equal(t, message+" (affected outputs: output, additionalOutputs[audit])", WithAffectedOutputs(message, []string{"", "audit"}))
+}
+
+func TestMessageForResourceRequirementsInvalid(t *testing.T) {
+	message := MessageForResourceRequirementsInvalid(errors.New("CPU request 2 exceeds CPU limit 1"))
+
+	require.Equal(t, "Resource requests in the Telemetry resource exceed their limits: the default resources are used (CPU request 2 exceeds CPU limit 1)", message)
+}
@@ -6,6 +6,7 @@ import (
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
 )
 
 type PipelineDefaults struct {
@@ -19,6 +20,8 @@ type PipelineDefaults struct {
 type BuilderConfig struct {
 	PipelineDefaults
 	CollectAgentLogs bool
+	// TapEndpoint is set if the tap of the pipeline is active. The pipeline then gets an additional output, which sends the logs to the telemetry manager.
+	TapEndpoint *tap.Endpoint
 }
 
 // BuildFluentBitConfig merges Fluent Bit filters and outputs to a single Fluent Bit configuration.
@@ -33,16 +36,24 @@ func BuildFluentBitConfig(pipeline *telemetryv1alpha1.LogPipeline, config Builde
 		return "", err
 	}
 
+	err = pipeline.ValidateRedaction()
+	if err != nil {
+		return "", err
+	}
+
 	includePath := createIncludePath(pipeline)
 	excludePath := createExcludePath(pipeline, config.CollectAgentLogs)
 
 	var sb strings.Builder
 	sb.WriteString(createInputSection(pipeline, includePath, excludePath))
+	sb.WriteString(createSystemInputSections(pipeline))
 	sb.WriteString(createRecordModifierFilter(pipeline))
 	sb.WriteString(createKubernetesFilter(pipeline))
 	sb.WriteString(createCustomFilters(pipeline))
+	sb.WriteString(createRedactionFilter(pipeline))
 	sb.WriteString(createLuaDedotFilter(pipeline))
 	sb.WriteString(createOutputSection(pipeline, config.PipelineDefaults))
+	sb.WriteString(createTapOutputSection(pipeline, config.TapEndpoint))
 
 	return sb.String(), nil
 }
@@ -64,7 +75,7 @@ func createLuaDedotFilter(logPipeline *telemetryv1alpha1.LogPipeline) string {
 	return NewFilterSectionBuilder().
 		AddConfigParam("name", "lua").
 		AddConfigParam("match", fmt.Sprintf("%s.*", logPipeline.Name)).
-		AddConfigParam("script", "/fluent-bit/scripts/filter-script.lua").
+		AddConfigParam("script", luaScriptPath).
 		AddConfigParam("call", "kubernetes_map_keys").
 		Build()
 }
@@ -260,3 +260,19 @@ func TestMergeSectionsConfigWithMissingOutput(t *testing.T) {
 	require.Error(t, err)
 	require.Empty(t, actual)
 }
+
+func TestMergeSectionsConfigWithUnsupportedRedactionPattern(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Output: telemetryv1alpha1.Output{
+				HTTP: &telemetryv1alpha1.HTTPOutput{Host: telemetryv1alpha1.ValueType{Value: "localhost"}},
+			},
+			Redaction: &telemetryv1alpha1.LogPipelineRedaction{Custom: []string{`a|b`}},
+		},
+	}
+
+	actual, err := BuildFluentBitConfig(logPipeline, BuilderConfig{})
+	require.ErrorContains(t, err, "invalid redaction pattern")
+	require.Empty(t, actual)
+}
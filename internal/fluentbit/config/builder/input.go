@@ -25,6 +25,50 @@ func createInputSection(pipeline *telemetryv1alpha1.LogPipeline, includePath, ex
 	return inputBuilder.Build()
 }
 
+// createSystemInputSections creates the inputs for the logs of the Nodes. Their tags don't start with the prefix of the
+// container logs, so that they skip the Kubernetes filter.
+func createSystemInputSections(pipeline *telemetryv1alpha1.LogPipeline) string {
+	system := pipeline.Spec.Input.System
+	if system == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	if system.IsJournaldDefined() {
+		inputBuilder := NewInputSectionBuilder()
+		inputBuilder.AddConfigParam("name", "systemd")
+		inputBuilder.AddConfigParam("alias", fmt.Sprintf("%s-journal", pipeline.Name))
+		inputBuilder.AddConfigParam("tag", fmt.Sprintf("%s.system.journal", pipeline.Name))
+		for _, unit := range system.Units {
+			inputBuilder.AddConfigParam("systemd_filter", fmt.Sprintf("_SYSTEMD_UNIT=%s", unit))
+		}
+		if system.Kernel {
+			inputBuilder.AddConfigParam("systemd_filter", "_TRANSPORT=kernel")
+		}
+		inputBuilder.AddConfigParam("db", fmt.Sprintf("/data/flb_%s_journal.db", pipeline.Name))
+		inputBuilder.AddConfigParam("read_from_tail", "on")
+		inputBuilder.AddConfigParam("strip_underscores", "on")
+		inputBuilder.AddConfigParam("storage.type", "filesystem")
+		inputBuilder.AddConfigParam("mem_buf_limit", "5MB")
+		sb.WriteString(inputBuilder.Build())
+	}
+
+	if len(system.Files) > 0 {
+		inputBuilder := NewInputSectionBuilder()
+		inputBuilder.AddConfigParam("name", "tail")
+		inputBuilder.AddConfigParam("alias", fmt.Sprintf("%s-system", pipeline.Name))
+		inputBuilder.AddConfigParam("path", strings.Join(system.Files, ","))
+		inputBuilder.AddConfigParam("tag", fmt.Sprintf("%s.system.*", pipeline.Name))
+		inputBuilder.AddConfigParam("skip_long_lines", "on")
+		inputBuilder.AddConfigParam("db", fmt.Sprintf("/data/flb_%s_system.db", pipeline.Name))
+		inputBuilder.AddConfigParam("storage.type", "filesystem")
+		inputBuilder.AddConfigParam("mem_buf_limit", "5MB")
+		sb.WriteString(inputBuilder.Build())
+	}
+
+	return sb.String()
+}
+
 func createIncludePath(pipeline *telemetryv1alpha1.LogPipeline) string {
 	var includePath []string
 
@@ -361,3 +361,68 @@ func TestCreateIncludeAndExcludePath(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateSystemInputSections(t *testing.T) {
+	expected := `[INPUT]
+    name              systemd
+    alias             test-logpipeline-journal
+    db                /data/flb_test-logpipeline_journal.db
+    mem_buf_limit     5MB
+    read_from_tail    on
+    storage.type      filesystem
+    strip_underscores on
+    systemd_filter    _SYSTEMD_UNIT=containerd.service
+    systemd_filter    _SYSTEMD_UNIT=kubelet.service
+    systemd_filter    _TRANSPORT=kernel
+    tag               test-logpipeline.system.journal
+
+[INPUT]
+    name            tail
+    alias           test-logpipeline-system
+    db              /data/flb_test-logpipeline_system.db
+    mem_buf_limit   5MB
+    path            /var/log/syslog*,/var/log/kube-proxy/*.log
+    skip_long_lines on
+    storage.type    filesystem
+    tag             test-logpipeline.system.*
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-logpipeline"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Input: telemetryv1alpha1.Input{
+				System: &telemetryv1alpha1.SystemInput{
+					Units:  []string{"kubelet.service", "containerd.service"},
+					Kernel: true,
+					Files:  []string{"/var/log/syslog*", "/var/log/kube-proxy/*.log"},
+				},
+			},
+		},
+	}
+
+	actual := createSystemInputSections(logPipeline)
+	require.Equal(t, expected, actual)
+}
+
+func TestCreateSystemInputSectionsWithFilesOnly(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-logpipeline"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Input: telemetryv1alpha1.Input{
+				System: &telemetryv1alpha1.SystemInput{Files: []string{"/var/log/syslog"}},
+			},
+		},
+	}
+
+	actual := createSystemInputSections(logPipeline)
+	require.NotContains(t, actual, "systemd")
+	require.Contains(t, actual, "path            /var/log/syslog\n")
+}
+
+func TestCreateSystemInputSectionsWithoutSystemInput(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-logpipeline"},
+	}
+
+	require.Empty(t, createSystemInputSections(logPipeline))
+}
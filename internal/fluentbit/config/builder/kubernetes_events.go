@@ -0,0 +1,123 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/namespaces"
+)
+
+const kubernetesEventsTag = "kube-events"
+
+// BuildKubernetesEventsConfig generates the sections of the Fluent Bit instance that collects the Kubernetes events.
+// The events are collected once and copied to the tag of every pipeline with the kubernetesEvents input, so that they run
+// through the same filters and output as the application logs of the pipeline. A pipeline that cannot be built is skipped,
+// so that it does not stop the collection for the other pipelines. Its status already reports the problem.
+// If no pipeline selects Kubernetes events, the result is empty.
+func BuildKubernetesEventsConfig(pipelines []telemetryv1alpha1.LogPipeline, defaults PipelineDefaults) string {
+	var sb strings.Builder
+	for i := range pipelines {
+		pipeline := &pipelines[i]
+		if pipeline.Spec.Input.KubernetesEvents == nil || pipeline.Spec.Output.IsOtlpDefined() {
+			continue
+		}
+
+		if validateOutput(pipeline) != nil || validateCustomSections(pipeline) != nil || pipeline.ValidateRedaction() != nil {
+			continue
+		}
+
+		sb.WriteString(createKubernetesEventsRewriteTagFilter(pipeline, defaults))
+		sb.WriteString(createKubernetesEventsNamespaceFilter(pipeline))
+		sb.WriteString(createRecordModifierFilter(pipeline))
+		sb.WriteString(createKubernetesEventsMetadataFilter(pipeline))
+		sb.WriteString(createCustomFilters(pipeline))
+		sb.WriteString(createRedactionFilter(pipeline))
+		sb.WriteString(createLuaDedotFilter(pipeline))
+		sb.WriteString(createOutputSection(pipeline, defaults))
+	}
+
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	return createKubernetesEventsInputSection() + createKubernetesEventsNullOutputSection() + sb.String()
+}
+
+func createKubernetesEventsInputSection() string {
+	return NewInputSectionBuilder().
+		AddConfigParam("name", "kubernetes_events").
+		AddConfigParam("alias", kubernetesEventsTag).
+		AddConfigParam("tag", kubernetesEventsTag).
+		AddConfigParam("db", "/data/flb_kube_events.db").
+		AddConfigParam("storage.type", "filesystem").
+		Build()
+}
+
+// createKubernetesEventsNullOutputSection discards the original events after they have been copied to the pipelines.
+func createKubernetesEventsNullOutputSection() string {
+	return NewOutputSectionBuilder().
+		AddConfigParam("name", "null").
+		AddConfigParam("match", kubernetesEventsTag).
+		AddConfigParam("alias", kubernetesEventsTag).
+		Build()
+}
+
+// createKubernetesEventsRewriteTagFilter copies the events of the selected types to the tag of the pipeline.
+func createKubernetesEventsRewriteTagFilter(pipeline *telemetryv1alpha1.LogPipeline, defaults PipelineDefaults) string {
+	typeRegex := "^.*$"
+	if types := pipeline.Spec.Input.KubernetesEvents.Types; len(types) > 0 {
+		var typeNames []string
+		for _, t := range types {
+			typeNames = append(typeNames, string(t))
+		}
+		typeRegex = fmt.Sprintf("^(%s)$", strings.Join(typeNames, "|"))
+	}
+
+	return NewFilterSectionBuilder().
+		AddConfigParam("name", "rewrite_tag").
+		AddConfigParam("match", kubernetesEventsTag).
+		AddConfigParam("emitter_name", fmt.Sprintf("%s-events", pipeline.Name)).
+		AddConfigParam("emitter_storage.type", defaults.StorageType).
+		AddConfigParam("emitter_mem_buf_limit", defaults.MemoryBufferLimit).
+		AddConfigParam("rule", fmt.Sprintf("$type \"%s\" %s.events true", typeRegex, pipeline.Name)).
+		Build()
+}
+
+// createKubernetesEventsNamespaceFilter drops the events of the Namespaces that are not selected. Like for the
+// application logs, the system Namespaces are excluded if no selector is defined.
+func createKubernetesEventsNamespaceFilter(pipeline *telemetryv1alpha1.LogPipeline) string {
+	selector := pipeline.Spec.Input.KubernetesEvents.Namespaces
+	if selector.System {
+		return ""
+	}
+
+	builder := NewFilterSectionBuilder().
+		AddConfigParam("name", "grep").
+		AddConfigParam("match", fmt.Sprintf("%s.*", pipeline.Name))
+
+	if len(selector.Include) > 0 {
+		return builder.
+			AddConfigParam("regex", fmt.Sprintf("$metadata['namespace'] ^(%s)$", strings.Join(selector.Include, "|"))).
+			Build()
+	}
+
+	excludeNamespaces := selector.Exclude
+	if len(excludeNamespaces) == 0 {
+		excludeNamespaces = namespaces.System()
+	}
+
+	return builder.
+		AddConfigParam("exclude", fmt.Sprintf("$metadata['namespace'] ^(%s)$", strings.Join(excludeNamespaces, "|"))).
+		Build()
+}
+
+// createKubernetesEventsMetadataFilter adds the kubernetes attribute known from the application logs to the events.
+func createKubernetesEventsMetadataFilter(pipeline *telemetryv1alpha1.LogPipeline) string {
+	return NewFilterSectionBuilder().
+		AddConfigParam("name", "lua").
+		AddConfigParam("match", fmt.Sprintf("%s.*", pipeline.Name)).
+		AddConfigParam("script", luaScriptPath).
+		AddConfigParam("call", "kubernetes_event_metadata").
+		Build()
+}
@@ -0,0 +1,233 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+func TestBuildKubernetesEventsConfig(t *testing.T) {
+	expected := `[INPUT]
+    name         kubernetes_events
+    alias        kube-events
+    db           /data/flb_kube_events.db
+    storage.type filesystem
+    tag          kube-events
+
+[OUTPUT]
+    name  null
+    match kube-events
+    alias kube-events
+
+[FILTER]
+    name                  rewrite_tag
+    match                 kube-events
+    emitter_mem_buf_limit 10M
+    emitter_name          foo-events
+    emitter_storage.type  filesystem
+    rule                  $type "^(Warning)$" foo.events true
+
+[FILTER]
+    name    grep
+    match   foo.*
+    exclude $metadata['namespace'] ^(kyma-system|kube-system|istio-system|compass-system)$
+
+[FILTER]
+    name   record_modifier
+    match  foo.*
+    record cluster_identifier ${KUBERNETES_SERVICE_HOST}
+
+[FILTER]
+    name   lua
+    match  foo.*
+    call   kubernetes_event_metadata
+    script /fluent-bit/scripts/filter-script.lua
+
+[FILTER]
+    name  grep
+    match foo.*
+    regex reason ^BackOff$
+
+[FILTER]
+    name   lua
+    match  foo.*
+    call   redact_foo
+    script /fluent-bit/scripts/filter-script.lua
+
+[OUTPUT]
+    name                     http
+    match                    foo.*
+    alias                    foo
+    allow_duplicated_headers true
+    format                   json
+    host                     localhost
+    port                     443
+    retry_limit              300
+    storage.total_limit_size 1G
+    tls                      on
+    tls.verify               on
+
+`
+	pipelines := []telemetryv1alpha1.LogPipeline{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Input: telemetryv1alpha1.Input{
+					KubernetesEvents: &telemetryv1alpha1.KubernetesEventsInput{
+						Types: []telemetryv1alpha1.KubernetesEventType{telemetryv1alpha1.KubernetesEventTypeWarning},
+					},
+				},
+				Filters: []telemetryv1alpha1.Filter{{Custom: "name grep\nregex reason ^BackOff$"}},
+				Redaction: &telemetryv1alpha1.LogPipelineRedaction{
+					Patterns: []telemetryv1alpha1.RedactionPattern{telemetryv1alpha1.RedactionPatternEmail},
+				},
+				Output: telemetryv1alpha1.Output{
+					HTTP: &telemetryv1alpha1.HTTPOutput{Host: telemetryv1alpha1.ValueType{Value: "localhost"}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-events"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Output: telemetryv1alpha1.Output{Custom: "name stdout"},
+			},
+		},
+	}
+	defaults := PipelineDefaults{
+		InputTag:          "kube",
+		MemoryBufferLimit: "10M",
+		StorageType:       "filesystem",
+		FsBufferLimit:     "1G",
+	}
+
+	actual := BuildKubernetesEventsConfig(pipelines, defaults)
+	require.Equal(t, expected, actual)
+}
+
+func TestBuildKubernetesEventsConfigWithoutEventsInput(t *testing.T) {
+	pipelines := []telemetryv1alpha1.LogPipeline{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Output: telemetryv1alpha1.Output{Custom: "name stdout"},
+			},
+		},
+	}
+
+	actual := BuildKubernetesEventsConfig(pipelines, PipelineDefaults{})
+	require.Empty(t, actual)
+}
+
+func TestBuildKubernetesEventsConfigWithInvalidCustomFilter(t *testing.T) {
+	pipelines := []telemetryv1alpha1.LogPipeline{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Input:   telemetryv1alpha1.Input{KubernetesEvents: &telemetryv1alpha1.KubernetesEventsInput{}},
+				Filters: []telemetryv1alpha1.Filter{{Custom: "name"}},
+				Output:  telemetryv1alpha1.Output{Custom: "name stdout"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Input:  telemetryv1alpha1.Input{KubernetesEvents: &telemetryv1alpha1.KubernetesEventsInput{}},
+				Output: telemetryv1alpha1.Output{Custom: "name stdout"},
+			},
+		},
+	}
+
+	actual := BuildKubernetesEventsConfig(pipelines, PipelineDefaults{})
+	require.Contains(t, actual, "name         kubernetes_events")
+	require.Contains(t, actual, "emitter_name          bar-events")
+	require.NotContains(t, actual, "foo-events", "the invalid pipeline must be skipped")
+}
+
+func TestBuildKubernetesEventsConfigWithOnlyInvalidPipelines(t *testing.T) {
+	pipelines := []telemetryv1alpha1.LogPipeline{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Input:   telemetryv1alpha1.Input{KubernetesEvents: &telemetryv1alpha1.KubernetesEventsInput{}},
+				Filters: []telemetryv1alpha1.Filter{{Custom: "name"}},
+				Output:  telemetryv1alpha1.Output{Custom: "name stdout"},
+			},
+		},
+	}
+
+	require.Empty(t, BuildKubernetesEventsConfig(pipelines, PipelineDefaults{}))
+}
+
+func TestCreateKubernetesEventsRewriteTagFilterWithoutTypes(t *testing.T) {
+	expected := `[FILTER]
+    name                  rewrite_tag
+    match                 kube-events
+    emitter_mem_buf_limit 10M
+    emitter_name          foo-events
+    emitter_storage.type  filesystem
+    rule                  $type "^.*$" foo.events true
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Input: telemetryv1alpha1.Input{KubernetesEvents: &telemetryv1alpha1.KubernetesEventsInput{}},
+		},
+	}
+
+	actual := createKubernetesEventsRewriteTagFilter(logPipeline, PipelineDefaults{MemoryBufferLimit: "10M", StorageType: "filesystem"})
+	require.Equal(t, expected, actual)
+}
+
+func TestCreateKubernetesEventsNamespaceFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces telemetryv1alpha1.InputNamespaces
+		expected   string
+	}{
+		{
+			name:       "include",
+			namespaces: telemetryv1alpha1.InputNamespaces{Include: []string{"default", "prod"}},
+			expected: `[FILTER]
+    name  grep
+    match foo.*
+    regex $metadata['namespace'] ^(default|prod)$
+
+`,
+		},
+		{
+			name:       "exclude",
+			namespaces: telemetryv1alpha1.InputNamespaces{Exclude: []string{"test"}},
+			expected: `[FILTER]
+    name    grep
+    match   foo.*
+    exclude $metadata['namespace'] ^(test)$
+
+`,
+		},
+		{
+			name:       "system",
+			namespaces: telemetryv1alpha1.InputNamespaces{System: true},
+			expected:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPipeline := &telemetryv1alpha1.LogPipeline{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: telemetryv1alpha1.LogPipelineSpec{
+					Input: telemetryv1alpha1.Input{
+						KubernetesEvents: &telemetryv1alpha1.KubernetesEventsInput{Namespaces: tt.namespaces},
+					},
+				},
+			}
+
+			actual := createKubernetesEventsNamespaceFilter(logPipeline)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -7,9 +7,15 @@ import (
 )
 
 func createKubernetesFilter(pipeline *telemetryv1alpha1.LogPipeline) string {
+	// The logs of the Nodes don't belong to a Pod, so the filter is restricted to the container logs if they are collected
+	match := fmt.Sprintf("%s.*", pipeline.Name)
+	if pipeline.Spec.Input.System != nil {
+		match = fmt.Sprintf("%s.var.log.containers.*", pipeline.Name)
+	}
+
 	return NewFilterSectionBuilder().
 		AddConfigParam("name", "kubernetes").
-		AddConfigParam("match", fmt.Sprintf("%s.*", pipeline.Name)).
+		AddConfigParam("match", match).
 		AddConfigParam("merge_log", "on").
 		AddConfigParam("k8s-logging.parser", "on").
 		AddConfigParam("k8s-logging.exclude", "off").
@@ -58,3 +58,14 @@ func TestCreateKubernetesFilterDropAll(t *testing.T) {
 	actual := createKubernetesFilter(logPipeline)
 	require.Equal(t, expected, actual)
 }
+
+func TestCreateKubernetesFilterWithSystemInput(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-logpipeline"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Input: telemetryv1alpha1.Input{
+				System: &telemetryv1alpha1.SystemInput{Kernel: true}}}}
+
+	actual := createKubernetesFilter(logPipeline)
+	require.Contains(t, actual, "match               test-logpipeline.var.log.containers.*\n")
+}
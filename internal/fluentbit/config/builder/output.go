@@ -2,8 +2,10 @@ package builder
 
 import (
 	"fmt"
+	"strconv"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
 )
 
 // Considering Fluent Bit's exponential back-off and jitter algorithm with the default scheduler.base and scheduler.cap,
@@ -12,6 +14,13 @@ import (
 // that malformed logs stay in the buffer forever.
 var retryLimit = "300"
 
+// TapCAFile is the key of the CA certificate of the tap endpoint in the output TLS config Secret.
+// Unlike the TLS files of the pipelines, it has no dash before the file extension, so that the keys cannot collide.
+const TapCAFile = "tap.ca.crt"
+
+// tapFsBufferLimit limits the filesystem buffer of a tap output, which only forwards samples and must not compete with the actual output for buffer space.
+var tapFsBufferLimit = "10M"
+
 func createOutputSection(pipeline *telemetryv1alpha1.LogPipeline, defaults PipelineDefaults) string {
 	output := &pipeline.Spec.Output
 	if output.IsCustomDefined() {
@@ -25,6 +34,30 @@ func createOutputSection(pipeline *telemetryv1alpha1.LogPipeline, defaults Pipel
 	return ""
 }
 
+// createTapOutputSection creates an additional output for a pipeline with an active tap, which sends the logs to the telemetry manager for inspection.
+// The output never retries, so that the tap cannot delay or block the actual output.
+func createTapOutputSection(pipeline *telemetryv1alpha1.LogPipeline, endpoint *tap.Endpoint) string {
+	if endpoint == nil {
+		return ""
+	}
+
+	sb := NewOutputSectionBuilder()
+	sb.AddConfigParam("name", "http")
+	sb.AddConfigParam("match", fmt.Sprintf("%s.*", pipeline.Name))
+	// The dot cannot be part of the pipeline name extracted by the self-monitor, which drops the metrics of tap outputs
+	sb.AddConfigParam("alias", fmt.Sprintf("tap.%s", pipeline.Name))
+	sb.AddConfigParam("host", endpoint.Host)
+	sb.AddConfigParam("port", strconv.Itoa(int(endpoint.Port)))
+	sb.AddConfigParam("uri", endpoint.Path(tap.KindLogPipelines, pipeline.Name))
+	sb.AddConfigParam("format", "json")
+	sb.AddConfigParam("tls", "on")
+	sb.AddConfigParam("tls.verify", "on")
+	sb.AddConfigParam("tls.ca_file", "/fluent-bit/etc/output-tls-config/"+TapCAFile)
+	sb.AddConfigParam("retry_limit", "no_retries")
+	sb.AddConfigParam("storage.total_limit_size", tapFsBufferLimit)
+	return sb.Build()
+}
+
 func generateCustomOutput(output *telemetryv1alpha1.Output, fsBufferLimit string, name string) string {
 	sb := NewOutputSectionBuilder()
 	customOutputParams := parseMultiline(output.Custom)
@@ -7,6 +7,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
 )
 
 func TestCreateOutputSectionWithCustomOutput(t *testing.T) {
@@ -167,6 +168,31 @@ func TestCreateOutputSectionWithHTTPOutputWithTLS(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func TestCreateTapOutputSection(t *testing.T) {
+	expected := `[OUTPUT]
+    name                     http
+    match                    foo.*
+    alias                    tap.foo
+    format                   json
+    host                     telemetry-manager-webhook.kyma-system.svc
+    port                     443
+    retry_limit              no_retries
+    storage.total_limit_size 10M
+    tls                      on
+    tls.ca_file              /fluent-bit/etc/output-tls-config/tap.ca.crt
+    tls.verify               on
+    uri                      /tap/logpipelines/foo
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{}
+	logPipeline.Name = "foo"
+
+	require.Empty(t, createTapOutputSection(logPipeline, nil))
+
+	actual := createTapOutputSection(logPipeline, &tap.Endpoint{Host: "telemetry-manager-webhook.kyma-system.svc", Port: 443})
+	require.Equal(t, expected, actual)
+}
+
 func TestResolveValueWithValue(t *testing.T) {
 	value := telemetryv1alpha1.ValueType{
 		Value: "test",
@@ -0,0 +1,114 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config"
+)
+
+const (
+	defaultRedactionReplacement = "[REDACTED]"
+	luaScriptPath               = "/fluent-bit/scripts/filter-script.lua"
+)
+
+var defaultRedactionKeys = []string{"log", "message", "msg"}
+
+func createRedactionFilter(pipeline *telemetryv1alpha1.LogPipeline) string {
+	if pipeline.Spec.Redaction == nil {
+		return ""
+	}
+
+	return NewFilterSectionBuilder().
+		AddConfigParam("name", "lua").
+		AddConfigParam("match", fmt.Sprintf("%s.*", pipeline.Name)).
+		AddConfigParam("script", luaScriptPath).
+		AddConfigParam("call", redactionFunctionName(pipeline.Name)).
+		Build()
+}
+
+// BuildRedactionScript generates a Lua function per pipeline with redaction, which is called by the redaction filter of the pipeline.
+// The functions delegate to the redact_record helper of the Lua script that is mounted into Fluent Bit.
+// Pipelines with an invalid redaction are skipped; they are not deployed, and their status reports the error.
+func BuildRedactionScript(pipelines []telemetryv1alpha1.LogPipeline) string {
+	var sb strings.Builder
+	for i := range pipelines {
+		redaction := pipelines[i].Spec.Redaction
+		if redaction == nil || pipelines[i].ValidateRedaction() != nil {
+			continue
+		}
+
+		var patterns []string
+		for _, regex := range redaction.Custom {
+			// The pattern is valid, because the redaction has been validated
+			pattern, _ := config.ConvertRegexToLuaPattern(regex)
+			patterns = append(patterns, pattern)
+		}
+
+		var builtins []string
+		for _, pattern := range redaction.Patterns {
+			builtins = append(builtins, strings.ToLower(string(pattern)))
+		}
+
+		keys := redaction.Keys
+		if len(keys) == 0 {
+			keys = defaultRedactionKeys
+		}
+
+		replacement := redaction.Replacement
+		if replacement == "" {
+			replacement = defaultRedactionReplacement
+		}
+
+		fmt.Fprintf(&sb, "function %s(tag, timestamp, record)\n", redactionFunctionName(pipelines[i].Name))
+		fmt.Fprintf(&sb, "  return redact_record(timestamp, record, %s, %s, %s, %s)\n",
+			luaStringTable(keys), luaStringTable(builtins), luaStringTable(patterns), luaString(replacement))
+		sb.WriteString("end\n")
+	}
+
+	return sb.String()
+}
+
+// redactionFunctionName derives a valid Lua identifier from the pipeline name. Characters other than letters and digits
+// are hex-encoded with an underscore prefix, which cannot be part of a pipeline name, so that the identifiers are unique.
+func redactionFunctionName(pipelineName string) string {
+	var sb strings.Builder
+	sb.WriteString("redact_")
+	for _, r := range pipelineName {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		} else {
+			fmt.Fprintf(&sb, "_%x", r)
+		}
+	}
+	return sb.String()
+}
+
+func luaStringTable(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, luaString(value))
+	}
+	return "{" + strings.Join(quoted, ", ") + "}"
+}
+
+func luaString(value string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\' || c == '"':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			// Decimal escapes are the only numeric escapes supported by all Lua versions
+			fmt.Fprintf(&sb, "\\%03d", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+func TestCreateRedactionFilter(t *testing.T) {
+	expected := `[FILTER]
+    name   lua
+    match  foo.*
+    call   redact_foo
+    script /fluent-bit/scripts/filter-script.lua
+
+`
+	logPipeline := &telemetryv1alpha1.LogPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: telemetryv1alpha1.LogPipelineSpec{
+			Redaction: &telemetryv1alpha1.LogPipelineRedaction{
+				Patterns: []telemetryv1alpha1.RedactionPattern{telemetryv1alpha1.RedactionPatternEmail},
+			},
+		},
+	}
+
+	actual := createRedactionFilter(logPipeline)
+	require.Equal(t, expected, actual)
+}
+
+func TestCreateRedactionFilterWithoutRedaction(t *testing.T) {
+	logPipeline := &telemetryv1alpha1.LogPipeline{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+
+	actual := createRedactionFilter(logPipeline)
+	require.Equal(t, "", actual)
+}
+
+func TestBuildRedactionScript(t *testing.T) {
+	pipelines := []telemetryv1alpha1.LogPipeline{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Redaction: &telemetryv1alpha1.LogPipelineRedaction{
+					Patterns: []telemetryv1alpha1.RedactionPattern{
+						telemetryv1alpha1.RedactionPatternEmail,
+						telemetryv1alpha1.RedactionPatternCreditCard,
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-redaction"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar-1.example"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Redaction: &telemetryv1alpha1.LogPipelineRedaction{
+					Custom:      []string{`token=\w+`, `"secret":\s*"[^"]*"`},
+					Keys:        []string{"log"},
+					Replacement: "***",
+				},
+			},
+		},
+	}
+
+	expected := `function redact_foo(tag, timestamp, record)
+  return redact_record(timestamp, record, {"log", "message", "msg"}, {"email", "creditcard"}, {}, "[REDACTED]")
+end
+function redact_bar_2d1_2eexample(tag, timestamp, record)
+  return redact_record(timestamp, record, {"log"}, {}, {"token=[%w_]+", "\"secret\":%s*\"[^\"]*\""}, "***")
+end
+`
+
+	actual := BuildRedactionScript(pipelines)
+	require.Equal(t, expected, actual)
+}
+
+func TestBuildRedactionScriptSkipsUnsupportedRegex(t *testing.T) {
+	pipelines := []telemetryv1alpha1.LogPipeline{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Redaction: &telemetryv1alpha1.LogPipelineRedaction{
+					Custom: []string{`(user|password)=\S+`},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Redaction: &telemetryv1alpha1.LogPipelineRedaction{
+					Patterns: []telemetryv1alpha1.RedactionPattern{telemetryv1alpha1.RedactionPatternJWT},
+				},
+			},
+		},
+	}
+
+	expected := `function redact_bar(tag, timestamp, record)
+  return redact_record(timestamp, record, {"log", "message", "msg"}, {"jwt"}, {}, "[REDACTED]")
+end
+`
+
+	require.Equal(t, expected, BuildRedactionScript(pipelines))
+}
+
+func TestLuaString(t *testing.T) {
+	require.Equal(t, `"a\\b\"c\010d"`, luaString("a\\b\"c\nd"))
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ConvertRegexToLuaPattern translates a regular expression into an equivalent Lua pattern, since the Lua runtime of Fluent Bit
+// does not provide regular expressions. Only the subset of the syntax that Lua patterns can express is supported: literals,
+// the wildcard `.`, character classes, the escapes `\d`, `\s`, and `\w` and their negations, the quantifiers `*`, `+`, and `?`,
+// and the anchors `^` and `$`. Groups, alternations, and counted repetitions are rejected.
+func ConvertRegexToLuaPattern(regex string) (string, error) {
+	compiled, err := regexp.Compile(regex)
+	if err != nil {
+		return "", fmt.Errorf("invalid regular expression '%s': %w", regex, err)
+	}
+	if compiled.MatchString("") {
+		return "", fmt.Errorf("regular expression '%s' must not match an empty string", regex)
+	}
+
+	runes := []rune(regex)
+	var sb strings.Builder
+	quantifiable := false
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case '^':
+			if i != 0 {
+				return "", fmt.Errorf("regular expression '%s': anchor '^' is only supported at the beginning", regex)
+			}
+			sb.WriteRune(r)
+			quantifiable = false
+		case '$':
+			if i != len(runes)-1 {
+				return "", fmt.Errorf("regular expression '%s': anchor '$' is only supported at the end", regex)
+			}
+			sb.WriteRune(r)
+			quantifiable = false
+		case '*', '+', '?':
+			if !quantifiable {
+				return "", fmt.Errorf("regular expression '%s': quantifier '%c' is only supported after a single character or class", regex, r)
+			}
+			sb.WriteRune(r)
+			quantifiable = false
+		case '.':
+			sb.WriteRune(r)
+			quantifiable = true
+		case '\\':
+			i++
+			class, err := convertEscape(runes[i], false)
+			if err != nil {
+				return "", fmt.Errorf("regular expression '%s': %w", regex, err)
+			}
+			sb.WriteString(class)
+			quantifiable = true
+		case '[':
+			end, class, err := convertClass(runes, i)
+			if err != nil {
+				return "", fmt.Errorf("regular expression '%s': %w", regex, err)
+			}
+			sb.WriteString(class)
+			i = end
+			quantifiable = true
+		case '(', ')', '|', '{', '}':
+			return "", fmt.Errorf("regular expression '%s': '%c' is not supported", regex, r)
+		default:
+			// Lua patterns match bytes, so a quantifier after a multi-byte character would only apply to its last byte
+			sb.WriteString(escapeLuaLiteral(r))
+			quantifiable = r < 128
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// convertClass translates the character class starting at the given position and returns the position of its closing bracket.
+func convertClass(runes []rune, start int) (int, string, error) {
+	var sb strings.Builder
+	sb.WriteRune('[')
+
+	i := start + 1
+	if i < len(runes) && runes[i] == '^' {
+		sb.WriteRune('^')
+		i++
+	}
+
+	first := i
+	previousIsLiteral := false
+	for ; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == ']' && i != first:
+			sb.WriteRune(']')
+			return i, sb.String(), nil
+		case r == '[' && i+1 < len(runes) && runes[i+1] == ':':
+			return 0, "", fmt.Errorf("POSIX character classes are not supported")
+		case r == '\\':
+			i++
+			class, err := convertEscape(runes[i], true)
+			if err != nil {
+				return 0, "", err
+			}
+			sb.WriteString(class)
+			previousIsLiteral = false
+		case r == '-' && i != first && i+1 < len(runes) && runes[i+1] != ']':
+			if !previousIsLiteral || runes[i+1] == '\\' {
+				return 0, "", fmt.Errorf("ranges are only supported between literal characters")
+			}
+			sb.WriteRune('-')
+			previousIsLiteral = false
+		case r >= 128:
+			return 0, "", fmt.Errorf("non-ASCII characters are not supported within a character class")
+		default:
+			sb.WriteString(escapeLuaLiteral(r))
+			previousIsLiteral = true
+		}
+	}
+
+	return 0, "", fmt.Errorf("unterminated character class")
+}
+
+func convertEscape(r rune, inClass bool) (string, error) {
+	switch r {
+	case 'd', 'D', 's', 'S':
+		return "%" + string(r), nil
+	case 'w':
+		if inClass {
+			return "%w_", nil
+		}
+		return "[%w_]", nil
+	case 'W':
+		if inClass {
+			return "", fmt.Errorf("escape '\\W' is not supported within a character class")
+		}
+		return "[^%w_]", nil
+	case 'n':
+		return "\n", nil
+	case 't':
+		return "\t", nil
+	case 'r':
+		return "\r", nil
+	}
+
+	if isASCIIPunctuation(r) {
+		return "%" + string(r), nil
+	}
+
+	return "", fmt.Errorf("escape '\\%c' is not supported", r)
+}
+
+// escapeLuaLiteral escapes the characters that have a special meaning in Lua patterns, but not in regular expressions.
+func escapeLuaLiteral(r rune) string {
+	switch r {
+	case '%', '-', '[', ']', '^', '$', '(', ')', '.', '*', '+', '?':
+		return "%" + string(r)
+	}
+	return string(r)
+}
+
+func isASCIIPunctuation(r rune) bool {
+	return r < 128 && strings.ContainsRune("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", r)
+}
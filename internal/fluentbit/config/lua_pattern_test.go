@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertRegexToLuaPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		regex    string
+		expected string
+	}{
+		{name: "literal", regex: "password", expected: "password"},
+		{name: "escaped lua magic characters", regex: "100%-off", expected: "100%%%-off"},
+		{name: "escaped regex metacharacters", regex: `api\.key\?`, expected: "api%.key%?"},
+		{name: "shorthand classes", regex: `token=\w+\s\d*\S?`, expected: "token=[%w_]+%s%d*%S?"},
+		{name: "negated word class", regex: `\W`, expected: "[^%w_]"},
+		{name: "character class with range", regex: `[a-fA-F0-9]+`, expected: "[a-fA-F0-9]+"},
+		{name: "negated character class", regex: `[^\s"]+`, expected: `[^%s"]+`},
+		{name: "character class with literal dash and bracket", regex: `[]a-]`, expected: "[%]a%-]"},
+		{name: "character class with word escape", regex: `[\w.]+`, expected: "[%w_%.]+"},
+		{name: "anchors", regex: `^secret.*$`, expected: "^secret.*$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ConvertRegexToLuaPattern(tt.regex)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestConvertRegexToLuaPatternUnsupported(t *testing.T) {
+	tests := []struct {
+		name  string
+		regex string
+	}{
+		{name: "invalid regex", regex: "[a-"},
+		{name: "matches empty string", regex: "a*"},
+		{name: "group", regex: "(ab)+"},
+		{name: "alternation", regex: "a|b"},
+		{name: "counted repetition", regex: `\d{4}`},
+		{name: "lazy quantifier", regex: "a+?b"},
+		{name: "anchor in the middle", regex: "a^b"},
+		{name: "word boundary", regex: `\bkey`},
+		{name: "posix class", regex: "[[:alpha:]]"},
+		{name: "range from escape", regex: `[\d-z]`},
+		{name: "quantified multi-byte character", regex: "xä+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertRegexToLuaPattern(tt.regex)
+			require.Error(t, err)
+		})
+	}
+}
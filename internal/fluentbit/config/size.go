@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseSize converts a Fluent Bit size value, such as `10M` or `1GB`, to bytes.
+// Like Fluent Bit, it uses binary multiples, so that `1K` is 1024 bytes.
+func ParseSize(size string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(size))
+	value = strings.TrimSuffix(value, "B")
+
+	multiplier := int64(1)
+	if len(value) > 0 {
+		switch value[len(value)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		}
+	}
+	if multiplier > 1 {
+		value = value[:len(value)-1]
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size '%s'", size)
+	}
+
+	return number * multiplier, nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		size     string
+		expected int64
+	}{
+		{size: "512", expected: 512},
+		{size: "10K", expected: 10240},
+		{size: "10M", expected: 10485760},
+		{size: "1G", expected: 1073741824},
+		{size: "2GB", expected: 2147483648},
+		{size: "5mb", expected: 5242880},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.size, func(t *testing.T) {
+			actual, err := ParseSize(tc.size)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseSizeInvalid(t *testing.T) {
+	for _, size := range []string{"", "G", "1T", "-1M", "one"} {
+		t.Run(size, func(t *testing.T) {
+			_, err := ParseSize(size)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package k8sutils
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type StatefulSetProber struct {
+	client.Client
+}
+
+func (ssp *StatefulSetProber) IsReady(ctx context.Context, name types.NamespacedName) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	var ss appsv1.StatefulSet
+	if err := ssp.Get(ctx, name, &ss); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.V(1).Info("StatefulSet is not yet created")
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %s/%s StatefulSet: %w", name.Namespace, name.Name, err)
+	}
+
+	desired := int32(1)
+	if ss.Spec.Replicas != nil {
+		desired = *ss.Spec.Replicas
+	}
+	generation := ss.Generation
+	observedGeneration := ss.Status.ObservedGeneration
+	updated := ss.Status.UpdatedReplicas
+	ready := ss.Status.ReadyReplicas
+
+	return observedGeneration == generation && updated >= desired && ready >= desired, nil
+}
+
+// GatewayProber probes a gateway, which runs as a StatefulSet if it persists its sending queues, and as a Deployment otherwise.
+type GatewayProber struct {
+	client.Client
+}
+
+func (gp *GatewayProber) IsReady(ctx context.Context, name types.NamespacedName) (bool, error) {
+	var ss appsv1.StatefulSet
+	if err := gp.Get(ctx, name, &ss); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get %s/%s StatefulSet: %w", name.Namespace, name.Name, err)
+		}
+
+		deploymentProber := DeploymentProber{gp.Client}
+		return deploymentProber.IsReady(ctx, name)
+	}
+
+	statefulSetProber := StatefulSetProber{gp.Client}
+	return statefulSetProber.IsReady(ctx, name)
+}
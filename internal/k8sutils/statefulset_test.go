@@ -0,0 +1,70 @@
+package k8sutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStatefulSetProber(t *testing.T) {
+	tests := []struct {
+		summary            string
+		replicas           int32
+		updatedReplicas    int32
+		readyReplicas      int32
+		observedGeneration int64
+		desiredGeneration  int64
+		expected           bool
+	}{
+		{summary: "all updated all ready", replicas: 2, updatedReplicas: 2, readyReplicas: 2, expected: true},
+		{summary: "all updated one ready", replicas: 2, updatedReplicas: 2, readyReplicas: 1, expected: false},
+		{summary: "one updated all ready", replicas: 2, updatedReplicas: 1, readyReplicas: 2, expected: false},
+		{summary: "generation mismatch", replicas: 2, updatedReplicas: 2, readyReplicas: 2, observedGeneration: 1, desiredGeneration: 2, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.summary, func(t *testing.T) {
+			t.Parallel()
+
+			statefulSet := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "telemetry-system", Generation: tc.desiredGeneration},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(tc.replicas)},
+				Status: appsv1.StatefulSetStatus{
+					UpdatedReplicas:    tc.updatedReplicas,
+					ReadyReplicas:      tc.readyReplicas,
+					ObservedGeneration: tc.observedGeneration,
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().WithObjects(statefulSet).Build()
+
+			sut := StatefulSetProber{fakeClient}
+			ready, err := sut.IsReady(context.Background(), types.NamespacedName{Name: "foo", Namespace: "telemetry-system"})
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ready)
+		})
+	}
+}
+
+func TestGatewayProberPrefersStatefulSet(t *testing.T) {
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "telemetry-system"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+		Status:     appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(statefulSet).Build()
+
+	sut := GatewayProber{fakeClient}
+	ready, err := sut.IsReady(context.Background(), types.NamespacedName{Name: "foo", Namespace: "telemetry-system"})
+
+	require.NoError(t, err)
+	require.True(t, ready)
+}
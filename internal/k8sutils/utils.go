@@ -2,11 +2,13 @@ package k8sutils
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	istiosecurityclientv1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -14,6 +16,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -182,11 +185,122 @@ func CreateOrUpdateDeployment(ctx context.Context, c client.Client, desired *app
 		return c.Create(ctx, desired)
 	}
 
+	// If the replicas are not set, they are managed by a HorizontalPodAutoscaler and must not be reset.
+	if desired.Spec.Replicas == nil {
+		desired.Spec.Replicas = existing.Spec.Replicas
+	}
+
 	mergeMetadata(&desired.ObjectMeta, existing.ObjectMeta)
 	mergePodAnnotations(&desired.Spec.Template.ObjectMeta, existing.Spec.Template.ObjectMeta)
 	return c.Update(ctx, desired)
 }
 
+// CreateOrUpdateStatefulSet creates or updates a StatefulSet. The volume claim templates of a StatefulSet cannot be changed,
+// so if the size or the storage class of a claim changes, the existing StatefulSet is deleted together with its pods
+// and is created again once it is gone. Claims that are retained on deletion are adopted again by the new StatefulSet.
+// Before that, they are expanded in place if the desired size is larger, because the template only applies to new claims.
+func CreateOrUpdateStatefulSet(ctx context.Context, c client.Client, desired *appsv1.StatefulSet) error {
+	var existing appsv1.StatefulSet
+	err := c.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		return c.Create(ctx, desired)
+	}
+
+	if existing.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if !equalVolumeClaimTemplates(desired.Spec.VolumeClaimTemplates, existing.Spec.VolumeClaimTemplates) {
+		if err := expandVolumeClaims(ctx, c, desired); err != nil {
+			return err
+		}
+		return client.IgnoreNotFound(c.Delete(ctx, &existing, client.PropagationPolicy(metav1.DeletePropagationForeground)))
+	}
+
+	// If the replicas are not set, they are managed by a HorizontalPodAutoscaler and must not be reset.
+	if desired.Spec.Replicas == nil {
+		desired.Spec.Replicas = existing.Spec.Replicas
+	}
+
+	desired.Spec.VolumeClaimTemplates = existing.Spec.VolumeClaimTemplates
+	mergeMetadata(&desired.ObjectMeta, existing.ObjectMeta)
+	mergePodAnnotations(&desired.Spec.Template.ObjectMeta, existing.Spec.Template.ObjectMeta)
+	return c.Update(ctx, desired)
+}
+
+// expandVolumeClaims raises the storage request of the existing claims of a StatefulSet to the size of their template.
+// Claims are never shrunk, because a PersistentVolumeClaim cannot be made smaller.
+func expandVolumeClaims(ctx context.Context, c client.Client, desired *appsv1.StatefulSet) error {
+	var claims corev1.PersistentVolumeClaimList
+	if err := c.List(ctx, &claims, client.InNamespace(desired.Namespace)); err != nil {
+		return err
+	}
+
+	for _, template := range desired.Spec.VolumeClaimTemplates {
+		size := template.Spec.Resources.Requests.Storage()
+		for i := range claims.Items {
+			claim := &claims.Items[i]
+			if !isClaimOfTemplate(claim.Name, template.Name, desired.Name) || claim.Spec.Resources.Requests.Storage().Cmp(*size) >= 0 {
+				continue
+			}
+
+			if claim.Spec.Resources.Requests == nil {
+				claim.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			claim.Spec.Resources.Requests[corev1.ResourceStorage] = *size
+			if err := c.Update(ctx, claim); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isClaimOfTemplate checks if a claim was created from the given template, in which case it is named <template>-<statefulset>-<ordinal>.
+func isClaimOfTemplate(claimName, templateName, statefulSetName string) bool {
+	ordinal, found := strings.CutPrefix(claimName, templateName+"-"+statefulSetName+"-")
+	if !found {
+		return false
+	}
+	_, err := strconv.Atoi(ordinal)
+	return err == nil
+}
+
+func equalVolumeClaimTemplates(desired, existing []corev1.PersistentVolumeClaim) bool {
+	if len(desired) != len(existing) {
+		return false
+	}
+
+	for i := range desired {
+		if desired[i].Name != existing[i].Name ||
+			!ptr.Equal(desired[i].Spec.StorageClassName, existing[i].Spec.StorageClassName) ||
+			!desired[i].Spec.Resources.Requests.Storage().Equal(*existing[i].Spec.Resources.Requests.Storage()) {
+			return false
+		}
+	}
+	return true
+}
+
+func CreateOrUpdateHorizontalPodAutoscaler(ctx context.Context, c client.Client, desired *autoscalingv2.HorizontalPodAutoscaler) error {
+	var existing autoscalingv2.HorizontalPodAutoscaler
+	err := c.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		return c.Create(ctx, desired)
+	}
+
+	mergeMetadata(&desired.ObjectMeta, existing.ObjectMeta)
+	return c.Update(ctx, desired)
+}
+
 func CreateOrUpdateDaemonSet(ctx context.Context, c client.Client, desired *appsv1.DaemonSet) error {
 	var existing appsv1.DaemonSet
 	err := c.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
@@ -344,3 +458,12 @@ func GetOrCreateSecret(ctx context.Context, c client.Client, name types.Namespac
 	}
 	return corev1.Secret{}, err
 }
+
+// DeleteIfExists deletes the given object. It looks the object up first, so that no delete request is sent for an object that
+// does not exist, which is the common case for optional resources checked on every reconciliation.
+func DeleteIfExists(ctx context.Context, c client.Client, obj client.Object) error {
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return client.IgnoreNotFound(c.Delete(ctx, obj))
+}
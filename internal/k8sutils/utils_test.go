@@ -7,10 +7,14 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/kyma-project/telemetry-manager/internal/k8sutils/mocks"
 )
@@ -197,3 +201,111 @@ func TestMergeOwnerReference(t *testing.T) {
 	merged := mergeOwnerReferences(newOwners, oldOwners)
 	require.Equal(t, 3, len(merged))
 }
+
+func TestDeleteIfExists(t *testing.T) {
+	t.Run("object does not exist", func(t *testing.T) {
+		mockClient := &mocks.Client{}
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(apierrors.NewNotFound(schema.GroupResource{}, ""))
+
+		err := DeleteIfExists(context.Background(), mockClient, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "some-deployment", Namespace: "some-ns"}})
+
+		require.NoError(t, err)
+		mockClient.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("object exists", func(t *testing.T) {
+		mockClient := &mocks.Client{}
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockClient.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+		err := DeleteIfExists(context.Background(), mockClient, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "some-deployment", Namespace: "some-ns"}})
+
+		require.NoError(t, err)
+		mockClient.AssertCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("get fails", func(t *testing.T) {
+		mockClient := &mocks.Client{}
+		badReqErr := apierrors.NewBadRequest("")
+		mockClient.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(badReqErr)
+
+		err := DeleteIfExists(context.Background(), mockClient, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "some-deployment", Namespace: "some-ns"}})
+
+		require.Equal(t, badReqErr, err)
+	})
+}
+
+func TestCreateOrUpdateStatefulSet(t *testing.T) {
+	makeStatefulSet := func(size string, replicas *int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "kyma-system"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: replicas,
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+					ObjectMeta: metav1.ObjectMeta{Name: "persistent-queue"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+						},
+					},
+				}},
+			},
+		}
+	}
+	name := types.NamespacedName{Name: "gateway", Namespace: "kyma-system"}
+
+	t.Run("unchanged claims keep replicas managed by autoscaler", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithObjects(makeStatefulSet("1Gi", ptr.To(int32(3)))).Build()
+
+		err := CreateOrUpdateStatefulSet(context.Background(), fakeClient, makeStatefulSet("1Gi", nil))
+		require.NoError(t, err)
+
+		var statefulSet appsv1.StatefulSet
+		require.NoError(t, fakeClient.Get(context.Background(), name, &statefulSet))
+		require.Equal(t, int32(3), *statefulSet.Spec.Replicas)
+	})
+
+	t.Run("changed claim size deletes the stateful set", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithObjects(makeStatefulSet("1Gi", ptr.To(int32(1)))).Build()
+
+		err := CreateOrUpdateStatefulSet(context.Background(), fakeClient, makeStatefulSet("2Gi", ptr.To(int32(1))))
+		require.NoError(t, err)
+
+		var statefulSet appsv1.StatefulSet
+		err = fakeClient.Get(context.Background(), name, &statefulSet)
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("larger claim size expands the existing claims", func(t *testing.T) {
+		makeClaim := func(name, size string) *corev1.PersistentVolumeClaim {
+			return &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kyma-system"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+					},
+				},
+			}
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(
+			makeStatefulSet("1Gi", ptr.To(int32(2))),
+			makeClaim("persistent-queue-gateway-0", "1Gi"),
+			makeClaim("persistent-queue-gateway-1", "3Gi"),
+			makeClaim("persistent-queue-gateway-other-0", "1Gi"),
+		).Build()
+
+		err := CreateOrUpdateStatefulSet(context.Background(), fakeClient, makeStatefulSet("2Gi", ptr.To(int32(2))))
+		require.NoError(t, err)
+
+		expectedSizes := map[string]string{
+			"persistent-queue-gateway-0":       "2Gi",
+			"persistent-queue-gateway-1":       "3Gi",
+			"persistent-queue-gateway-other-0": "1Gi",
+		}
+		for claimName, size := range expectedSizes {
+			var claim corev1.PersistentVolumeClaim
+			require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: claimName, Namespace: "kyma-system"}, &claim))
+			require.True(t, resource.MustParse(size).Equal(*claim.Spec.Resources.Requests.Storage()), claimName)
+		}
+	})
+}
@@ -12,8 +12,41 @@ type Base struct {
 }
 
 type Extensions struct {
-	HealthCheck Endpoint `yaml:"health_check,omitempty"`
-	Pprof       Endpoint `yaml:"pprof,omitempty"`
+	HealthCheck Endpoint              `yaml:"health_check,omitempty"`
+	Pprof       Endpoint              `yaml:"pprof,omitempty"`
+	FileStorage *FileStorageExtension `yaml:"file_storage,omitempty"`
+
+	// Dynamic contains extensions, which need different configurations per exporter, like the authenticators of OTLP outputs
+	Dynamic DynamicExtensions `yaml:",inline,omitempty"`
+}
+
+type DynamicExtensions map[string]Extension
+
+type Extension struct {
+	OAuth2Client    *OAuth2ClientExtension    `yaml:",inline,omitempty"`
+	BearerTokenAuth *BearerTokenAuthExtension `yaml:",inline,omitempty"`
+}
+
+type OAuth2ClientExtension struct {
+	TokenURL     string   `yaml:"token_url"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	Scopes       []string `yaml:"scopes,omitempty"`
+}
+
+type BearerTokenAuthExtension struct {
+	Token string `yaml:"token"`
+}
+
+type FileStorageExtension struct {
+	Directory  string                `yaml:"directory"`
+	Compaction FileStorageCompaction `yaml:"compaction"`
+}
+
+type FileStorageCompaction struct {
+	Directory string `yaml:"directory"`
+	OnStart   bool   `yaml:"on_start"`
+	OnRebound bool   `yaml:"on_rebound"`
 }
 
 type Endpoint struct {
@@ -75,3 +108,13 @@ func DefaultExtensions() Extensions {
 		},
 	}
 }
+
+// AddExtension declares the given extension and enables it in the service.
+func (b *Base) AddExtension(id string, extension Extension) {
+	if b.Extensions.Dynamic == nil {
+		b.Extensions.Dynamic = make(DynamicExtensions)
+	}
+
+	b.Extensions.Dynamic[id] = extension
+	b.Service.Extensions = append(b.Service.Extensions, id)
+}
@@ -3,13 +3,22 @@ package config
 type OTLPExporter struct {
 	MetricsEndpoint string            `yaml:"metrics_endpoint,omitempty"`
 	TracesEndpoint  string            `yaml:"traces_endpoint,omitempty"`
+	LogsEndpoint    string            `yaml:"logs_endpoint,omitempty"`
 	Endpoint        string            `yaml:"endpoint,omitempty"`
 	Headers         map[string]string `yaml:"headers,omitempty"`
+	Auth            *Auth             `yaml:"auth,omitempty"`
+	Timeout         string            `yaml:"timeout,omitempty"`
+	Compression     string            `yaml:"compression,omitempty"`
+	Encoding        string            `yaml:"encoding,omitempty"`
 	TLS             TLS               `yaml:"tls,omitempty"`
 	SendingQueue    SendingQueue      `yaml:"sending_queue,omitempty"`
 	RetryOnFailure  RetryOnFailure    `yaml:"retry_on_failure,omitempty"`
 }
 
+type Auth struct {
+	Authenticator string `yaml:"authenticator"`
+}
+
 type TLS struct {
 	Insecure           bool   `yaml:"insecure"`
 	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
@@ -19,8 +28,9 @@ type TLS struct {
 }
 
 type SendingQueue struct {
-	Enabled   bool `yaml:"enabled"`
-	QueueSize int  `yaml:"queue_size"`
+	Enabled   bool   `yaml:"enabled"`
+	QueueSize int    `yaml:"queue_size"`
+	Storage   string `yaml:"storage,omitempty"`
 }
 
 type RetryOnFailure struct {
@@ -0,0 +1,41 @@
+package gatewayprocs
+
+import (
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/ottlexpr"
+)
+
+// TransformRuleStatements compiles user-defined transform rules into transform processor statements.
+// Consecutive rules with the same context are grouped, so that the rules are applied in the given order.
+func TransformRuleStatements(rules []telemetryv1alpha1.TransformRule) []config.TransformProcessorStatements {
+	var result []config.TransformProcessorStatements
+	for _, rule := range rules {
+		context := string(rule.Context)
+		if len(result) == 0 || result[len(result)-1].Context != context {
+			result = append(result, config.TransformProcessorStatements{Context: context})
+		}
+
+		last := &result[len(result)-1]
+		last.Statements = append(last.Statements, transformRuleStatements(rule)...)
+	}
+
+	return result
+}
+
+func transformRuleStatements(rule telemetryv1alpha1.TransformRule) []string {
+	switch rule.Action {
+	case telemetryv1alpha1.TransformActionSet:
+		return []string{ottlexpr.SetAttribute(rule.Key, rule.Value)}
+	case telemetryv1alpha1.TransformActionDelete:
+		return []string{ottlexpr.DeleteAttribute(rule.Key)}
+	case telemetryv1alpha1.TransformActionRename:
+		return ottlexpr.RenameAttribute(rule.Key, rule.NewKey)
+	case telemetryv1alpha1.TransformActionHash:
+		return []string{ottlexpr.HashAttribute(rule.Key)}
+	case telemetryv1alpha1.TransformActionTruncate:
+		return []string{ottlexpr.TruncateAttribute(rule.Key, rule.Limit)}
+	default:
+		return nil
+	}
+}
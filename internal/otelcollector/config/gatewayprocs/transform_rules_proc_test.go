@@ -0,0 +1,53 @@
+package gatewayprocs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+func TestTransformRuleStatements(t *testing.T) {
+	rules := []telemetryv1alpha1.TransformRule{
+		{Context: telemetryv1alpha1.TransformContextResource, Action: telemetryv1alpha1.TransformActionSet, Key: "env", Value: "prod"},
+		{Context: telemetryv1alpha1.TransformContextResource, Action: telemetryv1alpha1.TransformActionDelete, Key: "host.name"},
+		{Context: telemetryv1alpha1.TransformContextSpan, Action: telemetryv1alpha1.TransformActionHash, Key: "user.email"},
+		{Context: telemetryv1alpha1.TransformContextSpan, Action: telemetryv1alpha1.TransformActionRename, Key: "http.url", NewKey: "url.full"},
+		{Context: telemetryv1alpha1.TransformContextScope, Action: telemetryv1alpha1.TransformActionTruncate, Key: "note", Limit: 10},
+		{Context: telemetryv1alpha1.TransformContextResource, Action: telemetryv1alpha1.TransformActionDelete, Key: "os.type"},
+	}
+
+	expected := []config.TransformProcessorStatements{
+		{
+			Context: "resource",
+			Statements: []string{
+				`set(attributes["env"], "prod")`,
+				`delete_key(attributes, "host.name")`,
+			},
+		},
+		{
+			Context: "span",
+			Statements: []string{
+				`set(attributes["user.email"], SHA256(attributes["user.email"])) where attributes["user.email"] != nil`,
+				`set(attributes["url.full"], attributes["http.url"]) where attributes["http.url"] != nil`,
+				`delete_key(attributes, "http.url")`,
+			},
+		},
+		{
+			Context: "scope",
+			Statements: []string{
+				`set(attributes["note"], Substring(attributes["note"], 0, 10)) where attributes["note"] != nil and Len(attributes["note"]) > 10`,
+			},
+		},
+		{
+			Context: "resource",
+			Statements: []string{
+				`delete_key(attributes, "os.type")`,
+			},
+		},
+	}
+
+	require.Equal(t, expected, TransformRuleStatements(rules))
+}
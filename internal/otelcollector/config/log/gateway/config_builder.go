@@ -0,0 +1,113 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+)
+
+func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.LogPipeline) (*Config, otlpexporter.EnvVars, error) {
+	cfg := &Config{
+		Base: config.Base{
+			Service:    config.DefaultService(make(config.Pipelines)),
+			Extensions: config.DefaultExtensions(),
+		},
+		Receivers:  makeReceiversConfig(),
+		Processors: makeProcessorsConfig(),
+		Exporters:  make(Exporters),
+	}
+
+	envVars := make(otlpexporter.EnvVars)
+	outputs := make(map[string]*telemetryv1alpha1.OtlpOutput)
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp != nil || !pipelines[i].Spec.Output.IsOtlpDefined() {
+			continue
+		}
+		maps.Copy(outputs, otlpexporter.PipelineOutputs(pipelines[i].Name, pipelines[i].Spec.Output.Otlp, pipelines[i].Spec.AdditionalOutputs))
+	}
+	queueSizes := otlpexporter.MakeQueueSizes(outputs)
+
+	for i := range pipelines {
+		pipeline := pipelines[i]
+		if pipeline.DeletionTimestamp != nil || !pipeline.Spec.Output.IsOtlpDefined() {
+			continue
+		}
+
+		otlpExporterBuilders := otlpexporter.NewConfigBuilders(c, pipeline.Spec.Output.Otlp, pipeline.Spec.AdditionalOutputs, pipeline.Name, queueSizes, otlpexporter.SignalTypeLog)
+		if err := addComponentsForLogPipeline(ctx, otlpExporterBuilders, &pipeline, cfg, envVars); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return cfg, envVars, nil
+}
+
+func makeReceiversConfig() Receivers {
+	return Receivers{
+		OTLP: config.OTLPReceiver{
+			Protocols: config.ReceiverProtocols{
+				HTTP: config.Endpoint{
+					Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.OTLPHTTP),
+				},
+				GRPC: config.Endpoint{
+					Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.OTLPGRPC),
+				},
+			},
+		},
+	}
+}
+
+// AddTapExporters adds an exporter to every pipeline with an active tap, which sends the data of the pipeline to the telemetry manager for inspection.
+func AddTapExporters(cfg *Config, taps otlpexporter.Taps) {
+	otlpexporter.AddTapExporters(cfg.Exporters, cfg.Service.Pipelines, "logs", taps, func(exporter *config.OTLPExporter) Exporter {
+		return Exporter{OTLP: exporter}
+	})
+}
+
+// addComponentsForLogPipeline enriches a Config (exporters, processors, etc.) with components for a given telemetryv1alpha1.LogPipeline.
+func addComponentsForLogPipeline(ctx context.Context, otlpExporterBuilders []*otlpexporter.ConfigBuilder, pipeline *telemetryv1alpha1.LogPipeline, cfg *Config, envVars otlpexporter.EnvVars) error {
+	var otlpExporterIDs []string
+	for _, otlpExporterBuilder := range otlpExporterBuilders {
+		otlpExporterConfig, otlpExporterEnvVars, err := otlpExporterBuilder.MakeConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to make otlp exporter config: %w", err)
+		}
+
+		maps.Copy(envVars, otlpExporterEnvVars)
+
+		if authExtensionID, authExtensionConfig := otlpExporterBuilder.MakeAuthExtensionConfig(); authExtensionConfig != nil {
+			cfg.AddExtension(authExtensionID, *authExtensionConfig)
+		}
+
+		otlpExporterID := otlpExporterBuilder.ExporterID()
+		cfg.Exporters[otlpExporterID] = Exporter{OTLP: otlpExporterConfig}
+		otlpExporterIDs = append(otlpExporterIDs, otlpExporterID)
+	}
+
+	pipelineID := fmt.Sprintf("logs/%s", pipeline.Name)
+	cfg.Service.Pipelines[pipelineID] = makePipelineConfig(otlpExporterIDs...)
+
+	return nil
+}
+
+func makePipelineConfig(exporterIDs ...string) config.Pipeline {
+	return config.Pipeline{
+		Receivers: []string{"otlp"},
+		Processors: []string{
+			"memory_limiter",
+			"k8sattributes",
+			"resource/insert-cluster-name",
+			"transform/resolve-service-name",
+			"resource/drop-kyma-attributes",
+			"batch",
+		},
+		Exporters: exporterIDs,
+	}
+}
@@ -0,0 +1,158 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestMakeConfig(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+
+	t.Run("otlp exporter endpoint", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test").WithOTLPOutput(testutils.OTLPEndpoint("http://localhost")).Build(),
+		})
+		require.NoError(t, err)
+
+		expectedEndpoint := fmt.Sprintf("${%s}", "OTLP_ENDPOINT_TEST")
+		require.Contains(t, collectorConfig.Exporters, "otlp/test")
+
+		otlpExporterConfig := collectorConfig.Exporters["otlp/test"]
+		require.Equal(t, expectedEndpoint, otlpExporterConfig.OTLP.Endpoint)
+		require.True(t, otlpExporterConfig.OTLP.TLS.Insecure)
+
+		require.Contains(t, envVars, "OTLP_ENDPOINT_TEST")
+		require.Equal(t, "http://localhost", string(envVars["OTLP_ENDPOINT_TEST"]))
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test").WithOTLPOutput(testutils.OTLPBasicAuth("user", "password")).Build(),
+		})
+		require.NoError(t, err)
+		require.Contains(t, collectorConfig.Exporters, "otlp/test")
+
+		otlpExporterConfig := collectorConfig.Exporters["otlp/test"]
+		require.Equal(t, "${BASIC_AUTH_HEADER_TEST}", otlpExporterConfig.OTLP.Headers["Authorization"])
+		require.Contains(t, envVars, "BASIC_AUTH_HEADER_TEST")
+	})
+
+	t.Run("skips pipelines without otlp output", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test-otlp").WithOTLPOutput().Build(),
+			testutils.NewLogPipelineBuilder().WithName("test-http").WithHTTPOutput().Build(),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+		require.Contains(t, collectorConfig.Service.Pipelines, "logs/test-otlp")
+		require.Len(t, collectorConfig.Exporters, 1)
+		require.Contains(t, collectorConfig.Exporters, "otlp/test-otlp")
+	})
+
+	t.Run("multi pipeline topology", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test-1").WithOTLPOutput().Build(),
+			testutils.NewLogPipelineBuilder().WithName("test-2").WithOTLPOutput().Build(),
+		})
+		require.NoError(t, err)
+
+		for _, name := range []string{"test-1", "test-2"} {
+			pipelineID := "logs/" + name
+			require.Contains(t, collectorConfig.Service.Pipelines, pipelineID)
+			require.Equal(t, []string{"otlp"}, collectorConfig.Service.Pipelines[pipelineID].Receivers)
+			require.Equal(t, []string{
+				"memory_limiter",
+				"k8sattributes",
+				"resource/insert-cluster-name",
+				"transform/resolve-service-name",
+				"resource/drop-kyma-attributes",
+				"batch",
+			}, collectorConfig.Service.Pipelines[pipelineID].Processors)
+			require.Equal(t, []string{"otlp/" + name}, collectorConfig.Service.Pipelines[pipelineID].Exporters)
+			require.Equal(t, 128, collectorConfig.Exporters["otlp/"+name].OTLP.SendingQueue.QueueSize)
+		}
+
+		require.Contains(t, envVars, "OTLP_ENDPOINT_TEST_1")
+		require.Contains(t, envVars, "OTLP_ENDPOINT_TEST_2")
+	})
+
+	t.Run("queue size not shared with deleted or fluent bit pipelines", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test").WithOTLPOutput().Build(),
+			testutils.NewLogPipelineBuilder().WithName("deleted").WithOTLPOutput().WithDeletionTimeStamp(metav1.Now()).Build(),
+			testutils.NewLogPipelineBuilder().WithName("http").WithHTTPOutput().Build(),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, collectorConfig.Exporters, 1)
+		require.Equal(t, 256, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.QueueSize, "Pipeline should have the full queue size")
+	})
+
+	t.Run("additional outputs", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test").WithOTLPOutput().
+				WithAdditionalOTLPOutput("audit", testutils.OTLPEndpoint("https://audit:4317")).
+				Build(),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, collectorConfig.Exporters, 2)
+		require.Equal(t, "${OTLP_ENDPOINT_TEST_out_AUDIT}", collectorConfig.Exporters["otlp/test/audit"].OTLP.Endpoint)
+		require.Equal(t, "https://audit:4317", string(envVars["OTLP_ENDPOINT_TEST_out_AUDIT"]))
+		require.Equal(t, []string{"otlp/test", "otlp/test/audit"}, collectorConfig.Service.Pipelines["logs/test"].Exporters)
+	})
+
+	t.Run("marshaling", func(t *testing.T) {
+		config, _, err := MakeConfig(context.Background(), fakeClient, []telemetryv1alpha1.LogPipeline{
+			testutils.NewLogPipelineBuilder().WithName("test").WithOTLPOutput().Build(),
+		})
+		require.NoError(t, err)
+
+		configYAML, err := yaml.Marshal(config)
+		require.NoError(t, err, "failed to marshal config")
+
+		goldenFilePath := filepath.Join("testdata", "config.yaml")
+		goldenFile, err := os.ReadFile(goldenFilePath)
+		require.NoError(t, err, "failed to load golden file")
+
+		require.Equal(t, string(goldenFile), string(configYAML))
+	})
+}
+
+func TestAddTapExporters(t *testing.T) {
+	collectorConfig, _, err := MakeConfig(context.Background(), fake.NewClientBuilder().Build(), []telemetryv1alpha1.LogPipeline{
+		testutils.NewLogPipelineBuilder().WithName("test-1").WithOTLPOutput().Build(),
+		testutils.NewLogPipelineBuilder().WithName("test-2").WithOTLPOutput().Build(),
+	})
+	require.NoError(t, err)
+
+	AddTapExporters(collectorConfig, otlpexporter.Taps{
+		Endpoints: map[string]string{
+			"test-1":  "https://telemetry-manager-webhook.kyma-system.svc:443/tap/logpipelines/test-1",
+			"deleted": "https://telemetry-manager-webhook.kyma-system.svc:443/tap/logpipelines/deleted",
+		},
+		CAPem: "ca",
+	})
+
+	require.Contains(t, collectorConfig.Exporters, "otlphttp/tap.test-1")
+	tapExporter := collectorConfig.Exporters["otlphttp/tap.test-1"].OTLP
+	require.Equal(t, "https://telemetry-manager-webhook.kyma-system.svc:443/tap/logpipelines/test-1", tapExporter.Endpoint)
+	require.Equal(t, "ca", tapExporter.TLS.CAPem)
+	require.NotContains(t, collectorConfig.Exporters, "otlphttp/tap.deleted")
+	require.Equal(t, []string{"otlp/test-1", "otlphttp/tap.test-1"}, collectorConfig.Service.Pipelines["logs/test-1"].Exporters)
+	require.Equal(t, []string{"otlp/test-2"}, collectorConfig.Service.Pipelines["logs/test-2"].Exporters)
+}
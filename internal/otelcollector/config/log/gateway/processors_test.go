@@ -0,0 +1,54 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestProcessors(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().Build()
+
+	t.Run("insert cluster name processor", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{testutils.NewLogPipelineBuilder().WithOTLPOutput().Build()})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(collectorConfig.Processors.InsertClusterName.Attributes))
+		require.Equal(t, "insert", collectorConfig.Processors.InsertClusterName.Attributes[0].Action)
+		require.Equal(t, "k8s.cluster.name", collectorConfig.Processors.InsertClusterName.Attributes[0].Key)
+		require.Equal(t, "${KUBERNETES_SERVICE_HOST}", collectorConfig.Processors.InsertClusterName.Attributes[0].Value)
+	})
+
+	t.Run("k8s attributes processor", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{testutils.NewLogPipelineBuilder().WithOTLPOutput().Build()})
+		require.NoError(t, err)
+
+		require.Equal(t, "serviceAccount", collectorConfig.Processors.K8sAttributes.AuthType)
+		require.Contains(t, collectorConfig.Processors.K8sAttributes.Extract.Metadata, "k8s.pod.name")
+		require.Contains(t, collectorConfig.Processors.K8sAttributes.Extract.Metadata, "k8s.namespace.name")
+	})
+
+	t.Run("resolve service name processor", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{testutils.NewLogPipelineBuilder().WithOTLPOutput().Build()})
+		require.NoError(t, err)
+
+		require.Equal(t, "ignore", collectorConfig.Processors.ResolveServiceName.ErrorMode)
+		require.Len(t, collectorConfig.Processors.ResolveServiceName.LogStatements, 1)
+		require.Equal(t, "resource", collectorConfig.Processors.ResolveServiceName.LogStatements[0].Context)
+	})
+
+	t.Run("batch and memory limiter processors", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.LogPipeline{testutils.NewLogPipelineBuilder().WithOTLPOutput().Build()})
+		require.NoError(t, err)
+
+		require.Equal(t, 512, collectorConfig.Processors.Batch.SendBatchSize)
+		require.Equal(t, "10s", collectorConfig.Processors.Batch.Timeout)
+		require.Equal(t, 75, collectorConfig.Processors.MemoryLimiter.LimitPercentage)
+	})
+}
@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric"
+)
+
+// MakeClusterConfig creates the configuration of the singleton collector that observes the state of the Kubernetes objects
+// for the whole cluster. Unlike the agent, it runs as a single replica so that every state metric is reported only once.
+func MakeClusterConfig(gatewayServiceName types.NamespacedName) *Config {
+	return &Config{
+		Base: config.Base{
+			Service: config.DefaultService(config.Pipelines{
+				"metrics/cluster": config.Pipeline{
+					Receivers:  []string{"k8s_cluster"},
+					Processors: []string{"memory_limiter", "resource/delete-service-name", "transform/set-instrumentation-scope-cluster", "batch"},
+					Exporters:  []string{"otlp"},
+				},
+			}),
+			Extensions: config.DefaultExtensions(),
+		},
+		Receivers: Receivers{
+			K8sCluster: makeK8sClusterConfig(),
+		},
+		Processors: Processors{
+			BaseProcessors: config.BaseProcessors{
+				Batch:         makeBatchProcessorConfig(),
+				MemoryLimiter: makeMemoryLimiterConfig(),
+			},
+			DeleteServiceName:              makeDeleteServiceNameConfig(),
+			SetInstrumentationScopeCluster: makeInstrumentationScopeProcessor(metric.InputSourceCluster),
+		},
+		Exporters: makeExportersConfig(gatewayServiceName),
+	}
+}
+
+// IsClusterInputEnabled returns true if any of the pipelines collects the cluster state metrics.
+func IsClusterInputEnabled(pipelines []telemetryv1alpha1.MetricPipeline) bool {
+	for i := range pipelines {
+		input := pipelines[i].Spec.Input
+		if input.Cluster != nil && input.Cluster.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+func makeK8sClusterConfig() *K8sClusterReceiver {
+	const collectionInterval = "30s"
+	return &K8sClusterReceiver{
+		AuthType:                 "serviceAccount",
+		CollectionInterval:       collectionInterval,
+		NodeConditionsToReport:   []string{"Ready", "MemoryPressure", "DiskPressure", "PIDPressure"},
+		AllocatableTypesToReport: []string{"cpu", "memory", "pods"},
+	}
+}
@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/types"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestMakeClusterConfig(t *testing.T) {
+	gatewayServiceName := types.NamespacedName{Name: "metrics", Namespace: "telemetry-system"}
+
+	t.Run("pipeline topology", func(t *testing.T) {
+		collectorConfig := MakeClusterConfig(gatewayServiceName)
+
+		require.NotNil(t, collectorConfig.Receivers.K8sCluster)
+		require.Nil(t, collectorConfig.Receivers.KubeletStats)
+		require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopeCluster)
+		require.Nil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
+
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+		require.Contains(t, collectorConfig.Service.Pipelines, "metrics/cluster")
+		require.Equal(t, []string{"k8s_cluster"}, collectorConfig.Service.Pipelines["metrics/cluster"].Receivers)
+		require.Equal(t, []string{"memory_limiter", "resource/delete-service-name", "transform/set-instrumentation-scope-cluster", "batch"}, collectorConfig.Service.Pipelines["metrics/cluster"].Processors)
+		require.Equal(t, []string{"otlp"}, collectorConfig.Service.Pipelines["metrics/cluster"].Exporters)
+	})
+
+	t.Run("otlp exporter endpoint", func(t *testing.T) {
+		collectorConfig := MakeClusterConfig(gatewayServiceName)
+
+		require.Equal(t, "metrics.telemetry-system.svc.cluster.local:4317", collectorConfig.Exporters.OTLP.Endpoint)
+	})
+
+	t.Run("marshaling", func(t *testing.T) {
+		overwriteGoldenFile := false
+
+		configYAML, err := yaml.Marshal(MakeClusterConfig(gatewayServiceName))
+		require.NoError(t, err, "failed to marshal config")
+
+		goldenFilePath := filepath.Join("testdata", "config_cluster.yaml")
+		if overwriteGoldenFile {
+			err = os.WriteFile(goldenFilePath, configYAML, 0600)
+			require.NoError(t, err, "failed to overwrite golden file")
+			return
+		}
+
+		goldenFile, err := os.ReadFile(goldenFilePath)
+		require.NoError(t, err, "failed to load golden file")
+		require.Equal(t, string(goldenFile), string(configYAML))
+	})
+}
+
+func TestIsClusterInputEnabled(t *testing.T) {
+	tests := []struct {
+		name      string
+		pipelines []telemetryv1alpha1.MetricPipeline
+		expected  bool
+	}{
+		{
+			name:      "no pipelines",
+			pipelines: nil,
+			expected:  false,
+		},
+		{
+			name: "cluster input not defined",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).Build(),
+			},
+			expected: false,
+		},
+		{
+			name: "cluster input disabled",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithClusterInput(false).Build(),
+			},
+			expected: false,
+		},
+		{
+			name: "cluster input enabled in one pipeline",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().Build(),
+				testutils.NewMetricPipelineBuilder().WithClusterInput(true).Build(),
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, IsClusterInputEnabled(tt.pipelines))
+		})
+	}
+}
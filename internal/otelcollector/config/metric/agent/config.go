@@ -3,6 +3,8 @@ package agent
 import (
 	"time"
 
+	"github.com/prometheus/common/model"
+
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 )
 
@@ -19,6 +21,8 @@ type Receivers struct {
 	PrometheusAppPods     *PrometheusReceiver   `yaml:"prometheus/app-pods,omitempty"`
 	PrometheusAppServices *PrometheusReceiver   `yaml:"prometheus/app-services,omitempty"`
 	PrometheusIstio       *PrometheusReceiver   `yaml:"prometheus/istio,omitempty"`
+	PrometheusMonitors    *PrometheusReceiver   `yaml:"prometheus/monitors,omitempty"`
+	K8sCluster            *K8sClusterReceiver   `yaml:"k8s_cluster,omitempty"`
 }
 
 type KubeletStatsReceiver struct {
@@ -43,11 +47,20 @@ type KubeletMetricsConfig struct {
 	K8sPodCPUUtilization    KubeletMetricConfig `yaml:"k8s.pod.cpu.utilization"`
 }
 
+type K8sClusterReceiver struct {
+	AuthType                 string   `yaml:"auth_type"`
+	CollectionInterval       string   `yaml:"collection_interval"`
+	NodeConditionsToReport   []string `yaml:"node_conditions_to_report"`
+	AllocatableTypesToReport []string `yaml:"allocatable_types_to_report"`
+}
+
 type MetricGroupType string
 
 const (
 	MetricGroupTypeContainer MetricGroupType = "container"
 	MetricGroupTypePod       MetricGroupType = "pod"
+	MetricGroupTypeNode      MetricGroupType = "node"
+	MetricGroupTypeVolume    MetricGroupType = "volume"
 )
 
 type PrometheusReceiver struct {
@@ -61,8 +74,11 @@ type PrometheusConfig struct {
 type ScrapeConfig struct {
 	JobName              string          `yaml:"job_name"`
 	SampleLimit          int             `yaml:"sample_limit,omitempty"`
-	ScrapeInterval       time.Duration   `yaml:"scrape_interval,omitempty"`
+	ScrapeInterval       model.Duration  `yaml:"scrape_interval,omitempty"`
+	ScrapeTimeout        model.Duration  `yaml:"scrape_timeout,omitempty"`
+	HonorLabels          bool            `yaml:"honor_labels,omitempty"`
 	MetricsPath          string          `yaml:"metrics_path,omitempty"`
+	Scheme               string          `yaml:"scheme,omitempty"`
 	RelabelConfigs       []RelabelConfig `yaml:"relabel_configs,omitempty"`
 	MetricRelabelConfigs []RelabelConfig `yaml:"metric_relabel_configs,omitempty"`
 
@@ -76,6 +92,7 @@ type TLSConfig struct {
 	CAFile             string `yaml:"ca_file,omitempty"`
 	CertFile           string `yaml:"cert_file,omitempty"`
 	KeyFile            string `yaml:"key_file,omitempty"`
+	ServerName         string `yaml:"server_name,omitempty"`
 	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
 }
 
@@ -84,7 +101,12 @@ type StaticDiscoveryConfig struct {
 }
 
 type KubernetesDiscoveryConfig struct {
-	Role Role `yaml:"role"`
+	Role       Role                  `yaml:"role"`
+	Namespaces *KubernetesNamespaces `yaml:"namespaces,omitempty"`
+}
+
+type KubernetesNamespaces struct {
+	Names []string `yaml:"names"`
 }
 
 type Role string
@@ -116,14 +138,27 @@ type Processors struct {
 	config.BaseProcessors `yaml:",inline"`
 
 	DeleteServiceName                 *config.ResourceProcessor `yaml:"resource/delete-service-name,omitempty"`
+	InsertPrometheusMonitorAttribute  *config.ResourceProcessor `yaml:"resource/insert-prometheus-monitor-attribute,omitempty"`
 	DropInternalCommunication         *FilterProcessor          `yaml:"filter/drop-internal-communication,omitempty"`
+	KeepScrapeHealth                  *FilterProcessor          `yaml:"filter/keep-scrape-health,omitempty"`
+	TransformScrapeHealth             *TransformProcessor       `yaml:"transform/scrape-health,omitempty"`
+	GroupScrapeHealthByJob            *GroupByAttrsProcessor    `yaml:"groupbyattrs/scrape-health,omitempty"`
+	AggregateScrapeHealth             *TransformProcessor       `yaml:"transform/aggregate-scrape-health,omitempty"`
 	SetInstrumentationScopeRuntime    *TransformProcessor       `yaml:"transform/set-instrumentation-scope-runtime,omitempty"`
 	SetInstrumentationScopePrometheus *TransformProcessor       `yaml:"transform/set-instrumentation-scope-prometheus,omitempty"`
 	SetInstrumentationScopeIstio      *TransformProcessor       `yaml:"transform/set-instrumentation-scope-istio,omitempty"`
+	SetInstrumentationScopeCluster    *TransformProcessor       `yaml:"transform/set-instrumentation-scope-cluster,omitempty"`
 }
 
 type Exporters struct {
-	OTLP config.OTLPExporter `yaml:"otlp"`
+	OTLP                   config.OTLPExporter `yaml:"otlp"`
+	PrometheusScrapeHealth *PrometheusExporter `yaml:"prometheus/scrape-health,omitempty"`
+}
+
+type PrometheusExporter struct {
+	Endpoint         string        `yaml:"endpoint"`
+	Namespace        string        `yaml:"namespace,omitempty"`
+	MetricExpiration time.Duration `yaml:"metric_expiration,omitempty"`
 }
 
 type FilterProcessor struct {
@@ -135,6 +170,10 @@ type FilterProcessorMetrics struct {
 	Metric    []string `yaml:"metric,omitempty"`
 }
 
+type GroupByAttrsProcessor struct {
+	Keys []string `yaml:"keys"`
+}
+
 type TransformProcessor struct {
 	ErrorMode        string                                `yaml:"error_mode"`
 	MetricStatements []config.TransformProcessorStatements `yaml:"metric_statements"`
@@ -7,31 +7,61 @@ import (
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+	"github.com/kyma-project/telemetry-manager/internal/prometheusmonitor"
 )
 
 type inputSources struct {
 	runtime    bool
 	prometheus bool
 	istio      bool
+
+	// prometheusMonitors contains the scrape configs for the targets of ServiceMonitors and PodMonitors. It is nil if no pipeline enables the monitors.
+	prometheusMonitors *PrometheusReceiver
+
+	// runtimeResources contains the resources for which any of the pipelines collects runtime metrics
+	runtimeResources []metric.RuntimeResourceType
+
+	// prometheusScrapeSettings are resolved from the Prometheus inputs of all pipelines
+	prometheusScrapeSettings ScrapeSettings
+}
+
+type BuildOptions struct {
+	IsIstioActive bool
+	// ServiceMonitors and PodMonitors are translated into scrape configs if any pipeline enables the monitors of the Prometheus input.
+	ServiceMonitors []prometheusmonitor.ServiceMonitor
+	PodMonitors     []prometheusmonitor.PodMonitor
 }
 
-func MakeConfig(gatewayServiceName types.NamespacedName, pipelines []telemetryv1alpha1.MetricPipeline, isIstioActive bool) *Config {
+func MakeConfig(gatewayServiceName types.NamespacedName, pipelines []telemetryv1alpha1.MetricPipeline, opts BuildOptions) *Config {
+	runtimeResources := enabledRuntimeResources(pipelines)
 	inputs := inputSources{
-		runtime:    enableRuntimeMetricScraping(pipelines),
-		prometheus: enablePrometheusMetricScraping(pipelines),
-		istio:      enableIstioMetricScraping(pipelines),
+		runtime:          len(runtimeResources) > 0,
+		prometheus:       enablePrometheusMetricScraping(pipelines),
+		istio:            enableIstioMetricScraping(pipelines),
+		runtimeResources: runtimeResources,
+
+		prometheusScrapeSettings: ResolvePrometheusScrapeSettings(pipelines),
+	}
+
+	if IsPrometheusMonitorsEnabled(pipelines) {
+		inputs.prometheusMonitors = makePrometheusConfigForMonitors(opts.ServiceMonitors, opts.PodMonitors, inputs.prometheusScrapeSettings, opts.IsIstioActive)
 	}
 
-	return &Config{
+	cfg := &Config{
 		Base: config.Base{
 			Service:    config.DefaultService(makePipelinesConfig(inputs)),
 			Extensions: config.DefaultExtensions(),
 		},
-		Receivers:  makeReceiversConfig(inputs, isIstioActive),
+		Receivers:  makeReceiversConfig(inputs, opts.IsIstioActive),
 		Processors: makeProcessorsConfig(inputs),
 		Exporters:  makeExportersConfig(gatewayServiceName),
 	}
+
+	addComponentsForScrapeHealth(cfg, inputs)
+
+	return cfg
 }
 
 func enablePrometheusMetricScraping(pipelines []telemetryv1alpha1.MetricPipeline) bool {
@@ -44,14 +74,19 @@ func enablePrometheusMetricScraping(pipelines []telemetryv1alpha1.MetricPipeline
 	return false
 }
 
-func enableRuntimeMetricScraping(pipelines []telemetryv1alpha1.MetricPipeline) bool {
-	for i := range pipelines {
-		input := pipelines[i].Spec.Input
-		if input.Runtime != nil && input.Runtime.Enabled {
-			return true
+// enabledRuntimeResources returns the union of the resources for which the pipelines collect runtime metrics.
+// The metrics are filtered per pipeline by the gateway.
+func enabledRuntimeResources(pipelines []telemetryv1alpha1.MetricPipeline) []metric.RuntimeResourceType {
+	var resources []metric.RuntimeResourceType
+	for _, resource := range metric.RuntimeResources {
+		for i := range pipelines {
+			if metric.IsRuntimeResourceEnabled(pipelines[i].Spec.Input.Runtime, resource) {
+				resources = append(resources, resource)
+				break
+			}
 		}
 	}
-	return false
+	return resources
 }
 
 func enableIstioMetricScraping(pipelines []telemetryv1alpha1.MetricPipeline) bool {
@@ -104,6 +139,14 @@ func makePipelinesConfig(inputs inputSources) config.Pipelines {
 		}
 	}
 
+	if inputs.prometheusMonitors != nil {
+		pipelinesConfig["metrics/prometheus-monitors"] = config.Pipeline{
+			Receivers:  []string{"prometheus/monitors"},
+			Processors: []string{"memory_limiter", "resource/delete-service-name", "resource/insert-prometheus-monitor-attribute", "transform/set-instrumentation-scope-prometheus", "batch"},
+			Exporters:  []string{"otlp"},
+		}
+	}
+
 	if inputs.istio {
 		pipelinesConfig["metrics/istio"] = config.Pipeline{
 			Receivers:  []string{"prometheus/istio"},
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
@@ -16,7 +17,7 @@ import (
 func TestMakeAgentConfig(t *testing.T) {
 	gatewayServiceName := types.NamespacedName{Name: "metrics", Namespace: "telemetry-system"}
 	t.Run("otlp exporter endpoint", func(t *testing.T) {
-		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, false)
+		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 
 		actualExporterConfig := collectorConfig.Exporters.OTLP
 		require.Equal(t, "metrics.telemetry-system.svc.cluster.local:4317", actualExporterConfig.Endpoint)
@@ -24,7 +25,7 @@ func TestMakeAgentConfig(t *testing.T) {
 
 	t.Run("insecure", func(t *testing.T) {
 		t.Run("otlp exporter endpoint", func(t *testing.T) {
-			collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, false)
+			collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 
 			actualExporterConfig := collectorConfig.Exporters.OTLP
 			require.True(t, actualExporterConfig.TLS.Insecure)
@@ -32,14 +33,14 @@ func TestMakeAgentConfig(t *testing.T) {
 	})
 
 	t.Run("extensions", func(t *testing.T) {
-		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, false)
+		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 
 		require.NotEmpty(t, collectorConfig.Extensions.HealthCheck.Endpoint)
 		require.Contains(t, collectorConfig.Service.Extensions, "health_check")
 	})
 
 	t.Run("telemetry", func(t *testing.T) {
-		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, false)
+		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 
 		require.Equal(t, "info", collectorConfig.Service.Telemetry.Logs.Level)
 		require.Equal(t, "json", collectorConfig.Service.Telemetry.Logs.Encoding)
@@ -50,7 +51,7 @@ func TestMakeAgentConfig(t *testing.T) {
 		t.Run("no input enabled", func(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.Nil(t, collectorConfig.Processors.DeleteServiceName)
 
@@ -60,7 +61,7 @@ func TestMakeAgentConfig(t *testing.T) {
 		t.Run("runtime input enabled", func(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.NotNil(t, collectorConfig.Processors.DeleteServiceName)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
@@ -77,24 +78,42 @@ func TestMakeAgentConfig(t *testing.T) {
 		t.Run("prometheus input enabled", func(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.NotNil(t, collectorConfig.Processors.DeleteServiceName)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopePrometheus)
 			require.Nil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
 			require.Nil(t, collectorConfig.Processors.SetInstrumentationScopeIstio)
 
-			require.Len(t, collectorConfig.Service.Pipelines, 1)
+			require.Len(t, collectorConfig.Service.Pipelines, 2)
 			require.Contains(t, collectorConfig.Service.Pipelines, "metrics/prometheus")
 			require.Equal(t, []string{"prometheus/app-pods", "prometheus/app-services"}, collectorConfig.Service.Pipelines["metrics/prometheus"].Receivers)
 			require.Equal(t, []string{"memory_limiter", "resource/delete-service-name", "transform/set-instrumentation-scope-prometheus", "batch"}, collectorConfig.Service.Pipelines["metrics/prometheus"].Processors)
 			require.Equal(t, []string{"otlp"}, collectorConfig.Service.Pipelines["metrics/prometheus"].Exporters)
+
+			require.Contains(t, collectorConfig.Service.Pipelines, "metrics/scrape-health")
+			require.Equal(t, []string{"prometheus/app-pods", "prometheus/app-services"}, collectorConfig.Service.Pipelines["metrics/scrape-health"].Receivers)
+			require.Equal(t, []string{
+				"memory_limiter",
+				"filter/keep-scrape-health",
+				"transform/scrape-health",
+				"groupbyattrs/scrape-health",
+				"transform/aggregate-scrape-health",
+			}, collectorConfig.Service.Pipelines["metrics/scrape-health"].Processors)
+			require.Equal(t, []string{"prometheus/scrape-health"}, collectorConfig.Service.Pipelines["metrics/scrape-health"].Exporters)
+			require.NotNil(t, collectorConfig.Processors.KeepScrapeHealth)
+			require.NotNil(t, collectorConfig.Processors.TransformScrapeHealth)
+			require.NotNil(t, collectorConfig.Processors.GroupScrapeHealthByJob)
+			require.NotNil(t, collectorConfig.Processors.AggregateScrapeHealth)
+			require.NotNil(t, collectorConfig.Exporters.PrometheusScrapeHealth)
+			require.Equal(t, "${MY_POD_IP}:8889", collectorConfig.Exporters.PrometheusScrapeHealth.Endpoint)
+			require.Equal(t, 90*time.Second, collectorConfig.Exporters.PrometheusScrapeHealth.MetricExpiration)
 		})
 
 		t.Run("istio input enabled", func(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithIstioInput(true).Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.NotNil(t, collectorConfig.Processors.DeleteServiceName)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopeIstio)
@@ -111,14 +130,14 @@ func TestMakeAgentConfig(t *testing.T) {
 		t.Run("multiple input enabled", func(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).WithPrometheusInput(true).WithIstioInput(true).Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.NotNil(t, collectorConfig.Processors.DeleteServiceName)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopePrometheus)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopeIstio)
 
-			require.Len(t, collectorConfig.Service.Pipelines, 3)
+			require.Len(t, collectorConfig.Service.Pipelines, 4)
 			require.Contains(t, collectorConfig.Service.Pipelines, "metrics/runtime")
 			require.Equal(t, []string{"kubeletstats"}, collectorConfig.Service.Pipelines["metrics/runtime"].Receivers)
 			require.Equal(t, []string{"memory_limiter", "resource/delete-service-name", "transform/set-instrumentation-scope-runtime", "batch"}, collectorConfig.Service.Pipelines["metrics/runtime"].Processors)
@@ -139,7 +158,7 @@ func TestMakeAgentConfig(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().Build(),
 				testutils.NewMetricPipelineBuilder().Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.Nil(t, collectorConfig.Processors.DeleteServiceName)
 			require.Nil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
@@ -153,7 +172,7 @@ func TestMakeAgentConfig(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithRuntimeInput(false).Build(),
 				testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.NotNil(t, collectorConfig.Processors.DeleteServiceName)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
@@ -171,7 +190,7 @@ func TestMakeAgentConfig(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).Build(),
 				testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.NotNil(t, collectorConfig.Processors.DeleteServiceName)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
@@ -189,14 +208,14 @@ func TestMakeAgentConfig(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithPrometheusInput(false).Build(),
 				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.NotNil(t, collectorConfig.Processors.DeleteServiceName)
 			require.Nil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopePrometheus)
 			require.Nil(t, collectorConfig.Processors.SetInstrumentationScopeIstio)
 
-			require.Len(t, collectorConfig.Service.Pipelines, 1)
+			require.Len(t, collectorConfig.Service.Pipelines, 2)
 			require.Contains(t, collectorConfig.Service.Pipelines, "metrics/prometheus")
 			require.Equal(t, []string{"prometheus/app-pods", "prometheus/app-services"}, collectorConfig.Service.Pipelines["metrics/prometheus"].Receivers)
 			require.Equal(t, []string{"memory_limiter", "resource/delete-service-name", "transform/set-instrumentation-scope-prometheus", "batch"}, collectorConfig.Service.Pipelines["metrics/prometheus"].Processors)
@@ -207,13 +226,13 @@ func TestMakeAgentConfig(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
 				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.NotNil(t, collectorConfig.Processors.DeleteServiceName)
 			require.Nil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopePrometheus)
 
-			require.Len(t, collectorConfig.Service.Pipelines, 1)
+			require.Len(t, collectorConfig.Service.Pipelines, 2)
 			require.Contains(t, collectorConfig.Service.Pipelines, "metrics/prometheus")
 			require.Equal(t, []string{"prometheus/app-pods", "prometheus/app-services"}, collectorConfig.Service.Pipelines["metrics/prometheus"].Receivers)
 			require.Equal(t, []string{"memory_limiter", "resource/delete-service-name", "transform/set-instrumentation-scope-prometheus", "batch"}, collectorConfig.Service.Pipelines["metrics/prometheus"].Processors)
@@ -224,13 +243,13 @@ func TestMakeAgentConfig(t *testing.T) {
 			collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
 				testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).Build(),
-			}, false)
+			}, BuildOptions{})
 
 			require.NotNil(t, collectorConfig.Processors.DeleteServiceName)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
 			require.NotNil(t, collectorConfig.Processors.SetInstrumentationScopeRuntime)
 
-			require.Len(t, collectorConfig.Service.Pipelines, 2)
+			require.Len(t, collectorConfig.Service.Pipelines, 3)
 			require.Contains(t, collectorConfig.Service.Pipelines, "metrics/runtime")
 			require.Equal(t, []string{"kubeletstats"}, collectorConfig.Service.Pipelines["metrics/runtime"].Receivers)
 			require.Equal(t, []string{"memory_limiter", "resource/delete-service-name", "transform/set-instrumentation-scope-runtime", "batch"}, collectorConfig.Service.Pipelines["metrics/runtime"].Processors)
@@ -242,6 +261,29 @@ func TestMakeAgentConfig(t *testing.T) {
 		})
 	})
 
+	t.Run("marshaling scrape intervals in the Prometheus format", func(t *testing.T) {
+		tests := []struct {
+			interval string
+			expected string
+		}{
+			{interval: "1500ms", expected: "scrape_interval: 1s500ms"},
+			{interval: "90s", expected: "scrape_interval: 1m30s"},
+			{interval: "1m", expected: "scrape_interval: 1m"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.interval, func(t *testing.T) {
+				config := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{
+					testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputScrapeInterval(tt.interval).Build(),
+				}, BuildOptions{})
+				configYAML, err := yaml.Marshal(config)
+				require.NoError(t, err)
+
+				require.Contains(t, string(configYAML), tt.expected)
+			})
+		}
+	})
+
 	t.Run("marshaling", func(t *testing.T) {
 		tests := []struct {
 			name                string
@@ -266,7 +308,7 @@ func TestMakeAgentConfig(t *testing.T) {
 				pipelines := []telemetryv1alpha1.MetricPipeline{
 					testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).WithPrometheusInput(true).WithIstioInput(tt.istioActive).Build(),
 				}
-				config := MakeConfig(gatewayServiceName, pipelines, tt.istioActive)
+				config := MakeConfig(gatewayServiceName, pipelines, BuildOptions{IsIstioActive: tt.istioActive})
 				configYAML, err := yaml.Marshal(config)
 				require.NoError(t, err, "failed to marshal config")
 
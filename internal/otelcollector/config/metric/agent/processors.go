@@ -24,6 +24,10 @@ func makeProcessorsConfig(inputs inputSources) Processors {
 			processorsConfig.SetInstrumentationScopePrometheus = makeInstrumentationScopeProcessor(metric.InputSourcePrometheus)
 		}
 
+		if inputs.prometheusMonitors != nil {
+			processorsConfig.InsertPrometheusMonitorAttribute = makeInsertPrometheusMonitorAttributeConfig()
+		}
+
 		if inputs.istio {
 			processorsConfig.DropInternalCommunication = makeFilterToDropMetricsForTelemetryComponents()
 			processorsConfig.SetInstrumentationScopeIstio = makeInstrumentationScopeProcessor(metric.InputSourceIstio)
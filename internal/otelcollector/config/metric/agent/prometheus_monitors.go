@@ -0,0 +1,315 @@
+package agent
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric"
+	"github.com/kyma-project/telemetry-manager/internal/prometheusmonitor"
+)
+
+var invalidLabelCharRE = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// IsPrometheusMonitorsEnabled returns true if any pipeline scrapes the targets of ServiceMonitors and PodMonitors.
+func IsPrometheusMonitorsEnabled(pipelines []telemetryv1alpha1.MetricPipeline) bool {
+	for i := range pipelines {
+		prometheus := pipelines[i].Spec.Input.Prometheus
+		if prometheus != nil && prometheus.Enabled && prometheus.Monitors != nil && prometheus.Monitors.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// makePrometheusConfigForMonitors translates every endpoint of the given ServiceMonitors and PodMonitors into a scrape config,
+// following the conventions of the Prometheus Operator. It returns nil if there is nothing to scrape.
+func makePrometheusConfigForMonitors(serviceMonitors []prometheusmonitor.ServiceMonitor, podMonitors []prometheusmonitor.PodMonitor, settings ScrapeSettings, isIstioActive bool) *PrometheusReceiver {
+	var scrapeConfigs []ScrapeConfig
+
+	for i := range serviceMonitors {
+		monitor := &serviceMonitors[i]
+		for j, endpoint := range monitor.Spec.Endpoints {
+			scrapeConfigs = append(scrapeConfigs, makeServiceMonitorScrapeConfig(monitor, j, endpoint, settings, isIstioActive))
+		}
+	}
+
+	for i := range podMonitors {
+		monitor := &podMonitors[i]
+		for j, endpoint := range monitor.Spec.PodMetricsEndpoints {
+			scrapeConfigs = append(scrapeConfigs, makePodMonitorScrapeConfig(monitor, j, endpoint, settings, isIstioActive))
+		}
+	}
+
+	if len(scrapeConfigs) == 0 {
+		return nil
+	}
+
+	return &PrometheusReceiver{
+		Config: PrometheusConfig{
+			ScrapeConfigs: scrapeConfigs,
+		},
+	}
+}
+
+func makeServiceMonitorScrapeConfig(monitor *prometheusmonitor.ServiceMonitor, index int, endpoint prometheusmonitor.Endpoint, settings ScrapeSettings, isIstioActive bool) ScrapeConfig {
+	relabelConfigs := []RelabelConfig{keepIfRunningOnSameNode(NodeAffiliatedEndpoint)}
+	relabelConfigs = append(relabelConfigs, keepIfLabelSelectorMatches("service", monitor.Spec.Selector)...)
+
+	if endpoint.Port != "" {
+		relabelConfigs = append(relabelConfigs, keepIfLabelMatches("__meta_kubernetes_endpoint_port_name", endpoint.Port))
+	} else if endpoint.TargetPort != nil {
+		relabelConfigs = append(relabelConfigs, keepIfTargetPortMatches(*endpoint.TargetPort))
+	}
+
+	// Targets with the scrape annotation are already scraped by the annotation-based scrape configs
+	relabelConfigs = append(relabelConfigs,
+		dropIfPodNotRunning(),
+		dropIfScrapingEnabled(AnnotatedService),
+		dropIfScrapingEnabled(AnnotatedPod),
+		inferServiceFromMetaLabel())
+
+	scrapeInterval := makeMonitorScrapeInterval(endpoint.Interval, settings.Interval)
+	return ScrapeConfig{
+		JobName:                    fmt.Sprintf("serviceMonitor/%s/%s/%d", monitor.Namespace, monitor.Name, index),
+		SampleLimit:                makeMonitorSampleLimit(monitor.Spec.SampleLimit, settings.SampleLimit),
+		ScrapeInterval:             scrapeInterval,
+		ScrapeTimeout:              makeMonitorScrapeTimeout(endpoint.ScrapeTimeout, scrapeInterval),
+		HonorLabels:                endpoint.HonorLabels,
+		MetricsPath:                endpoint.Path,
+		Scheme:                     endpoint.Scheme,
+		KubernetesDiscoveryConfigs: []KubernetesDiscoveryConfig{makeMonitorDiscoveryConfig(RoleEndpoints, monitor.Namespace, monitor.Spec.NamespaceSelector)},
+		TLSConfig:                  makeMonitorTLSConfig(endpoint.Scheme, endpoint.TLSConfig, isIstioActive),
+		RelabelConfigs:             append(relabelConfigs, convertRelabelConfigs(endpoint.RelabelConfigs)...),
+		MetricRelabelConfigs:       convertRelabelConfigs(endpoint.MetricRelabelConfigs),
+	}
+}
+
+func makePodMonitorScrapeConfig(monitor *prometheusmonitor.PodMonitor, index int, endpoint prometheusmonitor.PodMetricsEndpoint, settings ScrapeSettings, isIstioActive bool) ScrapeConfig {
+	relabelConfigs := []RelabelConfig{keepIfRunningOnSameNode(NodeAffiliatedPod)}
+	relabelConfigs = append(relabelConfigs, keepIfLabelSelectorMatches("pod", monitor.Spec.Selector)...)
+
+	if endpoint.Port != "" {
+		relabelConfigs = append(relabelConfigs, keepIfLabelMatches("__meta_kubernetes_pod_container_port_name", endpoint.Port))
+	} else if endpoint.TargetPort != nil {
+		relabelConfigs = append(relabelConfigs, keepIfTargetPortMatches(*endpoint.TargetPort))
+	}
+
+	// Targets with the scrape annotation are already scraped by the annotation-based scrape configs
+	relabelConfigs = append(relabelConfigs,
+		dropIfPodNotRunning(),
+		dropIfInitContainer(),
+		dropIfScrapingEnabled(AnnotatedPod))
+
+	scrapeInterval := makeMonitorScrapeInterval(endpoint.Interval, settings.Interval)
+	return ScrapeConfig{
+		JobName:                    fmt.Sprintf("podMonitor/%s/%s/%d", monitor.Namespace, monitor.Name, index),
+		SampleLimit:                makeMonitorSampleLimit(monitor.Spec.SampleLimit, settings.SampleLimit),
+		ScrapeInterval:             scrapeInterval,
+		ScrapeTimeout:              makeMonitorScrapeTimeout(endpoint.ScrapeTimeout, scrapeInterval),
+		HonorLabels:                endpoint.HonorLabels,
+		MetricsPath:                endpoint.Path,
+		Scheme:                     endpoint.Scheme,
+		KubernetesDiscoveryConfigs: []KubernetesDiscoveryConfig{makeMonitorDiscoveryConfig(RolePod, monitor.Namespace, monitor.Spec.NamespaceSelector)},
+		TLSConfig:                  makeMonitorTLSConfig(endpoint.Scheme, endpoint.TLSConfig, isIstioActive),
+		RelabelConfigs:             append(relabelConfigs, convertRelabelConfigs(endpoint.RelabelConfigs)...),
+		MetricRelabelConfigs:       convertRelabelConfigs(endpoint.MetricRelabelConfigs),
+	}
+}
+
+// makeMonitorDiscoveryConfig restricts the service discovery to the Namespace of the monitor, unless the monitor selects other Namespaces.
+func makeMonitorDiscoveryConfig(role Role, monitorNamespace string, selector prometheusmonitor.NamespaceSelector) KubernetesDiscoveryConfig {
+	if selector.Any {
+		return KubernetesDiscoveryConfig{Role: role}
+	}
+
+	names := []string{monitorNamespace}
+	if len(selector.MatchNames) > 0 {
+		names = selector.MatchNames
+	}
+
+	return KubernetesDiscoveryConfig{
+		Role:       role,
+		Namespaces: &KubernetesNamespaces{Names: names},
+	}
+}
+
+// makeMonitorSampleLimit applies the sample limit of the monitor, but never exceeds the limit of the pipelines.
+func makeMonitorSampleLimit(monitorSampleLimit *uint64, pipelineSampleLimit int) int {
+	if monitorSampleLimit != nil && *monitorSampleLimit > 0 && *monitorSampleLimit < uint64(pipelineSampleLimit) {
+		return int(*monitorSampleLimit)
+	}
+	return pipelineSampleLimit
+}
+
+// makeMonitorScrapeInterval applies the interval of the endpoint, and falls back to the interval of the pipelines.
+func makeMonitorScrapeInterval(interval string, pipelineInterval time.Duration) model.Duration {
+	if interval == "" {
+		return model.Duration(pipelineInterval)
+	}
+
+	parsed, err := model.ParseDuration(interval)
+	if err != nil || parsed <= 0 {
+		return model.Duration(pipelineInterval)
+	}
+
+	return parsed
+}
+
+// makeMonitorScrapeTimeout applies the scrape timeout of the endpoint, but never exceeds the scrape interval, which the collector
+// would reject. If the endpoint has no valid timeout, the default timeout of the collector is used.
+func makeMonitorScrapeTimeout(timeout string, scrapeInterval model.Duration) model.Duration {
+	if timeout == "" {
+		return 0
+	}
+
+	parsed, err := model.ParseDuration(timeout)
+	if err != nil || parsed <= 0 {
+		return 0
+	}
+
+	return min(parsed, scrapeInterval)
+}
+
+// makeMonitorTLSConfig translates the file-based TLS settings of an endpoint. HTTPS endpoints without TLS settings
+// are scraped with the Istio certificates of the agent if Istio is active.
+func makeMonitorTLSConfig(scheme string, tlsConfig *prometheusmonitor.TLSConfig, isIstioActive bool) *TLSConfig {
+	if tlsConfig == nil {
+		if isIstioActive && scheme == "https" {
+			return makeTLSConfig()
+		}
+		return nil
+	}
+
+	return &TLSConfig{
+		CAFile:             tlsConfig.CAFile,
+		CertFile:           tlsConfig.CertFile,
+		KeyFile:            tlsConfig.KeyFile,
+		ServerName:         tlsConfig.ServerName,
+		InsecureSkipVerify: tlsConfig.InsecureSkipVerify,
+	}
+}
+
+// keepIfLabelSelectorMatches translates a label selector into relabel configs on the discovered labels of the given role ("service" or "pod").
+func keepIfLabelSelectorMatches(role string, selector metav1.LabelSelector) []RelabelConfig {
+	var relabelConfigs []RelabelConfig
+
+	keys := make([]string, 0, len(selector.MatchLabels))
+	for key := range selector.MatchLabels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		relabelConfigs = append(relabelConfigs, RelabelConfig{
+			SourceLabels: labelAndPresenceSourceLabels(role, key),
+			Regex:        fmt.Sprintf("(%s);true", escapeDollarSigns(selector.MatchLabels[key])),
+			Action:       Keep,
+		})
+	}
+
+	for _, expression := range selector.MatchExpressions {
+		switch expression.Operator {
+		case metav1.LabelSelectorOpIn:
+			relabelConfigs = append(relabelConfigs, RelabelConfig{
+				SourceLabels: labelAndPresenceSourceLabels(role, expression.Key),
+				Regex:        fmt.Sprintf("(%s);true", escapeDollarSigns(strings.Join(expression.Values, "|"))),
+				Action:       Keep,
+			})
+		case metav1.LabelSelectorOpNotIn:
+			relabelConfigs = append(relabelConfigs, RelabelConfig{
+				SourceLabels: labelAndPresenceSourceLabels(role, expression.Key),
+				Regex:        fmt.Sprintf("(%s);true", escapeDollarSigns(strings.Join(expression.Values, "|"))),
+				Action:       Drop,
+			})
+		case metav1.LabelSelectorOpExists:
+			relabelConfigs = append(relabelConfigs, RelabelConfig{
+				SourceLabels: []string{fmt.Sprintf("__meta_kubernetes_%s_labelpresent_%s", role, sanitizeLabelName(expression.Key))},
+				Regex:        "true",
+				Action:       Keep,
+			})
+		case metav1.LabelSelectorOpDoesNotExist:
+			relabelConfigs = append(relabelConfigs, RelabelConfig{
+				SourceLabels: []string{fmt.Sprintf("__meta_kubernetes_%s_labelpresent_%s", role, sanitizeLabelName(expression.Key))},
+				Regex:        "true",
+				Action:       Drop,
+			})
+		}
+	}
+
+	return relabelConfigs
+}
+
+func labelAndPresenceSourceLabels(role, key string) []string {
+	sanitized := sanitizeLabelName(key)
+	return []string{
+		fmt.Sprintf("__meta_kubernetes_%s_label_%s", role, sanitized),
+		fmt.Sprintf("__meta_kubernetes_%s_labelpresent_%s", role, sanitized),
+	}
+}
+
+func keepIfLabelMatches(label, value string) RelabelConfig {
+	return RelabelConfig{
+		SourceLabels: []string{label},
+		Regex:        escapeDollarSigns(value),
+		Action:       Keep,
+	}
+}
+
+func keepIfTargetPortMatches(targetPort intstr.IntOrString) RelabelConfig {
+	if targetPort.Type == intstr.Int {
+		return keepIfLabelMatches("__meta_kubernetes_pod_container_port_number", targetPort.String())
+	}
+	return keepIfLabelMatches("__meta_kubernetes_pod_container_port_name", targetPort.String())
+}
+
+// convertRelabelConfigs translates user-defined relabelings. Dollar signs are escaped, because the collector would otherwise
+// interpret capture group references like $1 as environment variables.
+func convertRelabelConfigs(relabelConfigs []prometheusmonitor.RelabelConfig) []RelabelConfig {
+	var result []RelabelConfig
+	for _, rc := range relabelConfigs {
+		converted := RelabelConfig{
+			SourceLabels: rc.SourceLabels,
+			Regex:        escapeDollarSigns(rc.Regex),
+			Modulus:      rc.Modulus,
+			TargetLabel:  escapeDollarSigns(rc.TargetLabel),
+			Action:       RelabelAction(strings.ToLower(rc.Action)),
+		}
+		if rc.Separator != nil {
+			converted.Separator = *rc.Separator
+		}
+		if rc.Replacement != nil {
+			converted.Replacement = escapeDollarSigns(*rc.Replacement)
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+func sanitizeLabelName(name string) string {
+	return invalidLabelCharRE.ReplaceAllString(name, "_")
+}
+
+func escapeDollarSigns(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
+
+func makeInsertPrometheusMonitorAttributeConfig() *config.ResourceProcessor {
+	return &config.ResourceProcessor{
+		Attributes: []config.AttributeAction{
+			{
+				Action: "insert",
+				Key:    metric.PrometheusMonitorAttribute,
+				Value:  "true",
+			},
+		},
+	}
+}
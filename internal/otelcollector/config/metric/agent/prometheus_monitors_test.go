@@ -0,0 +1,226 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/prometheusmonitor"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestPrometheusMonitors(t *testing.T) {
+	gatewayServiceName := types.NamespacedName{Name: "metrics-gateway"}
+
+	serviceMonitor := prometheusmonitor.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "backend"},
+		Spec: prometheusmonitor.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "backend"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"test", "dev"}},
+				},
+			},
+			NamespaceSelector: prometheusmonitor.NamespaceSelector{MatchNames: []string{"team-a", "team-b"}},
+			SampleLimit:       ptr.To[uint64](1000),
+			Endpoints: []prometheusmonitor.Endpoint{
+				{
+					Port:          "http-metrics",
+					Path:          "/custom/metrics",
+					Scheme:        "https",
+					Interval:      "1m",
+					ScrapeTimeout: "30s",
+					HonorLabels:   true,
+					TLSConfig: &prometheusmonitor.TLSConfig{
+						CAFile:             "/etc/certs/ca.crt",
+						ServerName:         "backend.team-a",
+						InsecureSkipVerify: true,
+					},
+					RelabelConfigs: []prometheusmonitor.RelabelConfig{
+						{SourceLabels: []string{"__meta_kubernetes_pod_node_name"}, TargetLabel: "node", Replacement: ptr.To("$1"), Action: "Replace"},
+					},
+					MetricRelabelConfigs: []prometheusmonitor.RelabelConfig{
+						{SourceLabels: []string{"__name__"}, Regex: "go_.*", Action: "drop"},
+					},
+				},
+			},
+		},
+	}
+
+	podMonitor := prometheusmonitor.PodMonitor{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "worker"},
+		Spec: prometheusmonitor.PodMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: metav1.LabelSelectorOpExists},
+				},
+			},
+			PodMetricsEndpoints: []prometheusmonitor.PodMetricsEndpoint{
+				{TargetPort: ptr.To(intstr.FromInt32(9090))},
+				{Port: "admin", Scheme: "https", ScrapeTimeout: "5m"},
+			},
+		},
+	}
+
+	opts := BuildOptions{
+		IsIstioActive:   true,
+		ServiceMonitors: []prometheusmonitor.ServiceMonitor{serviceMonitor},
+		PodMonitors:     []prometheusmonitor.PodMonitor{podMonitor},
+	}
+
+	t.Run("monitors disabled", func(t *testing.T) {
+		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
+		}, opts)
+
+		require.Nil(t, collectorConfig.Receivers.PrometheusMonitors)
+		require.Nil(t, collectorConfig.Processors.InsertPrometheusMonitorAttribute)
+		require.NotContains(t, collectorConfig.Service.Pipelines, "metrics/prometheus-monitors")
+	})
+
+	t.Run("monitors enabled without any monitors", func(t *testing.T) {
+		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputMonitors(true).Build(),
+		}, BuildOptions{})
+
+		require.Nil(t, collectorConfig.Receivers.PrometheusMonitors)
+		require.NotContains(t, collectorConfig.Service.Pipelines, "metrics/prometheus-monitors")
+	})
+
+	t.Run("pipeline topology", func(t *testing.T) {
+		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputMonitors(true).Build(),
+		}, opts)
+
+		require.NotNil(t, collectorConfig.Processors.InsertPrometheusMonitorAttribute)
+		require.Contains(t, collectorConfig.Service.Pipelines, "metrics/prometheus-monitors")
+		require.Equal(t, []string{"prometheus/monitors"}, collectorConfig.Service.Pipelines["metrics/prometheus-monitors"].Receivers)
+		require.Equal(t, []string{"memory_limiter", "resource/delete-service-name", "resource/insert-prometheus-monitor-attribute", "transform/set-instrumentation-scope-prometheus", "batch"}, collectorConfig.Service.Pipelines["metrics/prometheus-monitors"].Processors)
+		require.Equal(t, []string{"otlp"}, collectorConfig.Service.Pipelines["metrics/prometheus-monitors"].Exporters)
+	})
+
+	t.Run("scrape configs", func(t *testing.T) {
+		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputMonitors(true).Build(),
+		}, opts)
+
+		require.NotNil(t, collectorConfig.Receivers.PrometheusMonitors)
+		scrapeConfigs := collectorConfig.Receivers.PrometheusMonitors.Config.ScrapeConfigs
+		require.Len(t, scrapeConfigs, 3)
+
+		require.Equal(t, ScrapeConfig{
+			JobName:        "serviceMonitor/team-a/backend/0",
+			SampleLimit:    1000,
+			ScrapeInterval: model.Duration(time.Minute),
+			ScrapeTimeout:  model.Duration(30 * time.Second),
+			HonorLabels:    true,
+			MetricsPath:    "/custom/metrics",
+			Scheme:         "https",
+			KubernetesDiscoveryConfigs: []KubernetesDiscoveryConfig{{
+				Role:       RoleEndpoints,
+				Namespaces: &KubernetesNamespaces{Names: []string{"team-a", "team-b"}},
+			}},
+			TLSConfig: &TLSConfig{
+				CAFile:             "/etc/certs/ca.crt",
+				ServerName:         "backend.team-a",
+				InsecureSkipVerify: true,
+			},
+			RelabelConfigs: []RelabelConfig{
+				{SourceLabels: []string{"__meta_kubernetes_endpoint_node_name"}, Regex: "$MY_NODE_NAME", Action: Keep},
+				{SourceLabels: []string{"__meta_kubernetes_service_label_app_kubernetes_io_name", "__meta_kubernetes_service_labelpresent_app_kubernetes_io_name"}, Regex: "(backend);true", Action: Keep},
+				{SourceLabels: []string{"__meta_kubernetes_service_label_tier", "__meta_kubernetes_service_labelpresent_tier"}, Regex: "(test|dev);true", Action: Drop},
+				{SourceLabels: []string{"__meta_kubernetes_endpoint_port_name"}, Regex: "http-metrics", Action: Keep},
+				{SourceLabels: []string{"__meta_kubernetes_pod_phase"}, Regex: "Pending|Succeeded|Failed", Action: Drop},
+				{SourceLabels: []string{"__meta_kubernetes_service_annotation_prometheus_io_scrape"}, Regex: "true", Action: Drop},
+				{SourceLabels: []string{"__meta_kubernetes_pod_annotation_prometheus_io_scrape"}, Regex: "true", Action: Drop},
+				{SourceLabels: []string{"__meta_kubernetes_service_name"}, TargetLabel: "service", Action: Replace},
+				{SourceLabels: []string{"__meta_kubernetes_pod_node_name"}, TargetLabel: "node", Replacement: "$$1", Action: Replace},
+			},
+			MetricRelabelConfigs: []RelabelConfig{
+				{SourceLabels: []string{"__name__"}, Regex: "go_.*", Action: Drop},
+			},
+		}, scrapeConfigs[0])
+
+		require.Equal(t, ScrapeConfig{
+			JobName:                    "podMonitor/team-b/worker/0",
+			SampleLimit:                sampleLimit,
+			ScrapeInterval:             model.Duration(scrapeInterval),
+			KubernetesDiscoveryConfigs: []KubernetesDiscoveryConfig{{Role: RolePod, Namespaces: &KubernetesNamespaces{Names: []string{"team-b"}}}},
+			RelabelConfigs: []RelabelConfig{
+				{SourceLabels: []string{"__meta_kubernetes_pod_node_name"}, Regex: "$MY_NODE_NAME", Action: Keep},
+				{SourceLabels: []string{"__meta_kubernetes_pod_labelpresent_app"}, Regex: "true", Action: Keep},
+				{SourceLabels: []string{"__meta_kubernetes_pod_container_port_number"}, Regex: "9090", Action: Keep},
+				{SourceLabels: []string{"__meta_kubernetes_pod_phase"}, Regex: "Pending|Succeeded|Failed", Action: Drop},
+				{SourceLabels: []string{"__meta_kubernetes_pod_container_init"}, Regex: "(true)", Action: Drop},
+				{SourceLabels: []string{"__meta_kubernetes_pod_annotation_prometheus_io_scrape"}, Regex: "true", Action: Drop},
+			},
+		}, scrapeConfigs[1])
+
+		require.Equal(t, "podMonitor/team-b/worker/1", scrapeConfigs[2].JobName)
+		require.Equal(t, RelabelConfig{SourceLabels: []string{"__meta_kubernetes_pod_container_port_name"}, Regex: "admin", Action: Keep}, scrapeConfigs[2].RelabelConfigs[2])
+		require.Equal(t, makeTLSConfig(), scrapeConfigs[2].TLSConfig, "https endpoints without TLS settings must use the Istio certificates")
+		require.Equal(t, model.Duration(scrapeInterval), scrapeConfigs[2].ScrapeTimeout, "the scrape timeout must not exceed the scrape interval")
+	})
+
+	t.Run("any namespace", func(t *testing.T) {
+		anyNamespaceMonitor := podMonitor
+		anyNamespaceMonitor.Spec.NamespaceSelector = prometheusmonitor.NamespaceSelector{Any: true}
+
+		collectorConfig := MakeConfig(gatewayServiceName, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputMonitors(true).Build(),
+		}, BuildOptions{PodMonitors: []prometheusmonitor.PodMonitor{anyNamespaceMonitor}})
+
+		scrapeConfigs := collectorConfig.Receivers.PrometheusMonitors.Config.ScrapeConfigs
+		require.Equal(t, []KubernetesDiscoveryConfig{{Role: RolePod}}, scrapeConfigs[0].KubernetesDiscoveryConfigs)
+		require.Nil(t, scrapeConfigs[1].TLSConfig, "https endpoints without TLS settings must not use the Istio certificates if Istio is not active")
+	})
+}
+
+func TestIsPrometheusMonitorsEnabled(t *testing.T) {
+	tests := []struct {
+		name      string
+		pipelines []telemetryv1alpha1.MetricPipeline
+		expected  bool
+	}{
+		{
+			name:      "no pipelines",
+			pipelines: nil,
+			expected:  false,
+		},
+		{
+			name: "monitors not defined",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
+			},
+			expected: false,
+		},
+		{
+			name: "monitors enabled but prometheus input disabled",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(false).WithPrometheusInputMonitors(true).Build(),
+			},
+			expected: false,
+		},
+		{
+			name: "monitors enabled in one pipeline",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputMonitors(true).Build(),
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, IsPrometheusMonitorsEnabled(tt.pipelines))
+		})
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/prometheus/common/model"
+
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric"
 )
 
 const scrapeInterval = 30 * time.Second
@@ -22,30 +25,45 @@ func makeReceiversConfig(inputs inputSources, isIstioActive bool) Receivers {
 	var receiversConfig Receivers
 
 	if inputs.prometheus {
-		receiversConfig.PrometheusAppPods = makePrometheusConfigForPods(isIstioActive)
-		receiversConfig.PrometheusAppServices = makePrometheusConfigForServices(isIstioActive)
+		receiversConfig.PrometheusAppPods = makePrometheusConfigForPods(inputs.prometheusScrapeSettings, isIstioActive)
+		receiversConfig.PrometheusAppServices = makePrometheusConfigForServices(inputs.prometheusScrapeSettings, isIstioActive)
 	}
 
 	if inputs.runtime {
-		receiversConfig.KubeletStats = makeKubeletStatsConfig()
+		receiversConfig.KubeletStats = makeKubeletStatsConfig(inputs.runtimeResources)
 	}
 
 	if inputs.istio {
 		receiversConfig.PrometheusIstio = makePrometheusIstioConfig()
 	}
 
+	receiversConfig.PrometheusMonitors = inputs.prometheusMonitors
+
 	return receiversConfig
 }
 
-func makeKubeletStatsConfig() *KubeletStatsReceiver {
+var metricGroupByRuntimeResource = map[metric.RuntimeResourceType]MetricGroupType{
+	metric.RuntimeResourceContainer: MetricGroupTypeContainer,
+	metric.RuntimeResourcePod:       MetricGroupTypePod,
+	metric.RuntimeResourceNode:      MetricGroupTypeNode,
+	metric.RuntimeResourceVolume:    MetricGroupTypeVolume,
+}
+
+func makeKubeletStatsConfig(runtimeResources []metric.RuntimeResourceType) *KubeletStatsReceiver {
 	const collectionInterval = "30s"
 	const portKubelet = 10250
+
+	var metricGroups []MetricGroupType
+	for _, resource := range runtimeResources {
+		metricGroups = append(metricGroups, metricGroupByRuntimeResource[resource])
+	}
+
 	return &KubeletStatsReceiver{
 		CollectionInterval: collectionInterval,
 		AuthType:           "serviceAccount",
 		InsecureSkipVerify: true,
 		Endpoint:           fmt.Sprintf("https://${env:%s}:%d", config.EnvVarCurrentNodeName, portKubelet),
-		MetricGroups:       []MetricGroupType{MetricGroupTypeContainer, MetricGroupTypePod},
+		MetricGroups:       metricGroups,
 		Metrics: KubeletMetricsConfig{
 			ContainerCPUUsage:       KubeletMetricConfig{Enabled: true},
 			ContainerCPUUtilization: KubeletMetricConfig{Enabled: false},
@@ -57,20 +75,20 @@ func makeKubeletStatsConfig() *KubeletStatsReceiver {
 	}
 }
 
-func makePrometheusConfigForPods(isIstioActive bool) *PrometheusReceiver {
-	return makePrometheusConfig(isIstioActive, "app-pods", RolePod, makePrometheusPodsRelabelConfigs)
+func makePrometheusConfigForPods(settings ScrapeSettings, isIstioActive bool) *PrometheusReceiver {
+	return makePrometheusConfig(settings, isIstioActive, "app-pods", RolePod, makePrometheusPodsRelabelConfigs)
 }
 
-func makePrometheusConfigForServices(isIstioActive bool) *PrometheusReceiver {
-	return makePrometheusConfig(isIstioActive, "app-services", RoleEndpoints, makePrometheusServicesRelabelConfigs)
+func makePrometheusConfigForServices(settings ScrapeSettings, isIstioActive bool) *PrometheusReceiver {
+	return makePrometheusConfig(settings, isIstioActive, "app-services", RoleEndpoints, makePrometheusServicesRelabelConfigs)
 }
 
-func makePrometheusConfig(isIstioActive bool, jobNamePrefix string, role Role, relabelConfigFn func(keepSecure bool) []RelabelConfig) *PrometheusReceiver {
+func makePrometheusConfig(settings ScrapeSettings, isIstioActive bool, jobNamePrefix string, role Role, relabelConfigFn func(keepSecure bool) []RelabelConfig) *PrometheusReceiver {
 	var config PrometheusReceiver
 
 	baseScrapeConfig := ScrapeConfig{
-		ScrapeInterval:             scrapeInterval,
-		SampleLimit:                sampleLimit,
+		ScrapeInterval:             model.Duration(settings.Interval),
+		SampleLimit:                settings.SampleLimit,
 		KubernetesDiscoveryConfigs: []KubernetesDiscoveryConfig{{Role: role}},
 	}
 
@@ -109,7 +127,9 @@ func makePrometheusPodsRelabelConfigs(keepSecure bool) []RelabelConfig {
 
 	return append(relabelConfigs,
 		inferMetricsPathFromAnnotation(AnnotatedPod),
-		inferAddressFromAnnotation(AnnotatedPod))
+		inferAddressFromAnnotation(AnnotatedPod),
+		inferScrapeIntervalFromAnnotation(AnnotatedPod),
+		inferScrapeTimeoutFromAnnotation(AnnotatedPod))
 }
 
 func makePrometheusServicesRelabelConfigs(keepSecure bool) []RelabelConfig {
@@ -132,6 +152,8 @@ func makePrometheusServicesRelabelConfigs(keepSecure bool) []RelabelConfig {
 	return append(relabelConfigs,
 		inferMetricsPathFromAnnotation(AnnotatedService),
 		inferAddressFromAnnotation(AnnotatedService),
+		inferScrapeIntervalFromAnnotation(AnnotatedService),
+		inferScrapeTimeoutFromAnnotation(AnnotatedService),
 		inferServiceFromMetaLabel())
 }
 
@@ -152,7 +174,7 @@ func makePrometheusIstioConfig() *PrometheusReceiver {
 					JobName:                    "istio-proxy",
 					SampleLimit:                sampleLimit,
 					MetricsPath:                "/stats/prometheus",
-					ScrapeInterval:             scrapeInterval,
+					ScrapeInterval:             model.Duration(scrapeInterval),
 					KubernetesDiscoveryConfigs: []KubernetesDiscoveryConfig{{Role: RolePod}},
 					RelabelConfigs: []RelabelConfig{
 						keepIfRunningOnSameNode(NodeAffiliatedPod),
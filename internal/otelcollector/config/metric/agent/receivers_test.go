@@ -2,7 +2,9 @@ package agent
 
 import (
 	"testing"
+	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -14,7 +16,7 @@ func TestReceivers(t *testing.T) {
 	t.Run("no input enabled", func(t *testing.T) {
 		collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().Build(),
-		}, false)
+		}, BuildOptions{})
 
 		require.Nil(t, collectorConfig.Receivers.KubeletStats)
 		require.Nil(t, collectorConfig.Receivers.PrometheusAppPods)
@@ -24,17 +26,37 @@ func TestReceivers(t *testing.T) {
 	t.Run("runtime input enabled", func(t *testing.T) {
 		collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).Build(),
-		}, false)
+		}, BuildOptions{})
 
 		require.NotNil(t, collectorConfig.Receivers.KubeletStats)
 		require.Equal(t, "serviceAccount", collectorConfig.Receivers.KubeletStats.AuthType)
 		require.Equal(t, true, collectorConfig.Receivers.KubeletStats.InsecureSkipVerify)
 		require.Equal(t, "https://${env:MY_NODE_NAME}:10250", collectorConfig.Receivers.KubeletStats.Endpoint)
+		require.Equal(t, []MetricGroupType{MetricGroupTypeContainer, MetricGroupTypePod}, collectorConfig.Receivers.KubeletStats.MetricGroups)
 
 		require.Nil(t, collectorConfig.Receivers.PrometheusAppPods)
 		require.Nil(t, collectorConfig.Receivers.PrometheusIstio)
 	})
 
+	t.Run("runtime input with resources of multiple pipelines", func(t *testing.T) {
+		collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).WithRuntimeInputContainerMetrics(false).WithRuntimeInputNodeMetrics(true).Build(),
+			testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).WithRuntimeInputPodMetrics(false).WithRuntimeInputVolumeMetrics(true).Build(),
+		}, BuildOptions{})
+
+		require.NotNil(t, collectorConfig.Receivers.KubeletStats)
+		require.Equal(t, []MetricGroupType{MetricGroupTypeContainer, MetricGroupTypePod, MetricGroupTypeNode, MetricGroupTypeVolume}, collectorConfig.Receivers.KubeletStats.MetricGroups)
+	})
+
+	t.Run("runtime input with all resources disabled", func(t *testing.T) {
+		collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).WithRuntimeInputContainerMetrics(false).WithRuntimeInputPodMetrics(false).Build(),
+		}, BuildOptions{})
+
+		require.Nil(t, collectorConfig.Receivers.KubeletStats)
+		require.NotContains(t, collectorConfig.Service.Pipelines, "metrics/runtime")
+	})
+
 	t.Run("prometheus input enabled", func(t *testing.T) {
 		tests := []struct {
 			name                      string
@@ -69,7 +91,7 @@ func TestReceivers(t *testing.T) {
 			t.Run(tt.name, func(t *testing.T) {
 				collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 					testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
-				}, tt.istioActive)
+				}, BuildOptions{IsIstioActive: tt.istioActive})
 
 				receivers := collectorConfig.Receivers
 
@@ -91,10 +113,36 @@ func TestReceivers(t *testing.T) {
 		}
 	})
 
+	t.Run("prometheus input with scrape settings of multiple pipelines", func(t *testing.T) {
+		collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputScrapeInterval("10s").Build(),
+			testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputSampleLimit(100000).Build(),
+			testutils.NewMetricPipelineBuilder().WithIstioInput(true).Build(),
+		}, BuildOptions{IsIstioActive: true})
+
+		receivers := collectorConfig.Receivers
+		for _, scrapeConfig := range append(receivers.PrometheusAppPods.Config.ScrapeConfigs, receivers.PrometheusAppServices.Config.ScrapeConfigs...) {
+			require.Equal(t, model.Duration(10*time.Second), scrapeConfig.ScrapeInterval, "job %s", scrapeConfig.JobName)
+			require.Equal(t, 100000, scrapeConfig.SampleLimit, "job %s", scrapeConfig.JobName)
+		}
+
+		for _, scrapeConfig := range receivers.PrometheusAppPods.Config.ScrapeConfigs {
+			require.Contains(t, scrapeConfig.RelabelConfigs, RelabelConfig{
+				SourceLabels: []string{"__meta_kubernetes_pod_annotation_prometheus_io_scrape_interval"},
+				Regex:        "(([0-9]+(ms|s|m|h))+)",
+				TargetLabel:  "__scrape_interval__",
+				Action:       Replace,
+			}, "job %s", scrapeConfig.JobName)
+		}
+
+		require.Equal(t, model.Duration(30*time.Second), receivers.PrometheusIstio.Config.ScrapeConfigs[0].ScrapeInterval, "istio input must not be affected")
+		require.Equal(t, 50000, receivers.PrometheusIstio.Config.ScrapeConfigs[0].SampleLimit, "istio input must not be affected")
+	})
+
 	t.Run("istio input enabled", func(t *testing.T) {
 		collectorConfig := MakeConfig(types.NamespacedName{Name: "metrics-gateway"}, []telemetryv1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithIstioInput(true).Build(),
-		}, false)
+		}, BuildOptions{})
 
 		require.Nil(t, collectorConfig.Receivers.KubeletStats)
 		require.Nil(t, collectorConfig.Receivers.PrometheusAppPods)
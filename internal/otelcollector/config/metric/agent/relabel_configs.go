@@ -13,6 +13,8 @@ const (
 	NodeAffiliatedEndpoint NodeAffiliatedResource = "endpoint"
 )
 
+const scrapeIntervalAnnotationRegex = "(([0-9]+(ms|s|m|h))+)"
+
 type AnnotatedResource string
 
 const (
@@ -36,6 +38,14 @@ func keepIfScrapingEnabled(annotated AnnotatedResource) RelabelConfig {
 	}
 }
 
+func dropIfScrapingEnabled(annotated AnnotatedResource) RelabelConfig {
+	return RelabelConfig{
+		SourceLabels: []string{fmt.Sprintf("__meta_kubernetes_%s_annotation_prometheus_io_scrape", annotated)},
+		Regex:        "true",
+		Action:       Drop,
+	}
+}
+
 func keepIfIstioProxy() RelabelConfig {
 	return RelabelConfig{
 		SourceLabels: []string{"__meta_kubernetes_pod_container_name"},
@@ -98,6 +108,28 @@ func inferAddressFromAnnotation(annotated AnnotatedResource) RelabelConfig {
 	}
 }
 
+// inferScrapeIntervalFromAnnotation overrides the scrape interval of the job for a single target.
+// Values that are no valid durations do not match the regex and are ignored.
+func inferScrapeIntervalFromAnnotation(annotated AnnotatedResource) RelabelConfig {
+	return RelabelConfig{
+		SourceLabels: []string{fmt.Sprintf("__meta_kubernetes_%s_annotation_prometheus_io_scrape_interval", annotated)},
+		Action:       Replace,
+		Regex:        scrapeIntervalAnnotationRegex,
+		TargetLabel:  "__scrape_interval__",
+	}
+}
+
+// inferScrapeTimeoutFromAnnotation aligns the scrape timeout with an overridden scrape interval, because
+// Prometheus rejects targets with a timeout that exceeds the interval.
+func inferScrapeTimeoutFromAnnotation(annotated AnnotatedResource) RelabelConfig {
+	return RelabelConfig{
+		SourceLabels: []string{fmt.Sprintf("__meta_kubernetes_%s_annotation_prometheus_io_scrape_interval", annotated)},
+		Action:       Replace,
+		Regex:        scrapeIntervalAnnotationRegex,
+		TargetLabel:  "__scrape_timeout__",
+	}
+}
+
 func inferServiceFromMetaLabel() RelabelConfig {
 	return RelabelConfig{
 		SourceLabels: []string{"__meta_kubernetes_service_name"},
@@ -0,0 +1,110 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+)
+
+const (
+	// ScrapeHealthMetricNamespace prefixes the scrape health metrics exposed to the self-monitor, so that they do not clash with the
+	// metrics that the self-monitor records for its own targets
+	ScrapeHealthMetricNamespace = "scrape_health"
+
+	scrapeHealthPipelineID = "metrics/scrape-health"
+
+	// The scrape health metrics are aggregated per job, so that the self-monitor does not store a series for every scrape target
+	metricTargetsDown                = "targets_down"
+	metricTargetsSampleLimitExceeded = "targets_sample_limit_exceeded"
+
+	// attrScrapeUp temporarily stores the up value of a target on its resource, so that it can be correlated with the scraped samples
+	attrScrapeUp = "scrape_health.up"
+)
+
+// addComponentsForScrapeHealth adds a pipeline, which exposes the number of failing Prometheus scrape targets per job
+// on a Prometheus endpoint, so that the self-monitor can detect them. The metrics are still delivered to the pipelines as before.
+func addComponentsForScrapeHealth(cfg *Config, inputs inputSources) {
+	var receiverIDs []string
+	if inputs.prometheus {
+		receiverIDs = append(receiverIDs, "prometheus/app-pods", "prometheus/app-services")
+	}
+	if inputs.prometheusMonitors != nil {
+		receiverIDs = append(receiverIDs, "prometheus/monitors")
+	}
+	if len(receiverIDs) == 0 {
+		return
+	}
+
+	cfg.Processors.KeepScrapeHealth = &FilterProcessor{
+		Metrics: FilterProcessorMetrics{
+			Metric: []string{`name != "up" and name != "scrape_samples_scraped"`},
+		},
+	}
+	cfg.Processors.TransformScrapeHealth = makeScrapeHealthTransformProcessor()
+	// Without keys, the processor merges the resources with the same attributes, which only differ by target before
+	cfg.Processors.GroupScrapeHealthByJob = &GroupByAttrsProcessor{Keys: []string{}}
+	cfg.Processors.AggregateScrapeHealth = &TransformProcessor{
+		ErrorMode: "ignore",
+		MetricStatements: []config.TransformProcessorStatements{
+			{
+				Context:    "metric",
+				Statements: []string{`aggregate_on_attributes("sum")`},
+			},
+		},
+	}
+	cfg.Exporters.PrometheusScrapeHealth = &PrometheusExporter{
+		Endpoint:  fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.ScrapeHealthMetrics),
+		Namespace: ScrapeHealthMetricNamespace,
+		// A metric of a job that disappeared must not outlive more than a few missed scrapes
+		MetricExpiration: 3 * inputs.prometheusScrapeSettings.Interval,
+	}
+	cfg.Service.Pipelines[scrapeHealthPipelineID] = config.Pipeline{
+		Receivers: receiverIDs,
+		Processors: []string{
+			"memory_limiter",
+			"filter/keep-scrape-health",
+			"transform/scrape-health",
+			"groupbyattrs/scrape-health",
+			"transform/aggregate-scrape-health",
+		},
+		Exporters: []string{"prometheus/scrape-health"},
+	}
+}
+
+// makeScrapeHealthTransformProcessor turns the up and scrape_samples_scraped metrics of every target into indicators,
+// which are 1 if the target is down or exceeds the sample limit, and removes all resource attributes but the job.
+// A target that exceeds the sample limit is down, but still reports the number of scraped samples,
+// whereas an unreachable target reports no samples at all.
+func makeScrapeHealthTransformProcessor() *TransformProcessor {
+	return &TransformProcessor{
+		ErrorMode: "ignore",
+		MetricStatements: []config.TransformProcessorStatements{
+			{
+				Context: "datapoint",
+				Statements: []string{
+					fmt.Sprintf(`set(resource.attributes["%s"], value_double) where metric.name == "up"`, attrScrapeUp),
+				},
+			},
+			{
+				Context: "datapoint",
+				Statements: []string{
+					`set(value_double, 1.0 - value_double) where metric.name == "up"`,
+					fmt.Sprintf(`set(value_double, 0.0) where metric.name == "scrape_samples_scraped" and resource.attributes["%s"] == 1.0`, attrScrapeUp),
+					`set(value_double, 1.0) where metric.name == "scrape_samples_scraped" and value_double > 0.0`,
+				},
+			},
+			{
+				Context: "metric",
+				Statements: []string{
+					fmt.Sprintf(`set(name, "%s") where name == "up"`, metricTargetsDown),
+					fmt.Sprintf(`set(name, "%s") where name == "scrape_samples_scraped"`, metricTargetsSampleLimitExceeded),
+				},
+			},
+			{
+				Context:    "resource",
+				Statements: []string{`keep_keys(attributes, ["service.name"])`},
+			},
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+// ScrapeSettings are applied to all Prometheus scrape jobs of the agent, because the agent scrapes every target only once for all pipelines.
+type ScrapeSettings struct {
+	Interval    time.Duration
+	SampleLimit int
+}
+
+// ResolvePrometheusScrapeSettings merges the scrape settings of all pipelines with an enabled Prometheus input.
+// The shortest interval and the highest sample limit win, so that every pipeline gets at least the resolution and the samples it asks for.
+// Pipelines that do not request a value count as requesting the default.
+func ResolvePrometheusScrapeSettings(pipelines []telemetryv1alpha1.MetricPipeline) ScrapeSettings {
+	var resolved ScrapeSettings
+
+	for i := range pipelines {
+		prometheus := pipelines[i].Spec.Input.Prometheus
+		if prometheus == nil || !prometheus.Enabled {
+			continue
+		}
+
+		requested := requestedScrapeSettings(prometheus)
+		if resolved.Interval == 0 || requested.Interval < resolved.Interval {
+			resolved.Interval = requested.Interval
+		}
+		if requested.SampleLimit > resolved.SampleLimit {
+			resolved.SampleLimit = requested.SampleLimit
+		}
+	}
+
+	if resolved.Interval == 0 {
+		resolved.Interval = scrapeInterval
+	}
+	if resolved.SampleLimit == 0 {
+		resolved.SampleLimit = sampleLimit
+	}
+
+	return resolved
+}
+
+// UnhonoredPrometheusScrapeSettings describes the scrape settings of the pipeline that are overridden by the settings of other pipelines.
+// It returns an empty slice if the pipeline gets exactly what it requests.
+func UnhonoredPrometheusScrapeSettings(pipeline *telemetryv1alpha1.MetricPipeline, resolved ScrapeSettings) []string {
+	prometheus := pipeline.Spec.Input.Prometheus
+	if prometheus == nil || !prometheus.Enabled {
+		return nil
+	}
+
+	var unhonored []string
+	requested := requestedScrapeSettings(prometheus)
+	if requested.Interval != resolved.Interval {
+		unhonored = append(unhonored, fmt.Sprintf("scrape interval %s is used instead of %s", model.Duration(resolved.Interval), model.Duration(requested.Interval)))
+	}
+	if requested.SampleLimit != resolved.SampleLimit {
+		unhonored = append(unhonored, fmt.Sprintf("sample limit %d is used instead of %d", resolved.SampleLimit, requested.SampleLimit))
+	}
+
+	return unhonored
+}
+
+func requestedScrapeSettings(prometheus *telemetryv1alpha1.MetricPipelinePrometheusInput) ScrapeSettings {
+	requested := ScrapeSettings{
+		Interval:    scrapeInterval,
+		SampleLimit: sampleLimit,
+	}
+
+	// The format is validated by the CRD, so a parsing error only occurs for objects that bypassed the validation
+	if interval, err := time.ParseDuration(prometheus.ScrapeInterval); err == nil && interval > 0 {
+		requested.Interval = interval
+	}
+	if prometheus.SampleLimit > 0 {
+		requested.SampleLimit = prometheus.SampleLimit
+	}
+
+	return requested
+}
@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestResolvePrometheusScrapeSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		pipelines []telemetryv1alpha1.MetricPipeline
+		expected  ScrapeSettings
+		unhonored [][]string
+	}{
+		{
+			name:      "no pipelines",
+			pipelines: nil,
+			expected:  ScrapeSettings{Interval: 30 * time.Second, SampleLimit: 50000},
+		},
+		{
+			name: "defaults",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).Build(),
+			},
+			expected:  ScrapeSettings{Interval: 30 * time.Second, SampleLimit: 50000},
+			unhonored: [][]string{nil},
+		},
+		{
+			name: "single pipeline with custom settings",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputScrapeInterval("1m").WithPrometheusInputSampleLimit(1000).Build(),
+			},
+			expected:  ScrapeSettings{Interval: time.Minute, SampleLimit: 1000},
+			unhonored: [][]string{nil},
+		},
+		{
+			name: "shortest interval and highest sample limit win",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputScrapeInterval("10s").WithPrometheusInputSampleLimit(1000).Build(),
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputSampleLimit(100000).Build(),
+			},
+			expected: ScrapeSettings{Interval: 10 * time.Second, SampleLimit: 100000},
+			unhonored: [][]string{
+				{"sample limit 100000 is used instead of 1000"},
+				{"scrape interval 10s is used instead of 30s"},
+			},
+		},
+		{
+			name: "pipelines without prometheus input are ignored",
+			pipelines: []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputScrapeInterval("1m").Build(),
+				testutils.NewMetricPipelineBuilder().WithPrometheusInput(false).WithPrometheusInputScrapeInterval("5s").Build(),
+			},
+			expected:  ScrapeSettings{Interval: time.Minute, SampleLimit: 50000},
+			unhonored: [][]string{nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved := ResolvePrometheusScrapeSettings(tt.pipelines)
+			require.Equal(t, tt.expected, resolved)
+
+			for i := range tt.pipelines {
+				require.Equal(t, tt.unhonored[i], UnhonoredPrometheusScrapeSettings(&tt.pipelines[i], resolved))
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ var upstreamInstrumentationScopeName = map[metric.InputSourceType]string{
 	metric.InputSourceRuntime:    "otelcol/kubeletstatsreceiver",
 	metric.InputSourcePrometheus: "otelcol/prometheusreceiver",
 	metric.InputSourceIstio:      "otelcol/prometheusreceiver",
+	metric.InputSourceCluster:    "otelcol/k8sclusterreceiver",
 }
 
 func makeInstrumentationScopeProcessor(inputSource metric.InputSourceType) *TransformProcessor {
@@ -26,17 +26,43 @@ type Processors struct {
 	DropIfInputSourceRuntime                     *FilterProcessor               `yaml:"filter/drop-if-input-source-runtime,omitempty"`
 	DropIfInputSourcePrometheus                  *FilterProcessor               `yaml:"filter/drop-if-input-source-prometheus,omitempty"`
 	DropIfInputSourceIstio                       *FilterProcessor               `yaml:"filter/drop-if-input-source-istio,omitempty"`
+	DropIfInputSourceCluster                     *FilterProcessor               `yaml:"filter/drop-if-input-source-cluster,omitempty"`
+	DropIfInputSourceSpanMetrics                 *FilterProcessor               `yaml:"filter/drop-if-input-source-spanmetrics,omitempty"`
 	DropIfInputSourceOtlp                        *FilterProcessor               `yaml:"filter/drop-if-input-source-otlp,omitempty"`
+	DropRuntimeContainerMetrics                  *FilterProcessor               `yaml:"filter/drop-runtime-container-metrics,omitempty"`
+	DropRuntimePodMetrics                        *FilterProcessor               `yaml:"filter/drop-runtime-pod-metrics,omitempty"`
+	DropRuntimeNodeMetrics                       *FilterProcessor               `yaml:"filter/drop-runtime-node-metrics,omitempty"`
+	DropRuntimeVolumeMetrics                     *FilterProcessor               `yaml:"filter/drop-runtime-volume-metrics,omitempty"`
+	DropPrometheusMonitorMetrics                 *FilterProcessor               `yaml:"filter/drop-prometheus-monitor-metrics,omitempty"`
+	DeletePrometheusMonitorAttribute             *config.ResourceProcessor      `yaml:"resource/delete-prometheus-monitor-attribute,omitempty"`
 	ResolveServiceName                           *TransformProcessor            `yaml:"transform/resolve-service-name,omitempty"`
 
-	// NamespaceFilters contains filter processors, which need different configurations per pipeline
-	NamespaceFilters NamespaceFilters `yaml:",inline,omitempty"`
+	// Dynamic contains processors, which need different configurations per pipeline, such as namespace filters and user-defined filters and transforms
+	Dynamic DynamicProcessors `yaml:",inline,omitempty"`
 }
 
-type NamespaceFilters map[string]*FilterProcessor
+type DynamicProcessors map[string]Processor
+
+type Processor struct {
+	Filter    *FilterProcessor    `yaml:",inline,omitempty"`
+	Transform *TransformProcessor `yaml:",inline,omitempty"`
+}
+
+// MarshalYAML renders only the configured processor, since the processor types share keys such as error_mode, which cannot be inlined together.
+func (p Processor) MarshalYAML() (any, error) {
+	switch {
+	case p.Filter != nil:
+		return p.Filter, nil
+	case p.Transform != nil:
+		return p.Transform, nil
+	default:
+		return struct{}{}, nil
+	}
+}
 
 type FilterProcessor struct {
-	Metrics FilterProcessorMetrics `yaml:"metrics"`
+	ErrorMode string                 `yaml:"error_mode,omitempty"`
+	Metrics   FilterProcessorMetrics `yaml:"metrics"`
 }
 
 type FilterProcessorMetrics struct {
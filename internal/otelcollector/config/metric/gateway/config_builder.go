@@ -14,7 +14,13 @@ import (
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 )
 
-func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.MetricPipeline) (*Config, otlpexporter.EnvVars, error) {
+// BuildOptions contains the settings of the metric gateway deployment, which the collector configuration depends on.
+type BuildOptions struct {
+	// PersistentQueue stores the sending queues of the exporters on a volume instead of in memory.
+	PersistentQueue config.PersistentQueue
+}
+
+func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.MetricPipeline, opts BuildOptions) (*Config, otlpexporter.EnvVars, error) {
 	cfg := &Config{
 		Base: config.Base{
 			Service:    config.DefaultService(make(config.Pipelines)),
@@ -26,7 +32,14 @@ func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alp
 	}
 
 	envVars := make(otlpexporter.EnvVars)
-	queueSize := 256 / len(pipelines)
+	outputs := make(map[string]*telemetryv1alpha1.OtlpOutput)
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp != nil {
+			continue
+		}
+		maps.Copy(outputs, otlpexporter.PipelineOutputs(pipelines[i].Name, pipelines[i].Spec.Output.Otlp, pipelines[i].Spec.AdditionalOutputs))
+	}
+	queueSizes := otlpexporter.MakeQueueSizes(outputs)
 
 	for i := range pipelines {
 		pipeline := pipelines[i]
@@ -34,13 +47,17 @@ func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alp
 			continue
 		}
 
-		otlpExporterBuilder := otlpexporter.NewConfigBuilder(c, pipeline.Spec.Output.Otlp, pipeline.Name, queueSize, otlpexporter.SignalTypeMetric)
-		if err := declareComponentsForMetricPipeline(ctx, otlpExporterBuilder, &pipeline, cfg, envVars); err != nil {
+		otlpExporterBuilders := otlpexporter.NewConfigBuilders(c, pipeline.Spec.Output.Otlp, pipeline.Spec.AdditionalOutputs, pipeline.Name, queueSizes, otlpexporter.SignalTypeMetric)
+		if err := declareComponentsForMetricPipeline(ctx, otlpExporterBuilders, &pipeline, cfg, envVars); err != nil {
 			return nil, nil, err
 		}
 
 		pipelineID := fmt.Sprintf("metrics/%s", pipeline.Name)
-		cfg.Service.Pipelines[pipelineID] = makeServicePipelineConfig(&pipeline)
+		cfg.Service.Pipelines[pipelineID] = makeServicePipelineConfig(&pipeline, otlpExporterBuilders)
+	}
+
+	if opts.PersistentQueue.Enabled {
+		config.EnablePersistentQueue(&cfg.Base, sendingQueues(cfg), opts.PersistentQueue, cfg.Processors.Batch.SendBatchMaxSize)
 	}
 
 	return cfg, envVars, nil
@@ -62,11 +79,20 @@ func makeReceiversConfig() Receivers {
 }
 
 // declareComponentsForMetricPipeline enriches a Config (exporters, processors, etc.) with components for a given telemetryv1alpha1.MetricPipeline.
-func declareComponentsForMetricPipeline(ctx context.Context, otlpExporterBuilder *otlpexporter.ConfigBuilder, pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config, envVars otlpexporter.EnvVars) error {
+func declareComponentsForMetricPipeline(ctx context.Context, otlpExporterBuilders []*otlpexporter.ConfigBuilder, pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config, envVars otlpexporter.EnvVars) error {
 	declareDiagnosticMetricsDropFilters(pipeline, cfg)
 	declareDropFilters(pipeline, cfg)
 	declareNamespaceFilters(pipeline, cfg)
-	return declareOTLPExporter(ctx, otlpExporterBuilder, pipeline, cfg, envVars)
+	declareUserDefinedFilters(pipeline, cfg)
+	declareUserDefinedTransforms(pipeline, cfg)
+
+	for _, otlpExporterBuilder := range otlpExporterBuilders {
+		if err := declareOTLPExporter(ctx, otlpExporterBuilder, cfg, envVars); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func declareDropFilters(pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config) {
@@ -81,9 +107,36 @@ func declareDropFilters(pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config)
 	if !isIstioInputEnabled(input) {
 		cfg.Processors.DropIfInputSourceIstio = makeDropIfInputSourceIstioConfig()
 	}
+	if !isClusterInputEnabled(input) {
+		cfg.Processors.DropIfInputSourceCluster = makeDropIfInputSourceClusterConfig()
+	}
+	if !isSpanMetricsInputEnabled(input) {
+		cfg.Processors.DropIfInputSourceSpanMetrics = makeDropIfInputSourceSpanMetricsConfig()
+	}
 	if !isOtlpInputEnabled(input) {
 		cfg.Processors.DropIfInputSourceOtlp = makeDropIfInputSourceOtlpConfig()
 	}
+
+	for _, resource := range disabledRuntimeResources(input) {
+		switch resource {
+		case metric.RuntimeResourceContainer:
+			cfg.Processors.DropRuntimeContainerMetrics = makeDropRuntimeResourceMetricsConfig(resource)
+		case metric.RuntimeResourcePod:
+			cfg.Processors.DropRuntimePodMetrics = makeDropRuntimeResourceMetricsConfig(resource)
+		case metric.RuntimeResourceNode:
+			cfg.Processors.DropRuntimeNodeMetrics = makeDropRuntimeResourceMetricsConfig(resource)
+		case metric.RuntimeResourceVolume:
+			cfg.Processors.DropRuntimeVolumeMetrics = makeDropRuntimeResourceMetricsConfig(resource)
+		}
+	}
+
+	if isPrometheusInputEnabled(input) {
+		if isPrometheusMonitorsEnabled(input) {
+			cfg.Processors.DeletePrometheusMonitorAttribute = makeDeletePrometheusMonitorAttributeConfig()
+		} else {
+			cfg.Processors.DropPrometheusMonitorMetrics = makeDropPrometheusMonitorMetricsConfig()
+		}
+	}
 }
 
 func declareDiagnosticMetricsDropFilters(pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config) {
@@ -98,30 +151,50 @@ func declareDiagnosticMetricsDropFilters(pipeline *telemetryv1alpha1.MetricPipel
 }
 
 func declareNamespaceFilters(pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config) {
-	if cfg.Processors.NamespaceFilters == nil {
-		cfg.Processors.NamespaceFilters = make(NamespaceFilters)
+	if cfg.Processors.Dynamic == nil {
+		cfg.Processors.Dynamic = make(DynamicProcessors)
 	}
 
 	input := pipeline.Spec.Input
 	if isRuntimeInputEnabled(input) && shouldFilterByNamespace(input.Runtime.Namespaces) {
 		processorID := makeNamespaceFilterID(pipeline.Name, metric.InputSourceRuntime)
-		cfg.Processors.NamespaceFilters[processorID] = makeFilterByNamespaceRuntimeInputConfig(pipeline.Spec.Input.Runtime.Namespaces)
+		cfg.Processors.Dynamic[processorID] = Processor{Filter: makeFilterByNamespaceRuntimeInputConfig(pipeline.Spec.Input.Runtime.Namespaces)}
 	}
 	if isPrometheusInputEnabled(input) && shouldFilterByNamespace(input.Prometheus.Namespaces) {
 		processorID := makeNamespaceFilterID(pipeline.Name, metric.InputSourcePrometheus)
-		cfg.Processors.NamespaceFilters[processorID] = makeFilterByNamespacePrometheusInputConfig(pipeline.Spec.Input.Prometheus.Namespaces)
+		cfg.Processors.Dynamic[processorID] = Processor{Filter: makeFilterByNamespacePrometheusInputConfig(pipeline.Spec.Input.Prometheus.Namespaces)}
 	}
 	if isIstioInputEnabled(input) && shouldFilterByNamespace(input.Istio.Namespaces) {
 		processorID := makeNamespaceFilterID(pipeline.Name, metric.InputSourceIstio)
-		cfg.Processors.NamespaceFilters[processorID] = makeFilterByNamespaceIstioInputConfig(pipeline.Spec.Input.Istio.Namespaces)
+		cfg.Processors.Dynamic[processorID] = Processor{Filter: makeFilterByNamespaceIstioInputConfig(pipeline.Spec.Input.Istio.Namespaces)}
 	}
 	if isOtlpInputEnabled(input) && input.Otlp != nil && shouldFilterByNamespace(input.Otlp.Namespaces) {
 		processorID := makeNamespaceFilterID(pipeline.Name, metric.InputSourceOtlp)
-		cfg.Processors.NamespaceFilters[processorID] = makeFilterByNamespaceOtlpInputConfig(pipeline.Spec.Input.Otlp.Namespaces)
+		cfg.Processors.Dynamic[processorID] = Processor{Filter: makeFilterByNamespaceOtlpInputConfig(pipeline.Spec.Input.Otlp.Namespaces)}
+	}
+}
+
+func declareUserDefinedFilters(pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config) {
+	if cfg.Processors.Dynamic == nil {
+		cfg.Processors.Dynamic = make(DynamicProcessors)
+	}
+
+	if shouldApplyUserDefinedFilters(pipeline.Spec.Filters) {
+		cfg.Processors.Dynamic[makeUserDefinedFilterID(pipeline.Name)] = Processor{Filter: makeUserDefinedFilterConfig(pipeline.Spec.Filters)}
+	}
+}
+
+func declareUserDefinedTransforms(pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config) {
+	if cfg.Processors.Dynamic == nil {
+		cfg.Processors.Dynamic = make(DynamicProcessors)
+	}
+
+	if len(pipeline.Spec.Transforms) > 0 {
+		cfg.Processors.Dynamic[makeUserDefinedTransformID(pipeline.Name)] = Processor{Transform: makeUserDefinedTransformConfig(pipeline.Spec.Transforms)}
 	}
 }
 
-func declareOTLPExporter(ctx context.Context, otlpExporterBuilder *otlpexporter.ConfigBuilder, pipeline *telemetryv1alpha1.MetricPipeline, cfg *Config, envVars otlpexporter.EnvVars) error {
+func declareOTLPExporter(ctx context.Context, otlpExporterBuilder *otlpexporter.ConfigBuilder, cfg *Config, envVars otlpexporter.EnvVars) error {
 	otlpExporterConfig, otlpExporterEnvVars, err := otlpExporterBuilder.MakeConfig(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to make otlp exporter config: %w", err)
@@ -129,13 +202,16 @@ func declareOTLPExporter(ctx context.Context, otlpExporterBuilder *otlpexporter.
 
 	maps.Copy(envVars, otlpExporterEnvVars)
 
-	exporterID := otlpexporter.ExporterID(pipeline.Spec.Output.Otlp.Protocol, pipeline.Name)
-	cfg.Exporters[exporterID] = Exporter{OTLP: otlpExporterConfig}
+	if authExtensionID, authExtensionConfig := otlpExporterBuilder.MakeAuthExtensionConfig(); authExtensionConfig != nil {
+		cfg.AddExtension(authExtensionID, *authExtensionConfig)
+	}
+
+	cfg.Exporters[otlpExporterBuilder.ExporterID()] = Exporter{OTLP: otlpExporterConfig}
 
 	return nil
 }
 
-func makeServicePipelineConfig(pipeline *telemetryv1alpha1.MetricPipeline) config.Pipeline {
+func makeServicePipelineConfig(pipeline *telemetryv1alpha1.MetricPipeline, otlpExporterBuilders []*otlpexporter.ConfigBuilder) config.Pipeline {
 	processors := []string{"memory_limiter", "k8sattributes"}
 
 	input := pipeline.Spec.Input
@@ -148,10 +224,28 @@ func makeServicePipelineConfig(pipeline *telemetryv1alpha1.MetricPipeline) confi
 	if !isIstioInputEnabled(input) {
 		processors = append(processors, "filter/drop-if-input-source-istio")
 	}
+	if !isClusterInputEnabled(input) {
+		processors = append(processors, "filter/drop-if-input-source-cluster")
+	}
+	if !isSpanMetricsInputEnabled(input) {
+		processors = append(processors, "filter/drop-if-input-source-spanmetrics")
+	}
 	if !isOtlpInputEnabled(input) {
 		processors = append(processors, "filter/drop-if-input-source-otlp")
 	}
 
+	for _, resource := range disabledRuntimeResources(input) {
+		processors = append(processors, makeDropRuntimeResourceMetricsID(resource))
+	}
+
+	if isPrometheusInputEnabled(input) {
+		if isPrometheusMonitorsEnabled(input) {
+			processors = append(processors, "resource/delete-prometheus-monitor-attribute")
+		} else {
+			processors = append(processors, "filter/drop-prometheus-monitor-metrics")
+		}
+	}
+
 	if isRuntimeInputEnabled(input) && shouldFilterByNamespace(input.Runtime.Namespaces) {
 		processors = append(processors, makeNamespaceFilterID(pipeline.Name, metric.InputSourceRuntime))
 	}
@@ -167,12 +261,27 @@ func makeServicePipelineConfig(pipeline *telemetryv1alpha1.MetricPipeline) confi
 
 	processors = append(processors, makeDiagnosticMetricFilters(input)...)
 
-	processors = append(processors, "resource/insert-cluster-name", "transform/resolve-service-name", "batch")
+	if shouldApplyUserDefinedFilters(pipeline.Spec.Filters) {
+		processors = append(processors, makeUserDefinedFilterID(pipeline.Name))
+	}
+
+	processors = append(processors, "resource/insert-cluster-name", "transform/resolve-service-name")
+
+	if len(pipeline.Spec.Transforms) > 0 {
+		processors = append(processors, makeUserDefinedTransformID(pipeline.Name))
+	}
+
+	processors = append(processors, "batch")
+
+	var exporterIDs []string
+	for _, otlpExporterBuilder := range otlpExporterBuilders {
+		exporterIDs = append(exporterIDs, otlpExporterBuilder.ExporterID())
+	}
 
 	return config.Pipeline{
 		Receivers:  []string{"otlp"},
 		Processors: processors,
-		Exporters:  []string{makeOTLPExporterID(pipeline)},
+		Exporters:  exporterIDs,
 	}
 }
 
@@ -196,14 +305,26 @@ func makeNamespaceFilterID(pipelineName string, inputSourceType metric.InputSour
 	return fmt.Sprintf("filter/%s-filter-by-namespace-%s-input", pipelineName, inputSourceType)
 }
 
-func makeOTLPExporterID(pipeline *telemetryv1alpha1.MetricPipeline) string {
-	return otlpexporter.ExporterID(pipeline.Spec.Output.Otlp.Protocol, pipeline.Name)
+func shouldApplyUserDefinedFilters(filters *telemetryv1alpha1.MetricPipelineFilters) bool {
+	return filters != nil && (len(filters.Include) > 0 || len(filters.Exclude) > 0 || len(filters.DataPoints) > 0)
+}
+
+func makeUserDefinedFilterID(pipelineName string) string {
+	return fmt.Sprintf("filter/%s-user-defined-filters", pipelineName)
+}
+
+func makeUserDefinedTransformID(pipelineName string) string {
+	return fmt.Sprintf("transform/%s-user-defined-transforms", pipelineName)
 }
 
 func isPrometheusInputEnabled(input telemetryv1alpha1.MetricPipelineInput) bool {
 	return input.Prometheus != nil && input.Prometheus.Enabled
 }
 
+func isPrometheusMonitorsEnabled(input telemetryv1alpha1.MetricPipelineInput) bool {
+	return input.Prometheus.Monitors != nil && input.Prometheus.Monitors.Enabled
+}
+
 func isRuntimeInputEnabled(input telemetryv1alpha1.MetricPipelineInput) bool {
 	return input.Runtime != nil && input.Runtime.Enabled
 }
@@ -212,6 +333,34 @@ func isIstioInputEnabled(input telemetryv1alpha1.MetricPipelineInput) bool {
 	return input.Istio != nil && input.Istio.Enabled
 }
 
+// disabledRuntimeResources returns the resources for which the pipeline does not collect runtime metrics. If the runtime input
+// is disabled, the runtime metrics are dropped as a whole, so no resource is returned.
+func disabledRuntimeResources(input telemetryv1alpha1.MetricPipelineInput) []metric.RuntimeResourceType {
+	if !isRuntimeInputEnabled(input) {
+		return nil
+	}
+
+	var resources []metric.RuntimeResourceType
+	for _, resource := range metric.RuntimeResources {
+		if !metric.IsRuntimeResourceEnabled(input.Runtime, resource) {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+func makeDropRuntimeResourceMetricsID(resource metric.RuntimeResourceType) string {
+	return fmt.Sprintf("filter/drop-runtime-%s-metrics", resource)
+}
+
+func isClusterInputEnabled(input telemetryv1alpha1.MetricPipelineInput) bool {
+	return input.Cluster != nil && input.Cluster.Enabled
+}
+
+func isSpanMetricsInputEnabled(input telemetryv1alpha1.MetricPipelineInput) bool {
+	return input.SpanMetrics != nil && input.SpanMetrics.Enabled
+}
+
 func isOtlpInputEnabled(input telemetryv1alpha1.MetricPipelineInput) bool {
 	return input.Otlp == nil || !input.Otlp.Disabled
 }
@@ -223,3 +372,21 @@ func isPrometheusDiagnosticMetricsEnabled(input telemetryv1alpha1.MetricPipeline
 func isIstioDiagnosticMetricsEnabled(input telemetryv1alpha1.MetricPipelineInput) bool {
 	return input.Istio.DiagnosticMetrics != nil && input.Istio.DiagnosticMetrics.Enabled
 }
+
+// sendingQueues returns the sending queues of all OTLP exporters, which are persisted if the persistent queue is enabled.
+func sendingQueues(cfg *Config) []*config.SendingQueue {
+	var queues []*config.SendingQueue
+	for _, exporter := range cfg.Exporters {
+		if exporter.OTLP != nil {
+			queues = append(queues, &exporter.OTLP.SendingQueue)
+		}
+	}
+	return queues
+}
+
+// AddTapExporters adds an exporter to every pipeline with an active tap, which sends the data of the pipeline to the telemetry manager for inspection.
+func AddTapExporters(cfg *Config, taps otlpexporter.Taps) {
+	otlpexporter.AddTapExporters(cfg.Exporters, cfg.Service.Pipelines, "metrics", taps, func(exporter *config.OTLPExporter) Exporter {
+		return Exporter{OTLP: exporter}
+	})
+}
@@ -7,13 +7,18 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/namespaces"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
 	"github.com/kyma-project/telemetry-manager/internal/testutils"
 )
 
@@ -24,7 +29,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("otlp exporter endpoint", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test").WithOTLPOutput(testutils.OTLPEndpoint("http://localhost")).Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 
 		expectedEndpoint := fmt.Sprintf("${%s}", "OTLP_ENDPOINT_TEST")
@@ -39,7 +44,7 @@ func TestMakeConfig(t *testing.T) {
 
 	t.Run("secure", func(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().
-			WithName("test").WithOTLPOutput(testutils.OTLPEndpoint("https://localhost")).Build()})
+			WithName("test").WithOTLPOutput(testutils.OTLPEndpoint("https://localhost")).Build()}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test")
 
@@ -49,8 +54,7 @@ func TestMakeConfig(t *testing.T) {
 
 	t.Run("insecure", func(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-			testutils.NewMetricPipelineBuilder().WithName("test-insecure").WithOTLPOutput(testutils.OTLPEndpoint("http://localhost")).Build()},
-		)
+			testutils.NewMetricPipelineBuilder().WithName("test-insecure").WithOTLPOutput(testutils.OTLPEndpoint("http://localhost")).Build()}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-insecure")
 
@@ -61,7 +65,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("basic auth", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test-basic-auth").WithOTLPOutput(testutils.OTLPBasicAuth("user", "password")).Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-basic-auth")
 
@@ -79,7 +83,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("custom header", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test-custom-header").WithOTLPOutput(testutils.OTLPCustomHeader("Authorization", "TOKEN_VALUE", "Api-Token")).Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-custom-header")
 
@@ -96,7 +100,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("mtls", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test-mtls").WithOTLPOutput(testutils.OTLPClientTLS("ca", "cert", "key")).Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-mtls")
 
@@ -112,7 +116,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("extensions", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.NotEmpty(t, collectorConfig.Extensions.HealthCheck.Endpoint)
@@ -122,7 +126,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("telemetry", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "info", collectorConfig.Service.Telemetry.Logs.Level)
@@ -131,28 +135,82 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("single pipeline queue size", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 		require.Equal(t, 256, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.QueueSize, "Pipeline should have the full queue size")
 	})
 
+	t.Run("queue size not shared with deleted pipelines", func(t *testing.T) {
+		deletedPipeline := testutils.NewMetricPipelineBuilder().WithName("deleted").Build()
+		deletedPipeline.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").Build(),
+			deletedPipeline}, BuildOptions{})
+		require.NoError(t, err)
+		require.NotContains(t, collectorConfig.Exporters, "otlp/deleted")
+		require.Equal(t, 256, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.QueueSize, "Pipeline should have the full queue size")
+	})
+
 	t.Run("multi pipeline queue size", func(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test-1").Build(),
 			testutils.NewMetricPipelineBuilder().WithName("test-2").Build(),
-			testutils.NewMetricPipelineBuilder().WithName("test-3").Build()},
-		)
+			testutils.NewMetricPipelineBuilder().WithName("test-3").Build()}, BuildOptions{})
 		require.NoError(t, err)
 		require.Equal(t, 85, collectorConfig.Exporters["otlp/test-1"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
 		require.Equal(t, 85, collectorConfig.Exporters["otlp/test-2"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
 		require.Equal(t, 85, collectorConfig.Exporters["otlp/test-3"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
 	})
 
+	t.Run("persistent queue", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").WithAdditionalOTLPOutput("audit").Build(),
+		}, BuildOptions{PersistentQueue: config.PersistentQueue{Enabled: true, VolumeSize: resource.MustParse("1Gi")}})
+		require.NoError(t, err)
+
+		require.NotNil(t, collectorConfig.Extensions.FileStorage)
+		require.Equal(t, "/var/lib/otelcol/file_storage", collectorConfig.Extensions.FileStorage.Directory)
+		require.Equal(t, "/var/lib/otelcol/file_storage", collectorConfig.Extensions.FileStorage.Compaction.Directory)
+		require.Contains(t, collectorConfig.Service.Extensions, "file_storage")
+
+		require.Equal(t, "file_storage", collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.Storage)
+		require.Equal(t, "file_storage", collectorConfig.Exporters["otlp/test/audit"].OTLP.SendingQueue.Storage)
+	})
+
+	t.Run("in-memory queue by default", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Nil(t, collectorConfig.Extensions.FileStorage)
+		require.NotContains(t, collectorConfig.Service.Extensions, "file_storage")
+		require.Empty(t, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.Storage)
+	})
+
+	t.Run("additional outputs", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").
+				WithAdditionalOTLPOutput("audit", testutils.OTLPEndpoint("https://audit:4317")).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, collectorConfig.Exporters, 2)
+		require.Equal(t, "${OTLP_ENDPOINT_TEST_out_AUDIT}", collectorConfig.Exporters["otlp/test/audit"].OTLP.Endpoint)
+		require.Equal(t, "https://audit:4317", string(envVars["OTLP_ENDPOINT_TEST_out_AUDIT"]))
+		require.Equal(t, 128, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of outputs")
+		require.Equal(t, 128, collectorConfig.Exporters["otlp/test/audit"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of outputs")
+
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+		require.Equal(t, []string{"otlp/test", "otlp/test/audit"}, collectorConfig.Service.Pipelines["metrics/test"].Exporters)
+	})
+
 	t.Run("single pipeline topology", func(t *testing.T) {
 		t.Run("with no inputs enabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithOTLPInput(false).Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").WithOTLPInput(false).Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -165,6 +223,8 @@ func TestMakeConfig(t *testing.T) {
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-prometheus",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
 				"filter/drop-if-input-source-otlp",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
@@ -174,8 +234,7 @@ func TestMakeConfig(t *testing.T) {
 
 		t.Run("with prometheus input enabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInput(true).WithPrometheusInputDiagnosticMetrics(true).Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInput(true).WithPrometheusInputDiagnosticMetrics(true).Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -187,16 +246,45 @@ func TestMakeConfig(t *testing.T) {
 				"k8sattributes",
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
+				"filter/drop-prometheus-monitor-metrics",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
 				"batch",
 			}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
+
+			require.NotNil(t, collectorConfig.Processors.DropPrometheusMonitorMetrics)
+			require.Equal(t, []string{`instrumentation_scope.name == "io.kyma-project.telemetry/prometheus" and resource.attributes["kyma.prometheus.monitor"] == "true"`},
+				collectorConfig.Processors.DropPrometheusMonitorMetrics.Metrics.Metric)
+			require.Nil(t, collectorConfig.Processors.DeletePrometheusMonitorAttribute)
+		})
+
+		t.Run("with prometheus input and monitors enabled", func(t *testing.T) {
+			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInput(true).WithPrometheusInputDiagnosticMetrics(true).WithPrometheusInputMonitors(true).Build()}, BuildOptions{})
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"memory_limiter",
+				"k8sattributes",
+				"filter/drop-if-input-source-runtime",
+				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
+				"resource/delete-prometheus-monitor-attribute",
+				"resource/insert-cluster-name",
+				"transform/resolve-service-name",
+				"batch",
+			}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
+
+			require.Nil(t, collectorConfig.Processors.DropPrometheusMonitorMetrics)
+			require.NotNil(t, collectorConfig.Processors.DeletePrometheusMonitorAttribute)
+			require.Equal(t, "kyma.prometheus.monitor", collectorConfig.Processors.DeletePrometheusMonitorAttribute.Attributes[0].Key)
 		})
 
 		t.Run("with prometheus input enabled and diagnostic metrics disabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInput(true).WithPrometheusInputDiagnosticMetrics(false).Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInput(true).WithPrometheusInputDiagnosticMetrics(false).Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -208,6 +296,9 @@ func TestMakeConfig(t *testing.T) {
 				"k8sattributes",
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
+				"filter/drop-prometheus-monitor-metrics",
 				"filter/drop-diagnostic-metrics-if-input-source-prometheus",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
@@ -217,8 +308,7 @@ func TestMakeConfig(t *testing.T) {
 
 		t.Run("with prometheus input enabled and diagnostic metrics implicitly disabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInput(true).Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").WithPrometheusInput(true).Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -230,6 +320,9 @@ func TestMakeConfig(t *testing.T) {
 				"k8sattributes",
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
+				"filter/drop-prometheus-monitor-metrics",
 				"filter/drop-diagnostic-metrics-if-input-source-prometheus",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
@@ -239,8 +332,7 @@ func TestMakeConfig(t *testing.T) {
 
 		t.Run("with runtime input enabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithRuntimeInput(true).Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").WithRuntimeInput(true).Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -252,16 +344,45 @@ func TestMakeConfig(t *testing.T) {
 				"k8sattributes",
 				"filter/drop-if-input-source-prometheus",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
+				"filter/drop-runtime-node-metrics",
+				"filter/drop-runtime-volume-metrics",
+				"resource/insert-cluster-name",
+				"transform/resolve-service-name",
+				"batch",
+			}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
+		})
+
+		t.Run("with runtime input and custom resources enabled", func(t *testing.T) {
+			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithName("test").WithRuntimeInput(true).
+					WithRuntimeInputPodMetrics(false).WithRuntimeInputNodeMetrics(true).WithRuntimeInputVolumeMetrics(true).Build()}, BuildOptions{})
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"memory_limiter",
+				"k8sattributes",
+				"filter/drop-if-input-source-prometheus",
+				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
+				"filter/drop-runtime-pod-metrics",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
 				"batch",
 			}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
+
+			require.NotNil(t, collectorConfig.Processors.DropRuntimePodMetrics)
+			require.Equal(t, []string{"instrumentation_scope.name == \"io.kyma-project.telemetry/runtime\" and IsMatch(name, \"^k8s.pod.*\")"},
+				collectorConfig.Processors.DropRuntimePodMetrics.Metrics.Metric)
+			require.Nil(t, collectorConfig.Processors.DropRuntimeContainerMetrics)
+			require.Nil(t, collectorConfig.Processors.DropRuntimeNodeMetrics)
+			require.Nil(t, collectorConfig.Processors.DropRuntimeVolumeMetrics)
 		})
 
 		t.Run("with istio input enabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithIstioInput(true).WithIstioInputDiagnosticMetrics(true).Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").WithIstioInput(true).WithIstioInputDiagnosticMetrics(true).Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -273,6 +394,8 @@ func TestMakeConfig(t *testing.T) {
 				"k8sattributes",
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-prometheus",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
 				"batch",
@@ -281,8 +404,7 @@ func TestMakeConfig(t *testing.T) {
 
 		t.Run("with istio input enabled and diagnostic metrics disabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithIstioInput(true).WithIstioInputDiagnosticMetrics(false).Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").WithIstioInput(true).WithIstioInputDiagnosticMetrics(false).Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -294,6 +416,8 @@ func TestMakeConfig(t *testing.T) {
 				"k8sattributes",
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-prometheus",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
 				"filter/drop-diagnostic-metrics-if-input-source-istio",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
@@ -303,8 +427,7 @@ func TestMakeConfig(t *testing.T) {
 
 		t.Run("with istio input enabled and diagnostic metrics implicitly disabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithIstioInput(true).Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").WithIstioInput(true).Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -316,6 +439,8 @@ func TestMakeConfig(t *testing.T) {
 				"k8sattributes",
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-prometheus",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
 				"filter/drop-diagnostic-metrics-if-input-source-istio",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
@@ -323,10 +448,27 @@ func TestMakeConfig(t *testing.T) {
 			}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
 		})
 
+		t.Run("with span metrics input enabled", func(t *testing.T) {
+			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithName("test").WithSpanMetricsInput(true).Build()}, BuildOptions{})
+			require.NoError(t, err)
+
+			require.Nil(t, collectorConfig.Processors.DropIfInputSourceSpanMetrics)
+			require.Equal(t, []string{"memory_limiter",
+				"k8sattributes",
+				"filter/drop-if-input-source-runtime",
+				"filter/drop-if-input-source-prometheus",
+				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"resource/insert-cluster-name",
+				"transform/resolve-service-name",
+				"batch",
+			}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
+		})
+
 		t.Run("with otlp input implicitly enabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -339,6 +481,8 @@ func TestMakeConfig(t *testing.T) {
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-prometheus",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
 				"batch",
@@ -347,8 +491,7 @@ func TestMakeConfig(t *testing.T) {
 
 		t.Run("with otlp input explicitly enabled", func(t *testing.T) {
 			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
-				testutils.NewMetricPipelineBuilder().WithName("test").WithOTLPInput(true).Build()},
-			)
+				testutils.NewMetricPipelineBuilder().WithName("test").WithOTLPInput(true).Build()}, BuildOptions{})
 			require.NoError(t, err)
 
 			require.Contains(t, collectorConfig.Exporters, "otlp/test")
@@ -361,19 +504,65 @@ func TestMakeConfig(t *testing.T) {
 				"filter/drop-if-input-source-runtime",
 				"filter/drop-if-input-source-prometheus",
 				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
 				"resource/insert-cluster-name",
 				"transform/resolve-service-name",
 				"batch",
 			}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
 		})
+
+		t.Run("with user-defined filters", func(t *testing.T) {
+			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithName("test").
+					WithPrometheusInput(true).
+					WithExcludeMetrics(".*_bucket").
+					Build()}, BuildOptions{})
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"memory_limiter",
+				"k8sattributes",
+				"filter/drop-if-input-source-runtime",
+				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
+				"filter/drop-prometheus-monitor-metrics",
+				"filter/drop-diagnostic-metrics-if-input-source-prometheus",
+				"filter/test-user-defined-filters",
+				"resource/insert-cluster-name",
+				"transform/resolve-service-name",
+				"batch",
+			}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
+		})
+
+		t.Run("with user-defined transforms", func(t *testing.T) {
+			collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+				testutils.NewMetricPipelineBuilder().WithName("test").
+					WithOTLPInput(true).
+					WithTransform(telemetryv1alpha1.TransformRule{Context: telemetryv1alpha1.TransformContextDataPoint, Action: telemetryv1alpha1.TransformActionHash, Key: "user.id"}).
+					Build()}, BuildOptions{})
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"memory_limiter",
+				"k8sattributes",
+				"filter/drop-if-input-source-runtime",
+				"filter/drop-if-input-source-prometheus",
+				"filter/drop-if-input-source-istio",
+				"filter/drop-if-input-source-cluster",
+				"filter/drop-if-input-source-spanmetrics",
+				"resource/insert-cluster-name",
+				"transform/resolve-service-name",
+				"transform/test-user-defined-transforms",
+				"batch",
+			}, collectorConfig.Service.Pipelines["metrics/test"].Processors)
+		})
 	})
 
 	t.Run("multi pipeline topology", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
 			testutils.NewMetricPipelineBuilder().WithName("test-1").WithRuntimeInput(true, testutils.ExcludeNamespaces(namespaces.System()...)).Build(),
 			testutils.NewMetricPipelineBuilder().WithName("test-2").WithPrometheusInput(true, testutils.ExcludeNamespaces(namespaces.System()...)).Build(),
-			testutils.NewMetricPipelineBuilder().WithName("test-3").WithIstioInput(true).Build()},
-		)
+			testutils.NewMetricPipelineBuilder().WithName("test-3").WithIstioInput(true).Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-1")
@@ -387,6 +576,10 @@ func TestMakeConfig(t *testing.T) {
 			"k8sattributes",
 			"filter/drop-if-input-source-prometheus",
 			"filter/drop-if-input-source-istio",
+			"filter/drop-if-input-source-cluster",
+			"filter/drop-if-input-source-spanmetrics",
+			"filter/drop-runtime-node-metrics",
+			"filter/drop-runtime-volume-metrics",
 			"filter/test-1-filter-by-namespace-runtime-input",
 			"resource/insert-cluster-name",
 			"transform/resolve-service-name",
@@ -400,6 +593,9 @@ func TestMakeConfig(t *testing.T) {
 			"k8sattributes",
 			"filter/drop-if-input-source-runtime",
 			"filter/drop-if-input-source-istio",
+			"filter/drop-if-input-source-cluster",
+			"filter/drop-if-input-source-spanmetrics",
+			"filter/drop-prometheus-monitor-metrics",
 			"filter/test-2-filter-by-namespace-prometheus-input",
 			"filter/drop-diagnostic-metrics-if-input-source-prometheus",
 			"resource/insert-cluster-name",
@@ -414,6 +610,8 @@ func TestMakeConfig(t *testing.T) {
 			"k8sattributes",
 			"filter/drop-if-input-source-runtime",
 			"filter/drop-if-input-source-prometheus",
+			"filter/drop-if-input-source-cluster",
+			"filter/drop-if-input-source-spanmetrics",
 			"filter/drop-diagnostic-metrics-if-input-source-istio",
 			"resource/insert-cluster-name",
 			"transform/resolve-service-name",
@@ -447,7 +645,7 @@ func TestMakeConfig(t *testing.T) {
 
 				config, _, err := MakeConfig(context.Background(), fakeClient, []telemetryv1alpha1.MetricPipeline{
 					testutils.NewMetricPipelineBuilder().WithName("test").WithOTLPInput(tt.withOtlpInput).WithOTLPOutput(testutils.OTLPEndpoint("https://localhost")).Build(),
-				})
+				}, BuildOptions{})
 				require.NoError(t, err)
 
 				configYAML, err := yaml.Marshal(config)
@@ -463,3 +661,28 @@ func TestMakeConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestAddTapExporters(t *testing.T) {
+	collectorConfig, _, err := MakeConfig(context.Background(), fake.NewClientBuilder().Build(), []telemetryv1alpha1.MetricPipeline{
+		testutils.NewMetricPipelineBuilder().WithName("test-1").Build(),
+		testutils.NewMetricPipelineBuilder().WithName("test-2").Build(),
+	}, BuildOptions{PersistentQueue: config.PersistentQueue{Enabled: true}})
+	require.NoError(t, err)
+
+	AddTapExporters(collectorConfig, otlpexporter.Taps{
+		Endpoints: map[string]string{
+			"test-1":  "https://telemetry-manager-webhook.kyma-system.svc:443/tap/metricpipelines/test-1",
+			"deleted": "https://telemetry-manager-webhook.kyma-system.svc:443/tap/metricpipelines/deleted",
+		},
+		CAPem: "ca",
+	})
+
+	require.Contains(t, collectorConfig.Exporters, "otlphttp/tap.test-1")
+	tapExporter := collectorConfig.Exporters["otlphttp/tap.test-1"].OTLP
+	require.Equal(t, "https://telemetry-manager-webhook.kyma-system.svc:443/tap/metricpipelines/test-1", tapExporter.Endpoint)
+	require.Equal(t, "ca", tapExporter.TLS.CAPem)
+	require.Empty(t, tapExporter.SendingQueue.Storage, "tap exporter must not use the persistent queue")
+	require.NotContains(t, collectorConfig.Exporters, "otlphttp/tap.deleted")
+	require.Equal(t, []string{"otlp/test-1", "otlphttp/tap.test-1"}, collectorConfig.Service.Pipelines["metrics/test-1"].Exporters)
+	require.Equal(t, []string{"otlp/test-2"}, collectorConfig.Service.Pipelines["metrics/test-2"].Exporters)
+}
@@ -68,6 +68,26 @@ func makeDropIfInputSourceIstioConfig() *FilterProcessor {
 	}
 }
 
+func makeDropIfInputSourceClusterConfig() *FilterProcessor {
+	return &FilterProcessor{
+		Metrics: FilterProcessorMetrics{
+			Metric: []string{
+				ottlexpr.ScopeNameEquals(metric.InstrumentationScopeCluster),
+			},
+		},
+	}
+}
+
+func makeDropIfInputSourceSpanMetricsConfig() *FilterProcessor {
+	return &FilterProcessor{
+		Metrics: FilterProcessorMetrics{
+			Metric: []string{
+				ottlexpr.ScopeNameEquals(metric.InstrumentationScopeSpanMetrics),
+			},
+		},
+	}
+}
+
 func makeDropIfInputSourceOtlpConfig() *FilterProcessor {
 	return &FilterProcessor{
 		Metrics: FilterProcessorMetrics{
@@ -78,6 +98,45 @@ func makeDropIfInputSourceOtlpConfig() *FilterProcessor {
 	}
 }
 
+// makeDropRuntimeResourceMetricsConfig drops the runtime metrics of a resource, which the agent collects for other pipelines.
+func makeDropRuntimeResourceMetricsConfig(resource metric.RuntimeResourceType) *FilterProcessor {
+	return &FilterProcessor{
+		Metrics: FilterProcessorMetrics{
+			Metric: []string{
+				ottlexpr.JoinWithAnd(
+					inputSourceEquals(metric.InputSourceRuntime),
+					ottlexpr.IsMatch("name", metric.RuntimeResourceMetricNamePattern[resource]),
+				),
+			},
+		},
+	}
+}
+
+// makeDropPrometheusMonitorMetricsConfig drops the metrics of ServiceMonitor and PodMonitor targets, which the agent scrapes for other pipelines.
+func makeDropPrometheusMonitorMetricsConfig() *FilterProcessor {
+	return &FilterProcessor{
+		Metrics: FilterProcessorMetrics{
+			Metric: []string{
+				ottlexpr.JoinWithAnd(
+					inputSourceEquals(metric.InputSourcePrometheus),
+					ottlexpr.ResourceAttributeEquals(metric.PrometheusMonitorAttribute, "true"),
+				),
+			},
+		},
+	}
+}
+
+func makeDeletePrometheusMonitorAttributeConfig() *config.ResourceProcessor {
+	return &config.ResourceProcessor{
+		Attributes: []config.AttributeAction{
+			{
+				Action: "delete",
+				Key:    metric.PrometheusMonitorAttribute,
+			},
+		},
+	}
+}
+
 func makeResolveServiceNameConfig() *TransformProcessor {
 	return &TransformProcessor{
 		ErrorMode:        "ignore",
@@ -85,8 +144,14 @@ func makeResolveServiceNameConfig() *TransformProcessor {
 	}
 }
 
+// makeFilterByNamespaceRuntimeInputConfig filters the runtime metrics by namespace. Node metrics do not belong to a namespace,
+// so they are exempted and not dropped if only some namespaces are included.
 func makeFilterByNamespaceRuntimeInputConfig(namespaceSelector *telemetryv1alpha1.MetricPipelineInputNamespaceSelector) *FilterProcessor {
-	return makeFilterByNamespaceConfig(namespaceSelector, inputSourceEquals(metric.InputSourceRuntime))
+	namespacedRuntimeMetrics := ottlexpr.JoinWithAnd(
+		inputSourceEquals(metric.InputSourceRuntime),
+		not(ottlexpr.IsMatch("name", metric.RuntimeResourceMetricNamePattern[metric.RuntimeResourceNode])),
+	)
+	return makeFilterByNamespaceConfig(namespaceSelector, namespacedRuntimeMetrics)
 }
 
 func makeFilterByNamespacePrometheusInputConfig(namespaceSelector *telemetryv1alpha1.MetricPipelineInputNamespaceSelector) *FilterProcessor {
@@ -123,6 +188,51 @@ func makeFilterByNamespaceConfig(namespaceSelector *telemetryv1alpha1.MetricPipe
 	}
 }
 
+// makeUserDefinedFilterConfig compiles the filters of a pipeline into a filter processor. The metric name patterns are anchored,
+// so that they must match the whole name. Conditions that cannot be evaluated for a data point, for example, because of a type mismatch, are ignored.
+func makeUserDefinedFilterConfig(filters *telemetryv1alpha1.MetricPipelineFilters) *FilterProcessor {
+	var metricExpressions []string
+
+	if len(filters.Include) > 0 {
+		metricExpressions = append(metricExpressions, not(ottlexpr.JoinWithOr(createMetricNameConditions(filters.Include)...)))
+	}
+
+	metricExpressions = append(metricExpressions, createMetricNameConditions(filters.Exclude)...)
+
+	var dataPointExpressions []string
+	for _, filter := range filters.DataPoints {
+		attribute := "attributes[\"" + ottlexpr.EscapeString(filter.Key) + "\"]"
+		if filter.Regex != "" {
+			dataPointExpressions = append(dataPointExpressions, ottlexpr.IsMatch(attribute, ottlexpr.EscapeString(filter.Regex)))
+		} else {
+			dataPointExpressions = append(dataPointExpressions, attribute+" == \""+ottlexpr.EscapeString(filter.Value)+"\"")
+		}
+	}
+
+	return &FilterProcessor{
+		ErrorMode: "ignore",
+		Metrics: FilterProcessorMetrics{
+			Metric:    metricExpressions,
+			DataPoint: dataPointExpressions,
+		},
+	}
+}
+
+func makeUserDefinedTransformConfig(rules []telemetryv1alpha1.TransformRule) *TransformProcessor {
+	return &TransformProcessor{
+		ErrorMode:        "ignore",
+		MetricStatements: gatewayprocs.TransformRuleStatements(rules),
+	}
+}
+
+func createMetricNameConditions(patterns []string) []string {
+	var conditions []string
+	for _, pattern := range patterns {
+		conditions = append(conditions, ottlexpr.IsMatch("name", ottlexpr.EscapeString("^(?:"+pattern+")$")))
+	}
+	return conditions
+}
+
 func createNamespacesConditions(namespaces []string) []string {
 	var namespacesConditions []string
 	for _, ns := range namespaces {
@@ -138,11 +248,13 @@ func inputSourceEquals(inputSourceType metric.InputSourceType) string {
 func otlpInputSource() string {
 	// When instrumentation scope is not set to
 	// io.kyma-project.telemetry/runtime or io.kyma-project.telemetry/prometheus or io.kyma-project.telemetry/istio
-	// we assume the metric is being pushed directly to metrics gateway.
-	return fmt.Sprintf("not(%s or %s or %s)",
+	// or io.kyma-project.telemetry/cluster or io.kyma-project.telemetry/spanmetrics we assume the metric is being pushed directly to metrics gateway.
+	return fmt.Sprintf("not(%s or %s or %s or %s or %s)",
 		ottlexpr.ScopeNameEquals(metric.InstrumentationScopeRuntime),
 		ottlexpr.ScopeNameEquals(metric.InstrumentationScopePrometheus),
 		ottlexpr.ScopeNameEquals(metric.InstrumentationScopeIstio),
+		ottlexpr.ScopeNameEquals(metric.InstrumentationScopeCluster),
+		ottlexpr.ScopeNameEquals(metric.InstrumentationScopeSpanMetrics),
 	)
 }
 
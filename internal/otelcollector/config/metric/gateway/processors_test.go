@@ -16,7 +16,7 @@ func TestProcessors(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().Build()
 
 	t.Run("insert cluster name processor", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, 1, len(collectorConfig.Processors.InsertClusterName.Attributes))
@@ -26,7 +26,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("memory limit processors", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "1s", collectorConfig.Processors.MemoryLimiter.CheckInterval)
@@ -35,7 +35,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("batch processors", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, 1024, collectorConfig.Processors.Batch.SendBatchSize)
@@ -44,7 +44,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("k8s attributes processors", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "serviceAccount", collectorConfig.Processors.K8sAttributes.AuthType)
@@ -72,7 +72,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("drop by input source filter", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithOTLPInput(false).Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{testutils.NewMetricPipelineBuilder().WithOTLPInput(false).Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.NotNil(t, collectorConfig.Processors.DropIfInputSourceRuntime)
@@ -87,12 +87,18 @@ func TestProcessors(t *testing.T) {
 		require.Len(t, collectorConfig.Processors.DropIfInputSourceIstio.Metrics.Metric, 1)
 		require.Equal(t, "instrumentation_scope.name == \"io.kyma-project.telemetry/istio\"", collectorConfig.Processors.DropIfInputSourceIstio.Metrics.Metric[0])
 
+		require.NotNil(t, collectorConfig.Processors.DropIfInputSourceSpanMetrics)
+		require.Len(t, collectorConfig.Processors.DropIfInputSourceSpanMetrics.Metrics.Metric, 1)
+		require.Equal(t, "instrumentation_scope.name == \"io.kyma-project.telemetry/spanmetrics\"", collectorConfig.Processors.DropIfInputSourceSpanMetrics.Metrics.Metric[0])
+
 		require.NotNil(t, collectorConfig.Processors.DropIfInputSourceOtlp)
 		require.Len(t, collectorConfig.Processors.DropIfInputSourceOtlp.Metrics.Metric, 1)
 		require.Equal(t,
 			"not(instrumentation_scope.name == \"io.kyma-project.telemetry/runtime\" or "+
 				"instrumentation_scope.name == \"io.kyma-project.telemetry/prometheus\" or "+
-				"instrumentation_scope.name == \"io.kyma-project.telemetry/istio\")",
+				"instrumentation_scope.name == \"io.kyma-project.telemetry/istio\" or "+
+				"instrumentation_scope.name == \"io.kyma-project.telemetry/cluster\" or "+
+				"instrumentation_scope.name == \"io.kyma-project.telemetry/spanmetrics\")",
 			collectorConfig.Processors.DropIfInputSourceOtlp.Metrics.Metric[0],
 		)
 	})
@@ -104,35 +110,50 @@ func TestProcessors(t *testing.T) {
 				WithPrometheusInput(true, testutils.IncludeNamespaces("ns-1", "ns-2")).
 				WithIstioInput(true, testutils.IncludeNamespaces("ns-1", "ns-2")).
 				WithOTLPInput(true, testutils.IncludeNamespaces("ns-1", "ns-2")).
-				Build()},
-		)
+				Build()}, BuildOptions{})
 		require.NoError(t, err)
 
-		namespaceFilters := collectorConfig.Processors.NamespaceFilters
+		namespaceFilters := collectorConfig.Processors.Dynamic
 		require.NotNil(t, namespaceFilters)
 
 		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace-runtime-input")
-		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-runtime-input"].Metrics.Metric, 1)
-		expectedCondition := "instrumentation_scope.name == \"io.kyma-project.telemetry/runtime\" and not((resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\"))"
-		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-runtime-input"].Metrics.Metric[0])
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-runtime-input"].Filter.Metrics.Metric, 1)
+		expectedCondition := "instrumentation_scope.name == \"io.kyma-project.telemetry/runtime\" and not(IsMatch(name, \"^k8s.node.*\")) and not((resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\"))"
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-runtime-input"].Filter.Metrics.Metric[0])
 
 		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace-prometheus-input")
-		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-prometheus-input"].Metrics.Metric, 1)
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-prometheus-input"].Filter.Metrics.Metric, 1)
 		expectedCondition = "instrumentation_scope.name == \"io.kyma-project.telemetry/prometheus\" and not((resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\"))"
-		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-prometheus-input"].Metrics.Metric[0])
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-prometheus-input"].Filter.Metrics.Metric[0])
 
 		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace-istio-input")
-		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-istio-input"].Metrics.Metric, 1)
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-istio-input"].Filter.Metrics.Metric, 1)
 		expectedCondition = "instrumentation_scope.name == \"io.kyma-project.telemetry/istio\" and not((resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\"))"
-		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-istio-input"].Metrics.Metric[0])
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-istio-input"].Filter.Metrics.Metric[0])
 
 		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace-otlp-input")
-		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-otlp-input"].Metrics.Metric, 1)
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-otlp-input"].Filter.Metrics.Metric, 1)
 		expectedCondition = "not(instrumentation_scope.name == \"io.kyma-project.telemetry/runtime\" or " +
 			"instrumentation_scope.name == \"io.kyma-project.telemetry/prometheus\" or " +
-			"instrumentation_scope.name == \"io.kyma-project.telemetry/istio\") and " +
+			"instrumentation_scope.name == \"io.kyma-project.telemetry/istio\" or " +
+			"instrumentation_scope.name == \"io.kyma-project.telemetry/cluster\" or " +
+			"instrumentation_scope.name == \"io.kyma-project.telemetry/spanmetrics\") and " +
 			"not((resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\"))"
-		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-otlp-input"].Metrics.Metric[0])
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-otlp-input"].Filter.Metrics.Metric[0])
+	})
+
+	t.Run("namespace filter processor keeps node metrics", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").
+				WithRuntimeInput(true, testutils.IncludeNamespaces("ns-1")).
+				WithRuntimeInputNodeMetrics(true).
+				Build()}, BuildOptions{})
+		require.NoError(t, err)
+
+		namespaceFilter := collectorConfig.Processors.Dynamic["filter/test-filter-by-namespace-runtime-input"]
+		require.NotNil(t, namespaceFilter)
+		require.Len(t, namespaceFilter.Filter.Metrics.Metric, 1)
+		require.Contains(t, namespaceFilter.Filter.Metrics.Metric[0], "not(IsMatch(name, \"^k8s.node.*\"))", "node metrics have no namespace and must not be dropped")
 	})
 
 	t.Run("namespace filter processor using exclude", func(t *testing.T) {
@@ -142,35 +163,89 @@ func TestProcessors(t *testing.T) {
 				WithPrometheusInput(true, testutils.ExcludeNamespaces("ns-1", "ns-2")).
 				WithIstioInput(true, testutils.ExcludeNamespaces("ns-1", "ns-2")).
 				WithOTLPInput(true, testutils.ExcludeNamespaces("ns-1", "ns-2")).
-				Build()},
-		)
+				Build()}, BuildOptions{})
 		require.NoError(t, err)
 
-		namespaceFilters := collectorConfig.Processors.NamespaceFilters
+		namespaceFilters := collectorConfig.Processors.Dynamic
 		require.NotNil(t, namespaceFilters)
 
 		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace-runtime-input")
-		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-runtime-input"].Metrics.Metric, 1)
-		expectedCondition := "instrumentation_scope.name == \"io.kyma-project.telemetry/runtime\" and (resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\")"
-		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-runtime-input"].Metrics.Metric[0])
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-runtime-input"].Filter.Metrics.Metric, 1)
+		expectedCondition := "instrumentation_scope.name == \"io.kyma-project.telemetry/runtime\" and not(IsMatch(name, \"^k8s.node.*\")) and (resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\")"
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-runtime-input"].Filter.Metrics.Metric[0])
 
 		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace-prometheus-input")
-		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-prometheus-input"].Metrics.Metric, 1)
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-prometheus-input"].Filter.Metrics.Metric, 1)
 		expectedCondition = "instrumentation_scope.name == \"io.kyma-project.telemetry/prometheus\" and (resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\")"
-		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-prometheus-input"].Metrics.Metric[0])
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-prometheus-input"].Filter.Metrics.Metric[0])
 
 		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace-istio-input")
-		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-istio-input"].Metrics.Metric, 1)
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-istio-input"].Filter.Metrics.Metric, 1)
 		expectedCondition = "instrumentation_scope.name == \"io.kyma-project.telemetry/istio\" and (resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\")"
-		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-istio-input"].Metrics.Metric[0])
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-istio-input"].Filter.Metrics.Metric[0])
 
 		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace-otlp-input")
-		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-otlp-input"].Metrics.Metric, 1)
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace-otlp-input"].Filter.Metrics.Metric, 1)
 		expectedCondition = "not(instrumentation_scope.name == \"io.kyma-project.telemetry/runtime\" or " +
 			"instrumentation_scope.name == \"io.kyma-project.telemetry/prometheus\" or " +
-			"instrumentation_scope.name == \"io.kyma-project.telemetry/istio\") and " +
+			"instrumentation_scope.name == \"io.kyma-project.telemetry/istio\" or " +
+			"instrumentation_scope.name == \"io.kyma-project.telemetry/cluster\" or " +
+			"instrumentation_scope.name == \"io.kyma-project.telemetry/spanmetrics\") and " +
 			"(resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\")"
-		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-otlp-input"].Metrics.Metric[0])
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace-otlp-input"].Filter.Metrics.Metric[0])
+	})
+
+	t.Run("user-defined filter processor", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").
+				WithIncludeMetrics("http_server_.*", "up").
+				WithExcludeMetrics(".*_bucket").
+				WithDataPointFilter(telemetryv1alpha1.MetricPipelineAttributeFilter{Key: "http.route", Value: "/healthz"}).
+				WithDataPointFilter(telemetryv1alpha1.MetricPipelineAttributeFilter{Key: "url.full", Regex: `.*\?id="[0-9]+"`}).
+				Build()}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Processors.Dynamic, "filter/test-user-defined-filters")
+		filter := collectorConfig.Processors.Dynamic["filter/test-user-defined-filters"].Filter
+		require.Equal(t, "ignore", filter.ErrorMode)
+		require.Equal(t, []string{
+			`not((IsMatch(name, "^(?:http_server_.*)$") or IsMatch(name, "^(?:up)$")))`,
+			`IsMatch(name, "^(?:.*_bucket)$")`,
+		}, filter.Metrics.Metric)
+		require.Equal(t, []string{
+			`attributes["http.route"] == "/healthz"`,
+			`IsMatch(attributes["url.full"], ".*\\?id=\"[0-9]+\"")`,
+		}, filter.Metrics.DataPoint)
+	})
+
+	t.Run("no user-defined filter processor without filters", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").Build()}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.NotContains(t, collectorConfig.Processors.Dynamic, "filter/test-user-defined-filters")
+	})
+
+	t.Run("user-defined transform processor", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.MetricPipeline{
+			testutils.NewMetricPipelineBuilder().WithName("test").
+				WithTransform(telemetryv1alpha1.TransformRule{Context: telemetryv1alpha1.TransformContextDataPoint, Action: telemetryv1alpha1.TransformActionRename, Key: "http.url", NewKey: "url.full"}).
+				WithTransform(telemetryv1alpha1.TransformRule{Context: telemetryv1alpha1.TransformContextResource, Action: telemetryv1alpha1.TransformActionSet, Key: "deployment.environment", Value: "production"}).
+				Build()}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Processors.Dynamic, "transform/test-user-defined-transforms")
+		transform := collectorConfig.Processors.Dynamic["transform/test-user-defined-transforms"].Transform
+		require.NotNil(t, transform)
+		require.Equal(t, "ignore", transform.ErrorMode)
+		require.Len(t, transform.MetricStatements, 2)
+		require.Equal(t, "datapoint", transform.MetricStatements[0].Context)
+		require.Equal(t, []string{
+			`set(attributes["url.full"], attributes["http.url"]) where attributes["http.url"] != nil`,
+			`delete_key(attributes, "http.url")`,
+		}, transform.MetricStatements[0].Statements)
+		require.Equal(t, "resource", transform.MetricStatements[1].Context)
+		require.Equal(t, []string{`set(attributes["deployment.environment"], "production")`}, transform.MetricStatements[1].Statements)
 	})
 
 	t.Run("diagnostic metric filter processor prometheus input using exclude", func(t *testing.T) {
@@ -178,8 +253,7 @@ func TestProcessors(t *testing.T) {
 			testutils.NewMetricPipelineBuilder().WithName("test").
 				WithPrometheusInput(true).
 				WithPrometheusInputDiagnosticMetrics(false).
-				Build()},
-		)
+				Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		prometheusScrapeFilter := collectorConfig.Processors.DropDiagnosticMetricsIfInputSourcePrometheus
@@ -195,8 +269,7 @@ func TestProcessors(t *testing.T) {
 			testutils.NewMetricPipelineBuilder().WithName("test").
 				WithIstioInput(true).
 				WithIstioInputDiagnosticMetrics(false).
-				Build()},
-		)
+				Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		istioScrapeFilter := collectorConfig.Processors.DropDiagnosticMetricsIfInputSourceIstio
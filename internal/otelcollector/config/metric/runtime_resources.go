@@ -0,0 +1,60 @@
+package metric
+
+import (
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+type RuntimeResourceType string
+
+const (
+	RuntimeResourceContainer RuntimeResourceType = "container"
+	RuntimeResourcePod       RuntimeResourceType = "pod"
+	RuntimeResourceNode      RuntimeResourceType = "node"
+	RuntimeResourceVolume    RuntimeResourceType = "volume"
+)
+
+// RuntimeResources lists all resources of the runtime input in the order in which they are rendered into the collector configuration.
+var RuntimeResources = []RuntimeResourceType{
+	RuntimeResourceContainer,
+	RuntimeResourcePod,
+	RuntimeResourceNode,
+	RuntimeResourceVolume,
+}
+
+// RuntimeResourceMetricNamePattern matches the names of the runtime metrics that belong to a resource.
+var RuntimeResourceMetricNamePattern = map[RuntimeResourceType]string{
+	RuntimeResourceContainer: "^container.*",
+	RuntimeResourcePod:       "^k8s.pod.*",
+	RuntimeResourceNode:      "^k8s.node.*",
+	RuntimeResourceVolume:    "^k8s.volume.*",
+}
+
+// IsRuntimeResourceEnabled returns true if the runtime input collects the metrics of the given resource. Pod and container
+// metrics are collected unless they are disabled explicitly, whereas Node and Volume metrics must be enabled explicitly.
+func IsRuntimeResourceEnabled(input *telemetryv1alpha1.MetricPipelineRuntimeInput, resource RuntimeResourceType) bool {
+	if input == nil || !input.Enabled {
+		return false
+	}
+
+	resources := input.Resources
+	if resources == nil {
+		resources = &telemetryv1alpha1.MetricPipelineRuntimeInputResources{}
+	}
+
+	switch resource {
+	case RuntimeResourceContainer:
+		return isEnabledByDefault(resources.Container)
+	case RuntimeResourcePod:
+		return isEnabledByDefault(resources.Pod)
+	case RuntimeResourceNode:
+		return resources.Node != nil && resources.Node.Enabled
+	case RuntimeResourceVolume:
+		return resources.Volume != nil && resources.Volume.Enabled
+	default:
+		return false
+	}
+}
+
+func isEnabledByDefault(resource *telemetryv1alpha1.MetricPipelineRuntimeInputResourceEnabledByDefault) bool {
+	return resource == nil || resource.Enabled == nil || *resource.Enabled
+}
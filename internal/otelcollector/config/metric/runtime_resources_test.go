@@ -0,0 +1,90 @@
+package metric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+func TestIsRuntimeResourceEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *telemetryv1alpha1.MetricPipelineRuntimeInput
+		expected map[RuntimeResourceType]bool
+	}{
+		{
+			name:  "runtime input not defined",
+			input: nil,
+			expected: map[RuntimeResourceType]bool{
+				RuntimeResourceContainer: false,
+				RuntimeResourcePod:       false,
+				RuntimeResourceNode:      false,
+				RuntimeResourceVolume:    false,
+			},
+		},
+		{
+			name:  "runtime input disabled",
+			input: &telemetryv1alpha1.MetricPipelineRuntimeInput{Enabled: false},
+			expected: map[RuntimeResourceType]bool{
+				RuntimeResourceContainer: false,
+				RuntimeResourcePod:       false,
+				RuntimeResourceNode:      false,
+				RuntimeResourceVolume:    false,
+			},
+		},
+		{
+			name:  "defaults",
+			input: &telemetryv1alpha1.MetricPipelineRuntimeInput{Enabled: true},
+			expected: map[RuntimeResourceType]bool{
+				RuntimeResourceContainer: true,
+				RuntimeResourcePod:       true,
+				RuntimeResourceNode:      false,
+				RuntimeResourceVolume:    false,
+			},
+		},
+		{
+			name: "all toggled",
+			input: &telemetryv1alpha1.MetricPipelineRuntimeInput{
+				Enabled: true,
+				Resources: &telemetryv1alpha1.MetricPipelineRuntimeInputResources{
+					Container: &telemetryv1alpha1.MetricPipelineRuntimeInputResourceEnabledByDefault{Enabled: ptr.To(false)},
+					Pod:       &telemetryv1alpha1.MetricPipelineRuntimeInputResourceEnabledByDefault{Enabled: ptr.To(false)},
+					Node:      &telemetryv1alpha1.MetricPipelineRuntimeInputResourceDisabledByDefault{Enabled: true},
+					Volume:    &telemetryv1alpha1.MetricPipelineRuntimeInputResourceDisabledByDefault{Enabled: true},
+				},
+			},
+			expected: map[RuntimeResourceType]bool{
+				RuntimeResourceContainer: false,
+				RuntimeResourcePod:       false,
+				RuntimeResourceNode:      true,
+				RuntimeResourceVolume:    true,
+			},
+		},
+		{
+			name: "enabled by default without explicit value",
+			input: &telemetryv1alpha1.MetricPipelineRuntimeInput{
+				Enabled: true,
+				Resources: &telemetryv1alpha1.MetricPipelineRuntimeInputResources{
+					Pod: &telemetryv1alpha1.MetricPipelineRuntimeInputResourceEnabledByDefault{},
+				},
+			},
+			expected: map[RuntimeResourceType]bool{
+				RuntimeResourceContainer: true,
+				RuntimeResourcePod:       true,
+				RuntimeResourceNode:      false,
+				RuntimeResourceVolume:    false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for resource, expected := range tt.expected {
+				require.Equal(t, expected, IsRuntimeResourceEnabled(tt.input, resource), "resource %s", resource)
+			}
+		})
+	}
+}
@@ -3,20 +3,30 @@ package metric
 type InputSourceType string
 
 const (
-	InputSourceRuntime    InputSourceType = "runtime"
-	InputSourcePrometheus InputSourceType = "prometheus"
-	InputSourceIstio      InputSourceType = "istio"
-	InputSourceOtlp       InputSourceType = "otlp"
+	InputSourceRuntime     InputSourceType = "runtime"
+	InputSourcePrometheus  InputSourceType = "prometheus"
+	InputSourceIstio       InputSourceType = "istio"
+	InputSourceCluster     InputSourceType = "cluster"
+	InputSourceSpanMetrics InputSourceType = "spanmetrics"
+	InputSourceOtlp        InputSourceType = "otlp"
 )
 
 const (
-	InstrumentationScopeRuntime    = "io.kyma-project.telemetry/runtime"
-	InstrumentationScopePrometheus = "io.kyma-project.telemetry/prometheus"
-	InstrumentationScopeIstio      = "io.kyma-project.telemetry/istio"
+	InstrumentationScopeRuntime     = "io.kyma-project.telemetry/runtime"
+	InstrumentationScopePrometheus  = "io.kyma-project.telemetry/prometheus"
+	InstrumentationScopeIstio       = "io.kyma-project.telemetry/istio"
+	InstrumentationScopeCluster     = "io.kyma-project.telemetry/cluster"
+	InstrumentationScopeSpanMetrics = "io.kyma-project.telemetry/spanmetrics"
 )
 
 var InstrumentationScope = map[InputSourceType]string{
-	InputSourceRuntime:    InstrumentationScopeRuntime,
-	InputSourcePrometheus: InstrumentationScopePrometheus,
-	InputSourceIstio:      InstrumentationScopeIstio,
+	InputSourceRuntime:     InstrumentationScopeRuntime,
+	InputSourcePrometheus:  InstrumentationScopePrometheus,
+	InputSourceIstio:       InstrumentationScopeIstio,
+	InputSourceCluster:     InstrumentationScopeCluster,
+	InputSourceSpanMetrics: InstrumentationScopeSpanMetrics,
 }
+
+// PrometheusMonitorAttribute is set by the agent on the resources of metrics that are scraped from the targets of ServiceMonitors and PodMonitors.
+// The gateway uses it to deliver those metrics only to the pipelines that enable the monitors, and removes it before exporting.
+const PrometheusMonitorAttribute = "kyma.prometheus.monitor"
@@ -13,9 +13,12 @@ import (
 
 type EnvVars map[string][]byte
 
+const defaultRetryMaxElapsedTime = "300s"
+
 const (
 	SignalTypeMetric = "metric"
 	SignalTypeTrace  = "trace"
+	SignalTypeLog    = "log"
 )
 
 type ConfigBuilder struct {
@@ -36,6 +39,23 @@ func NewConfigBuilder(reader client.Reader, otlpOutput *telemetryv1alpha1.OtlpOu
 	}
 }
 
+// NewConfigBuilders returns a ConfigBuilder for the primary output of a pipeline, followed by one for each of its additional outputs.
+// The queue sizes of the outputs are looked up by their output names.
+func NewConfigBuilders(reader client.Reader, otlpOutput *telemetryv1alpha1.OtlpOutput, additionalOutputs []telemetryv1alpha1.AdditionalOtlpOutput, pipelineName string, queueSizes QueueSizes, signalType string) []*ConfigBuilder {
+	builders := []*ConfigBuilder{NewConfigBuilder(reader, otlpOutput, pipelineName, queueSizes[pipelineName], signalType)}
+	for _, additionalOutput := range additionalOutputs {
+		outputName := OutputName(pipelineName, additionalOutput.Name)
+		builders = append(builders, NewConfigBuilder(reader, additionalOutput.Otlp, outputName, queueSizes[outputName], signalType))
+	}
+
+	return builders
+}
+
+// ExporterID returns the ID of the exporter that is configured by the builder.
+func (cb *ConfigBuilder) ExporterID() string {
+	return ExporterID(cb.otlpOutput.Protocol, cb.pipelineName)
+}
+
 func (cb *ConfigBuilder) MakeConfig(ctx context.Context) (*config.OTLPExporter, EnvVars, error) {
 	envVars, err := makeEnvVars(ctx, cb.reader, cb.otlpOutput, cb.pipelineName)
 	if err != nil {
@@ -46,6 +66,36 @@ func (cb *ConfigBuilder) MakeConfig(ctx context.Context) (*config.OTLPExporter,
 	return exportersConfig, envVars, nil
 }
 
+// MakeAuthExtensionConfig returns the ID and the configuration of the authenticator extension, which the exporter uses to authenticate at the backend.
+// It returns nil if the output does not use OAuth2 or bearer token authentication.
+func (cb *ConfigBuilder) MakeAuthExtensionConfig() (string, *config.Extension) {
+	authentication := cb.otlpOutput.Authentication
+	if authentication == nil {
+		return "", nil
+	}
+
+	if authentication.OAuth2.IsDefined() {
+		return makeAuthExtensionID(cb.otlpOutput, cb.pipelineName), &config.Extension{
+			OAuth2Client: &config.OAuth2ClientExtension{
+				TokenURL:     fmt.Sprintf("${%s}", makeOAuth2TokenURLVariable(cb.pipelineName)),
+				ClientID:     fmt.Sprintf("${%s}", makeOAuth2ClientIDVariable(cb.pipelineName)),
+				ClientSecret: fmt.Sprintf("${%s}", makeOAuth2ClientSecretVariable(cb.pipelineName)),
+				Scopes:       authentication.OAuth2.Scopes,
+			},
+		}
+	}
+
+	if authentication.BearerToken.IsDefined() {
+		return makeAuthExtensionID(cb.otlpOutput, cb.pipelineName), &config.Extension{
+			BearerTokenAuth: &config.BearerTokenAuthExtension{
+				Token: fmt.Sprintf("${%s}", makeBearerTokenVariable(cb.pipelineName)),
+			},
+		}
+	}
+
+	return "", nil
+}
+
 func makeExportersConfig(otlpOutput *telemetryv1alpha1.OtlpOutput, pipelineName string, envVars map[string][]byte, queueSize int, signalType string) *config.OTLPExporter {
 	headers := makeHeaders(otlpOutput, pipelineName)
 	otlpEndpointVariable := makeOtlpEndpointVariable(pipelineName)
@@ -64,10 +114,16 @@ func makeExportersConfig(otlpOutput *telemetryv1alpha1.OtlpOutput, pipelineName
 			Enabled:         true,
 			InitialInterval: "5s",
 			MaxInterval:     "30s",
-			MaxElapsedTime:  "300s",
+			MaxElapsedTime:  defaultRetryMaxElapsedTime,
 		},
 	}
 
+	applyDeliveryConfig(&otlpExporterConfig, otlpOutput.Delivery)
+
+	if authExtensionID := makeAuthExtensionID(otlpOutput, pipelineName); authExtensionID != "" {
+		otlpExporterConfig.Auth = &config.Auth{Authenticator: authExtensionID}
+	}
+
 	if len(otlpOutput.Path) > 0 && SignalTypeMetric == signalType {
 		otlpExporterConfig.Endpoint = ""
 		otlpExporterConfig.MetricsEndpoint = fmt.Sprintf("${%s}", otlpEndpointVariable)
@@ -76,9 +132,37 @@ func makeExportersConfig(otlpOutput *telemetryv1alpha1.OtlpOutput, pipelineName
 		otlpExporterConfig.Endpoint = ""
 		otlpExporterConfig.TracesEndpoint = fmt.Sprintf("${%s}", otlpEndpointVariable)
 	}
+	if len(otlpOutput.Path) > 0 && SignalTypeLog == signalType {
+		otlpExporterConfig.Endpoint = ""
+		otlpExporterConfig.LogsEndpoint = fmt.Sprintf("${%s}", otlpEndpointVariable)
+	}
 	return &otlpExporterConfig
 }
 
+// applyDeliveryConfig overrides the default retry and request settings of the exporter with the ones defined by the user.
+// The queue size is not applied here, because it counts against the capacity shared by all outputs of the gateway, see MakeQueueSizes.
+func applyDeliveryConfig(otlpExporterConfig *config.OTLPExporter, delivery *telemetryv1alpha1.OtlpDelivery) {
+	if delivery == nil {
+		return
+	}
+
+	if delivery.RetryMaxElapsedTime != "" {
+		otlpExporterConfig.RetryOnFailure.MaxElapsedTime = delivery.RetryMaxElapsedTime
+	}
+	otlpExporterConfig.Timeout = delivery.Timeout
+	otlpExporterConfig.Compression = delivery.Compression
+}
+
+// OutputName returns the name used for the exporter ID and the environment variables of a pipeline output.
+// The primary output is named after the pipeline, an additional output is named after the pipeline and the output.
+func OutputName(pipelineName, additionalOutputName string) string {
+	if additionalOutputName == "" {
+		return pipelineName
+	}
+
+	return fmt.Sprintf("%s/%s", pipelineName, additionalOutputName)
+}
+
 func ExporterID(protocol string, pipelineName string) string {
 	var outputType string
 	if protocol == telemetryv1alpha1.OtlpProtocolHTTP {
@@ -90,6 +174,22 @@ func ExporterID(protocol string, pipelineName string) string {
 	return fmt.Sprintf("%s/%s", outputType, pipelineName)
 }
 
+func makeAuthExtensionID(output *telemetryv1alpha1.OtlpOutput, pipelineName string) string {
+	if output.Authentication == nil {
+		return ""
+	}
+
+	if output.Authentication.OAuth2.IsDefined() {
+		return fmt.Sprintf("oauth2client/%s", pipelineName)
+	}
+
+	if output.Authentication.BearerToken.IsDefined() {
+		return fmt.Sprintf("bearertokenauth/%s", pipelineName)
+	}
+
+	return ""
+}
+
 func makeTLSConfig(output *telemetryv1alpha1.OtlpOutput, otlpEndpointValue, pipelineName string) config.TLS {
 	var cfg config.TLS
 	cfg.Insecure = isInsecureOutput(otlpEndpointValue)
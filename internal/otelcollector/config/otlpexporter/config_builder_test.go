@@ -22,6 +22,27 @@ func TestExorterIDDefault(t *testing.T) {
 	require.Equal(t, "otlp/test", ExporterID("", "test"))
 }
 
+func TestExporterIDAdditionalOutput(t *testing.T) {
+	require.Equal(t, "otlp/test/audit", ExporterID("grpc", OutputName("test", "audit")))
+}
+
+func TestOutputNamePrimaryOutput(t *testing.T) {
+	require.Equal(t, "test", OutputName("test", ""))
+}
+
+func TestMakeConfigAdditionalOutput(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, OutputName("test", "audit"), 512, SignalTypeTrace)
+	otlpExporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []byte("otlp-endpoint"), envVars["OTLP_ENDPOINT_TEST_out_AUDIT"])
+	require.Equal(t, "${OTLP_ENDPOINT_TEST_out_AUDIT}", otlpExporterConfig.Endpoint)
+}
+
 func TestMakeConfig(t *testing.T) {
 	output := &telemetryv1alpha1.OtlpOutput{
 		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
@@ -45,6 +66,47 @@ func TestMakeConfig(t *testing.T) {
 	require.Equal(t, "300s", otlpExporterConfig.RetryOnFailure.MaxElapsedTime)
 }
 
+func TestMakeConfigWithDelivery(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Delivery: &telemetryv1alpha1.OtlpDelivery{
+			QueueSize:           100,
+			RetryMaxElapsedTime: "1h",
+			Timeout:             "10s",
+			Compression:         "zstd",
+		},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 100, SignalTypeTrace)
+	otlpExporterConfig, _, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 100, otlpExporterConfig.SendingQueue.QueueSize, "Queue size should be the one assigned to the output")
+	require.Equal(t, "1h", otlpExporterConfig.RetryOnFailure.MaxElapsedTime)
+	require.Equal(t, "5s", otlpExporterConfig.RetryOnFailure.InitialInterval)
+	require.Equal(t, "30s", otlpExporterConfig.RetryOnFailure.MaxInterval)
+	require.Equal(t, "10s", otlpExporterConfig.Timeout)
+	require.Equal(t, "zstd", otlpExporterConfig.Compression)
+}
+
+func TestMakeConfigWithPartialDelivery(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Delivery: &telemetryv1alpha1.OtlpDelivery{
+			Timeout: "10s",
+		},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 512, SignalTypeTrace)
+	otlpExporterConfig, _, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 512, otlpExporterConfig.SendingQueue.QueueSize, "Queue size should default to the shared capacity")
+	require.Equal(t, "300s", otlpExporterConfig.RetryOnFailure.MaxElapsedTime)
+	require.Equal(t, "10s", otlpExporterConfig.Timeout)
+	require.Empty(t, otlpExporterConfig.Compression)
+}
+
 func TestMakeConfigTraceWithPath(t *testing.T) {
 	output := &telemetryv1alpha1.OtlpOutput{
 		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
@@ -83,6 +145,25 @@ func TestMakeConfigMetricWithPath(t *testing.T) {
 	require.Empty(t, otlpExporterConfig.Endpoint)
 }
 
+func TestMakeConfigLogWithPath(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Path:     "/v1/test",
+		Protocol: "http",
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 512, SignalTypeLog)
+	otlpExporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, envVars)
+
+	require.NotNil(t, envVars["OTLP_ENDPOINT_TEST"])
+	require.Equal(t, envVars["OTLP_ENDPOINT_TEST"], []byte("otlp-endpoint/v1/test"))
+
+	require.Equal(t, "${OTLP_ENDPOINT_TEST}", otlpExporterConfig.LogsEndpoint)
+	require.Empty(t, otlpExporterConfig.Endpoint)
+}
+
 func TestMakeExporterConfigWithCustomHeaders(t *testing.T) {
 	headers := []telemetryv1alpha1.Header{
 		{
@@ -106,6 +187,87 @@ func TestMakeExporterConfigWithCustomHeaders(t *testing.T) {
 	require.Equal(t, "${HEADER_TEST_AUTHORIZATION}", otlpExporterConfig.Headers["Authorization"])
 }
 
+func TestMakeExporterConfigWithOAuth2(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Authentication: &telemetryv1alpha1.AuthenticationOptions{
+			OAuth2: &telemetryv1alpha1.OAuth2Options{
+				TokenURL:     telemetryv1alpha1.ValueType{Value: "https://auth.example.com/oauth2/token"},
+				ClientID:     telemetryv1alpha1.ValueType{Value: "client-id"},
+				ClientSecret: telemetryv1alpha1.ValueType{Value: "client-secret"},
+				Scopes:       []string{"ingest"},
+			},
+		},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 512, SignalTypeTrace)
+	otlpExporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Empty(t, otlpExporterConfig.Headers)
+	require.NotNil(t, otlpExporterConfig.Auth)
+	require.Equal(t, "oauth2client/test", otlpExporterConfig.Auth.Authenticator)
+
+	require.Equal(t, "https://auth.example.com/oauth2/token", string(envVars["OAUTH2_TOKEN_URL_TEST"]))
+	require.Equal(t, "client-id", string(envVars["OAUTH2_CLIENT_ID_TEST"]))
+	require.Equal(t, "client-secret", string(envVars["OAUTH2_CLIENT_SECRET_TEST"]))
+
+	extensionID, extensionConfig := cb.MakeAuthExtensionConfig()
+	require.Equal(t, "oauth2client/test", extensionID)
+	require.NotNil(t, extensionConfig)
+	require.NotNil(t, extensionConfig.OAuth2Client)
+	require.Equal(t, "${OAUTH2_TOKEN_URL_TEST}", extensionConfig.OAuth2Client.TokenURL)
+	require.Equal(t, "${OAUTH2_CLIENT_ID_TEST}", extensionConfig.OAuth2Client.ClientID)
+	require.Equal(t, "${OAUTH2_CLIENT_SECRET_TEST}", extensionConfig.OAuth2Client.ClientSecret)
+	require.Equal(t, []string{"ingest"}, extensionConfig.OAuth2Client.Scopes)
+}
+
+func TestMakeExporterConfigWithBearerToken(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Authentication: &telemetryv1alpha1.AuthenticationOptions{
+			BearerToken: &telemetryv1alpha1.ValueType{Value: "xyz"},
+		},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test/audit", 512, SignalTypeTrace)
+	otlpExporterConfig, envVars, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Empty(t, otlpExporterConfig.Headers)
+	require.NotNil(t, otlpExporterConfig.Auth)
+	require.Equal(t, "bearertokenauth/test/audit", otlpExporterConfig.Auth.Authenticator)
+	require.Equal(t, "xyz", string(envVars["BEARER_TOKEN_TEST_out_AUDIT"]))
+
+	extensionID, extensionConfig := cb.MakeAuthExtensionConfig()
+	require.Equal(t, "bearertokenauth/test/audit", extensionID)
+	require.NotNil(t, extensionConfig)
+	require.NotNil(t, extensionConfig.BearerTokenAuth)
+	require.Equal(t, "${BEARER_TOKEN_TEST_out_AUDIT}", extensionConfig.BearerTokenAuth.Token)
+}
+
+func TestMakeExporterConfigWithBasicAuthHasNoAuthExtension(t *testing.T) {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "otlp-endpoint"},
+		Authentication: &telemetryv1alpha1.AuthenticationOptions{
+			Basic: &telemetryv1alpha1.BasicAuthOptions{
+				User:     telemetryv1alpha1.ValueType{Value: "user"},
+				Password: telemetryv1alpha1.ValueType{Value: "password"},
+			},
+		},
+	}
+
+	cb := NewConfigBuilder(fake.NewClientBuilder().Build(), output, "test", 512, SignalTypeTrace)
+	otlpExporterConfig, _, err := cb.MakeConfig(context.Background())
+	require.NoError(t, err)
+
+	require.Nil(t, otlpExporterConfig.Auth)
+	require.Equal(t, "${BASIC_AUTH_HEADER_TEST}", otlpExporterConfig.Headers["Authorization"])
+
+	_, extensionConfig := cb.MakeAuthExtensionConfig()
+	require.Nil(t, extensionConfig)
+}
+
 func TestMakeExporterConfigWithTLSInsecure(t *testing.T) {
 	tls := &telemetryv1alpha1.OtlpTLS{
 		Insecure: true,
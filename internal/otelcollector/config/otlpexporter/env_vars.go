@@ -16,11 +16,15 @@ import (
 )
 
 const (
-	basicAuthHeaderVariablePrefix = "BASIC_AUTH_HEADER"
-	otlpEndpointVariablePrefix    = "OTLP_ENDPOINT"
-	tlsConfigCertVariablePrefix   = "OTLP_TLS_CERT_PEM"
-	tlsConfigKeyVariablePrefix    = "OTLP_TLS_KEY_PEM"
-	tlsConfigCaVariablePrefix     = "OTLP_TLS_CA_PEM"
+	basicAuthHeaderVariablePrefix    = "BASIC_AUTH_HEADER"
+	bearerTokenVariablePrefix        = "BEARER_TOKEN"
+	oauth2TokenURLVariablePrefix     = "OAUTH2_TOKEN_URL"
+	oauth2ClientIDVariablePrefix     = "OAUTH2_CLIENT_ID"
+	oauth2ClientSecretVariablePrefix = "OAUTH2_CLIENT_SECRET"
+	otlpEndpointVariablePrefix       = "OTLP_ENDPOINT"
+	tlsConfigCertVariablePrefix      = "OTLP_TLS_CERT_PEM"
+	tlsConfigKeyVariablePrefix       = "OTLP_TLS_KEY_PEM"
+	tlsConfigCaVariablePrefix        = "OTLP_TLS_CA_PEM"
 )
 
 func makeEnvVars(ctx context.Context, c client.Reader, output *telemetryv1alpha1.OtlpOutput, pipelineName string) (map[string][]byte, error) {
@@ -61,6 +65,27 @@ func makeAuthenticationEnvVar(ctx context.Context, c client.Reader, secretData m
 		basicAuthHeaderVariable := fmt.Sprintf("%s_%s", basicAuthHeaderVariablePrefix, sanitizeEnvVarName(pipelineName))
 		secretData[basicAuthHeaderVariable] = []byte(basicAuthHeader)
 	}
+	if output.Authentication != nil && output.Authentication.OAuth2.IsDefined() {
+		oauth2 := output.Authentication.OAuth2
+		for variable, value := range map[string]telemetryv1alpha1.ValueType{
+			makeOAuth2TokenURLVariable(pipelineName):     oauth2.TokenURL,
+			makeOAuth2ClientIDVariable(pipelineName):     oauth2.ClientID,
+			makeOAuth2ClientSecretVariable(pipelineName): oauth2.ClientSecret,
+		} {
+			resolved, err := resolveValue(ctx, c, value)
+			if err != nil {
+				return err
+			}
+			secretData[variable] = resolved
+		}
+	}
+	if output.Authentication != nil && output.Authentication.BearerToken.IsDefined() {
+		token, err := resolveValue(ctx, c, *output.Authentication.BearerToken)
+		if err != nil {
+			return err
+		}
+		secretData[makeBearerTokenVariable(pipelineName)] = token
+	}
 	return nil
 }
 
@@ -172,6 +197,22 @@ func makeBasicAuthHeaderVariable(pipelineName string) string {
 	return fmt.Sprintf("%s_%s", basicAuthHeaderVariablePrefix, sanitizeEnvVarName(pipelineName))
 }
 
+func makeBearerTokenVariable(pipelineName string) string {
+	return fmt.Sprintf("%s_%s", bearerTokenVariablePrefix, sanitizeEnvVarName(pipelineName))
+}
+
+func makeOAuth2TokenURLVariable(pipelineName string) string {
+	return fmt.Sprintf("%s_%s", oauth2TokenURLVariablePrefix, sanitizeEnvVarName(pipelineName))
+}
+
+func makeOAuth2ClientIDVariable(pipelineName string) string {
+	return fmt.Sprintf("%s_%s", oauth2ClientIDVariablePrefix, sanitizeEnvVarName(pipelineName))
+}
+
+func makeOAuth2ClientSecretVariable(pipelineName string) string {
+	return fmt.Sprintf("%s_%s", oauth2ClientSecretVariablePrefix, sanitizeEnvVarName(pipelineName))
+}
+
 func makeHeaderVariable(header telemetryv1alpha1.Header, pipelineName string) string {
 	return fmt.Sprintf("HEADER_%s_%s", sanitizeEnvVarName(pipelineName), sanitizeEnvVarName(header.Name))
 }
@@ -188,10 +229,17 @@ func makeTLSCaVariable(pipelineName string) string {
 	return fmt.Sprintf("%s_%s", tlsConfigCaVariablePrefix, sanitizeEnvVarName(pipelineName))
 }
 
+// sanitizeEnvVarName converts a pipeline or output name to a part of an environment variable name.
+// The names consist of lower case letters, digits, hyphens, and dots, and the output names are separated from the pipeline name by a slash.
+// Letters are converted to upper case and hyphens to underscores, so that the common names stay readable.
+// Dots and slashes are escaped with lower case words, which no name can produce, so that different names never share a variable.
+// For example, "a--b" becomes "A__B", "a/b" becomes "A_out_B", and "a.b" becomes "A_dot_B".
 func sanitizeEnvVarName(input string) string {
-	result := input
-	result = strings.ToUpper(result)
-	result = strings.Replace(result, ".", "_", -1)
-	result = strings.Replace(result, "-", "_", -1)
-	return result
+	return envVarNameReplacer.Replace(strings.ToUpper(input))
 }
+
+var envVarNameReplacer = strings.NewReplacer(
+	"-", "_",
+	".", "_dot_",
+	"/", "_out_",
+)
@@ -0,0 +1,39 @@
+package otlpexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeEnvVarName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "pipeline name", input: "my-pipeline", expected: "MY_PIPELINE"},
+		{name: "pipeline name with dots", input: "my.pipeline", expected: "MY_dot_PIPELINE"},
+		{name: "additional output", input: "my-pipeline/audit", expected: "MY_PIPELINE_out_AUDIT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, sanitizeEnvVarName(tt.input))
+		})
+	}
+}
+
+func TestSanitizeEnvVarNameHasNoCollisions(t *testing.T) {
+	names := []string{
+		"a--b", OutputName("a", "b"), OutputName("a-", "b"), OutputName("a", "-b"),
+		"a-b", "a.b", "a-dot-b", OutputName("a-out", "b"),
+	}
+
+	seen := make(map[string]string)
+	for _, name := range names {
+		sanitized := sanitizeEnvVarName(name)
+		require.NotContains(t, seen, sanitized, "%q and %q result in the same variable name", seen[sanitized], name)
+		seen[sanitized] = name
+	}
+}
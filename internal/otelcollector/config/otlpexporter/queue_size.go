@@ -0,0 +1,78 @@
+package otlpexporter
+
+import (
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+// maxQueueSize is the sending queue capacity of a gateway in batches.
+const maxQueueSize = 256
+
+// QueueSizes maps the output names of the pipelines of a gateway (see OutputName) to the sizes of their sending queues.
+type QueueSizes map[string]int
+
+// PipelineOutputs returns the OTLP outputs of a pipeline by their output names. A pipeline without OTLP output has none.
+func PipelineOutputs(pipelineName string, otlpOutput *telemetryv1alpha1.OtlpOutput, additionalOutputs []telemetryv1alpha1.AdditionalOtlpOutput) map[string]*telemetryv1alpha1.OtlpOutput {
+	outputs := make(map[string]*telemetryv1alpha1.OtlpOutput)
+	if otlpOutput == nil {
+		return outputs
+	}
+
+	outputs[pipelineName] = otlpOutput
+	for i := range additionalOutputs {
+		outputs[OutputName(pipelineName, additionalOutputs[i].Name)] = additionalOutputs[i].Otlp
+	}
+
+	return outputs
+}
+
+// MakeQueueSizes splits the sending queue capacity of a gateway between the outputs of all its pipelines.
+// Every output has an exporter with its own sending queue, so the capacity is split per output and not per pipeline.
+// The queue sizes that are defined in the delivery settings of an output are subtracted from the capacity first, and the rest is shared equally by the other outputs.
+// If the defined queue sizes exceed the capacity, they are reduced proportionally, so that the gateway never buffers more than its capacity.
+// Every output keeps a queue of at least one batch.
+func MakeQueueSizes(outputs map[string]*telemetryv1alpha1.OtlpOutput) QueueSizes {
+	definedSum, sharingCount := 0, 0
+	for _, output := range outputs {
+		if size := definedQueueSize(output); size > 0 {
+			definedSum += size
+		} else {
+			sharingCount++
+		}
+	}
+
+	// Every output that shares the rest of the capacity needs at least one batch
+	definedCapacity := max(maxQueueSize-sharingCount, 0)
+
+	queueSizes := make(QueueSizes, len(outputs))
+	usedCapacity := 0
+	for name, output := range outputs {
+		size := definedQueueSize(output)
+		if size == 0 {
+			continue
+		}
+		if definedSum > definedCapacity {
+			size = max(size*definedCapacity/definedSum, 1)
+		}
+		queueSizes[name] = size
+		usedCapacity += size
+	}
+
+	sharedSize := maxQueueSize
+	if sharingCount > 0 {
+		sharedSize = max((maxQueueSize-usedCapacity)/sharingCount, 1)
+	}
+	for name, output := range outputs {
+		if definedQueueSize(output) == 0 {
+			queueSizes[name] = sharedSize
+		}
+	}
+
+	return queueSizes
+}
+
+func definedQueueSize(output *telemetryv1alpha1.OtlpOutput) int {
+	if output == nil || output.Delivery == nil {
+		return 0
+	}
+	return output.Delivery.QueueSize
+}
@@ -0,0 +1,74 @@
+package otlpexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+func TestPipelineOutputs(t *testing.T) {
+	primary := &telemetryv1alpha1.OtlpOutput{Endpoint: telemetryv1alpha1.ValueType{Value: "primary"}}
+	audit := &telemetryv1alpha1.OtlpOutput{Endpoint: telemetryv1alpha1.ValueType{Value: "audit"}}
+
+	require.Equal(t, map[string]*telemetryv1alpha1.OtlpOutput{"test": primary}, PipelineOutputs("test", primary, nil))
+	require.Equal(t, map[string]*telemetryv1alpha1.OtlpOutput{"test": primary, "test/audit": audit},
+		PipelineOutputs("test", primary, []telemetryv1alpha1.AdditionalOtlpOutput{{Name: "audit", Otlp: audit}}))
+	require.Empty(t, PipelineOutputs("test", nil, nil))
+}
+
+func TestMakeQueueSizes(t *testing.T) {
+	withQueueSize := func(queueSize int) *telemetryv1alpha1.OtlpOutput {
+		return &telemetryv1alpha1.OtlpOutput{Delivery: &telemetryv1alpha1.OtlpDelivery{QueueSize: queueSize}}
+	}
+
+	tests := []struct {
+		name     string
+		outputs  map[string]*telemetryv1alpha1.OtlpOutput
+		expected QueueSizes
+	}{
+		{
+			name:     "no outputs",
+			expected: QueueSizes{},
+		},
+		{
+			name:     "single output",
+			outputs:  map[string]*telemetryv1alpha1.OtlpOutput{"a": {}},
+			expected: QueueSizes{"a": 256},
+		},
+		{
+			name:     "capacity shared equally",
+			outputs:  map[string]*telemetryv1alpha1.OtlpOutput{"a": {}, "b": {}, "c": {}},
+			expected: QueueSizes{"a": 85, "b": 85, "c": 85},
+		},
+		{
+			name:     "defined queue size subtracted from the shared capacity",
+			outputs:  map[string]*telemetryv1alpha1.OtlpOutput{"a": withQueueSize(128), "b": {}, "c": {}},
+			expected: QueueSizes{"a": 128, "b": 64, "c": 64},
+		},
+		{
+			name:     "defined queue sizes exceeding the capacity",
+			outputs:  map[string]*telemetryv1alpha1.OtlpOutput{"a": withQueueSize(256), "b": withQueueSize(256)},
+			expected: QueueSizes{"a": 128, "b": 128},
+		},
+		{
+			name:     "defined queue sizes leave one batch for the other outputs",
+			outputs:  map[string]*telemetryv1alpha1.OtlpOutput{"a": withQueueSize(200), "b": withQueueSize(200), "c": {}},
+			expected: QueueSizes{"a": 127, "b": 127, "c": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queueSizes := MakeQueueSizes(tt.outputs)
+			require.Equal(t, tt.expected, queueSizes)
+
+			total := 0
+			for _, size := range queueSizes {
+				total += size
+			}
+			require.LessOrEqual(t, total, 256)
+		})
+	}
+}
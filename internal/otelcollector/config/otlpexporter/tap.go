@@ -0,0 +1,60 @@
+package otlpexporter
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+// Taps contains the settings of the tap exporters of a gateway.
+type Taps struct {
+	// Endpoints maps the names of the pipelines with an active tap to the endpoint, to which their data is sent for inspection.
+	Endpoints map[string]string
+	// CAPem is the CA certificate of the telemetry manager webhook server, which receives the tapped data.
+	CAPem string
+}
+
+// TapExporterID returns the ID of the exporter that sends the data of a pipeline with an active tap to the telemetry manager.
+// The dot cannot be part of the pipeline name extracted by the self-monitor, which drops the metrics of tap exporters.
+func TapExporterID(pipelineName string) string {
+	return fmt.Sprintf("otlphttp/tap.%s", pipelineName)
+}
+
+// MakeTapExporterConfig creates the configuration of a tap exporter sending to the given endpoint, whose certificate is signed by the given CA.
+// The tap is best effort: it uses a small in-memory queue and no retries, so that it never slows down or blocks the outputs of the pipeline.
+// The data is sent as JSON and uncompressed, which keeps the samples readable without decoding.
+func MakeTapExporterConfig(endpoint, caPem string) *config.OTLPExporter {
+	return &config.OTLPExporter{
+		Endpoint:    endpoint,
+		Encoding:    "json",
+		Compression: "none",
+		Timeout:     "5s",
+		TLS: config.TLS{
+			CAPem: caPem,
+		},
+		SendingQueue: config.SendingQueue{
+			Enabled:   true,
+			QueueSize: 8,
+		},
+		RetryOnFailure: config.RetryOnFailure{
+			Enabled: false,
+		},
+	}
+}
+
+// AddTapExporters adds a tap exporter to every pipeline with an active tap, whose ID is <signalType>/<pipeline name>.
+// The tap exporters always use an in-memory queue, so they must be added after the persistent queue has been enabled.
+func AddTapExporters[T any](exporters map[string]T, pipelines config.Pipelines, signalType string, taps Taps, makeExporter func(*config.OTLPExporter) T) {
+	for pipelineName, endpoint := range taps.Endpoints {
+		pipelineID := fmt.Sprintf("%s/%s", signalType, pipelineName)
+		pipelineConfig, found := pipelines[pipelineID]
+		if !found {
+			continue
+		}
+
+		exporterID := TapExporterID(pipelineName)
+		exporters[exporterID] = makeExporter(MakeTapExporterConfig(endpoint, taps.CAPem))
+		pipelineConfig.Exporters = append(pipelineConfig.Exporters, exporterID)
+		pipelines[pipelineID] = pipelineConfig
+	}
+}
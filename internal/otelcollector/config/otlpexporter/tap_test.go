@@ -0,0 +1,57 @@
+package otlpexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+)
+
+func TestTapExporterID(t *testing.T) {
+	require.Equal(t, "otlphttp/tap.test", TapExporterID("test"))
+}
+
+func TestMakeTapExporterConfig(t *testing.T) {
+	cfg := MakeTapExporterConfig("https://telemetry-manager-webhook.kyma-system.svc:443/tap/tracepipelines/test", "ca")
+
+	require.Equal(t, "https://telemetry-manager-webhook.kyma-system.svc:443/tap/tracepipelines/test", cfg.Endpoint)
+	require.Equal(t, "json", cfg.Encoding)
+	require.Equal(t, "none", cfg.Compression)
+	require.False(t, cfg.TLS.Insecure)
+	require.False(t, cfg.TLS.InsecureSkipVerify)
+	require.Equal(t, "ca", cfg.TLS.CAPem)
+	require.True(t, cfg.SendingQueue.Enabled)
+	require.Equal(t, 8, cfg.SendingQueue.QueueSize)
+	require.Empty(t, cfg.SendingQueue.Storage)
+	require.False(t, cfg.RetryOnFailure.Enabled)
+}
+
+func TestAddTapExporters(t *testing.T) {
+	exporters := map[string]*config.OTLPExporter{
+		"otlp/tapped": {},
+	}
+	pipelines := config.Pipelines{
+		"traces/tapped":   {Exporters: []string{"otlp/tapped"}},
+		"traces/untapped": {Exporters: []string{"otlp/untapped"}},
+	}
+	taps := Taps{
+		Endpoints: map[string]string{
+			"tapped":  "https://telemetry-manager-webhook.kyma-system.svc:443/tap/tracepipelines/tapped",
+			"missing": "https://telemetry-manager-webhook.kyma-system.svc:443/tap/tracepipelines/missing",
+		},
+		CAPem: "ca",
+	}
+
+	AddTapExporters(exporters, pipelines, "traces", taps, func(exporter *config.OTLPExporter) *config.OTLPExporter {
+		return exporter
+	})
+
+	require.Len(t, exporters, 2)
+	require.Contains(t, exporters, "otlphttp/tap.tapped")
+	require.Equal(t, "https://telemetry-manager-webhook.kyma-system.svc:443/tap/tracepipelines/tapped", exporters["otlphttp/tap.tapped"].Endpoint)
+	require.Equal(t, "ca", exporters["otlphttp/tap.tapped"].TLS.CAPem)
+	require.Equal(t, []string{"otlp/tapped", "otlphttp/tap.tapped"}, pipelines["traces/tapped"].Exporters)
+	require.Equal(t, []string{"otlp/untapped"}, pipelines["traces/untapped"].Exporters)
+	require.NotContains(t, pipelines, "traces/missing")
+}
@@ -0,0 +1,35 @@
+package ottlexpr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrEmptyCondition   = errors.New("condition must not be empty")
+	ErrInvalidCondition = errors.New("invalid condition")
+)
+
+// CheckSpanCondition parses an OTTL condition in the span context with the same functions as the filter processor of the trace gateway.
+// Next to the grammar, it resolves the paths and functions, so that a condition that passes the check does not prevent the collector from starting.
+func CheckSpanCondition(condition string) error {
+	if strings.TrimSpace(condition) == "" {
+		return ErrEmptyCondition
+	}
+
+	parser, err := ottlspan.NewParser(ottlfuncs.StandardConverters[ottlspan.TransformContext](), component.TelemetrySettings{Logger: zap.NewNop()})
+	if err != nil {
+		return fmt.Errorf("failed to create OTTL parser: %w", err)
+	}
+
+	if _, err := parser.ParseCondition(condition); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCondition, err)
+	}
+	return nil
+}
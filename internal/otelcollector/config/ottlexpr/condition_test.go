@@ -0,0 +1,79 @@
+package ottlexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSpanCondition(t *testing.T) {
+	tests := []struct {
+		name        string
+		condition   string
+		expectedErr error
+	}{
+		{
+			name:      "valid comparison",
+			condition: `attributes["http.route"] == "/healthz"`,
+		},
+		{
+			name:      "valid function call with escaped quotes and parentheses",
+			condition: `IsMatch(attributes["http.url"], "\"(ready|live)\"") == true`,
+		},
+		{
+			name:      "valid nested expression",
+			condition: `not(resource.attributes["k8s.namespace.name"] == "default" and (name == "a" or name == "b"))`,
+		},
+		{
+			name:        "empty",
+			condition:   "  ",
+			expectedErr: ErrEmptyCondition,
+		},
+		{
+			name:        "no expression",
+			condition:   `foo bar baz`,
+			expectedErr: ErrInvalidCondition,
+		},
+		{
+			name:        "incomplete comparison",
+			condition:   `attributes["x"] ==`,
+			expectedErr: ErrInvalidCondition,
+		},
+		{
+			name:        "unterminated string",
+			condition:   `attributes["http.route"] == "/healthz`,
+			expectedErr: ErrInvalidCondition,
+		},
+		{
+			name:        "unclosed parenthesis",
+			condition:   `IsMatch(name, "health.*"`,
+			expectedErr: ErrInvalidCondition,
+		},
+		{
+			name:        "unknown path",
+			condition:   `foo.bar == "a"`,
+			expectedErr: ErrInvalidCondition,
+		},
+		{
+			name:        "unknown function",
+			condition:   `IsHealthCheck(name)`,
+			expectedErr: ErrInvalidCondition,
+		},
+		{
+			name:        "editor function",
+			condition:   `set(name, "a")`,
+			expectedErr: ErrInvalidCondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSpanCondition(tt.condition)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -5,6 +5,11 @@ import (
 	"strings"
 )
 
+// EscapeString escapes a value, so that it can be embedded in a double-quoted OTTL string literal.
+func EscapeString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
 func NamespaceEquals(name string) string {
 	return ResourceAttributeEquals("k8s.namespace.name", name)
 }
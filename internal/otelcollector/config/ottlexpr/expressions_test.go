@@ -0,0 +1,14 @@
+package ottlexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeString(t *testing.T) {
+	require.Equal(t, `plain`, EscapeString(`plain`))
+	require.Equal(t, `say \"hi\"`, EscapeString(`say "hi"`))
+	require.Equal(t, `C:\\temp`, EscapeString(`C:\temp`))
+	require.Equal(t, `\\\"`, EscapeString(`\"`))
+}
@@ -0,0 +1,39 @@
+package ottlexpr
+
+import (
+	"fmt"
+)
+
+// SetAttribute returns a statement that assigns the given string value to an attribute.
+func SetAttribute(key, value string) string {
+	return fmt.Sprintf("set(%s, \"%s\")", attribute(key), EscapeString(value))
+}
+
+// DeleteAttribute returns a statement that removes an attribute.
+func DeleteAttribute(key string) string {
+	return fmt.Sprintf("delete_key(attributes, \"%s\")", EscapeString(key))
+}
+
+// RenameAttribute returns the statements that move an attribute to a new key. Existing values of the new key are overwritten.
+func RenameAttribute(key, newKey string) []string {
+	return []string{
+		fmt.Sprintf("set(%s, %s) where %s != nil", attribute(newKey), attribute(key), attribute(key)),
+		DeleteAttribute(key),
+	}
+}
+
+// HashAttribute returns a statement that replaces the value of an attribute by its SHA-256 hash.
+// The hash is not salted, so it only pseudonymizes the value: values from a small set can be recovered by hashing candidates.
+func HashAttribute(key string) string {
+	return fmt.Sprintf("set(%s, SHA256(%s)) where %s != nil", attribute(key), attribute(key), attribute(key))
+}
+
+// TruncateAttribute returns a statement that shortens the value of an attribute to the given number of characters.
+func TruncateAttribute(key string, limit int64) string {
+	return fmt.Sprintf("set(%s, Substring(%s, 0, %d)) where %s != nil and Len(%s) > %d",
+		attribute(key), attribute(key), limit, attribute(key), attribute(key), limit)
+}
+
+func attribute(key string) string {
+	return fmt.Sprintf("attributes[\"%s\"]", EscapeString(key))
+}
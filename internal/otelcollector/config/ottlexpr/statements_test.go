@@ -0,0 +1,36 @@
+package ottlexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatements(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		require.Equal(t, `set(attributes["env"], "prod")`, SetAttribute("env", "prod"))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.Equal(t, `delete_key(attributes, "user.email")`, DeleteAttribute("user.email"))
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		require.Equal(t, []string{
+			`set(attributes["http.target"], attributes["http.url"]) where attributes["http.url"] != nil`,
+			`delete_key(attributes, "http.url")`,
+		}, RenameAttribute("http.url", "http.target"))
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		require.Equal(t, `set(attributes["user.id"], SHA256(attributes["user.id"])) where attributes["user.id"] != nil`, HashAttribute("user.id"))
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		require.Equal(t, `set(attributes["db.statement"], Substring(attributes["db.statement"], 0, 64)) where attributes["db.statement"] != nil and Len(attributes["db.statement"]) > 64`, TruncateAttribute("db.statement", 64))
+	})
+
+	t.Run("escapes string literals", func(t *testing.T) {
+		require.Equal(t, `set(attributes["a\"b"], "c\\d")`, SetAttribute(`a"b`, `c\d`))
+	})
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// FileStorageExtensionID is the ID of the extension that persists the sending queues of exporters.
+	FileStorageExtensionID = "file_storage"
+	// FileStorageDirectory is the path at which the volume backing the file_storage extension is mounted.
+	FileStorageDirectory = "/var/lib/otelcol/file_storage"
+
+	// estimatedItemBytes is the assumed size of a span, metric data point, or log record in a persisted batch.
+	estimatedItemBytes = 1024
+)
+
+// PersistentQueue defines the volume on which a gateway replica stores the sending queues of its exporters.
+type PersistentQueue struct {
+	Enabled    bool
+	VolumeSize resource.Quantity
+}
+
+// EnablePersistentQueue stores the given sending queues with the file_storage extension instead of in memory, so that buffered data survives restarts of the gateway.
+// The file storage itself is not limited, so the queues are reduced until the batches fit into half of the volume, assuming that the batches are full.
+// The other half is left for the compaction, which copies the data to a new file. The compaction also uses the volume, because the root file system of the collector is read-only.
+func EnablePersistentQueue(base *Base, queues []*SendingQueue, pq PersistentQueue, maxBatchSize int) {
+	base.Extensions.FileStorage = &FileStorageExtension{
+		Directory: FileStorageDirectory,
+		Compaction: FileStorageCompaction{
+			Directory: FileStorageDirectory,
+			OnStart:   true,
+			OnRebound: true,
+		},
+	}
+	base.Service.Extensions = append(base.Service.Extensions, FileStorageExtensionID)
+
+	totalBatches := 0
+	for _, queue := range queues {
+		queue.Storage = FileStorageExtensionID
+		totalBatches += queue.QueueSize
+	}
+
+	maxBatches := int(pq.VolumeSize.Value() / 2 / int64(maxBatchSize*estimatedItemBytes))
+	if totalBatches <= maxBatches {
+		return
+	}
+	for _, queue := range queues {
+		queue.QueueSize = max(queue.QueueSize*maxBatches/totalBatches, 1)
+	}
+}
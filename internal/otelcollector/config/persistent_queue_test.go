@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestEnablePersistentQueue(t *testing.T) {
+	t.Run("queues fit into the volume", func(t *testing.T) {
+		base := Base{Extensions: DefaultExtensions(), Service: DefaultService(make(Pipelines))}
+		queues := []*SendingQueue{{Enabled: true, QueueSize: 128}, {Enabled: true, QueueSize: 128}}
+
+		EnablePersistentQueue(&base, queues, PersistentQueue{Enabled: true, VolumeSize: resource.MustParse("1Gi")}, 512)
+
+		require.NotNil(t, base.Extensions.FileStorage)
+		require.Equal(t, "/var/lib/otelcol/file_storage", base.Extensions.FileStorage.Directory)
+		require.Equal(t, "/var/lib/otelcol/file_storage", base.Extensions.FileStorage.Compaction.Directory)
+		require.Contains(t, base.Service.Extensions, "file_storage")
+		for _, queue := range queues {
+			require.Equal(t, "file_storage", queue.Storage)
+			require.Equal(t, 128, queue.QueueSize)
+		}
+	})
+
+	t.Run("queues reduced to half of the volume", func(t *testing.T) {
+		base := Base{Extensions: DefaultExtensions(), Service: DefaultService(make(Pipelines))}
+		queues := []*SendingQueue{{Enabled: true, QueueSize: 192}, {Enabled: true, QueueSize: 64}}
+
+		// 100Mi with batches of 512 KiB leave room for 100 persisted batches
+		EnablePersistentQueue(&base, queues, PersistentQueue{Enabled: true, VolumeSize: resource.MustParse("100Mi")}, 512)
+
+		require.Equal(t, 75, queues[0].QueueSize)
+		require.Equal(t, 25, queues[1].QueueSize)
+	})
+}
@@ -10,10 +10,12 @@ type Config struct {
 	Receivers  Receivers  `yaml:"receivers"`
 	Processors Processors `yaml:"processors"`
 	Exporters  Exporters  `yaml:"exporters"`
+	Connectors Connectors `yaml:"connectors,omitempty"`
 }
 
 type Receivers struct {
-	OTLP config.OTLPReceiver `yaml:"otlp"`
+	OTLP             config.OTLPReceiver  `yaml:"otlp"`
+	OTLPLoadBalanced *config.OTLPReceiver `yaml:"otlp/load-balanced,omitempty"`
 }
 
 type Processors struct {
@@ -24,10 +26,44 @@ type Processors struct {
 	DropNoisySpans     FilterProcessor                `yaml:"filter/drop-noisy-spans"`
 	ResolveServiceName *TransformProcessor            `yaml:"transform/resolve-service-name,omitempty"`
 	DropKymaAttributes *config.ResourceProcessor      `yaml:"resource/drop-kyma-attributes,omitempty"`
+
+	LoadBalancingFilter *FilterProcessor `yaml:"filter/load-balancing,omitempty"`
+
+	SpanMetricsFilter                  *FilterProcessor    `yaml:"filter/span-metrics,omitempty"`
+	SetInstrumentationScopeSpanMetrics *TransformProcessor `yaml:"transform/set-instrumentation-scope-spanmetrics,omitempty"`
+
+	// Dynamic contains processors, which need different configurations per pipeline, such as namespace filters, user-defined filters and transforms, and samplers
+	Dynamic DynamicProcessors `yaml:",inline,omitempty"`
+}
+
+type DynamicProcessors map[string]Processor
+
+type Processor struct {
+	Filter               *FilterProcessor               `yaml:",inline,omitempty"`
+	ProbabilisticSampler *ProbabilisticSamplerProcessor `yaml:",inline,omitempty"`
+	TailSampling         *TailSamplingProcessor         `yaml:",inline,omitempty"`
+	Transform            *TransformProcessor            `yaml:",inline,omitempty"`
+}
+
+// MarshalYAML renders only the configured processor, since the processor types share keys such as error_mode, which cannot be inlined together.
+func (p Processor) MarshalYAML() (any, error) {
+	switch {
+	case p.Filter != nil:
+		return p.Filter, nil
+	case p.ProbabilisticSampler != nil:
+		return p.ProbabilisticSampler, nil
+	case p.TailSampling != nil:
+		return p.TailSampling, nil
+	case p.Transform != nil:
+		return p.Transform, nil
+	default:
+		return struct{}{}, nil
+	}
 }
 
 type FilterProcessor struct {
-	Traces Traces `yaml:"traces"`
+	ErrorMode string `yaml:"error_mode,omitempty"`
+	Traces    Traces `yaml:"traces"`
 }
 
 type Traces struct {
@@ -35,12 +71,88 @@ type Traces struct {
 }
 
 type TransformProcessor struct {
-	ErrorMode       string                                `yaml:"error_mode"`
-	TraceStatements []config.TransformProcessorStatements `yaml:"trace_statements"`
+	ErrorMode        string                                `yaml:"error_mode"`
+	TraceStatements  []config.TransformProcessorStatements `yaml:"trace_statements,omitempty"`
+	MetricStatements []config.TransformProcessorStatements `yaml:"metric_statements,omitempty"`
+}
+
+type ProbabilisticSamplerProcessor struct {
+	SamplingPercentage float64 `yaml:"sampling_percentage"`
+}
+
+type TailSamplingProcessor struct {
+	DecisionWait string               `yaml:"decision_wait"`
+	Policies     []TailSamplingPolicy `yaml:"policies"`
+}
+
+type TailSamplingPolicy struct {
+	Name            string                       `yaml:"name"`
+	Type            string                       `yaml:"type"`
+	StatusCode      *StatusCodePolicyConfig      `yaml:"status_code,omitempty"`
+	Latency         *LatencyPolicyConfig         `yaml:"latency,omitempty"`
+	StringAttribute *StringAttributePolicyConfig `yaml:"string_attribute,omitempty"`
+}
+
+type StatusCodePolicyConfig struct {
+	StatusCodes []string `yaml:"status_codes"`
+}
+
+type LatencyPolicyConfig struct {
+	ThresholdMs int64 `yaml:"threshold_ms"`
+}
+
+type StringAttributePolicyConfig struct {
+	Key    string   `yaml:"key"`
+	Values []string `yaml:"values"`
+}
+
+type Connectors struct {
+	SpanMetrics  *SpanMetricsConnector  `yaml:"spanmetrics,omitempty"`
+	ServiceGraph *ServiceGraphConnector `yaml:"servicegraph,omitempty"`
+}
+
+type SpanMetricsConnector struct {
+	Dimensions           []Dimension `yaml:"dimensions,omitempty"`
+	MetricsFlushInterval string      `yaml:"metrics_flush_interval"`
+}
+
+type ServiceGraphConnector struct {
+	Dimensions           []string          `yaml:"dimensions,omitempty"`
+	Store                ServiceGraphStore `yaml:"store"`
+	MetricsFlushInterval string            `yaml:"metrics_flush_interval"`
+}
+
+type Dimension struct {
+	Name string `yaml:"name"`
+}
+
+type ServiceGraphStore struct {
+	TTL      string `yaml:"ttl"`
+	MaxItems int    `yaml:"max_items"`
 }
 
 type Exporters map[string]Exporter
 
 type Exporter struct {
-	OTLP *config.OTLPExporter `yaml:",inline,omitempty"`
+	OTLP          *config.OTLPExporter   `yaml:",inline,omitempty"`
+	LoadBalancing *LoadBalancingExporter `yaml:",inline,omitempty"`
+}
+
+type LoadBalancingExporter struct {
+	RoutingKey string                `yaml:"routing_key"`
+	Protocol   LoadBalancingProtocol `yaml:"protocol"`
+	Resolver   LoadBalancingResolver `yaml:"resolver"`
+}
+
+type LoadBalancingProtocol struct {
+	OTLP config.OTLPExporter `yaml:"otlp"`
+}
+
+type LoadBalancingResolver struct {
+	DNS LoadBalancingDNSResolver `yaml:"dns"`
+}
+
+type LoadBalancingDNSResolver struct {
+	Hostname string `yaml:"hostname"`
+	Port     string `yaml:"port"`
 }
@@ -6,15 +6,37 @@ import (
 	"maps"
 	"sort"
 
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/ottlexpr"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 )
 
-func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.TracePipeline) (*Config, otlpexporter.EnvVars, error) {
+const (
+	loadBalancingPipelineID = "traces/load-balancing"
+	loadBalancingExporterID = "loadbalancing"
+	loadBalancedReceiverID  = "otlp/load-balanced"
+	loadBalancingFilterID   = "filter/load-balancing"
+)
+
+// BuildOptions contains the settings of the trace gateway deployment, which the collector configuration depends on.
+type BuildOptions struct {
+	// LoadBalancingServiceName is the headless Service resolving to all gateway replicas.
+	// It is used to route all spans of a trace to the same replica if tail sampling is configured.
+	LoadBalancingServiceName types.NamespacedName
+	// PersistentQueue stores the sending queues of the exporters on a volume instead of in memory.
+	PersistentQueue config.PersistentQueue
+	// MetricGatewayServiceName is the OTLP Service of the metric gateway, which receives the span metrics.
+	MetricGatewayServiceName types.NamespacedName
+	// SpanMetricsCollected is true if a MetricPipeline collects the span metrics. Otherwise, no span metrics are generated.
+	SpanMetricsCollected bool
+}
+
+func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alpha1.TracePipeline, opts BuildOptions) (*Config, otlpexporter.EnvVars, error) {
 	cfg := &Config{
 		Base: config.Base{
 			Service:    config.DefaultService(make(config.Pipelines)),
@@ -26,7 +48,14 @@ func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alp
 	}
 
 	envVars := make(otlpexporter.EnvVars)
-	queueSize := 256 / len(pipelines)
+	outputs := make(map[string]*telemetryv1alpha1.OtlpOutput)
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp != nil {
+			continue
+		}
+		maps.Copy(outputs, otlpexporter.PipelineOutputs(pipelines[i].Name, pipelines[i].Spec.Output.Otlp, pipelines[i].Spec.AdditionalOutputs))
+	}
+	queueSizes := otlpexporter.MakeQueueSizes(outputs)
 
 	for i := range pipelines {
 		pipeline := pipelines[i]
@@ -34,12 +63,24 @@ func MakeConfig(ctx context.Context, c client.Reader, pipelines []telemetryv1alp
 			continue
 		}
 
-		otlpExporterBuilder := otlpexporter.NewConfigBuilder(c, pipeline.Spec.Output.Otlp, pipeline.Name, queueSize, otlpexporter.SignalTypeTrace)
-		if err := addComponentsForTracePipeline(ctx, otlpExporterBuilder, &pipeline, cfg, envVars); err != nil {
+		otlpExporterBuilders := otlpexporter.NewConfigBuilders(c, pipeline.Spec.Output.Otlp, pipeline.Spec.AdditionalOutputs, pipeline.Name, queueSizes, otlpexporter.SignalTypeTrace)
+		if err := addComponentsForTracePipeline(ctx, otlpExporterBuilders, &pipeline, cfg, envVars); err != nil {
 			return nil, nil, err
 		}
 	}
 
+	if RequiresLoadBalancing(pipelines, opts.SpanMetricsCollected) {
+		addComponentsForLoadBalancing(cfg, pipelines, opts)
+	}
+
+	if requiresSpanMetrics(pipelines, opts.SpanMetricsCollected) {
+		addComponentsForSpanMetrics(cfg, pipelines, opts.MetricGatewayServiceName)
+	}
+
+	if opts.PersistentQueue.Enabled {
+		config.EnablePersistentQueue(&cfg.Base, sendingQueues(cfg), opts.PersistentQueue, cfg.Processors.Batch.SendBatchMaxSize)
+	}
+
 	return cfg, envVars, nil
 }
 
@@ -59,36 +100,293 @@ func makeReceiversConfig() Receivers {
 }
 
 // addComponentsForTracePipeline enriches a Config (exporters, processors, etc.) with components for a given telemetryv1alpha1.TracePipeline.
-func addComponentsForTracePipeline(ctx context.Context, otlpExporterBuilder *otlpexporter.ConfigBuilder, pipeline *telemetryv1alpha1.TracePipeline, cfg *Config, envVars otlpexporter.EnvVars) error {
-	otlpExporterConfig, otlpExporterEnvVars, err := otlpExporterBuilder.MakeConfig(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to make otlp exporter config: %w", err)
-	}
+func addComponentsForTracePipeline(ctx context.Context, otlpExporterBuilders []*otlpexporter.ConfigBuilder, pipeline *telemetryv1alpha1.TracePipeline, cfg *Config, envVars otlpexporter.EnvVars) error {
+	var otlpExporterIDs []string
+	for _, otlpExporterBuilder := range otlpExporterBuilders {
+		otlpExporterConfig, otlpExporterEnvVars, err := otlpExporterBuilder.MakeConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to make otlp exporter config: %w", err)
+		}
 
-	maps.Copy(envVars, otlpExporterEnvVars)
+		maps.Copy(envVars, otlpExporterEnvVars)
 
-	otlpExporterID := otlpexporter.ExporterID(pipeline.Spec.Output.Otlp.Protocol, pipeline.Name)
-	cfg.Exporters[otlpExporterID] = Exporter{OTLP: otlpExporterConfig}
+		if authExtensionID, authExtensionConfig := otlpExporterBuilder.MakeAuthExtensionConfig(); authExtensionConfig != nil {
+			cfg.AddExtension(authExtensionID, *authExtensionConfig)
+		}
+
+		otlpExporterID := otlpExporterBuilder.ExporterID()
+		cfg.Exporters[otlpExporterID] = Exporter{OTLP: otlpExporterConfig}
+		otlpExporterIDs = append(otlpExporterIDs, otlpExporterID)
+	}
+
+	declareNamespaceFilters(pipeline, cfg)
+	declareUserDefinedFilters(pipeline, cfg)
+	declareUserDefinedTransforms(pipeline, cfg)
+	if err := declareSamplers(pipeline, cfg); err != nil {
+		return fmt.Errorf("failed to make sampling processor config: %w", err)
+	}
 
 	pipelineID := fmt.Sprintf("traces/%s", pipeline.Name)
-	cfg.Service.Pipelines[pipelineID] = makePipelineConfig(otlpExporterID)
+	cfg.Service.Pipelines[pipelineID] = makePipelineConfig(pipeline, otlpExporterIDs...)
+
+	return nil
+}
+
+// addComponentsForLoadBalancing adds a pipeline, which enriches the incoming spans with Kubernetes metadata and routes them
+// by trace ID to one of the gateway replicas. Pipelines with tail sampling and the span metrics pipeline receive the spans from there,
+// so that all spans of a trace are processed by the same replica. Pipelines without tail sampling receive the spans directly.
+func addComponentsForLoadBalancing(cfg *Config, pipelines []telemetryv1alpha1.TracePipeline, opts BuildOptions) {
+	cfg.Receivers.OTLPLoadBalanced = &config.OTLPReceiver{
+		Protocols: config.ReceiverProtocols{
+			GRPC: config.Endpoint{
+				Endpoint: fmt.Sprintf("${%s}:%d", config.EnvVarCurrentPodIP, ports.OTLPGRPCLoadBalanced),
+			},
+		},
+	}
+
+	cfg.Exporters[loadBalancingExporterID] = Exporter{LoadBalancing: makeLoadBalancingExporterConfig(opts.LoadBalancingServiceName)}
+
+	receivesLoadBalancedSpans := func(pipeline *telemetryv1alpha1.TracePipeline) bool {
+		return isTailSamplingEnabled(pipeline) || (opts.SpanMetricsCollected && isSpanMetricsEnabled(pipeline))
+	}
+
+	// The namespace filter must run after k8sattributes, which sets the namespace of spans that arrive without it
+	processors := []string{"memory_limiter", "k8sattributes"}
+	if filter := makeUnselectedNamespacesFilterConfig(pipelines, receivesLoadBalancedSpans); filter != nil {
+		cfg.Processors.LoadBalancingFilter = filter
+		processors = append(processors, loadBalancingFilterID)
+	}
+
+	cfg.Service.Pipelines[loadBalancingPipelineID] = config.Pipeline{
+		Receivers:  []string{"otlp"},
+		Processors: processors,
+		Exporters:  []string{loadBalancingExporterID},
+	}
+}
+
+func makeLoadBalancingExporterConfig(serviceName types.NamespacedName) *LoadBalancingExporter {
+	return &LoadBalancingExporter{
+		RoutingKey: "traceID",
+		Protocol: LoadBalancingProtocol{
+			OTLP: config.OTLPExporter{
+				TLS: config.TLS{
+					Insecure: true,
+				},
+				SendingQueue: config.SendingQueue{
+					Enabled:   true,
+					QueueSize: 256,
+				},
+				RetryOnFailure: config.RetryOnFailure{
+					Enabled:         true,
+					InitialInterval: "5s",
+					MaxInterval:     "30s",
+					MaxElapsedTime:  "300s",
+				},
+			},
+		},
+		Resolver: LoadBalancingResolver{
+			DNS: LoadBalancingDNSResolver{
+				Hostname: fmt.Sprintf("%s.%s.svc.cluster.local", serviceName.Name, serviceName.Namespace),
+				Port:     fmt.Sprintf("%d", ports.OTLPGRPCLoadBalanced),
+			},
+		},
+	}
+}
+
+// makeUnselectedNamespacesFilterConfig drops the spans that none of the selected pipelines is interested in.
+// It is used to let only those spans take the extra hop to the pipelines receiving load-balanced spans. A span is dropped
+// if it is dropped by the namespace selectors of all selected pipelines. It returns nil if one of them consumes the spans of all namespaces.
+func makeUnselectedNamespacesFilterConfig(pipelines []telemetryv1alpha1.TracePipeline, selected func(*telemetryv1alpha1.TracePipeline) bool) *FilterProcessor {
+	var dropConditions []string
+	for i := range pipelines {
+		pipeline := &pipelines[i]
+		if pipeline.DeletionTimestamp != nil || !selected(pipeline) {
+			continue
+		}
+
+		namespaceSelector := otlpInputNamespaces(pipeline.Spec.Input)
+		if !shouldFilterByNamespace(namespaceSelector) {
+			return nil
+		}
+		dropConditions = append(dropConditions, ottlexpr.JoinWithOr(makeFilterByNamespaceConfig(namespaceSelector).Traces.Span...))
+	}
+
+	if len(dropConditions) == 0 {
+		return nil
+	}
+
+	return &FilterProcessor{
+		Traces: Traces{
+			Span: []string{ottlexpr.JoinWithAnd(dropConditions...)},
+		},
+	}
+}
+
+func declareNamespaceFilters(pipeline *telemetryv1alpha1.TracePipeline, cfg *Config) {
+	if cfg.Processors.Dynamic == nil {
+		cfg.Processors.Dynamic = make(DynamicProcessors)
+	}
+
+	if namespaceSelector := otlpInputNamespaces(pipeline.Spec.Input); shouldFilterByNamespace(namespaceSelector) {
+		processorID := makeNamespaceFilterID(pipeline.Name)
+		cfg.Processors.Dynamic[processorID] = Processor{Filter: makeFilterByNamespaceConfig(namespaceSelector)}
+	}
+}
+
+func declareUserDefinedFilters(pipeline *telemetryv1alpha1.TracePipeline, cfg *Config) {
+	if cfg.Processors.Dynamic == nil {
+		cfg.Processors.Dynamic = make(DynamicProcessors)
+	}
+
+	if len(pipeline.Spec.Filters) > 0 {
+		cfg.Processors.Dynamic[makeUserDefinedFilterID(pipeline.Name)] = Processor{Filter: makeUserDefinedFilterConfig(pipeline.Spec.Filters)}
+	}
+}
+
+func declareUserDefinedTransforms(pipeline *telemetryv1alpha1.TracePipeline, cfg *Config) {
+	if cfg.Processors.Dynamic == nil {
+		cfg.Processors.Dynamic = make(DynamicProcessors)
+	}
+
+	if len(pipeline.Spec.Transforms) > 0 {
+		cfg.Processors.Dynamic[makeUserDefinedTransformID(pipeline.Name)] = Processor{Transform: makeUserDefinedTransformConfig(pipeline.Spec.Transforms)}
+	}
+}
+
+func declareSamplers(pipeline *telemetryv1alpha1.TracePipeline, cfg *Config) error {
+	if cfg.Processors.Dynamic == nil {
+		cfg.Processors.Dynamic = make(DynamicProcessors)
+	}
+
+	if isProbabilisticSamplingEnabled(pipeline) {
+		samplerConfig, err := makeProbabilisticSamplerConfig(pipeline.Spec.Sampling.Probabilistic)
+		if err != nil {
+			return err
+		}
+		cfg.Processors.Dynamic[makeProbabilisticSamplerID(pipeline.Name)] = Processor{ProbabilisticSampler: samplerConfig}
+	}
+
+	if isTailSamplingEnabled(pipeline) {
+		cfg.Processors.Dynamic[makeTailSamplingID(pipeline.Name)] = Processor{TailSampling: makeTailSamplingConfig(pipeline.Spec.Sampling.Tail)}
+	}
 
 	return nil
 }
 
-func makePipelineConfig(exporterIDs ...string) config.Pipeline {
+func makePipelineConfig(pipeline *telemetryv1alpha1.TracePipeline, exporterIDs ...string) config.Pipeline {
 	sort.Strings(exporterIDs)
 
+	receivers := []string{"otlp"}
+	processors := []string{"memory_limiter", "k8sattributes", "filter/drop-noisy-spans"}
+
+	if isTailSamplingEnabled(pipeline) {
+		// Spans of tail-sampled pipelines have already been enriched by the load balancing pipeline
+		receivers = []string{loadBalancedReceiverID}
+		processors = []string{"memory_limiter", "filter/drop-noisy-spans"}
+	}
+
+	if shouldFilterByNamespace(otlpInputNamespaces(pipeline.Spec.Input)) {
+		processors = append(processors, makeNamespaceFilterID(pipeline.Name))
+	}
+
+	if len(pipeline.Spec.Filters) > 0 {
+		processors = append(processors, makeUserDefinedFilterID(pipeline.Name))
+	}
+
+	processors = append(processors,
+		"resource/insert-cluster-name",
+		"transform/resolve-service-name",
+		"resource/drop-kyma-attributes",
+	)
+
+	if isProbabilisticSamplingEnabled(pipeline) {
+		processors = append(processors, makeProbabilisticSamplerID(pipeline.Name))
+	}
+
+	if isTailSamplingEnabled(pipeline) {
+		processors = append(processors, makeTailSamplingID(pipeline.Name))
+	}
+
+	if len(pipeline.Spec.Transforms) > 0 {
+		processors = append(processors, makeUserDefinedTransformID(pipeline.Name))
+	}
+
+	processors = append(processors, "batch")
+
 	return config.Pipeline{
-		Receivers: []string{"otlp"},
-		Processors: []string{"memory_limiter",
-			"k8sattributes",
-			"filter/drop-noisy-spans",
-			"resource/insert-cluster-name",
-			"transform/resolve-service-name",
-			"resource/drop-kyma-attributes",
-			"batch",
-		},
-		Exporters: exporterIDs,
+		Receivers:  receivers,
+		Processors: processors,
+		Exporters:  exporterIDs,
+	}
+}
+
+// RequiresLoadBalancing returns true if spans must be routed by trace ID between the gateway replicas, which requires a headless Service.
+// Span metrics only require it if a MetricPipeline collects them.
+func RequiresLoadBalancing(pipelines []telemetryv1alpha1.TracePipeline, spanMetricsCollected bool) bool {
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp == nil && isTailSamplingEnabled(&pipelines[i]) {
+			return true
+		}
+	}
+	return requiresSpanMetrics(pipelines, spanMetricsCollected)
+}
+
+func otlpInputNamespaces(input telemetryv1alpha1.TracePipelineInput) *telemetryv1alpha1.TracePipelineInputNamespaceSelector {
+	if input.Otlp == nil {
+		return nil
+	}
+	return input.Otlp.Namespaces
+}
+
+func shouldFilterByNamespace(namespaceSelector *telemetryv1alpha1.TracePipelineInputNamespaceSelector) bool {
+	return namespaceSelector != nil && (len(namespaceSelector.Include) > 0 || len(namespaceSelector.Exclude) > 0)
+}
+
+func isProbabilisticSamplingEnabled(pipeline *telemetryv1alpha1.TracePipeline) bool {
+	return pipeline.Spec.Sampling != nil && pipeline.Spec.Sampling.Probabilistic != nil
+}
+
+func isTailSamplingEnabled(pipeline *telemetryv1alpha1.TracePipeline) bool {
+	return pipeline.Spec.Sampling != nil && pipeline.Spec.Sampling.Tail != nil
+}
+
+func makeNamespaceFilterID(pipelineName string) string {
+	return fmt.Sprintf("filter/%s-filter-by-namespace", pipelineName)
+}
+
+func makeUserDefinedFilterID(pipelineName string) string {
+	return fmt.Sprintf("filter/%s-user-defined-filters", pipelineName)
+}
+
+func makeUserDefinedTransformID(pipelineName string) string {
+	return fmt.Sprintf("transform/%s-user-defined-transforms", pipelineName)
+}
+
+func makeProbabilisticSamplerID(pipelineName string) string {
+	return fmt.Sprintf("probabilistic_sampler/%s", pipelineName)
+}
+
+func makeTailSamplingID(pipelineName string) string {
+	return fmt.Sprintf("tail_sampling/%s", pipelineName)
+}
+
+// sendingQueues returns the sending queues of all OTLP and load balancing exporters, which are persisted if the persistent queue is enabled.
+func sendingQueues(cfg *Config) []*config.SendingQueue {
+	var queues []*config.SendingQueue
+	for _, exporter := range cfg.Exporters {
+		if exporter.OTLP != nil {
+			queues = append(queues, &exporter.OTLP.SendingQueue)
+		}
+		if exporter.LoadBalancing != nil {
+			queues = append(queues, &exporter.LoadBalancing.Protocol.OTLP.SendingQueue)
+		}
 	}
+	return queues
+}
+
+// AddTapExporters adds an exporter to every pipeline with an active tap, which sends the data of the pipeline to the telemetry manager for inspection.
+func AddTapExporters(cfg *Config, taps otlpexporter.Taps) {
+	otlpexporter.AddTapExporters(cfg.Exporters, cfg.Service.Pipelines, "traces", taps, func(exporter *config.OTLPExporter) Exporter {
+		return Exporter{OTLP: exporter}
+	})
 }
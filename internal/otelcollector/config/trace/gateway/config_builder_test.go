@@ -7,12 +7,18 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
 	"github.com/kyma-project/telemetry-manager/internal/testutils"
 )
 
@@ -23,7 +29,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("otlp exporter endpoint", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test").WithOTLPOutput(testutils.OTLPEndpoint("http://localhost")).Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 
 		expectedEndpoint := fmt.Sprintf("${%s}", "OTLP_ENDPOINT_TEST")
@@ -37,7 +43,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("secure", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test")
 
@@ -47,7 +53,7 @@ func TestMakeConfig(t *testing.T) {
 
 	t.Run("insecure", func(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
-			testutils.NewTracePipelineBuilder().WithName("test-insecure").WithOTLPOutput(testutils.OTLPEndpoint("http://localhost")).Build()},
+			testutils.NewTracePipelineBuilder().WithName("test-insecure").WithOTLPOutput(testutils.OTLPEndpoint("http://localhost")).Build()}, BuildOptions{},
 		)
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-insecure")
@@ -59,7 +65,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("basic auth", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test-basic-auth").WithOTLPOutput(testutils.OTLPBasicAuth("user", "password")).Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-basic-auth")
 
@@ -77,7 +83,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("custom header", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test-custom-header").WithOTLPOutput(testutils.OTLPCustomHeader("Authorization", "TOKEN_VALUE", "Api-Token")).Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-custom-header")
 
@@ -94,7 +100,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("mtls", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test-mtls").WithOTLPOutput(testutils.OTLPClientTLS("ca", "cert", "key")).Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 		require.Contains(t, collectorConfig.Exporters, "otlp/test-mtls")
 
@@ -110,7 +116,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("extensions", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.NotEmpty(t, collectorConfig.Extensions.HealthCheck.Endpoint)
@@ -120,7 +126,7 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("telemetry", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "info", collectorConfig.Service.Telemetry.Logs.Level)
@@ -129,8 +135,21 @@ func TestMakeConfig(t *testing.T) {
 	})
 
 	t.Run("single pipeline queue size", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()}, BuildOptions{})
+		require.NoError(t, err)
+		require.Equal(t, 256, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.QueueSize, "Pipeline should have the full queue size")
+	})
+
+	t.Run("queue size not shared with deleted pipelines", func(t *testing.T) {
+		deletedPipeline := testutils.NewTracePipelineBuilder().WithName("deleted").Build()
+		deletedPipeline.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").Build(),
+			deletedPipeline}, BuildOptions{},
+		)
 		require.NoError(t, err)
+		require.NotContains(t, collectorConfig.Exporters, "otlp/deleted")
 		require.Equal(t, 256, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.QueueSize, "Pipeline should have the full queue size")
 	})
 
@@ -138,7 +157,7 @@ func TestMakeConfig(t *testing.T) {
 		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test-1").Build(),
 			testutils.NewTracePipelineBuilder().WithName("test-2").Build(),
-			testutils.NewTracePipelineBuilder().WithName("test-3").Build()},
+			testutils.NewTracePipelineBuilder().WithName("test-3").Build()}, BuildOptions{},
 		)
 		require.NoError(t, err)
 		require.Equal(t, 85, collectorConfig.Exporters["otlp/test-1"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
@@ -146,8 +165,85 @@ func TestMakeConfig(t *testing.T) {
 		require.Equal(t, 85, collectorConfig.Exporters["otlp/test-3"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of pipelines")
 	})
 
+	t.Run("persistent queue", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithAdditionalOTLPOutput("audit").Build(),
+		}, BuildOptions{PersistentQueue: config.PersistentQueue{Enabled: true, VolumeSize: resource.MustParse("1Gi")}})
+		require.NoError(t, err)
+
+		require.NotNil(t, collectorConfig.Extensions.FileStorage)
+		require.Equal(t, "/var/lib/otelcol/file_storage", collectorConfig.Extensions.FileStorage.Directory)
+		require.Equal(t, "/var/lib/otelcol/file_storage", collectorConfig.Extensions.FileStorage.Compaction.Directory)
+		require.Contains(t, collectorConfig.Service.Extensions, "file_storage")
+
+		require.Equal(t, "file_storage", collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.Storage)
+		require.Equal(t, "file_storage", collectorConfig.Exporters["otlp/test/audit"].OTLP.SendingQueue.Storage)
+	})
+
+	t.Run("in-memory queue by default", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Nil(t, collectorConfig.Extensions.FileStorage)
+		require.NotContains(t, collectorConfig.Service.Extensions, "file_storage")
+		require.Empty(t, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.Storage)
+	})
+
+	t.Run("additional outputs", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").
+				WithAdditionalOTLPOutput("audit", testutils.OTLPEndpoint("https://audit:4317")).
+				WithAdditionalOTLPOutput("archive", testutils.OTLPEndpoint("https://archive:4318"), testutils.OTLPProtocol("http")).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, collectorConfig.Exporters, 3)
+		require.Contains(t, collectorConfig.Exporters, "otlp/test")
+		require.Contains(t, collectorConfig.Exporters, "otlp/test/audit")
+		require.Contains(t, collectorConfig.Exporters, "otlphttp/test/archive")
+		require.Equal(t, "${OTLP_ENDPOINT_TEST_out_AUDIT}", collectorConfig.Exporters["otlp/test/audit"].OTLP.Endpoint)
+		require.Equal(t, "https://audit:4317", string(envVars["OTLP_ENDPOINT_TEST_out_AUDIT"]))
+
+		require.Equal(t, 85, collectorConfig.Exporters["otlp/test"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of outputs")
+		require.Equal(t, 85, collectorConfig.Exporters["otlp/test/audit"].OTLP.SendingQueue.QueueSize, "Queue size should be divided by the number of outputs")
+
+		require.Len(t, collectorConfig.Service.Pipelines, 1)
+		require.ElementsMatch(t, []string{"otlp/test", "otlp/test/audit", "otlphttp/test/archive"}, collectorConfig.Service.Pipelines["traces/test"].Exporters)
+	})
+
+	t.Run("authenticator extensions", func(t *testing.T) {
+		collectorConfig, envVars, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").
+				WithOTLPOutput(testutils.OTLPOAuth2("https://auth.example.com/token", "client-id", "client-secret", "ingest")).
+				WithAdditionalOTLPOutput("audit", testutils.OTLPBearerToken("xyz")).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, collectorConfig.Extensions.Dynamic, 2)
+		require.Equal(t, &config.OAuth2ClientExtension{
+			TokenURL:     "${OAUTH2_TOKEN_URL_TEST}",
+			ClientID:     "${OAUTH2_CLIENT_ID_TEST}",
+			ClientSecret: "${OAUTH2_CLIENT_SECRET_TEST}",
+			Scopes:       []string{"ingest"},
+		}, collectorConfig.Extensions.Dynamic["oauth2client/test"].OAuth2Client)
+		require.Equal(t, &config.BearerTokenAuthExtension{
+			Token: "${BEARER_TOKEN_TEST_out_AUDIT}",
+		}, collectorConfig.Extensions.Dynamic["bearertokenauth/test/audit"].BearerTokenAuth)
+		require.Equal(t, []string{"health_check", "pprof", "oauth2client/test", "bearertokenauth/test/audit"}, collectorConfig.Service.Extensions)
+
+		require.Equal(t, "oauth2client/test", collectorConfig.Exporters["otlp/test"].OTLP.Auth.Authenticator)
+		require.Equal(t, "bearertokenauth/test/audit", collectorConfig.Exporters["otlp/test/audit"].OTLP.Auth.Authenticator)
+
+		require.Equal(t, "client-secret", string(envVars["OAUTH2_CLIENT_SECRET_TEST"]))
+		require.Equal(t, "xyz", string(envVars["BEARER_TOKEN_TEST_out_AUDIT"]))
+	})
+
 	t.Run("single pipeline topology", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().WithName("test").Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Contains(t, collectorConfig.Service.Pipelines, "traces/test")
@@ -164,10 +260,293 @@ func TestMakeConfig(t *testing.T) {
 		require.Contains(t, collectorConfig.Service.Pipelines["traces/test"].Exporters, "otlp/test")
 	})
 
+	t.Run("pipeline topology with namespace filter", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithOTLPInputExcludeNamespaces("kyma-system").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Service.Pipelines, "traces/test")
+		require.Equal(t, []string{
+			"memory_limiter",
+			"k8sattributes",
+			"filter/drop-noisy-spans",
+			"filter/test-filter-by-namespace",
+			"resource/insert-cluster-name",
+			"transform/resolve-service-name",
+			"resource/drop-kyma-attributes",
+			"batch",
+		}, collectorConfig.Service.Pipelines["traces/test"].Processors)
+	})
+
+	t.Run("pipeline topology with user-defined filters", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().
+				WithName("test").
+				WithOTLPInputExcludeNamespaces("kyma-system").
+				WithFilterCondition(`attributes["http.route"] == "/healthz"`).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			"memory_limiter",
+			"k8sattributes",
+			"filter/drop-noisy-spans",
+			"filter/test-filter-by-namespace",
+			"filter/test-user-defined-filters",
+			"resource/insert-cluster-name",
+			"transform/resolve-service-name",
+			"resource/drop-kyma-attributes",
+			"batch",
+		}, collectorConfig.Service.Pipelines["traces/test"].Processors)
+	})
+
+	t.Run("pipeline topology with transforms", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().
+				WithName("test").
+				WithProbabilisticSampling("50").
+				WithTransform(telemetryv1alpha1.TransformRule{Context: telemetryv1alpha1.TransformContextSpan, Action: telemetryv1alpha1.TransformActionDelete, Key: "user.email"}).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			"memory_limiter",
+			"k8sattributes",
+			"filter/drop-noisy-spans",
+			"resource/insert-cluster-name",
+			"transform/resolve-service-name",
+			"resource/drop-kyma-attributes",
+			"probabilistic_sampler/test",
+			"transform/test-user-defined-transforms",
+			"batch",
+		}, collectorConfig.Service.Pipelines["traces/test"].Processors)
+	})
+
+	t.Run("pipeline topology with probabilistic sampling", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithProbabilisticSampling("12.5").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			"memory_limiter",
+			"k8sattributes",
+			"filter/drop-noisy-spans",
+			"resource/insert-cluster-name",
+			"transform/resolve-service-name",
+			"resource/drop-kyma-attributes",
+			"probabilistic_sampler/test",
+			"batch",
+		}, collectorConfig.Service.Pipelines["traces/test"].Processors)
+		require.NotContains(t, collectorConfig.Service.Pipelines, "traces/load-balancing")
+		require.Nil(t, collectorConfig.Receivers.OTLPLoadBalanced)
+	})
+
+	t.Run("pipeline topology with tail sampling", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test-1").WithTailSampling("", telemetryv1alpha1.TailSamplingPolicy{
+				Name: "errors",
+				Type: telemetryv1alpha1.TailSamplingPolicyTypeError,
+			}).Build(),
+			testutils.NewTracePipelineBuilder().WithName("test-2").Build(),
+		}, BuildOptions{LoadBalancingServiceName: types.NamespacedName{Name: "load-balancing", Namespace: "kyma-system"}})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Service.Pipelines, "traces/load-balancing")
+		loadBalancingPipeline := collectorConfig.Service.Pipelines["traces/load-balancing"]
+		require.Equal(t, []string{"otlp"}, loadBalancingPipeline.Receivers)
+		require.Equal(t, []string{"memory_limiter", "k8sattributes"}, loadBalancingPipeline.Processors)
+		require.Equal(t, []string{"loadbalancing"}, loadBalancingPipeline.Exporters)
+
+		require.Contains(t, collectorConfig.Exporters, "loadbalancing")
+		loadBalancingExporter := collectorConfig.Exporters["loadbalancing"].LoadBalancing
+		require.NotNil(t, loadBalancingExporter)
+		require.Equal(t, "traceID", loadBalancingExporter.RoutingKey)
+		require.Equal(t, "load-balancing.kyma-system.svc.cluster.local", loadBalancingExporter.Resolver.DNS.Hostname)
+		require.Equal(t, "4319", loadBalancingExporter.Resolver.DNS.Port)
+		require.True(t, loadBalancingExporter.Protocol.OTLP.TLS.Insecure)
+
+		require.NotNil(t, collectorConfig.Receivers.OTLPLoadBalanced)
+		require.Equal(t, "${MY_POD_IP}:4319", collectorConfig.Receivers.OTLPLoadBalanced.Protocols.GRPC.Endpoint)
+
+		require.Equal(t, []string{"otlp/load-balanced"}, collectorConfig.Service.Pipelines["traces/test-1"].Receivers)
+		require.Equal(t, []string{
+			"memory_limiter",
+			"filter/drop-noisy-spans",
+			"resource/insert-cluster-name",
+			"transform/resolve-service-name",
+			"resource/drop-kyma-attributes",
+			"tail_sampling/test-1",
+			"batch",
+		}, collectorConfig.Service.Pipelines["traces/test-1"].Processors)
+
+		require.Equal(t, []string{"otlp"}, collectorConfig.Service.Pipelines["traces/test-2"].Receivers)
+		require.Contains(t, collectorConfig.Service.Pipelines["traces/test-2"].Processors, "k8sattributes")
+	})
+
+	t.Run("load balancing filtered by namespaces of tail-sampled pipelines", func(t *testing.T) {
+		errorsPolicy := telemetryv1alpha1.TailSamplingPolicy{Name: "errors", Type: telemetryv1alpha1.TailSamplingPolicyTypeError}
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test-1").WithOTLPInputIncludeNamespaces("shop").WithTailSampling("", errorsPolicy).Build(),
+			testutils.NewTracePipelineBuilder().WithName("test-2").WithOTLPInputExcludeNamespaces("kyma-system").WithTailSampling("", errorsPolicy).Build(),
+			testutils.NewTracePipelineBuilder().WithName("test-3").Build(),
+		}, BuildOptions{LoadBalancingServiceName: types.NamespacedName{Name: "load-balancing", Namespace: "kyma-system"}})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"memory_limiter", "k8sattributes", "filter/load-balancing"}, collectorConfig.Service.Pipelines["traces/load-balancing"].Processors)
+		require.NotNil(t, collectorConfig.Processors.LoadBalancingFilter)
+		require.Equal(t, []string{
+			`(not((resource.attributes["k8s.namespace.name"] == "shop"))) and ((resource.attributes["k8s.namespace.name"] == "kyma-system"))`,
+		}, collectorConfig.Processors.LoadBalancingFilter.Traces.Span)
+	})
+
+	t.Run("load balancing filtered by included namespaces after enrichment", func(t *testing.T) {
+		errorsPolicy := telemetryv1alpha1.TailSamplingPolicy{Name: "errors", Type: telemetryv1alpha1.TailSamplingPolicyTypeError}
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithOTLPInputIncludeNamespaces("shop").WithTailSampling("", errorsPolicy).Build(),
+		}, BuildOptions{LoadBalancingServiceName: types.NamespacedName{Name: "load-balancing", Namespace: "kyma-system"}})
+		require.NoError(t, err)
+
+		processors := collectorConfig.Service.Pipelines["traces/load-balancing"].Processors
+		require.Equal(t, []string{"memory_limiter", "k8sattributes", "filter/load-balancing"}, processors)
+		require.Equal(t, []string{
+			`(not((resource.attributes["k8s.namespace.name"] == "shop")))`,
+		}, collectorConfig.Processors.LoadBalancingFilter.Traces.Span)
+	})
+
+	t.Run("load balancing not filtered if a tail-sampled pipeline consumes all namespaces", func(t *testing.T) {
+		errorsPolicy := telemetryv1alpha1.TailSamplingPolicy{Name: "errors", Type: telemetryv1alpha1.TailSamplingPolicyTypeError}
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test-1").WithOTLPInputIncludeNamespaces("shop").WithTailSampling("", errorsPolicy).Build(),
+			testutils.NewTracePipelineBuilder().WithName("test-2").WithTailSampling("", errorsPolicy).Build(),
+		}, BuildOptions{LoadBalancingServiceName: types.NamespacedName{Name: "load-balancing", Namespace: "kyma-system"}})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"memory_limiter", "k8sattributes"}, collectorConfig.Service.Pipelines["traces/load-balancing"].Processors)
+		require.Nil(t, collectorConfig.Processors.LoadBalancingFilter)
+	})
+
+	t.Run("load balancing with persistent queue", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithTailSampling("", telemetryv1alpha1.TailSamplingPolicy{
+				Name: "errors",
+				Type: telemetryv1alpha1.TailSamplingPolicyTypeError,
+			}).Build(),
+		}, BuildOptions{
+			LoadBalancingServiceName: types.NamespacedName{Name: "load-balancing", Namespace: "kyma-system"},
+			PersistentQueue:          config.PersistentQueue{Enabled: true, VolumeSize: resource.MustParse("1Gi")},
+		})
+		require.NoError(t, err)
+
+		loadBalancingExporter := collectorConfig.Exporters["loadbalancing"].LoadBalancing
+		require.True(t, loadBalancingExporter.Protocol.OTLP.SendingQueue.Enabled)
+		require.Equal(t, "file_storage", loadBalancingExporter.Protocol.OTLP.SendingQueue.Storage)
+		require.True(t, loadBalancingExporter.Protocol.OTLP.RetryOnFailure.Enabled)
+	})
+
+	t.Run("pipeline topology with span metrics", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test-1").WithSpanMetrics("http.method").Build(),
+			testutils.NewTracePipelineBuilder().WithName("test-2").WithSpanMetrics("http.status_code", "http.method").Build(),
+			testutils.NewTracePipelineBuilder().WithName("test-3").Build(),
+		}, BuildOptions{
+			LoadBalancingServiceName: types.NamespacedName{Name: "load-balancing", Namespace: "kyma-system"},
+			MetricGatewayServiceName: types.NamespacedName{Name: "metric-gateway", Namespace: "kyma-system"},
+			SpanMetricsCollected:     true,
+		})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Service.Pipelines, "traces/load-balancing")
+		require.Nil(t, collectorConfig.Processors.LoadBalancingFilter)
+
+		require.Contains(t, collectorConfig.Service.Pipelines, "traces/span-metrics")
+		spanMetricsTracesPipeline := collectorConfig.Service.Pipelines["traces/span-metrics"]
+		require.Equal(t, []string{"otlp/load-balanced"}, spanMetricsTracesPipeline.Receivers)
+		require.Equal(t, []string{"servicegraph", "spanmetrics"}, spanMetricsTracesPipeline.Exporters)
+
+		require.Contains(t, collectorConfig.Service.Pipelines, "metrics/span-metrics")
+		spanMetricsMetricsPipeline := collectorConfig.Service.Pipelines["metrics/span-metrics"]
+		require.Equal(t, []string{"servicegraph", "spanmetrics"}, spanMetricsMetricsPipeline.Receivers)
+		require.Equal(t, []string{"memory_limiter", "transform/set-instrumentation-scope-spanmetrics", "batch"}, spanMetricsMetricsPipeline.Processors)
+		require.Equal(t, []string{"otlp/span-metrics.metric-gateway"}, spanMetricsMetricsPipeline.Exporters)
+
+		require.NotNil(t, collectorConfig.Connectors.SpanMetrics)
+		require.Equal(t, []Dimension{{Name: "http.method"}, {Name: "http.status_code"}}, collectorConfig.Connectors.SpanMetrics.Dimensions)
+		require.NotNil(t, collectorConfig.Connectors.ServiceGraph)
+		require.Equal(t, []string{"http.method", "http.status_code"}, collectorConfig.Connectors.ServiceGraph.Dimensions)
+
+		require.Contains(t, collectorConfig.Exporters, "otlp/span-metrics.metric-gateway")
+		require.Equal(t, "metric-gateway.kyma-system.svc.cluster.local:4317", collectorConfig.Exporters["otlp/span-metrics.metric-gateway"].OTLP.Endpoint)
+
+		require.Equal(t, []string{"otlp"}, collectorConfig.Service.Pipelines["traces/test-1"].Receivers)
+	})
+
+	t.Run("pipeline topology without span metrics", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.NotContains(t, collectorConfig.Service.Pipelines, "traces/span-metrics")
+		require.NotContains(t, collectorConfig.Service.Pipelines, "metrics/span-metrics")
+		require.Nil(t, collectorConfig.Connectors.SpanMetrics)
+		require.Nil(t, collectorConfig.Connectors.ServiceGraph)
+		require.NotContains(t, collectorConfig.Exporters, "otlp/span-metrics.metric-gateway")
+	})
+
+	t.Run("pipeline topology with span metrics not collected by any metric pipeline", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithSpanMetrics("http.method").Build(),
+		}, BuildOptions{
+			LoadBalancingServiceName: types.NamespacedName{Name: "load-balancing", Namespace: "kyma-system"},
+			MetricGatewayServiceName: types.NamespacedName{Name: "metric-gateway", Namespace: "kyma-system"},
+		})
+		require.NoError(t, err)
+
+		require.NotContains(t, collectorConfig.Service.Pipelines, "traces/load-balancing")
+		require.NotContains(t, collectorConfig.Service.Pipelines, "traces/span-metrics")
+		require.NotContains(t, collectorConfig.Service.Pipelines, "metrics/span-metrics")
+		require.Nil(t, collectorConfig.Connectors.SpanMetrics)
+		require.NotContains(t, collectorConfig.Exporters, "otlp/span-metrics.metric-gateway")
+	})
+
+	t.Run("load balancing and span metrics filtered by namespaces of span metrics pipelines", func(t *testing.T) {
+		errorsPolicy := telemetryv1alpha1.TailSamplingPolicy{Name: "errors", Type: telemetryv1alpha1.TailSamplingPolicyTypeError}
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test-1").WithOTLPInputIncludeNamespaces("shop").WithSpanMetrics().Build(),
+			testutils.NewTracePipelineBuilder().WithName("test-2").WithOTLPInputIncludeNamespaces("payment").WithTailSampling("", errorsPolicy).Build(),
+			testutils.NewTracePipelineBuilder().WithName("test-3").Build(),
+		}, BuildOptions{
+			LoadBalancingServiceName: types.NamespacedName{Name: "load-balancing", Namespace: "kyma-system"},
+			MetricGatewayServiceName: types.NamespacedName{Name: "metric-gateway", Namespace: "kyma-system"},
+			SpanMetricsCollected:     true,
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, collectorConfig.Processors.LoadBalancingFilter)
+		require.Equal(t, []string{
+			`(not((resource.attributes["k8s.namespace.name"] == "shop"))) and (not((resource.attributes["k8s.namespace.name"] == "payment")))`,
+		}, collectorConfig.Processors.LoadBalancingFilter.Traces.Span)
+
+		require.NotNil(t, collectorConfig.Processors.SpanMetricsFilter)
+		require.Equal(t, []string{`(not((resource.attributes["k8s.namespace.name"] == "shop")))`}, collectorConfig.Processors.SpanMetricsFilter.Traces.Span)
+		require.Equal(t, []string{
+			"memory_limiter",
+			"filter/drop-noisy-spans",
+			"filter/span-metrics",
+			"resource/insert-cluster-name",
+			"transform/resolve-service-name",
+			"resource/drop-kyma-attributes",
+		}, collectorConfig.Service.Pipelines["traces/span-metrics"].Processors)
+	})
+
 	t.Run("multi pipeline topology", func(t *testing.T) {
 		collectorConfig, envVars, err := MakeConfig(context.Background(), fakeClient, []telemetryv1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test-1").Build(),
-			testutils.NewTracePipelineBuilder().WithName("test-2").Build()},
+			testutils.NewTracePipelineBuilder().WithName("test-2").Build()}, BuildOptions{},
 		)
 		require.NoError(t, err)
 
@@ -203,7 +582,7 @@ func TestMakeConfig(t *testing.T) {
 	t.Run("marshaling", func(t *testing.T) {
 		config, _, err := MakeConfig(context.Background(), fakeClient, []telemetryv1alpha1.TracePipeline{
 			testutils.NewTracePipelineBuilder().WithName("test").Build(),
-		})
+		}, BuildOptions{})
 		require.NoError(t, err)
 
 		configYAML, err := yaml.Marshal(config)
@@ -216,4 +595,100 @@ func TestMakeConfig(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, string(goldenFile), string(configYAML))
 	})
+	t.Run("marshaling with transforms", func(t *testing.T) {
+		config, _, err := MakeConfig(context.Background(), fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").
+				WithTransform(telemetryv1alpha1.TransformRule{Context: telemetryv1alpha1.TransformContextResource, Action: telemetryv1alpha1.TransformActionSet, Key: "deployment.environment", Value: "production"}).
+				WithTransform(telemetryv1alpha1.TransformRule{Context: telemetryv1alpha1.TransformContextSpan, Action: telemetryv1alpha1.TransformActionHash, Key: "user.email"}).
+				WithTransform(telemetryv1alpha1.TransformRule{Context: telemetryv1alpha1.TransformContextSpan, Action: telemetryv1alpha1.TransformActionTruncate, Key: "http.url", Limit: 128}).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		configYAML, err := yaml.Marshal(config)
+		require.NoError(t, err, "failed to marshal config")
+
+		goldenFilePath := filepath.Join("testdata", "config_transforms.yaml")
+		goldenFile, err := os.ReadFile(goldenFilePath)
+		require.NoError(t, err, "failed to load golden file")
+
+		require.Equal(t, string(goldenFile), string(configYAML))
+	})
+	t.Run("marshaling with tail sampling", func(t *testing.T) {
+		config, _, err := MakeConfig(context.Background(), fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithTailSampling("30s",
+				telemetryv1alpha1.TailSamplingPolicy{
+					Name: "errors",
+					Type: telemetryv1alpha1.TailSamplingPolicyTypeError,
+				},
+				telemetryv1alpha1.TailSamplingPolicy{
+					Name:    "slow",
+					Type:    telemetryv1alpha1.TailSamplingPolicyTypeLatency,
+					Latency: &telemetryv1alpha1.TailSamplingLatencyPolicy{ThresholdMs: 500},
+				},
+				telemetryv1alpha1.TailSamplingPolicy{
+					Name:      "checkout",
+					Type:      telemetryv1alpha1.TailSamplingPolicyTypeAttribute,
+					Attribute: &telemetryv1alpha1.TailSamplingAttributePolicy{Key: "service.name", Values: []string{"checkout"}},
+				},
+			).Build(),
+		}, BuildOptions{LoadBalancingServiceName: types.NamespacedName{Name: "telemetry-trace-collector-load-balancing", Namespace: "kyma-system"}})
+		require.NoError(t, err)
+
+		configYAML, err := yaml.Marshal(config)
+		require.NoError(t, err, "failed to marshal config")
+
+		goldenFilePath := filepath.Join("testdata", "config_tail_sampling.yaml")
+		goldenFile, err := os.ReadFile(goldenFilePath)
+		require.NoError(t, err, "failed to load golden file")
+
+		require.NoError(t, err)
+		require.Equal(t, string(goldenFile), string(configYAML))
+	})
+
+	t.Run("marshaling with span metrics", func(t *testing.T) {
+		config, _, err := MakeConfig(context.Background(), fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithSpanMetrics("http.method").Build(),
+		}, BuildOptions{
+			LoadBalancingServiceName: types.NamespacedName{Name: "telemetry-trace-collector-load-balancing", Namespace: "kyma-system"},
+			MetricGatewayServiceName: types.NamespacedName{Name: "telemetry-otlp-metrics", Namespace: "kyma-system"},
+			SpanMetricsCollected:     true,
+		})
+		require.NoError(t, err)
+
+		configYAML, err := yaml.Marshal(config)
+		require.NoError(t, err, "failed to marshal config")
+
+		goldenFilePath := filepath.Join("testdata", "config_span_metrics.yaml")
+		goldenFile, err := os.ReadFile(goldenFilePath)
+		require.NoError(t, err, "failed to load golden file")
+
+		require.NoError(t, err)
+		require.Equal(t, string(goldenFile), string(configYAML))
+	})
+}
+
+func TestAddTapExporters(t *testing.T) {
+	collectorConfig, _, err := MakeConfig(context.Background(), fake.NewClientBuilder().Build(), []telemetryv1alpha1.TracePipeline{
+		testutils.NewTracePipelineBuilder().WithName("test-1").Build(),
+		testutils.NewTracePipelineBuilder().WithName("test-2").Build(),
+	}, BuildOptions{PersistentQueue: config.PersistentQueue{Enabled: true}})
+	require.NoError(t, err)
+
+	AddTapExporters(collectorConfig, otlpexporter.Taps{
+		Endpoints: map[string]string{
+			"test-1":  "https://telemetry-manager-webhook.kyma-system.svc:443/tap/tracepipelines/test-1",
+			"deleted": "https://telemetry-manager-webhook.kyma-system.svc:443/tap/tracepipelines/deleted",
+		},
+		CAPem: "ca",
+	})
+
+	require.Contains(t, collectorConfig.Exporters, "otlphttp/tap.test-1")
+	tapExporter := collectorConfig.Exporters["otlphttp/tap.test-1"].OTLP
+	require.Equal(t, "https://telemetry-manager-webhook.kyma-system.svc:443/tap/tracepipelines/test-1", tapExporter.Endpoint)
+	require.Equal(t, "ca", tapExporter.TLS.CAPem)
+	require.Empty(t, tapExporter.SendingQueue.Storage, "tap exporter must not use the persistent queue")
+	require.NotContains(t, collectorConfig.Exporters, "otlphttp/tap.deleted")
+	require.Equal(t, []string{"otlp/test-1", "otlphttp/tap.test-1"}, collectorConfig.Service.Pipelines["traces/test-1"].Exporters)
+	require.Equal(t, []string{"otlp/test-2"}, collectorConfig.Service.Pipelines["traces/test-2"].Exporters)
 }
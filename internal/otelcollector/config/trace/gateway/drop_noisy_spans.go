@@ -55,7 +55,7 @@ func makeDropNoisySpansConfig() FilterProcessor {
 }
 
 func spanAttributeEquals(key, value string) string {
-	return "attributes[\"" + key + "\"] == \"" + value + "\""
+	return "attributes[\"" + ottlexpr.EscapeString(key) + "\"] == \"" + ottlexpr.EscapeString(value) + "\""
 }
 
 func istioCanonicalNameEquals(name string) string {
@@ -71,7 +71,7 @@ func userAgentMatches(pattern string) string {
 }
 
 func spanAttributeMatches(key, pattern string) string {
-	return attributeMatches("attributes[\""+key+"\"]", pattern)
+	return attributeMatches("attributes[\""+ottlexpr.EscapeString(key)+"\"]", pattern)
 }
 
 func attributeMatches(key, pattern string) string {
@@ -1,8 +1,13 @@
 package gateway
 
 import (
+	"fmt"
+	"regexp"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/gatewayprocs"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/ottlexpr"
 )
 
 func makeProcessorsConfig() Processors {
@@ -41,3 +46,90 @@ func makeResolveServiceNameConfig() *TransformProcessor {
 		TraceStatements: gatewayprocs.ResolveServiceNameStatements(),
 	}
 }
+
+func makeUserDefinedTransformConfig(rules []telemetryv1alpha1.TransformRule) *TransformProcessor {
+	return &TransformProcessor{
+		ErrorMode:       "ignore",
+		TraceStatements: gatewayprocs.TransformRuleStatements(rules),
+	}
+}
+
+func makeFilterByNamespaceConfig(namespaceSelector *telemetryv1alpha1.TracePipelineInputNamespaceSelector) *FilterProcessor {
+	var filterExpressions []string
+
+	if len(namespaceSelector.Exclude) > 0 {
+		namespacesConditions := createNamespacesConditions(namespaceSelector.Exclude)
+		filterExpressions = append(filterExpressions, ottlexpr.JoinWithOr(namespacesConditions...))
+	}
+
+	if len(namespaceSelector.Include) > 0 {
+		namespacesConditions := createNamespacesConditions(namespaceSelector.Include)
+		filterExpressions = append(filterExpressions, not(ottlexpr.JoinWithOr(namespacesConditions...)))
+	}
+
+	return &FilterProcessor{
+		Traces: Traces{
+			Span: filterExpressions,
+		},
+	}
+}
+
+// ValidateFilters returns an error if a user-defined filter of a pipeline cannot be compiled into a filter processor.
+// Such a filter would prevent the shared trace gateway from starting, so the pipeline must be rejected or left out of the gateway.
+func ValidateFilters(filters []telemetryv1alpha1.TracePipelineFilter) error {
+	for i, filter := range filters {
+		if filter.Condition != "" {
+			if err := ottlexpr.CheckSpanCondition(filter.Condition); err != nil {
+				return fmt.Errorf("invalid condition in filter %d: %w", i, err)
+			}
+		}
+
+		if filter.Attribute != nil && filter.Attribute.Regex != "" {
+			if _, err := regexp.Compile(filter.Attribute.Regex); err != nil {
+				return fmt.Errorf("invalid regular expression in filter %d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// makeUserDefinedFilterConfig compiles the filters of a pipeline into a filter processor. Conditions that cannot be evaluated
+// for a span, for example, because of a type mismatch, are ignored instead of failing the whole batch.
+func makeUserDefinedFilterConfig(filters []telemetryv1alpha1.TracePipelineFilter) *FilterProcessor {
+	var filterExpressions []string
+
+	for _, filter := range filters {
+		switch {
+		case filter.Condition != "":
+			filterExpressions = append(filterExpressions, filter.Condition)
+		case filter.Attribute != nil && filter.Attribute.Regex != "":
+			filterExpressions = append(filterExpressions, attributeMatches(spanAttribute(filter.Attribute.Key), ottlexpr.EscapeString(filter.Attribute.Regex)))
+		case filter.Attribute != nil:
+			filterExpressions = append(filterExpressions, spanAttribute(filter.Attribute.Key)+" == \""+ottlexpr.EscapeString(filter.Attribute.Value)+"\"")
+		}
+	}
+
+	return &FilterProcessor{
+		ErrorMode: "ignore",
+		Traces: Traces{
+			Span: filterExpressions,
+		},
+	}
+}
+
+func spanAttribute(key string) string {
+	return "attributes[\"" + ottlexpr.EscapeString(key) + "\"]"
+}
+
+func createNamespacesConditions(namespaces []string) []string {
+	var namespacesConditions []string
+	for _, ns := range namespaces {
+		namespacesConditions = append(namespacesConditions, ottlexpr.NamespaceEquals(ns))
+	}
+	return namespacesConditions
+}
+
+func not(expression string) string {
+	return fmt.Sprintf("not(%s)", expression)
+}
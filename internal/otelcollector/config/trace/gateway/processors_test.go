@@ -16,7 +16,7 @@ func TestProcessors(t *testing.T) {
 	fakeClient := fake.NewClientBuilder().Build()
 
 	t.Run("insert cluster name processor", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, 1, len(collectorConfig.Processors.InsertClusterName.Attributes))
@@ -26,7 +26,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("memory limit processors", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "1s", collectorConfig.Processors.MemoryLimiter.CheckInterval)
@@ -35,7 +35,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("batch processors", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, 512, collectorConfig.Processors.Batch.SendBatchSize)
@@ -44,7 +44,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("k8s attributes processors", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, "serviceAccount", collectorConfig.Processors.K8sAttributes.AuthType)
@@ -72,7 +72,7 @@ func TestProcessors(t *testing.T) {
 	})
 
 	t.Run("filter processor", func(t *testing.T) {
-		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()})
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{testutils.NewTracePipelineBuilder().Build()}, BuildOptions{})
 		require.NoError(t, err)
 
 		require.Equal(t, 10, len(collectorConfig.Processors.DropNoisySpans.Traces.Span), "Span filter list size is wrong")
@@ -87,4 +87,180 @@ func TestProcessors(t *testing.T) {
 		require.Contains(t, collectorConfig.Processors.DropNoisySpans.Traces.Span, fromVMScrapeAgent, "fromVmScrapeAgent span filter is missing")
 		require.Contains(t, collectorConfig.Processors.DropNoisySpans.Traces.Span, fromTelemetryMetricAgent, "fromTelemetryMetricAgent span filter is missing")
 	})
+	t.Run("namespace filter processor using include", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithOTLPInputIncludeNamespaces("ns-1", "ns-2").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		namespaceFilters := collectorConfig.Processors.Dynamic
+		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace")
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace"].Filter.Traces.Span, 1)
+		expectedCondition := "not((resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\"))"
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace"].Filter.Traces.Span[0])
+	})
+
+	t.Run("namespace filter processor using exclude", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithOTLPInputExcludeNamespaces("ns-1", "ns-2").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		namespaceFilters := collectorConfig.Processors.Dynamic
+		require.Contains(t, namespaceFilters, "filter/test-filter-by-namespace")
+		require.Len(t, namespaceFilters["filter/test-filter-by-namespace"].Filter.Traces.Span, 1)
+		expectedCondition := "(resource.attributes[\"k8s.namespace.name\"] == \"ns-1\" or resource.attributes[\"k8s.namespace.name\"] == \"ns-2\")"
+		require.Equal(t, expectedCondition, namespaceFilters["filter/test-filter-by-namespace"].Filter.Traces.Span[0])
+	})
+
+	t.Run("no namespace filter processor without selector", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Empty(t, collectorConfig.Processors.Dynamic)
+	})
+	t.Run("user-defined filter processor", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().
+				WithName("test").
+				WithFilterCondition(`attributes["http.route"] == "/healthz"`).
+				WithAttributeFilter(telemetryv1alpha1.TracePipelineAttributeFilter{Key: "user_agent", Value: "kube-probe/1.30"}).
+				WithAttributeFilter(telemetryv1alpha1.TracePipelineAttributeFilter{Key: "http.url", Regex: `.*/ready\?check="full"`}).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Processors.Dynamic, "filter/test-user-defined-filters")
+		filter := collectorConfig.Processors.Dynamic["filter/test-user-defined-filters"].Filter
+		require.NotNil(t, filter)
+		require.Equal(t, "ignore", filter.ErrorMode)
+		require.Equal(t, []string{
+			`attributes["http.route"] == "/healthz"`,
+			`attributes["user_agent"] == "kube-probe/1.30"`,
+			`IsMatch(attributes["http.url"], ".*/ready\\?check=\"full\"") == true`,
+		}, filter.Traces.Span)
+	})
+
+	t.Run("user-defined transform processor", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().
+				WithName("test").
+				WithTransform(telemetryv1alpha1.TransformRule{Context: telemetryv1alpha1.TransformContextSpan, Action: telemetryv1alpha1.TransformActionHash, Key: "user.email"}).
+				WithTransform(telemetryv1alpha1.TransformRule{Context: telemetryv1alpha1.TransformContextResource, Action: telemetryv1alpha1.TransformActionDelete, Key: "host.name"}).
+				Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Processors.Dynamic, "transform/test-user-defined-transforms")
+		transform := collectorConfig.Processors.Dynamic["transform/test-user-defined-transforms"].Transform
+		require.NotNil(t, transform)
+		require.Equal(t, "ignore", transform.ErrorMode)
+		require.Len(t, transform.TraceStatements, 2)
+		require.Equal(t, "span", transform.TraceStatements[0].Context)
+		require.Equal(t, []string{`set(attributes["user.email"], SHA256(attributes["user.email"])) where attributes["user.email"] != nil`}, transform.TraceStatements[0].Statements)
+		require.Equal(t, "resource", transform.TraceStatements[1].Context)
+		require.Equal(t, []string{`delete_key(attributes, "host.name")`}, transform.TraceStatements[1].Statements)
+	})
+
+	t.Run("probabilistic sampler processor", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithProbabilisticSampling("0.5").Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Processors.Dynamic, "probabilistic_sampler/test")
+		sampler := collectorConfig.Processors.Dynamic["probabilistic_sampler/test"].ProbabilisticSampler
+		require.NotNil(t, sampler)
+		require.Equal(t, 0.5, sampler.SamplingPercentage)
+	})
+
+	t.Run("tail sampling processor", func(t *testing.T) {
+		collectorConfig, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithTailSampling("",
+				telemetryv1alpha1.TailSamplingPolicy{
+					Name: "errors",
+					Type: telemetryv1alpha1.TailSamplingPolicyTypeError,
+				},
+				telemetryv1alpha1.TailSamplingPolicy{
+					Name:    "slow",
+					Type:    telemetryv1alpha1.TailSamplingPolicyTypeLatency,
+					Latency: &telemetryv1alpha1.TailSamplingLatencyPolicy{ThresholdMs: 500},
+				},
+				telemetryv1alpha1.TailSamplingPolicy{
+					Name:      "checkout",
+					Type:      telemetryv1alpha1.TailSamplingPolicyTypeAttribute,
+					Attribute: &telemetryv1alpha1.TailSamplingAttributePolicy{Key: "service.name", Values: []string{"checkout"}},
+				},
+			).Build(),
+		}, BuildOptions{})
+		require.NoError(t, err)
+
+		require.Contains(t, collectorConfig.Processors.Dynamic, "tail_sampling/test")
+		tailSampling := collectorConfig.Processors.Dynamic["tail_sampling/test"].TailSampling
+		require.NotNil(t, tailSampling)
+		require.Equal(t, "10s", tailSampling.DecisionWait, "Decision wait should be defaulted")
+		require.Len(t, tailSampling.Policies, 3)
+
+		require.Equal(t, TailSamplingPolicy{
+			Name:       "errors",
+			Type:       "status_code",
+			StatusCode: &StatusCodePolicyConfig{StatusCodes: []string{"ERROR"}},
+		}, tailSampling.Policies[0])
+		require.Equal(t, TailSamplingPolicy{
+			Name:    "slow",
+			Type:    "latency",
+			Latency: &LatencyPolicyConfig{ThresholdMs: 500},
+		}, tailSampling.Policies[1])
+		require.Equal(t, TailSamplingPolicy{
+			Name:            "checkout",
+			Type:            "string_attribute",
+			StringAttribute: &StringAttributePolicyConfig{Key: "service.name", Values: []string{"checkout"}},
+		}, tailSampling.Policies[2])
+	})
+
+	t.Run("invalid sampling percentage", func(t *testing.T) {
+		_, _, err := MakeConfig(ctx, fakeClient, []telemetryv1alpha1.TracePipeline{
+			testutils.NewTracePipelineBuilder().WithName("test").WithProbabilisticSampling("abc").Build(),
+		}, BuildOptions{})
+		require.Error(t, err)
+	})
+}
+
+func TestValidateFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters []telemetryv1alpha1.TracePipelineFilter
+		wantErr bool
+	}{
+		{
+			name: "valid filters",
+			filters: []telemetryv1alpha1.TracePipelineFilter{
+				{Condition: `attributes["http.route"] == "/healthz"`},
+				{Attribute: &telemetryv1alpha1.TracePipelineAttributeFilter{Key: "http.url", Regex: ".*/ready"}},
+			},
+		},
+		{
+			name:    "condition that cannot be parsed",
+			filters: []telemetryv1alpha1.TracePipelineFilter{{Condition: `foo bar baz`}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid regular expression",
+			filters: []telemetryv1alpha1.TracePipelineFilter{{Attribute: &telemetryv1alpha1.TracePipelineAttributeFilter{Key: "http.url", Regex: "(ready"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFilters(tt.filters)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
 }
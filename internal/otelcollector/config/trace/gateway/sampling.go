@@ -0,0 +1,72 @@
+package gateway
+
+import (
+	"fmt"
+	"strconv"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+const defaultDecisionWait = "10s"
+
+func makeProbabilisticSamplerConfig(sampling *telemetryv1alpha1.ProbabilisticSampling) (*ProbabilisticSamplerProcessor, error) {
+	percentage, err := strconv.ParseFloat(sampling.Percentage, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sampling percentage %q: %w", sampling.Percentage, err)
+	}
+
+	if percentage < 0 || percentage > 100 {
+		return nil, fmt.Errorf("sampling percentage %q must be between 0 and 100", sampling.Percentage)
+	}
+
+	return &ProbabilisticSamplerProcessor{
+		SamplingPercentage: percentage,
+	}, nil
+}
+
+func makeTailSamplingConfig(sampling *telemetryv1alpha1.TailSampling) *TailSamplingProcessor {
+	decisionWait := sampling.DecisionWait
+	if decisionWait == "" {
+		decisionWait = defaultDecisionWait
+	}
+
+	var policies []TailSamplingPolicy
+	for _, policy := range sampling.Policies {
+		policies = append(policies, makeTailSamplingPolicy(policy))
+	}
+
+	return &TailSamplingProcessor{
+		DecisionWait: decisionWait,
+		Policies:     policies,
+	}
+}
+
+func makeTailSamplingPolicy(policy telemetryv1alpha1.TailSamplingPolicy) TailSamplingPolicy {
+	switch policy.Type {
+	case telemetryv1alpha1.TailSamplingPolicyTypeLatency:
+		return TailSamplingPolicy{
+			Name: policy.Name,
+			Type: "latency",
+			Latency: &LatencyPolicyConfig{
+				ThresholdMs: policy.Latency.ThresholdMs,
+			},
+		}
+	case telemetryv1alpha1.TailSamplingPolicyTypeAttribute:
+		return TailSamplingPolicy{
+			Name: policy.Name,
+			Type: "string_attribute",
+			StringAttribute: &StringAttributePolicyConfig{
+				Key:    policy.Attribute.Key,
+				Values: policy.Attribute.Values,
+			},
+		}
+	default:
+		return TailSamplingPolicy{
+			Name: policy.Name,
+			Type: "status_code",
+			StatusCode: &StatusCodePolicyConfig{
+				StatusCodes: []string{"ERROR"},
+			},
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+)
+
+const (
+	spanMetricsTracesPipelineID  = "traces/span-metrics"
+	spanMetricsMetricsPipelineID = "metrics/span-metrics"
+	spanMetricsFilterID          = "filter/span-metrics"
+	spanMetricsConnectorID       = "spanmetrics"
+	serviceGraphConnectorID      = "servicegraph"
+	// The dot cannot be part of the pipeline name extracted by the self-monitor, so the exporter is not mistaken for the output of a pipeline
+	metricGatewayExporterID = "otlp/span-metrics.metric-gateway"
+)
+
+// addComponentsForSpanMetrics adds a pipeline, which derives span metrics and service graph metrics from the spans in the namespaces
+// of the pipelines with span metrics and sends them to the metric gateway. The spans are received after they have been routed by trace ID,
+// so that the client and server spans of a service graph edge are paired by the same replica.
+func addComponentsForSpanMetrics(cfg *Config, pipelines []telemetryv1alpha1.TracePipeline, metricGatewayServiceName types.NamespacedName) {
+	dimensions := spanMetricsDimensions(pipelines)
+
+	cfg.Connectors.SpanMetrics = makeSpanMetricsConnectorConfig(dimensions)
+	cfg.Connectors.ServiceGraph = makeServiceGraphConnectorConfig(dimensions)
+	cfg.Processors.SetInstrumentationScopeSpanMetrics = makeSetInstrumentationScopeSpanMetricsConfig()
+	cfg.Exporters[metricGatewayExporterID] = Exporter{OTLP: makeMetricGatewayExporterConfig(metricGatewayServiceName)}
+
+	// The load-balanced spans also include the spans of the tail-sampled pipelines, which might select other namespaces
+	processors := []string{"memory_limiter", "filter/drop-noisy-spans"}
+	if filter := makeUnselectedNamespacesFilterConfig(pipelines, isSpanMetricsEnabled); filter != nil {
+		cfg.Processors.SpanMetricsFilter = filter
+		processors = append(processors, spanMetricsFilterID)
+	}
+	processors = append(processors,
+		"resource/insert-cluster-name",
+		"transform/resolve-service-name",
+		"resource/drop-kyma-attributes",
+	)
+
+	cfg.Service.Pipelines[spanMetricsTracesPipelineID] = config.Pipeline{
+		Receivers:  []string{loadBalancedReceiverID},
+		Processors: processors,
+		Exporters:  []string{serviceGraphConnectorID, spanMetricsConnectorID},
+	}
+	cfg.Service.Pipelines[spanMetricsMetricsPipelineID] = config.Pipeline{
+		Receivers: []string{serviceGraphConnectorID, spanMetricsConnectorID},
+		Processors: []string{
+			"memory_limiter",
+			"transform/set-instrumentation-scope-spanmetrics",
+			"batch",
+		},
+		Exporters: []string{metricGatewayExporterID},
+	}
+}
+
+func makeSpanMetricsConnectorConfig(dimensions []string) *SpanMetricsConnector {
+	var spanMetricsDimensions []Dimension
+	for _, dimension := range dimensions {
+		spanMetricsDimensions = append(spanMetricsDimensions, Dimension{Name: dimension})
+	}
+
+	return &SpanMetricsConnector{
+		Dimensions:           spanMetricsDimensions,
+		MetricsFlushInterval: "15s",
+	}
+}
+
+func makeServiceGraphConnectorConfig(dimensions []string) *ServiceGraphConnector {
+	return &ServiceGraphConnector{
+		Dimensions: dimensions,
+		Store: ServiceGraphStore{
+			TTL:      "10s",
+			MaxItems: 10000,
+		},
+		MetricsFlushInterval: "15s",
+	}
+}
+
+// makeSetInstrumentationScopeSpanMetricsConfig marks the metrics as span metrics, so that the metric gateway delivers them only
+// to the pipelines that enable the spanMetrics input.
+func makeSetInstrumentationScopeSpanMetricsConfig() *TransformProcessor {
+	return &TransformProcessor{
+		ErrorMode: "ignore",
+		MetricStatements: []config.TransformProcessorStatements{
+			{
+				Context:    "scope",
+				Statements: []string{fmt.Sprintf("set(name, \"%s\")", metric.InstrumentationScopeSpanMetrics)},
+			},
+		},
+	}
+}
+
+func makeMetricGatewayExporterConfig(serviceName types.NamespacedName) *config.OTLPExporter {
+	return &config.OTLPExporter{
+		Endpoint: fmt.Sprintf("%s.%s.svc.cluster.local:%d", serviceName.Name, serviceName.Namespace, ports.OTLPGRPC),
+		TLS: config.TLS{
+			Insecure: true,
+		},
+		SendingQueue: config.SendingQueue{
+			Enabled:   true,
+			QueueSize: 512,
+		},
+		RetryOnFailure: config.RetryOnFailure{
+			Enabled:         true,
+			InitialInterval: "5s",
+			MaxInterval:     "30s",
+			MaxElapsedTime:  "300s",
+		},
+	}
+}
+
+// spanMetricsDimensions returns the sorted union of the dimensions of all pipelines, because the span metrics are generated once for all pipelines.
+func spanMetricsDimensions(pipelines []telemetryv1alpha1.TracePipeline) []string {
+	seen := make(map[string]bool)
+	var dimensions []string
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp != nil || !isSpanMetricsEnabled(&pipelines[i]) {
+			continue
+		}
+
+		for _, dimension := range pipelines[i].Spec.SpanMetrics.Dimensions {
+			if !seen[dimension] {
+				seen[dimension] = true
+				dimensions = append(dimensions, dimension)
+			}
+		}
+	}
+
+	sort.Strings(dimensions)
+	return dimensions
+}
+
+// requiresSpanMetrics returns true if a pipeline defines span metrics and a MetricPipeline collects them.
+func requiresSpanMetrics(pipelines []telemetryv1alpha1.TracePipeline, spanMetricsCollected bool) bool {
+	if !spanMetricsCollected {
+		return false
+	}
+
+	for i := range pipelines {
+		if pipelines[i].DeletionTimestamp == nil && isSpanMetricsEnabled(&pipelines[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpanMetricsEnabled(pipeline *telemetryv1alpha1.TracePipeline) bool {
+	return pipeline.Spec.SpanMetrics != nil
+}
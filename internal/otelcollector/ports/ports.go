@@ -7,4 +7,10 @@ const (
 	HealthCheck = 13133
 	Pprof       = 1777
 	IstioEnvoy  = 15090
+
+	// ScrapeHealthMetrics is used by the metric agent to expose the health of its Prometheus scrape targets to the self-monitor
+	ScrapeHealthMetrics = 8889
+
+	// OTLPGRPCLoadBalanced is used by the trace gateway replicas to exchange spans routed by trace ID
+	OTLPGRPCLoadBalanced = 4319
 )
@@ -0,0 +1,172 @@
+package prometheusmonitor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	ServiceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+	PodMonitorGVK     = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+)
+
+// Issue describes a ServiceMonitor or PodMonitor, or one of its endpoints, that is not scraped.
+type Issue struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s %s/%s %s", i.Kind, i.Namespace, i.Name, i.Reason)
+}
+
+// ListServiceMonitors returns all ServiceMonitors of the cluster, sorted by Namespace and name.
+// ServiceMonitors that cannot be decoded and endpoints with unsupported settings are skipped and returned as issues.
+// If the ServiceMonitor CRD is not installed, no ServiceMonitors are returned.
+func ListServiceMonitors(ctx context.Context, c client.Reader) ([]ServiceMonitor, []Issue, error) {
+	monitors, issues, err := list[ServiceMonitor](ctx, c, ServiceMonitorGVK)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slices.SortFunc(monitors, func(a, b ServiceMonitor) int {
+		return compareNamespacedName(a.Namespace, a.Name, b.Namespace, b.Name)
+	})
+
+	for i := range monitors {
+		var supported []Endpoint
+		for j, endpoint := range monitors[i].Spec.Endpoints {
+			if fields := unsupportedFields(endpoint.TLSConfig, endpoint.Authentication); len(fields) > 0 {
+				issues = append(issues, makeEndpointIssue(ctx, ServiceMonitorGVK.Kind, monitors[i].ObjectMeta, j, fields))
+				continue
+			}
+			supported = append(supported, endpoint)
+		}
+		monitors[i].Spec.Endpoints = supported
+	}
+
+	return monitors, issues, nil
+}
+
+// ListPodMonitors returns all PodMonitors of the cluster, sorted by Namespace and name.
+// PodMonitors that cannot be decoded and endpoints with unsupported settings are skipped and returned as issues.
+// If the PodMonitor CRD is not installed, no PodMonitors are returned.
+func ListPodMonitors(ctx context.Context, c client.Reader) ([]PodMonitor, []Issue, error) {
+	monitors, issues, err := list[PodMonitor](ctx, c, PodMonitorGVK)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slices.SortFunc(monitors, func(a, b PodMonitor) int {
+		return compareNamespacedName(a.Namespace, a.Name, b.Namespace, b.Name)
+	})
+
+	for i := range monitors {
+		var supported []PodMetricsEndpoint
+		for j, endpoint := range monitors[i].Spec.PodMetricsEndpoints {
+			if fields := unsupportedFields(endpoint.TLSConfig, endpoint.Authentication); len(fields) > 0 {
+				issues = append(issues, makeEndpointIssue(ctx, PodMonitorGVK.Kind, monitors[i].ObjectMeta, j, fields))
+				continue
+			}
+			supported = append(supported, endpoint)
+		}
+		monitors[i].Spec.PodMetricsEndpoints = supported
+	}
+
+	return monitors, issues, nil
+}
+
+// list decodes the monitors of the given kind. A monitor that cannot be decoded must not prevent scraping the other monitors,
+// so it is skipped and returned as an issue.
+func list[T any](ctx context.Context, c client.Reader, gvk schema.GroupVersionKind) ([]T, []Issue, error) {
+	var unstructuredList unstructured.UnstructuredList
+	unstructuredList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	if err := c.List(ctx, &unstructuredList); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to list %s resources: %w", gvk.Kind, err)
+	}
+
+	items := make([]T, 0, len(unstructuredList.Items))
+	var issues []Issue
+	for i := range unstructuredList.Items {
+		obj := &unstructuredList.Items[i]
+
+		var item T
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &item); err != nil {
+			logf.FromContext(ctx).Error(err, "Skipping monitor that cannot be decoded", "kind", gvk.Kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+			issues = append(issues, Issue{Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Reason: "cannot be decoded"})
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, issues, nil
+}
+
+func makeEndpointIssue(ctx context.Context, kind string, monitor metav1.ObjectMeta, index int, fields []string) Issue {
+	logf.FromContext(ctx).V(1).Info("Skipping monitor endpoint with unsupported settings", "kind", kind, "namespace", monitor.Namespace, "name", monitor.Name, "endpoint", index, "settings", fields)
+	return Issue{
+		Kind:      kind,
+		Namespace: monitor.Namespace,
+		Name:      monitor.Name,
+		Reason:    fmt.Sprintf("endpoint %d uses the unsupported settings %s", index, strings.Join(fields, ", ")),
+	}
+}
+
+// unsupportedFields returns the settings of an endpoint that the agent cannot apply. Such an endpoint is skipped,
+// because scraping it without the settings would fail or bypass the intended authentication.
+func unsupportedFields(tlsConfig *TLSConfig, auth Authentication) []string {
+	var fields []string
+
+	if tlsConfig != nil {
+		if len(tlsConfig.CA) > 0 {
+			fields = append(fields, "tlsConfig.ca")
+		}
+		if len(tlsConfig.Cert) > 0 {
+			fields = append(fields, "tlsConfig.cert")
+		}
+		if len(tlsConfig.KeySecret) > 0 {
+			fields = append(fields, "tlsConfig.keySecret")
+		}
+	}
+
+	if len(auth.BasicAuth) > 0 {
+		fields = append(fields, "basicAuth")
+	}
+	if len(auth.BearerTokenSecret) > 0 {
+		fields = append(fields, "bearerTokenSecret")
+	}
+	if auth.BearerTokenFile != "" {
+		fields = append(fields, "bearerTokenFile")
+	}
+	if len(auth.Authorization) > 0 {
+		fields = append(fields, "authorization")
+	}
+	if len(auth.OAuth2) > 0 {
+		fields = append(fields, "oauth2")
+	}
+
+	return fields
+}
+
+func compareNamespacedName(namespaceA, nameA, namespaceB, nameB string) int {
+	if c := strings.Compare(namespaceA, namespaceB); c != 0 {
+		return c
+	}
+	return strings.Compare(nameA, nameB)
+}
@@ -0,0 +1,162 @@
+package prometheusmonitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestListServiceMonitors(t *testing.T) {
+	ctx := context.Background()
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(ServiceMonitorGVK, meta.RESTScopeNamespace)
+
+	client := fake.NewClientBuilder().
+		WithRESTMapper(restMapper).
+		WithObjects(
+			makeUnstructured(ServiceMonitorGVK, "team-b", "backend", map[string]any{
+				"selector": map[string]any{"matchLabels": map[string]any{"app": "backend"}},
+				"endpoints": []any{
+					map[string]any{
+						"port":          "http-metrics",
+						"path":          "/custom",
+						"interval":      "15s",
+						"scrapeTimeout": "10s",
+						"honorLabels":   true,
+						"tlsConfig":     map[string]any{"insecureSkipVerify": true},
+						"relabelings": []any{
+							map[string]any{"sourceLabels": []any{"__meta_kubernetes_pod_name"}, "targetLabel": "pod", "action": "replace"},
+						},
+					},
+				},
+			}),
+			makeUnstructured(ServiceMonitorGVK, "team-a", "frontend", map[string]any{
+				"selector":  map[string]any{"matchLabels": map[string]any{"app": "frontend"}},
+				"endpoints": []any{map[string]any{"targetPort": int64(8080)}},
+			}),
+		).Build()
+
+	monitors, issues, err := ListServiceMonitors(ctx, client)
+	require.NoError(t, err)
+	require.Empty(t, issues)
+	require.Len(t, monitors, 2)
+
+	require.Equal(t, "team-a", monitors[0].Namespace, "must be sorted by namespace")
+	require.Equal(t, "frontend", monitors[0].Name)
+	require.Equal(t, intstr.FromInt32(8080), *monitors[0].Spec.Endpoints[0].TargetPort)
+
+	endpoint := monitors[1].Spec.Endpoints[0]
+	require.Equal(t, map[string]string{"app": "backend"}, monitors[1].Spec.Selector.MatchLabels)
+	require.Equal(t, "http-metrics", endpoint.Port)
+	require.Equal(t, "/custom", endpoint.Path)
+	require.Equal(t, "15s", endpoint.Interval)
+	require.Equal(t, "10s", endpoint.ScrapeTimeout)
+	require.True(t, endpoint.HonorLabels)
+	require.True(t, endpoint.TLSConfig.InsecureSkipVerify)
+	require.Equal(t, []RelabelConfig{{SourceLabels: []string{"__meta_kubernetes_pod_name"}, TargetLabel: "pod", Action: "replace"}}, endpoint.RelabelConfigs)
+}
+
+func TestListPodMonitors(t *testing.T) {
+	ctx := context.Background()
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(PodMonitorGVK, meta.RESTScopeNamespace)
+
+	client := fake.NewClientBuilder().
+		WithRESTMapper(restMapper).
+		WithObjects(
+			makeUnstructured(PodMonitorGVK, "default", "sample", map[string]any{
+				"selector":            map[string]any{"matchLabels": map[string]any{"app": "sample"}},
+				"namespaceSelector":   map[string]any{"any": true},
+				"podMetricsEndpoints": []any{map[string]any{"port": "metrics", "scheme": "https"}},
+			}),
+		).Build()
+
+	monitors, issues, err := ListPodMonitors(ctx, client)
+	require.NoError(t, err)
+	require.Empty(t, issues)
+	require.Len(t, monitors, 1)
+	require.True(t, monitors[0].Spec.NamespaceSelector.Any)
+	require.Equal(t, "metrics", monitors[0].Spec.PodMetricsEndpoints[0].Port)
+	require.Equal(t, "https", monitors[0].Spec.PodMetricsEndpoints[0].Scheme)
+}
+
+func TestListWithoutCRDs(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithRESTMapper(meta.NewDefaultRESTMapper(nil)).Build()
+
+	serviceMonitors, serviceMonitorIssues, err := ListServiceMonitors(ctx, client)
+	require.NoError(t, err)
+	require.Empty(t, serviceMonitors)
+	require.Empty(t, serviceMonitorIssues)
+
+	podMonitors, podMonitorIssues, err := ListPodMonitors(ctx, client)
+	require.NoError(t, err)
+	require.Empty(t, podMonitors)
+	require.Empty(t, podMonitorIssues)
+}
+
+func TestListSkipsUnsupportedMonitors(t *testing.T) {
+	ctx := context.Background()
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(ServiceMonitorGVK, meta.RESTScopeNamespace)
+	restMapper.Add(PodMonitorGVK, meta.RESTScopeNamespace)
+
+	client := fake.NewClientBuilder().
+		WithRESTMapper(restMapper).
+		WithObjects(
+			makeUnstructured(ServiceMonitorGVK, "default", "backend", map[string]any{
+				"endpoints": []any{
+					map[string]any{
+						"port":      "https",
+						"tlsConfig": map[string]any{"ca": map[string]any{"secret": map[string]any{"name": "ca", "key": "ca.crt"}}},
+					},
+					map[string]any{
+						"port":              "metrics",
+						"bearerTokenSecret": map[string]any{"name": "token", "key": "token"},
+						"basicAuth":         map[string]any{"username": map[string]any{"name": "auth", "key": "user"}},
+					},
+					map[string]any{"port": "http-metrics"},
+				},
+			}),
+			makeUnstructured(PodMonitorGVK, "default", "broken", map[string]any{
+				"podMetricsEndpoints": "not a list",
+			}),
+			makeUnstructured(PodMonitorGVK, "default", "sample", map[string]any{
+				"podMetricsEndpoints": []any{map[string]any{"port": "metrics"}},
+			}),
+		).Build()
+
+	serviceMonitors, serviceMonitorIssues, err := ListServiceMonitors(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, serviceMonitors, 1)
+	require.Len(t, serviceMonitors[0].Spec.Endpoints, 1, "endpoints with unsupported settings must be skipped")
+	require.Equal(t, "http-metrics", serviceMonitors[0].Spec.Endpoints[0].Port)
+	require.Equal(t, []Issue{
+		{Kind: "ServiceMonitor", Namespace: "default", Name: "backend", Reason: "endpoint 0 uses the unsupported settings tlsConfig.ca"},
+		{Kind: "ServiceMonitor", Namespace: "default", Name: "backend", Reason: "endpoint 1 uses the unsupported settings basicAuth, bearerTokenSecret"},
+	}, serviceMonitorIssues)
+
+	podMonitors, podMonitorIssues, err := ListPodMonitors(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, podMonitors, 1, "monitors that cannot be decoded must not prevent scraping the other monitors")
+	require.Equal(t, "sample", podMonitors[0].Name)
+	require.Equal(t, []Issue{{Kind: "PodMonitor", Namespace: "default", Name: "broken", Reason: "cannot be decoded"}}, podMonitorIssues)
+	require.Equal(t, "PodMonitor default/broken cannot be decoded", podMonitorIssues[0].String())
+}
+
+func makeUnstructured(gvk schema.GroupVersionKind, namespace, name string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
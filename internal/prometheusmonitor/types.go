@@ -0,0 +1,102 @@
+package prometheusmonitor
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// The types in this file mirror the subset of the monitoring.coreos.com/v1 API of the Prometheus Operator that is translated into scrape configs.
+// They are decoded from unstructured objects, so the telemetry manager does not depend on the Prometheus Operator module.
+
+type ServiceMonitor struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceMonitorSpec `json:"spec"`
+}
+
+type ServiceMonitorSpec struct {
+	Endpoints         []Endpoint           `json:"endpoints"`
+	Selector          metav1.LabelSelector `json:"selector"`
+	NamespaceSelector NamespaceSelector    `json:"namespaceSelector,omitempty"`
+	SampleLimit       *uint64              `json:"sampleLimit,omitempty"`
+}
+
+type Endpoint struct {
+	Port                 string              `json:"port,omitempty"`
+	TargetPort           *intstr.IntOrString `json:"targetPort,omitempty"`
+	Path                 string              `json:"path,omitempty"`
+	Scheme               string              `json:"scheme,omitempty"`
+	Interval             string              `json:"interval,omitempty"`
+	ScrapeTimeout        string              `json:"scrapeTimeout,omitempty"`
+	HonorLabels          bool                `json:"honorLabels,omitempty"`
+	TLSConfig            *TLSConfig          `json:"tlsConfig,omitempty"`
+	RelabelConfigs       []RelabelConfig     `json:"relabelings,omitempty"`
+	MetricRelabelConfigs []RelabelConfig     `json:"metricRelabelings,omitempty"`
+	Authentication       `json:",inline"`
+}
+
+type PodMonitor struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PodMonitorSpec `json:"spec"`
+}
+
+type PodMonitorSpec struct {
+	PodMetricsEndpoints []PodMetricsEndpoint `json:"podMetricsEndpoints"`
+	Selector            metav1.LabelSelector `json:"selector"`
+	NamespaceSelector   NamespaceSelector    `json:"namespaceSelector,omitempty"`
+	SampleLimit         *uint64              `json:"sampleLimit,omitempty"`
+}
+
+type PodMetricsEndpoint struct {
+	Port                 string              `json:"port,omitempty"`
+	TargetPort           *intstr.IntOrString `json:"targetPort,omitempty"`
+	Path                 string              `json:"path,omitempty"`
+	Scheme               string              `json:"scheme,omitempty"`
+	Interval             string              `json:"interval,omitempty"`
+	ScrapeTimeout        string              `json:"scrapeTimeout,omitempty"`
+	HonorLabels          bool                `json:"honorLabels,omitempty"`
+	TLSConfig            *TLSConfig          `json:"tlsConfig,omitempty"`
+	RelabelConfigs       []RelabelConfig     `json:"relabelings,omitempty"`
+	MetricRelabelConfigs []RelabelConfig     `json:"metricRelabelings,omitempty"`
+	Authentication       `json:",inline"`
+}
+
+// Authentication covers the authentication settings of an endpoint. They are only decoded to detect them, because none of them is supported:
+// the agent cannot mount the referenced Secrets, and the token files belong to the Pod of the Prometheus server.
+type Authentication struct {
+	BasicAuth         map[string]any `json:"basicAuth,omitempty"`
+	BearerTokenSecret map[string]any `json:"bearerTokenSecret,omitempty"`
+	BearerTokenFile   string         `json:"bearerTokenFile,omitempty"`
+	Authorization     map[string]any `json:"authorization,omitempty"`
+	OAuth2            map[string]any `json:"oauth2,omitempty"`
+}
+
+// NamespaceSelector selects the Namespaces of the scrape targets. If neither Any nor MatchNames is set, only the Namespace of the monitor is selected.
+type NamespaceSelector struct {
+	Any        bool     `json:"any,omitempty"`
+	MatchNames []string `json:"matchNames,omitempty"`
+}
+
+// TLSConfig covers the TLS settings. Only the file-based settings are supported. References to Secrets and ConfigMaps are decoded
+// only to detect them, because the agent cannot mount them.
+type TLSConfig struct {
+	CAFile             string         `json:"caFile,omitempty"`
+	CertFile           string         `json:"certFile,omitempty"`
+	KeyFile            string         `json:"keyFile,omitempty"`
+	ServerName         string         `json:"serverName,omitempty"`
+	InsecureSkipVerify bool           `json:"insecureSkipVerify,omitempty"`
+	CA                 map[string]any `json:"ca,omitempty"`
+	Cert               map[string]any `json:"cert,omitempty"`
+	KeySecret          map[string]any `json:"keySecret,omitempty"`
+}
+
+type RelabelConfig struct {
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+	Separator    *string  `json:"separator,omitempty"`
+	TargetLabel  string   `json:"targetLabel,omitempty"`
+	Regex        string   `json:"regex,omitempty"`
+	Modulus      uint64   `json:"modulus,omitempty"`
+	Replacement  *string  `json:"replacement,omitempty"`
+	Action       string   `json:"action,omitempty"`
+}
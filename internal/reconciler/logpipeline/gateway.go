@@ -0,0 +1,75 @@
+package logpipeline
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log/gateway"
+	otelports "github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
+)
+
+const defaultGatewayReplicaCount int32 = 2
+
+func (r *Reconciler) reconcileLogGateway(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, otlpPipelines []telemetryv1alpha1.LogPipeline) error {
+	scaling := otelcollector.GatewayScalingConfig{
+		Replicas:                       defaultGatewayReplicaCount,
+		ResourceRequirementsMultiplier: len(otlpPipelines),
+	}
+
+	collectorConfig, collectorEnvVars, err := gateway.MakeConfig(ctx, r.Client, otlpPipelines)
+	if err != nil {
+		return fmt.Errorf("failed to create collector config: %w", err)
+	}
+
+	collectorConfigYAML, err := yaml.Marshal(collectorConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collector config: %w", err)
+	}
+
+	gateway.AddTapExporters(collectorConfig, tap.ExporterTaps(ctx, r.Client, r.config.TapEndpoint, tap.KindLogPipelines, otlpPipelines))
+	collectorConfigWithTapsYAML, err := yaml.Marshal(collectorConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collector config with tap exporters: %w", err)
+	}
+
+	isIstioActive := r.istioStatusChecker.IsIstioActive(ctx)
+
+	allowedPorts := []int32{
+		otelports.OTLPHTTP,
+		otelports.OTLPGRPC,
+		otelports.Metrics,
+		otelports.HealthCheck,
+	}
+
+	if isIstioActive {
+		allowedPorts = append(allowedPorts, otelports.IstioEnvoy)
+	}
+
+	if err := otelcollector.ApplyGatewayResources(ctx,
+		k8sutils.NewOwnerReferenceSetter(r.Client, pipeline),
+		r.config.Gateway.WithScaling(scaling).WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars).
+			WithTapExporters(string(collectorConfigWithTapsYAML)).
+			WithIstioConfig(fmt.Sprintf("%d", otelports.Metrics), isIstioActive).
+			WithAllowedPorts(allowedPorts)); err != nil {
+		return fmt.Errorf("failed to apply log gateway resources: %w", err)
+	}
+
+	return nil
+}
+
+// deleteLogGateway removes the log gateway once no pipeline has an OTLP output anymore.
+// Its resources are owned by the pipelines that used it, which are not necessarily deleted, but can have switched to another output.
+func (r *Reconciler) deleteLogGateway(ctx context.Context) error {
+	isIstioActive := r.istioStatusChecker.IsIstioActive(ctx)
+	if err := otelcollector.DeleteGatewayResources(ctx, r.Client, r.config.Gateway.WithIstioConfig("", isIstioActive)); err != nil {
+		return fmt.Errorf("failed to delete log gateway resources: %w", err)
+	}
+
+	return nil
+}
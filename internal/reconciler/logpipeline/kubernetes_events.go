@@ -0,0 +1,97 @@
+package logpipeline
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/configchecksum"
+	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config/builder"
+	"github.com/kyma-project/telemetry-manager/internal/fluentbit/ports"
+	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
+	"github.com/kyma-project/telemetry-manager/internal/resources/fluentbit"
+)
+
+// reconcileKubernetesEventsCollector deploys the Fluent Bit instance that collects the Kubernetes events for the pipelines with the
+// kubernetesEvents input. If no pipeline selects Kubernetes events, the instance is removed.
+func (r *Reconciler) reconcileKubernetesEventsCollector(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, pipelines []telemetryv1alpha1.LogPipeline) error {
+	name := r.config.KubernetesEventsCollector
+
+	sections := builder.BuildKubernetesEventsConfig(pipelines, r.config.PipelineDefaults)
+	if sections == "" {
+		if err := fluentbit.DeleteKubernetesEventsResources(ctx, r.Client, name); err != nil {
+			return fmt.Errorf("failed to delete kubernetes events collector resources: %w", err)
+		}
+		return nil
+	}
+
+	ownerRefSetter := k8sutils.NewOwnerReferenceSetter(r.Client, pipeline)
+
+	serviceAccount := commonresources.MakeServiceAccount(name)
+	if err := k8sutils.CreateOrUpdateServiceAccount(ctx, ownerRefSetter, serviceAccount); err != nil {
+		return fmt.Errorf("failed to create kubernetes events collector service account: %w", err)
+	}
+
+	clusterRole := fluentbit.MakeKubernetesEventsClusterRole(name)
+	if err := k8sutils.CreateOrUpdateClusterRole(ctx, ownerRefSetter, clusterRole); err != nil {
+		return fmt.Errorf("failed to create kubernetes events collector cluster role: %w", err)
+	}
+
+	clusterRoleBinding := commonresources.MakeClusterRoleBinding(name)
+	if err := k8sutils.CreateOrUpdateClusterRoleBinding(ctx, ownerRefSetter, clusterRoleBinding); err != nil {
+		return fmt.Errorf("failed to create kubernetes events collector cluster role binding: %w", err)
+	}
+
+	cm := fluentbit.MakeKubernetesEventsConfigMap(name, sections)
+	if err := k8sutils.CreateOrUpdateConfigMap(ctx, ownerRefSetter, cm); err != nil {
+		return fmt.Errorf("failed to reconcile kubernetes events collector configmap: %w", err)
+	}
+
+	checksum, err := r.calculateKubernetesEventsChecksum(ctx, cm)
+	if err != nil {
+		return fmt.Errorf("failed to calculate kubernetes events collector config checksum: %w", err)
+	}
+
+	deployment := fluentbit.MakeKubernetesEventsDeployment(name, r.config.DaemonSet.Name, checksum, r.config.DaemonSetConfig, r.config.KubernetesEventsCollectorConfig)
+	if err := k8sutils.CreateOrUpdateDeployment(ctx, ownerRefSetter, deployment); err != nil {
+		return fmt.Errorf("failed to reconcile kubernetes events collector deployment: %w", err)
+	}
+
+	allowedPorts := []int32{ports.HTTP}
+	if r.istioStatusChecker.IsIstioActive(ctx) {
+		allowedPorts = append(allowedPorts, ports.IstioEnvoy)
+	}
+	networkPolicy := commonresources.MakeNetworkPolicy(name, allowedPorts, fluentbit.KubernetesEventsLabels())
+	if err := k8sutils.CreateOrUpdateNetworkPolicy(ctx, ownerRefSetter, networkPolicy); err != nil {
+		return fmt.Errorf("failed to create kubernetes events collector network policy: %w", err)
+	}
+
+	return nil
+}
+
+// calculateKubernetesEventsChecksum covers the own configuration of the collector and the configuration it shares with the Fluent Bit DaemonSet.
+func (r *Reconciler) calculateKubernetesEventsChecksum(ctx context.Context, cm *corev1.ConfigMap) (string, error) {
+	configMaps := []corev1.ConfigMap{*cm}
+	for _, name := range []types.NamespacedName{r.config.LuaConfigMap, r.config.ParsersConfigMap, r.config.FilesConfigMap} {
+		var sharedCm corev1.ConfigMap
+		if err := r.Get(ctx, name, &sharedCm); err != nil {
+			return "", fmt.Errorf("failed to get %s/%s ConfigMap: %w", name.Namespace, name.Name, err)
+		}
+		configMaps = append(configMaps, sharedCm)
+	}
+
+	var secrets []corev1.Secret
+	for _, name := range []types.NamespacedName{r.config.EnvSecret, r.config.OutputTLSConfigSecret} {
+		var sharedSecret corev1.Secret
+		if err := r.Get(ctx, name, &sharedSecret); err != nil {
+			return "", fmt.Errorf("failed to get %s/%s Secret: %w", name.Namespace, name.Name, err)
+		}
+		secrets = append(secrets, sharedSecret)
+	}
+
+	return configchecksum.Calculate(configMaps, secrets), nil
+}
@@ -0,0 +1,94 @@
+package logpipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/istiostatus"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestReconcileKubernetesEventsCollector(t *testing.T) {
+	config := Config{
+		DaemonSet:                 types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit"},
+		KubernetesEventsCollector: types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-events"},
+		FilesConfigMap:            types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-files"},
+		LuaConfigMap:              types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-luascripts"},
+		ParsersConfigMap:          types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-parsers"},
+		EnvSecret:                 types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-env"},
+		OutputTLSConfigSecret:     types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-output-tls-config"},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+
+	eventsPipeline := testutils.NewLogPipelineBuilder().
+		WithName("events").
+		WithKubernetesEventsInput(telemetryv1alpha1.KubernetesEventTypeWarning).
+		WithCustomOutput("Name stdout").
+		Build()
+	logsPipeline := testutils.NewLogPipelineBuilder().WithName("logs").WithCustomOutput("Name stdout").Build()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&eventsPipeline,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.FilesConfigMap.Name, Namespace: config.FilesConfigMap.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.LuaConfigMap.Name, Namespace: config.LuaConfigMap.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.ParsersConfigMap.Name, Namespace: config.ParsersConfigMap.Namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: config.EnvSecret.Name, Namespace: config.EnvSecret.Namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: config.OutputTLSConfigSecret.Name, Namespace: config.OutputTLSConfigSecret.Namespace}},
+	).Build()
+
+	r := Reconciler{
+		Client:             fakeClient,
+		config:             config,
+		istioStatusChecker: istiostatus.NewChecker(fakeClient),
+	}
+	ctx := context.Background()
+
+	t.Run("should deploy the collector if a pipeline selects kubernetes events", func(t *testing.T) {
+		err := r.reconcileKubernetesEventsCollector(ctx, &eventsPipeline, []telemetryv1alpha1.LogPipeline{eventsPipeline, logsPipeline})
+		require.NoError(t, err)
+
+		var cm corev1.ConfigMap
+		require.NoError(t, fakeClient.Get(ctx, config.KubernetesEventsCollector, &cm))
+		require.Contains(t, cm.Data["fluent-bit.conf"], "kubernetes_events")
+		require.Contains(t, cm.Data["fluent-bit.conf"], "events.events")
+		require.NotContains(t, cm.Data["fluent-bit.conf"], "logs.events")
+
+		var deployment appsv1.Deployment
+		require.NoError(t, fakeClient.Get(ctx, config.KubernetesEventsCollector, &deployment))
+		require.NotEmpty(t, deployment.Spec.Template.Annotations["checksum/logpipeline-config"])
+	})
+
+	t.Run("should remove the collector if no pipeline selects kubernetes events", func(t *testing.T) {
+		err := r.reconcileKubernetesEventsCollector(ctx, &logsPipeline, []telemetryv1alpha1.LogPipeline{logsPipeline})
+		require.NoError(t, err)
+
+		for _, obj := range []client.Object{
+			&appsv1.Deployment{},
+			&corev1.ConfigMap{},
+			&corev1.ServiceAccount{},
+			&rbacv1.ClusterRole{},
+			&rbacv1.ClusterRoleBinding{},
+			&networkingv1.NetworkPolicy{},
+		} {
+			err = fakeClient.Get(ctx, config.KubernetesEventsCollector, obj)
+			require.True(t, apierrors.IsNotFound(err), "%T must be deleted", obj)
+		}
+	})
+}
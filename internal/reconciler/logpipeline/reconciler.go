@@ -34,26 +34,35 @@ import (
 	"github.com/kyma-project/telemetry-manager/internal/istiostatus"
 	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
 	"github.com/kyma-project/telemetry-manager/internal/overrides"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/telemetrysettings"
 	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 	"github.com/kyma-project/telemetry-manager/internal/resources/fluentbit"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
 	"github.com/kyma-project/telemetry-manager/internal/tlscert"
 )
 
 type Config struct {
-	DaemonSet               types.NamespacedName
-	SectionsConfigMap       types.NamespacedName
-	FilesConfigMap          types.NamespacedName
-	LuaConfigMap            types.NamespacedName
-	ParsersConfigMap        types.NamespacedName
-	EnvSecret               types.NamespacedName
-	OutputTLSConfigSecret   types.NamespacedName
-	OverrideConfigMap       types.NamespacedName
-	PipelineDefaults        builder.PipelineDefaults
-	Overrides               overrides.Config
-	DaemonSetConfig         fluentbit.DaemonSetConfig
-	ObserveBySelfMonitoring bool
+	DaemonSet                 types.NamespacedName
+	KubernetesEventsCollector types.NamespacedName
+	SectionsConfigMap         types.NamespacedName
+	FilesConfigMap            types.NamespacedName
+	LuaConfigMap              types.NamespacedName
+	ParsersConfigMap          types.NamespacedName
+	EnvSecret                 types.NamespacedName
+	OutputTLSConfigSecret     types.NamespacedName
+	OverrideConfigMap         types.NamespacedName
+	PipelineDefaults          builder.PipelineDefaults
+	Overrides                 overrides.Config
+	DaemonSetConfig           fluentbit.DaemonSetConfig
+	// KubernetesEventsCollectorConfig contains the resources of the Fluent Bit instance that collects the Kubernetes events.
+	KubernetesEventsCollectorConfig fluentbit.KubernetesEventsConfig
+	Gateway                         otelcollector.GatewayConfig
+	ObserveBySelfMonitoring         bool
+	// TapEndpoint is the endpoint of the telemetry manager, to which pipelines with an active tap send their data. The zero value disables the tap.
+	TapEndpoint tap.Endpoint
 }
 
 //go:generate mockery --name DaemonSetProber --filename daemon_set_prober.go
@@ -61,6 +70,11 @@ type DaemonSetProber interface {
 	IsReady(ctx context.Context, name types.NamespacedName) (bool, error)
 }
 
+//go:generate mockery --name DeploymentProber --filename deployment_prober.go
+type DeploymentProber interface {
+	IsReady(ctx context.Context, name types.NamespacedName) (bool, error)
+}
+
 //go:generate mockery --name DaemonSetAnnotator --filename daemon_set_annotator.go
 type DaemonSetAnnotator interface {
 	SetAnnotation(ctx context.Context, name types.NamespacedName, key, value string) error
@@ -76,12 +90,26 @@ type FlowHealthProber interface {
 	Probe(ctx context.Context, pipelineName string) (prober.LogPipelineProbeResult, error)
 }
 
+//go:generate mockery --name GatewayFlowHealthProber --filename gateway_flow_health_prober.go
+type GatewayFlowHealthProber interface {
+	Probe(ctx context.Context, pipelineName string) (prober.OTelPipelineProbeResult, error)
+}
+
+//go:generate mockery --name DataFlowStatsProber --filename data_flow_stats_prober.go
+type DataFlowStatsProber interface {
+	Stats(ctx context.Context, pipelineName string) (prober.PipelineStats, error)
+}
+
 type Reconciler struct {
 	client.Client
 	config                     Config
 	prober                     DaemonSetProber
+	gatewayProber              DeploymentProber
 	flowHealthProbingEnabled   bool
 	flowHealthProber           FlowHealthProber
+	dataFlowStatsProber        DataFlowStatsProber
+	gatewayFlowHealthProber    GatewayFlowHealthProber
+	gatewayDataFlowStatsProber DataFlowStatsProber
 	syncer                     syncer
 	overridesHandler           *overrides.Handler
 	istioStatusChecker         istiostatus.Checker
@@ -93,15 +121,23 @@ func NewReconciler(
 	client client.Client,
 	config Config,
 	agentProber DaemonSetProber,
+	gatewayProber DeploymentProber,
 	flowHealthProbingEnabled bool,
 	flowHealthProber FlowHealthProber,
+	dataFlowStatsProber DataFlowStatsProber,
+	gatewayFlowHealthProber GatewayFlowHealthProber,
+	gatewayDataFlowStatsProber DataFlowStatsProber,
 	overridesHandler *overrides.Handler) *Reconciler {
 	var r Reconciler
 	r.Client = client
 	r.config = config
 	r.prober = agentProber
+	r.gatewayProber = gatewayProber
 	r.flowHealthProbingEnabled = flowHealthProbingEnabled
 	r.flowHealthProber = flowHealthProber
+	r.dataFlowStatsProber = dataFlowStatsProber
+	r.gatewayFlowHealthProber = gatewayFlowHealthProber
+	r.gatewayDataFlowStatsProber = gatewayDataFlowStatsProber
 	r.syncer = syncer{client, config}
 	r.overridesHandler = overridesHandler
 	r.istioStatusChecker = istiostatus.NewChecker(client)
@@ -132,9 +168,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 }
 
 func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) (err error) {
+	settings := telemetrysettings.FromTelemetry(ctx, r.Client)
+
 	// defer the updating of status to ensure that the status is updated regardless of the outcome of the reconciliation
 	defer func() {
-		if statusErr := r.updateStatus(ctx, pipeline.Name); statusErr != nil {
+		if statusErr := r.updateStatus(ctx, pipeline.Name, settings); statusErr != nil {
 			if err != nil {
 				err = fmt.Errorf("failed while updating status: %w: %w", statusErr, err)
 			} else {
@@ -156,12 +194,29 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 		return err
 	}
 
+	if err = tap.DeleteInactiveSamples(ctx, r.Client, r.config.DaemonSet.Namespace, tap.KindLogPipelines, pipeline); err != nil {
+		return err
+	}
+
 	reconcilablePipelines := r.getReconcilablePipelines(ctx, allPipelines.Items)
-	if err = r.syncer.syncFluentBitConfig(ctx, pipeline, reconcilablePipelines); err != nil {
+	fluentBitPipelines, otlpPipelines := splitByOutputType(reconcilablePipelines)
+	if err = r.syncer.syncFluentBitConfig(ctx, pipeline, fluentBitPipelines); err != nil {
+		return err
+	}
+
+	if len(fluentBitPipelines) > 0 {
+		if err = r.reconcileFluentBit(ctx, pipeline, fluentBitPipelines, settings.FluentBit); err != nil {
+			return err
+		}
+	} else if err = r.deleteFluentBit(ctx); err != nil {
 		return err
 	}
 
-	if err = r.reconcileFluentBit(ctx, pipeline, reconcilablePipelines); err != nil {
+	if len(otlpPipelines) > 0 {
+		if err = r.reconcileLogGateway(ctx, pipeline, otlpPipelines); err != nil {
+			return err
+		}
+	} else if err = r.deleteLogGateway(ctx); err != nil {
 		return err
 	}
 
@@ -172,7 +227,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 	return err
 }
 
-func (r *Reconciler) reconcileFluentBit(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, pipelines []telemetryv1alpha1.LogPipeline) error {
+func (r *Reconciler) reconcileFluentBit(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, pipelines []telemetryv1alpha1.LogPipeline, resourceOverrides commonresources.ResourceOverrides) error {
 	ownerRefSetter := k8sutils.NewOwnerReferenceSetter(r.Client, pipeline)
 
 	serviceAccount := commonresources.MakeServiceAccount(r.config.DaemonSet)
@@ -200,16 +255,12 @@ func (r *Reconciler) reconcileFluentBit(ctx context.Context, pipeline *telemetry
 		return fmt.Errorf("failed to reconcile fluent bit metrics service: %w", err)
 	}
 
-	includeSections := true
-	if len(pipelines) == 0 {
-		includeSections = false
-	}
-	cm := fluentbit.MakeConfigMap(r.config.DaemonSet, includeSections)
+	cm := fluentbit.MakeConfigMap(r.config.DaemonSet, true)
 	if err := k8sutils.CreateOrUpdateConfigMap(ctx, ownerRefSetter, cm); err != nil {
 		return fmt.Errorf("failed to reconcile fluent bit configmap: %w", err)
 	}
 
-	luaCm := fluentbit.MakeLuaConfigMap(r.config.LuaConfigMap)
+	luaCm := fluentbit.MakeLuaConfigMap(r.config.LuaConfigMap, builder.BuildRedactionScript(pipelines))
 	if err := k8sutils.CreateOrUpdateConfigMap(ctx, ownerRefSetter, luaCm); err != nil {
 		return fmt.Errorf("failed to reconcile fluent bit lua configmap: %w", err)
 	}
@@ -219,13 +270,14 @@ func (r *Reconciler) reconcileFluentBit(ctx context.Context, pipeline *telemetry
 		return fmt.Errorf("failed to reconcile fluent bit parser configmap: %w", err)
 	}
 
-	var checksum string
-	var err error
-	if checksum, err = r.calculateChecksum(ctx); err != nil {
+	checksum, err := r.calculateChecksum(ctx)
+	if err != nil {
 		return fmt.Errorf("failed to calculate config checksum: %w", err)
 	}
 
-	daemonSet := fluentbit.MakeDaemonSet(r.config.DaemonSet, checksum, r.config.DaemonSetConfig)
+	daemonSetConfig := r.fluentBitConfig(ctx, resourceOverrides).
+		WithJournald(isJournaldRequired(pipelines))
+	daemonSet := fluentbit.MakeDaemonSet(r.config.DaemonSet, checksum, daemonSetConfig)
 	if err := k8sutils.CreateOrUpdateDaemonSet(ctx, ownerRefSetter, daemonSet); err != nil {
 		return fmt.Errorf("failed to reconcile fluent bit daemonset: %w", err)
 	}
@@ -239,6 +291,19 @@ func (r *Reconciler) reconcileFluentBit(ctx context.Context, pipeline *telemetry
 		return fmt.Errorf("failed to create fluent bit network policy: %w", err)
 	}
 
+	return r.reconcileKubernetesEventsCollector(ctx, pipeline, pipelines)
+}
+
+// deleteFluentBit removes the Fluent Bit DaemonSet and the Kubernetes events collector once no pipeline uses a Fluent Bit output.
+func (r *Reconciler) deleteFluentBit(ctx context.Context) error {
+	if err := fluentbit.DeleteKubernetesEventsResources(ctx, r.Client, r.config.KubernetesEventsCollector); err != nil {
+		return fmt.Errorf("failed to delete kubernetes events collector resources: %w", err)
+	}
+
+	if err := fluentbit.DeleteResources(ctx, r.Client, r.config.DaemonSet, r.config.LuaConfigMap); err != nil {
+		return fmt.Errorf("failed to delete fluent bit resources: %w", err)
+	}
+
 	return nil
 }
 
@@ -305,12 +370,12 @@ func (r *Reconciler) isReconcilable(ctx context.Context, pipeline *telemetryv1al
 	if pipeline.Spec.Output.IsLokiDefined() {
 		return false
 	}
+	if pipeline.ValidateRedaction() != nil {
+		return false
+	}
 
 	if tlsCertValidationRequired(pipeline) {
-		cert := pipeline.Spec.Output.HTTP.TLSConfig.Cert
-		key := pipeline.Spec.Output.HTTP.TLSConfig.Key
-
-		if err := r.tlsCertValidator.ValidateCertificate(ctx, cert, key); err != nil {
+		if err := r.validateTLSCertificates(ctx, pipeline); err != nil {
 			if !tlscert.IsCertAboutToExpireError(err) {
 				return false
 			}
@@ -327,12 +392,50 @@ func getFluentBitPorts() []int32 {
 	}
 }
 
+// splitByOutputType separates the pipelines rendered into the Fluent Bit configuration from the ones with an OTLP output, which are served by the log gateway.
+func splitByOutputType(pipelines []telemetryv1alpha1.LogPipeline) (fluentBitPipelines, otlpPipelines []telemetryv1alpha1.LogPipeline) {
+	for i := range pipelines {
+		if pipelines[i].Spec.Output.IsOtlpDefined() {
+			otlpPipelines = append(otlpPipelines, pipelines[i])
+		} else {
+			fluentBitPipelines = append(fluentBitPipelines, pipelines[i])
+		}
+	}
+
+	return fluentBitPipelines, otlpPipelines
+}
+
+func isJournaldRequired(pipelines []telemetryv1alpha1.LogPipeline) bool {
+	for i := range pipelines {
+		if pipelines[i].Spec.Input.System.IsJournaldDefined() {
+			return true
+		}
+	}
+	return false
+}
+
 func tlsCertValidationRequired(pipeline *telemetryv1alpha1.LogPipeline) bool {
-	http := pipeline.Spec.Output.HTTP
-	if http == nil {
-		return false
+	return len(clientCertificates(pipeline)) > 0
+}
+
+// validateTLSCertificates validates the client certificates of all outputs of the pipeline.
+func (r *Reconciler) validateTLSCertificates(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) error {
+	return tlscert.ValidateCertificates(ctx, r.tlsCertValidator, clientCertificates(pipeline))
+}
+
+// clientCertificates returns the client certificates and keys configured for the outputs of the pipeline.
+func clientCertificates(pipeline *telemetryv1alpha1.LogPipeline) []tlscert.ClientCertificate {
+	var certs []tlscert.ClientCertificate
+
+	if http := pipeline.Spec.Output.HTTP; http != nil && (http.TLSConfig.Cert != nil || http.TLSConfig.Key != nil) {
+		certs = append(certs, tlscert.ClientCertificate{Cert: http.TLSConfig.Cert, Key: http.TLSConfig.Key})
 	}
-	return http.TLSConfig.Cert != nil || http.TLSConfig.Key != nil
+
+	if pipeline.Spec.Output.Otlp != nil {
+		certs = append(certs, tlscert.OtlpClientCertificates(pipeline.Spec.Output.Otlp, pipeline.Spec.AdditionalOutputs)...)
+	}
+
+	return certs
 }
 
 // clearPipelinesConditions clears the status conditions for all LogPipelines only in the 1st reconciliation
@@ -354,3 +457,14 @@ func (r *Reconciler) clearPipelinesConditions(ctx context.Context, allPipelines
 
 	return nil
 }
+
+// fluentBitConfig returns the Fluent Bit config with the resource overrides of the Telemetry resource.
+// If the overrides are invalid, Fluent Bit keeps its default resources and the pipeline status reports the error.
+func (r *Reconciler) fluentBitConfig(ctx context.Context, overrides commonresources.ResourceOverrides) fluentbit.DaemonSetConfig {
+	daemonSetConfig := r.config.DaemonSetConfig.WithResourceOverrides(overrides)
+	if err := daemonSetConfig.ValidateResources(); err != nil {
+		logf.FromContext(ctx).V(1).Info("Ignoring invalid resource overrides of Fluent Bit", "error", err.Error())
+		return r.config.DaemonSetConfig
+	}
+	return daemonSetConfig
+}
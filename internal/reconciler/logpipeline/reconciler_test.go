@@ -6,15 +6,20 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/istiostatus"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/logpipeline/mocks"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 	"github.com/kyma-project/telemetry-manager/internal/testutils"
 	"github.com/kyma-project/telemetry-manager/internal/tlscert"
 )
@@ -80,6 +85,13 @@ func TestGetReconcilableLogPipelines(t *testing.T) {
 			},
 			reconcilableLogPipelines: true,
 		},
+		{
+			name: "should accept LogPipelines with OTLP output",
+			pipelines: []telemetryv1alpha1.LogPipeline{
+				testutils.NewLogPipelineBuilder().WithName("pipeline-with-otlp-output").WithOTLPOutput().Build(),
+			},
+			reconcilableLogPipelines: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -115,6 +127,28 @@ func TestGetReconcilableLogPipelines(t *testing.T) {
 	}
 }
 
+func TestSplitByOutputType(t *testing.T) {
+	httpPipeline := testutils.NewLogPipelineBuilder().WithName("http").Build()
+	customPipeline := testutils.NewLogPipelineBuilder().WithName("custom").WithCustomOutput("Name	stdout\n").Build()
+	otlpPipeline := testutils.NewLogPipelineBuilder().WithName("otlp").WithOTLPOutput().Build()
+
+	fluentBitPipelines, otlpPipelines := splitByOutputType([]telemetryv1alpha1.LogPipeline{httpPipeline, otlpPipeline, customPipeline})
+
+	require.Equal(t, []telemetryv1alpha1.LogPipeline{httpPipeline, customPipeline}, fluentBitPipelines)
+	require.Equal(t, []telemetryv1alpha1.LogPipeline{otlpPipeline}, otlpPipelines)
+}
+
+func TestIsJournaldRequired(t *testing.T) {
+	logsPipeline := testutils.NewLogPipelineBuilder().WithName("logs").Build()
+	filesPipeline := testutils.NewLogPipelineBuilder().WithName("files").Build()
+	filesPipeline.Spec.Input.System = &telemetryv1alpha1.SystemInput{Files: []string{"/var/log/syslog"}}
+	journaldPipeline := testutils.NewLogPipelineBuilder().WithName("journald").Build()
+	journaldPipeline.Spec.Input.System = &telemetryv1alpha1.SystemInput{Units: []string{"kubelet.service"}}
+
+	require.False(t, isJournaldRequired([]telemetryv1alpha1.LogPipeline{logsPipeline, filesPipeline}))
+	require.True(t, isJournaldRequired([]telemetryv1alpha1.LogPipeline{logsPipeline, journaldPipeline}))
+}
+
 func TestCalculateChecksum(t *testing.T) {
 	config := Config{
 		DaemonSet: types.NamespacedName{
@@ -300,3 +334,62 @@ func TestCalculateChecksum(t *testing.T) {
 		require.NotEqualf(t, checksum, newChecksum, "Checksum not changed by updating certificate secret")
 	})
 }
+
+func TestDeleteFluentBit(t *testing.T) {
+	config := Config{
+		DaemonSet:                 types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit"},
+		KubernetesEventsCollector: types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-events"},
+		SectionsConfigMap:         types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-sections"},
+		FilesConfigMap:            types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-files"},
+		LuaConfigMap:              types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-luascripts"},
+		ParsersConfigMap:          types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-parsers"},
+		EnvSecret:                 types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-env"},
+		OutputTLSConfigSecret:     types.NamespacedName{Namespace: "kyma-system", Name: "telemetry-fluent-bit-output-tls-config"},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+
+	pipeline := testutils.NewLogPipelineBuilder().
+		WithName("events").
+		WithKubernetesEventsInput(telemetryv1alpha1.KubernetesEventTypeWarning).
+		WithCustomOutput("Name stdout").
+		Build()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&pipeline,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.SectionsConfigMap.Name, Namespace: config.SectionsConfigMap.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: config.FilesConfigMap.Name, Namespace: config.FilesConfigMap.Namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: config.EnvSecret.Name, Namespace: config.EnvSecret.Namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: config.OutputTLSConfigSecret.Name, Namespace: config.OutputTLSConfigSecret.Namespace}},
+	).Build()
+
+	r := Reconciler{
+		Client:             fakeClient,
+		config:             config,
+		istioStatusChecker: istiostatus.NewChecker(fakeClient),
+	}
+	ctx := context.Background()
+
+	require.NoError(t, r.reconcileFluentBit(ctx, &pipeline, []telemetryv1alpha1.LogPipeline{pipeline}, commonresources.ResourceOverrides{}))
+	require.NoError(t, fakeClient.Get(ctx, config.DaemonSet, &appsv1.DaemonSet{}))
+	require.NoError(t, fakeClient.Get(ctx, config.KubernetesEventsCollector, &appsv1.Deployment{}))
+
+	require.NoError(t, r.deleteFluentBit(ctx))
+
+	for name, obj := range map[types.NamespacedName]client.Object{
+		config.DaemonSet:                 &appsv1.DaemonSet{},
+		config.KubernetesEventsCollector: &appsv1.Deployment{},
+		config.LuaConfigMap:              &corev1.ConfigMap{},
+		{Namespace: "kyma-system", Name: "telemetry-fluent-bit-metrics"}:          &corev1.Service{},
+		{Namespace: "kyma-system", Name: "telemetry-fluent-bit-exporter-metrics"}: &corev1.Service{},
+	} {
+		err := fakeClient.Get(ctx, name, obj)
+		require.True(t, apierrors.IsNotFound(err), "%T %s must be deleted", obj, name.Name)
+	}
+
+	for _, name := range []types.NamespacedName{config.ParsersConfigMap, config.SectionsConfigMap, config.FilesConfigMap} {
+		require.NoError(t, fakeClient.Get(ctx, name, &corev1.ConfigMap{}), "%s must be kept", name.Name)
+	}
+}
@@ -12,11 +12,12 @@ import (
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/telemetrysettings"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
 )
 
-func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string) error {
+func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, settings telemetrysettings.Settings) error {
 	var pipeline telemetryv1alpha1.LogPipeline
 	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -36,11 +37,16 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string) erro
 		return err
 	}
 
-	r.setAgentHealthyCondition(ctx, &pipeline)
-	r.setFluentBitConfigGeneratedCondition(ctx, &pipeline)
+	if pipeline.Spec.Output.IsOtlpDefined() {
+		r.setGatewayHealthyCondition(ctx, &pipeline)
+	} else {
+		r.setAgentHealthyCondition(ctx, &pipeline)
+	}
+	r.setFluentBitConfigGeneratedCondition(ctx, &pipeline, settings)
 
 	if r.flowHealthProbingEnabled {
 		r.setFlowHealthCondition(ctx, &pipeline)
+		pipeline.Status.DataFlow = prober.DataFlowStatus(ctx, r.dataFlowStatsProberFor(&pipeline), pipeline.Name, pipeline.Status.DataFlow)
 	}
 
 	r.setLegacyConditions(ctx, &pipeline)
@@ -89,8 +95,38 @@ func (r *Reconciler) setAgentHealthyCondition(ctx context.Context, pipeline *tel
 	meta.SetStatusCondition(&pipeline.Status.Conditions, condition)
 }
 
-func (r *Reconciler) setFluentBitConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) {
-	status, reason, message := r.evaluateConfigGeneratedCondition(ctx, pipeline)
+func (r *Reconciler) setGatewayHealthyCondition(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) {
+	status := metav1.ConditionFalse
+	reason := conditions.ReasonGatewayNotReady
+	if r.isGatewayReady(ctx) {
+		status = metav1.ConditionTrue
+		reason = conditions.ReasonGatewayReady
+	}
+
+	condition := metav1.Condition{
+		Type:               conditions.TypeGatewayHealthy,
+		Status:             status,
+		Reason:             reason,
+		Message:            conditions.MessageForLogPipeline(reason),
+		ObservedGeneration: pipeline.Generation,
+	}
+
+	meta.SetStatusCondition(&pipeline.Status.Conditions, condition)
+}
+
+func (r *Reconciler) isGatewayReady(ctx context.Context) bool {
+	name := types.NamespacedName{Name: r.config.Gateway.BaseName, Namespace: r.config.Gateway.Namespace}
+	ready, err := r.gatewayProber.IsReady(ctx, name)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to probe log gateway deployment - set condition as not healthy")
+		return false
+	}
+
+	return ready
+}
+
+func (r *Reconciler) setFluentBitConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, settings telemetrysettings.Settings) {
+	status, reason, message := r.evaluateConfigGeneratedCondition(ctx, pipeline, settings)
 
 	condition := metav1.Condition{
 		Type:               conditions.TypeConfigurationGenerated,
@@ -103,7 +139,7 @@ func (r *Reconciler) setFluentBitConfigGeneratedCondition(ctx context.Context, p
 	meta.SetStatusCondition(&pipeline.Status.Conditions, condition)
 }
 
-func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) (status metav1.ConditionStatus, reason string, message string) {
+func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline, settings telemetrysettings.Settings) (status metav1.ConditionStatus, reason string, message string) {
 	if pipeline.Spec.Output.IsLokiDefined() {
 		return metav1.ConditionFalse, conditions.ReasonUnsupportedLokiOutput, conditions.MessageForLogPipeline(conditions.ReasonUnsupportedLokiOutput)
 	}
@@ -112,11 +148,18 @@ func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipel
 		return metav1.ConditionFalse, conditions.ReasonReferencedSecretMissing, conditions.MessageForMetricPipeline(conditions.ReasonReferencedSecretMissing)
 	}
 
-	if tlsCertValidationRequired(pipeline) {
-		cert := pipeline.Spec.Output.HTTP.TLSConfig.Cert
-		key := pipeline.Spec.Output.HTTP.TLSConfig.Key
+	if err := pipeline.ValidateRedaction(); err != nil {
+		return metav1.ConditionFalse, conditions.ReasonRedactionInvalid, fmt.Sprintf("%s (%s)", conditions.MessageForLogPipeline(conditions.ReasonRedactionInvalid), err)
+	}
 
-		err := r.tlsCertValidator.ValidateCertificate(ctx, cert, key)
+	if !pipeline.Spec.Output.IsOtlpDefined() {
+		if err := r.config.DaemonSetConfig.WithResourceOverrides(settings.FluentBit).ValidateResources(); err != nil {
+			return metav1.ConditionFalse, conditions.ReasonResourceRequirementsInvalid, conditions.MessageForResourceRequirementsInvalid(err)
+		}
+	}
+
+	if tlsCertValidationRequired(pipeline) {
+		err := r.validateTLSCertificates(ctx, pipeline)
 		return conditions.EvaluateTLSCertCondition(err)
 	}
 
@@ -124,15 +167,11 @@ func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipel
 }
 
 func (r *Reconciler) setFlowHealthCondition(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) {
-	var reason string
 	var status metav1.ConditionStatus
 
-	probeResult, err := r.flowHealthProber.Probe(ctx, pipeline.Name)
+	healthy, reason, droppingOutputs, err := r.probeFlowHealth(ctx, pipeline)
 	if err == nil {
-		logf.FromContext(ctx).V(1).Info("Probed flow health", "result", probeResult)
-
-		reason = flowHealthReasonFor(probeResult)
-		if probeResult.Healthy {
+		if healthy {
 			status = metav1.ConditionTrue
 		} else {
 			status = metav1.ConditionFalse
@@ -148,13 +187,43 @@ func (r *Reconciler) setFlowHealthCondition(ctx context.Context, pipeline *telem
 		Type:               conditions.TypeFlowHealthy,
 		Status:             status,
 		Reason:             reason,
-		Message:            conditions.MessageForLogPipeline(reason),
+		Message:            conditions.WithAffectedOutputs(conditions.MessageForLogPipeline(reason), droppingOutputs),
 		ObservedGeneration: pipeline.Generation,
 	}
 
 	meta.SetStatusCondition(&pipeline.Status.Conditions, condition)
 }
 
+// probeFlowHealth probes the flow health of a pipeline with the prober of the component it runs in, which is the log gateway for OTLP output and Fluent Bit otherwise.
+// Only the log gateway reports the outputs that drop data, because Fluent Bit pipelines have a single output.
+func (r *Reconciler) probeFlowHealth(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) (healthy bool, reason string, droppingOutputs []string, err error) {
+	if pipeline.Spec.Output.IsOtlpDefined() {
+		probeResult, err := r.gatewayFlowHealthProber.Probe(ctx, pipeline.Name)
+		if err != nil {
+			return false, "", nil, err
+		}
+
+		logf.FromContext(ctx).V(1).Info("Probed flow health", "result", probeResult)
+		return probeResult.Healthy, gatewayFlowHealthReasonFor(probeResult), probeResult.DroppingOutputs, nil
+	}
+
+	probeResult, err := r.flowHealthProber.Probe(ctx, pipeline.Name)
+	if err != nil {
+		return false, "", nil, err
+	}
+
+	logf.FromContext(ctx).V(1).Info("Probed flow health", "result", probeResult)
+	return probeResult.Healthy, flowHealthReasonFor(probeResult), nil, nil
+}
+
+// dataFlowStatsProberFor returns the prober for the component that runs the pipeline. Pipelines with OTLP output run in the log gateway instead of Fluent Bit.
+func (r *Reconciler) dataFlowStatsProberFor(pipeline *telemetryv1alpha1.LogPipeline) DataFlowStatsProber {
+	if pipeline.Spec.Output.IsOtlpDefined() {
+		return r.gatewayDataFlowStatsProber
+	}
+	return r.dataFlowStatsProber
+}
+
 func flowHealthReasonFor(probeResult prober.LogPipelineProbeResult) string {
 	switch {
 	case probeResult.AllDataDropped:
@@ -170,6 +239,21 @@ func flowHealthReasonFor(probeResult prober.LogPipelineProbeResult) string {
 	}
 }
 
+func gatewayFlowHealthReasonFor(probeResult prober.OTelPipelineProbeResult) string {
+	switch {
+	case probeResult.AllDataDropped:
+		return conditions.ReasonSelfMonAllDataDropped
+	case probeResult.SomeDataDropped:
+		return conditions.ReasonSelfMonSomeDataDropped
+	case probeResult.QueueAlmostFull:
+		return conditions.ReasonSelfMonBufferFillingUp
+	case probeResult.Throttling:
+		return conditions.ReasonSelfMonGatewayThrottling
+	default:
+		return conditions.ReasonSelfMonFlowHealthy
+	}
+}
+
 func (r *Reconciler) setLegacyConditions(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) {
 	if pipeline.Spec.Output.IsLokiDefined() {
 		conditions.HandlePendingCondition(&pipeline.Status.Conditions, pipeline.Generation,
@@ -186,6 +270,22 @@ func (r *Reconciler) setLegacyConditions(ctx context.Context, pipeline *telemetr
 		return
 	}
 
+	if pipeline.Spec.Output.IsOtlpDefined() {
+		if !r.isGatewayReady(ctx) {
+			conditions.HandlePendingCondition(&pipeline.Status.Conditions, pipeline.Generation,
+				conditions.ReasonGatewayNotReady,
+				conditions.MessageForLogPipeline(conditions.ReasonGatewayNotReady))
+			return
+		}
+
+		conditions.HandleRunningCondition(&pipeline.Status.Conditions, pipeline.Generation,
+			conditions.ReasonGatewayReady,
+			conditions.ReasonGatewayNotReady,
+			conditions.MessageForLogPipeline(conditions.ReasonGatewayReady),
+			conditions.MessageForLogPipeline(conditions.ReasonGatewayNotReady))
+		return
+	}
+
 	fluentBitReady, err := r.prober.IsReady(ctx, r.config.DaemonSet)
 	if err != nil {
 		logf.FromContext(ctx).V(1).Error(err, "Failed to probe fluent bit daemonset")
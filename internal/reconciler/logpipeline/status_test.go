@@ -10,15 +10,20 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/logpipeline/mocks"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/telemetrysettings"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
+	"github.com/kyma-project/telemetry-manager/internal/resources/fluentbit"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
 	"github.com/kyma-project/telemetry-manager/internal/testutils"
 	"github.com/kyma-project/telemetry-manager/internal/tlscert"
@@ -42,7 +47,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -83,7 +88,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -118,6 +123,153 @@ func TestUpdateStatus(t *testing.T) {
 		require.NotEmpty(t, runningCond.LastTransitionTime)
 	})
 
+	t.Run("invalid fluent bit resource requirements", func(t *testing.T) {
+		pipeline := testutils.NewLogPipelineBuilder().WithName("pipeline").WithHTTPOutput().Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		proberStub := &mocks.DaemonSetProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{
+				DaemonSet:       types.NamespacedName{Name: "fluent-bit"},
+				DaemonSetConfig: fluentbit.DaemonSetConfig{CPULimit: resource.MustParse("1")},
+			},
+			prober: proberStub,
+		}
+
+		settings := telemetrysettings.Settings{
+			FluentBit: commonresources.ResourceOverrides{CPURequest: ptr.To(resource.MustParse("2"))},
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, settings)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.LogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		configurationGeneratedCond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, configurationGeneratedCond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionFalse, configurationGeneratedCond.Status)
+		require.Equal(t, conditions.ReasonResourceRequirementsInvalid, configurationGeneratedCond.Reason)
+		require.Contains(t, configurationGeneratedCond.Message, "CPU request 2 exceeds CPU limit 1")
+	})
+
+	t.Run("unsupported redaction pattern", func(t *testing.T) {
+		pipeline := testutils.NewLogPipelineBuilder().WithName("pipeline").WithHTTPOutput().Build()
+		pipeline.Spec.Redaction = &telemetryv1alpha1.LogPipelineRedaction{Custom: []string{`(user|password)=\S+`}}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		proberStub := &mocks.DaemonSetProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{DaemonSet: types.NamespacedName{Name: "fluent-bit"}},
+			prober: proberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.LogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		configurationGeneratedCond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, configurationGeneratedCond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionFalse, configurationGeneratedCond.Status)
+		require.Equal(t, conditions.ReasonRedactionInvalid, configurationGeneratedCond.Reason)
+		require.Contains(t, configurationGeneratedCond.Message, conditions.MessageForLogPipeline(conditions.ReasonRedactionInvalid))
+	})
+
+	t.Run("log gateway is not ready", func(t *testing.T) {
+		pipeline := testutils.NewLogPipelineBuilder().WithName("pipeline").WithOTLPOutput().Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(false, nil)
+
+		sut := Reconciler{
+			Client:        fakeClient,
+			config:        Config{DaemonSet: types.NamespacedName{Name: "fluent-bit"}},
+			gatewayProber: gatewayProberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.LogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		require.Nil(t, meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeAgentHealthy))
+
+		gatewayHealthyCond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeGatewayHealthy)
+		require.NotNil(t, gatewayHealthyCond, "could not find condition of type %s", conditions.TypeGatewayHealthy)
+		require.Equal(t, metav1.ConditionFalse, gatewayHealthyCond.Status)
+		require.Equal(t, conditions.ReasonGatewayNotReady, gatewayHealthyCond.Reason)
+		require.Equal(t, conditions.MessageForLogPipeline(conditions.ReasonGatewayNotReady), gatewayHealthyCond.Message)
+		require.Equal(t, updatedPipeline.Generation, gatewayHealthyCond.ObservedGeneration)
+
+		conditionsSize := len(updatedPipeline.Status.Conditions)
+		pendingCond := updatedPipeline.Status.Conditions[conditionsSize-1]
+		require.Equal(t, conditions.TypePending, pendingCond.Type)
+		require.Equal(t, metav1.ConditionTrue, pendingCond.Status)
+		require.Equal(t, conditions.ReasonGatewayNotReady, pendingCond.Reason)
+	})
+
+	t.Run("log gateway is ready", func(t *testing.T) {
+		pipeline := testutils.NewLogPipelineBuilder().WithName("pipeline").WithOTLPOutput().Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		gatewayFlowHealthProberStub := &mocks.GatewayFlowHealthProber{}
+		gatewayFlowHealthProberStub.On("Probe", mock.Anything, pipeline.Name).Return(prober.OTelPipelineProbeResult{
+			PipelineProbeResult: prober.PipelineProbeResult{SomeDataDropped: true},
+		}, nil)
+
+		gatewayDataFlowStatsProberStub := &mocks.DataFlowStatsProber{}
+		gatewayDataFlowStatsProberStub.On("Stats", mock.Anything, pipeline.Name).Return(prober.PipelineStats{SentPerMinute: ptr.To(42.0)}, nil)
+
+		sut := Reconciler{
+			Client:                     fakeClient,
+			config:                     Config{DaemonSet: types.NamespacedName{Name: "fluent-bit"}},
+			gatewayProber:              gatewayProberStub,
+			flowHealthProbingEnabled:   true,
+			flowHealthProber:           &mocks.FlowHealthProber{},
+			dataFlowStatsProber:        &mocks.DataFlowStatsProber{},
+			gatewayFlowHealthProber:    gatewayFlowHealthProberStub,
+			gatewayDataFlowStatsProber: gatewayDataFlowStatsProberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.LogPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		gatewayHealthyCond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeGatewayHealthy)
+		require.NotNil(t, gatewayHealthyCond, "could not find condition of type %s", conditions.TypeGatewayHealthy)
+		require.Equal(t, metav1.ConditionTrue, gatewayHealthyCond.Status)
+		require.Equal(t, conditions.ReasonGatewayReady, gatewayHealthyCond.Reason)
+		require.Equal(t, conditions.MessageForLogPipeline(conditions.ReasonGatewayReady), gatewayHealthyCond.Message)
+
+		flowHealthyCond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeFlowHealthy)
+		require.NotNil(t, flowHealthyCond, "could not find condition of type %s", conditions.TypeFlowHealthy)
+		require.Equal(t, metav1.ConditionFalse, flowHealthyCond.Status)
+		require.Equal(t, conditions.ReasonSelfMonSomeDataDropped, flowHealthyCond.Reason)
+
+		require.NotNil(t, updatedPipeline.Status.DataFlow)
+		require.Equal(t, ptr.To(int64(42)), updatedPipeline.Status.DataFlow.SentPerMinute)
+
+		conditionsSize := len(updatedPipeline.Status.Conditions)
+		runningCond := updatedPipeline.Status.Conditions[conditionsSize-1]
+		require.Equal(t, conditions.TypeRunning, runningCond.Type)
+		require.Equal(t, metav1.ConditionTrue, runningCond.Status)
+		require.Equal(t, conditions.ReasonGatewayReady, runningCond.Reason)
+	})
+
 	t.Run("referenced secret missing", func(t *testing.T) {
 		pipeline := testutils.NewLogPipelineBuilder().WithName("pipeline").WithHTTPOutput(testutils.HTTPHostFromSecret("some-secret", "some-namespace", "host")).Build()
 		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
@@ -131,7 +283,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -180,7 +332,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -218,7 +370,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -342,13 +494,17 @@ func TestUpdateStatus(t *testing.T) {
 				flowHealthProberStub := &mocks.FlowHealthProber{}
 				flowHealthProberStub.On("Probe", mock.Anything, pipeline.Name).Return(tt.probe, tt.probeErr)
 
+				dataFlowStatsProberStub := &mocks.DataFlowStatsProber{}
+				dataFlowStatsProberStub.On("Stats", mock.Anything, pipeline.Name).Return(prober.PipelineStats{}, nil)
+
 				sut := Reconciler{
 					Client:                   fakeClient,
 					prober:                   agentProberStub,
 					flowHealthProbingEnabled: true,
 					flowHealthProber:         flowHealthProberStub,
+					dataFlowStatsProber:      dataFlowStatsProberStub,
 				}
-				err := sut.updateStatus(context.Background(), pipeline.Name)
+				err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 				require.NoError(t, err)
 
 				var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -362,6 +518,69 @@ func TestUpdateStatus(t *testing.T) {
 		}
 	})
 
+	t.Run("data flow stats", func(t *testing.T) {
+		now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+		previous := &telemetryv1alpha1.DataFlowStatus{SentPerMinute: ptr.To(int64(600)), LastUpdateTime: metav1.NewTime(now.Add(-time.Minute))}
+
+		tests := []struct {
+			name     string
+			stats    prober.PipelineStats
+			statsErr error
+			expected *telemetryv1alpha1.DataFlowStatus
+		}{
+			{
+				name:  "prober succeeds",
+				stats: prober.PipelineStats{SentPerMinute: ptr.To(1200.0), DroppedPerMinute: ptr.To(30.0), Timestamp: now},
+				expected: &telemetryv1alpha1.DataFlowStatus{
+					SentPerMinute:    ptr.To(int64(1200)),
+					DroppedPerMinute: ptr.To(int64(30)),
+					LastUpdateTime:   metav1.NewTime(now),
+				},
+			},
+			{
+				name:     "prober fails",
+				statsErr: assert.AnError,
+				expected: previous,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				pipeline := testutils.NewLogPipelineBuilder().Build()
+				pipeline.Status.DataFlow = previous
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+				agentProberStub := &mocks.DaemonSetProber{}
+				agentProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+				flowHealthProberStub := &mocks.FlowHealthProber{}
+				flowHealthProberStub.On("Probe", mock.Anything, pipeline.Name).Return(prober.LogPipelineProbeResult{}, nil)
+
+				dataFlowStatsProberStub := &mocks.DataFlowStatsProber{}
+				dataFlowStatsProberStub.On("Stats", mock.Anything, pipeline.Name).Return(tt.stats, tt.statsErr)
+
+				sut := Reconciler{
+					Client:                   fakeClient,
+					prober:                   agentProberStub,
+					flowHealthProbingEnabled: true,
+					flowHealthProber:         flowHealthProberStub,
+					dataFlowStatsProber:      dataFlowStatsProberStub,
+				}
+				err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
+				require.NoError(t, err)
+
+				var updatedPipeline telemetryv1alpha1.LogPipeline
+				_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+				require.NotNil(t, updatedPipeline.Status.DataFlow)
+				require.Equal(t, tt.expected.SentPerMinute, updatedPipeline.Status.DataFlow.SentPerMinute)
+				require.Equal(t, tt.expected.DroppedPerMinute, updatedPipeline.Status.DataFlow.DroppedPerMinute)
+				require.Equal(t, tt.expected.QueueUtilizationPercent, updatedPipeline.Status.DataFlow.QueueUtilizationPercent)
+				require.True(t, tt.expected.LastUpdateTime.Equal(&updatedPipeline.Status.DataFlow.LastUpdateTime))
+			})
+		}
+	})
+
 	t.Run("should remove running condition and set pending condition to true if fluent bit becomes not ready again", func(t *testing.T) {
 		pipelineName := "pipeline"
 		pipeline := &telemetryv1alpha1.LogPipeline{
@@ -435,7 +654,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -520,7 +739,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -599,7 +818,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -641,7 +860,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -669,7 +888,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -735,7 +954,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name)
+		err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -822,7 +1041,7 @@ func TestUpdateStatus(t *testing.T) {
 					prober:           proberStub,
 				}
 
-				err := sut.updateStatus(context.Background(), pipeline.Name)
+				err := sut.updateStatus(context.Background(), pipeline.Name, telemetrysettings.Settings{})
 				require.NoError(t, err)
 
 				var updatedPipeline telemetryv1alpha1.LogPipeline
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -14,6 +16,7 @@ import (
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config/builder"
 	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
 )
 
 type syncer struct {
@@ -66,6 +69,9 @@ func (s *syncer) syncSectionsConfigMap(ctx context.Context, pipeline *telemetryv
 			PipelineDefaults: s.config.PipelineDefaults,
 			CollectAgentLogs: s.config.Overrides.Logging.CollectAgentLogs,
 		}
+		if s.config.TapEndpoint.IsEnabled() && tap.IsActive(pipeline, time.Now()) {
+			builderConfig.TapEndpoint = &s.config.TapEndpoint
+		}
 		newConfig, err := builder.BuildFluentBitConfig(pipeline, builderConfig)
 		if err != nil {
 			return fmt.Errorf("unable to build section: %w", err)
@@ -173,6 +179,15 @@ func (s *syncer) syncTLSConfigSecret(ctx context.Context, logPipelines []telemet
 			continue
 		}
 
+		// The CA certificate is added for any tap annotation, so that it is present whenever the tap output is configured
+		if s.config.TapEndpoint.IsEnabled() && metav1.HasAnnotation(logPipelines[i].ObjectMeta, tap.AnnotationKey) && newSecret.Data[builder.TapCAFile] == nil {
+			caCert, err := s.config.TapEndpoint.CACert(ctx, s)
+			if err != nil {
+				return fmt.Errorf("unable to get tap ca cert: %w", err)
+			}
+			newSecret.Data[builder.TapCAFile] = caCert
+		}
+
 		output := logPipelines[i].Spec.Output
 		if !output.IsHTTPDefined() {
 			continue
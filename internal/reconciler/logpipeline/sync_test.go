@@ -3,6 +3,7 @@ package logpipeline
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -17,6 +18,7 @@ import (
 	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config/builder"
 	"github.com/kyma-project/telemetry-manager/internal/k8sutils/mocks"
 	"github.com/kyma-project/telemetry-manager/internal/resources/fluentbit"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
 )
 
 var (
@@ -122,6 +124,45 @@ alias bar`
 		require.Contains(t, sectionsCm.Data["noop.conf"], "bar")
 	})
 
+	t.Run("should add tap output while the tap is active", func(t *testing.T) {
+		tapEndpoint := tap.Endpoint{Host: "telemetry-manager-webhook.kyma-system.svc", Port: 443}
+		sut := syncer{fakeClient, Config{SectionsConfigMap: sectionsCmName, TapEndpoint: tapEndpoint}}
+
+		pipeline := &telemetryv1alpha1.LogPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "tapped",
+				Annotations: map[string]string{
+					tap.AnnotationKey: time.Now().Add(10 * time.Minute).UTC().Format(time.RFC3339),
+				},
+			},
+			Spec: telemetryv1alpha1.LogPipelineSpec{
+				Output: telemetryv1alpha1.Output{
+					Custom: `
+name  null
+alias foo`,
+				},
+			},
+		}
+		deployableLogPipeline := []telemetryv1alpha1.LogPipeline{*pipeline}
+
+		err := sut.syncSectionsConfigMap(context.Background(), pipeline, deployableLogPipeline)
+		require.NoError(t, err)
+
+		var sectionsCm corev1.ConfigMap
+		err = fakeClient.Get(context.Background(), sectionsCmName, &sectionsCm)
+		require.NoError(t, err)
+		require.Contains(t, sectionsCm.Data["tapped.conf"], "alias                    tap.tapped")
+		require.Contains(t, sectionsCm.Data["tapped.conf"], "uri                      /tap/logpipelines/tapped")
+
+		pipeline.Annotations[tap.AnnotationKey] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		err = sut.syncSectionsConfigMap(context.Background(), pipeline, deployableLogPipeline)
+		require.NoError(t, err)
+
+		err = fakeClient.Get(context.Background(), sectionsCmName, &sectionsCm)
+		require.NoError(t, err)
+		require.NotContains(t, sectionsCm.Data["tapped.conf"], "tap.tapped")
+	})
+
 	t.Run("should remove section if marked for deletion", func(t *testing.T) {
 		sut := syncer{fakeClient, Config{SectionsConfigMap: sectionsCmName}}
 		require.NoError(t, telemetryv1alpha1.AddToScheme(fakeClient.Scheme()))
@@ -492,6 +533,41 @@ func TestSyncTLSConfigSecret(t *testing.T) {
 		require.Equal(t, []byte("new-fake-key-value"), tlsConfigSecret.Data["pipeline-1-key.key"])
 	})
 
+	t.Run("should add the tap CA certificate for pipelines with a tap", func(t *testing.T) {
+		caSecretName := types.NamespacedName{Name: "telemetry-webhook-cert", Namespace: "kyma-system"}
+		caSecret := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: caSecretName.Name, Namespace: caSecretName.Namespace},
+			Data:       map[string][]byte{"ca.crt": []byte("fake-tap-ca-value")},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(&caSecret).Build()
+
+		config := testConfig
+		config.TapEndpoint = tap.Endpoint{Host: "telemetry-manager-webhook.kyma-system.svc", Port: 443, CASecretName: caSecretName}
+		sut := syncer{fakeClient, config}
+
+		untapped := telemetryv1alpha1.LogPipeline{ObjectMeta: metav1.ObjectMeta{Name: "untapped"}}
+		err := sut.syncTLSConfigSecret(context.Background(), []telemetryv1alpha1.LogPipeline{untapped})
+		require.NoError(t, err)
+
+		var tlsConfigSecret corev1.Secret
+		err = fakeClient.Get(context.Background(), testConfig.OutputTLSConfigSecret, &tlsConfigSecret)
+		require.NoError(t, err)
+		require.NotContains(t, tlsConfigSecret.Data, "tap.ca.crt")
+
+		tapped := telemetryv1alpha1.LogPipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "tapped",
+				Annotations: map[string]string{tap.AnnotationKey: time.Now().Add(10 * time.Minute).UTC().Format(time.RFC3339)},
+			},
+		}
+		err = sut.syncTLSConfigSecret(context.Background(), []telemetryv1alpha1.LogPipeline{untapped, tapped})
+		require.NoError(t, err)
+
+		err = fakeClient.Get(context.Background(), testConfig.OutputTLSConfigSecret, &tlsConfigSecret)
+		require.NoError(t, err)
+		require.Equal(t, []byte("fake-tap-ca-value"), tlsConfigSecret.Data["tap.ca.crt"])
+	})
+
 	t.Run("should delete value in output TLS config secret if marked for deletion", func(t *testing.T) {
 		keySecret := corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
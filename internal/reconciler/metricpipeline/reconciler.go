@@ -10,27 +10,32 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
-	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/istiostatus"
 	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 	configmetricagent "github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric/agent"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric/gateway"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 	"github.com/kyma-project/telemetry-manager/internal/overrides"
+	"github.com/kyma-project/telemetry-manager/internal/prometheusmonitor"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/telemetrysettings"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
 	"github.com/kyma-project/telemetry-manager/internal/tlscert"
 )
 
-const defaultReplicaCount int32 = 2
-
 type Config struct {
 	Agent                  otelcollector.AgentConfig
+	ClusterCollector       otelcollector.ClusterCollectorConfig
 	Gateway                otelcollector.GatewayConfig
 	OverridesConfigMapName types.NamespacedName
 	MaxPipelines           int
+	// TapEndpoint is the endpoint of the telemetry manager, to which pipelines with an active tap send their data. The zero value disables the tap.
+	TapEndpoint tap.Endpoint
 }
 
 //go:generate mockery --name DeploymentProber --filename deployment_prober.go
@@ -48,6 +53,11 @@ type FlowHealthProber interface {
 	Probe(ctx context.Context, pipelineName string) (prober.OTelPipelineProbeResult, error)
 }
 
+//go:generate mockery --name DataFlowStatsProber --filename data_flow_stats_prober.go
+type DataFlowStatsProber interface {
+	Stats(ctx context.Context, pipelineName string) (prober.PipelineStats, error)
+}
+
 //go:generate mockery --name TLSCertValidator --filename tls_cert_validator.go
 type TLSCertValidator interface {
 	ValidateCertificate(ctx context.Context, cert, key *telemetryv1alpha1.ValueType) error
@@ -60,6 +70,7 @@ type Reconciler struct {
 	agentProber              DaemonSetProber
 	flowHealthProbingEnabled bool
 	flowHealthProber         FlowHealthProber
+	dataFlowStatsProber      DataFlowStatsProber
 	overridesHandler         *overrides.Handler
 	istioStatusChecker       istiostatus.Checker
 	tlsCertValidator         TLSCertValidator
@@ -71,6 +82,7 @@ func NewReconciler(
 	agentProber DaemonSetProber,
 	flowHealthProbingEnabled bool,
 	flowHealthProber FlowHealthProber,
+	dataFlowStatsProber DataFlowStatsProber,
 	overridesHandler *overrides.Handler) *Reconciler {
 	return &Reconciler{
 		Client:                   client,
@@ -79,6 +91,7 @@ func NewReconciler(
 		agentProber:              agentProber,
 		flowHealthProbingEnabled: flowHealthProbingEnabled,
 		flowHealthProber:         flowHealthProber,
+		dataFlowStatsProber:      dataFlowStatsProber,
 		overridesHandler:         overridesHandler,
 		istioStatusChecker:       istiostatus.NewChecker(client),
 		tlsCertValidator:         tlscert.New(client),
@@ -109,9 +122,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline) error {
 	var err error
 	lockAcquired := true
+	settings := telemetrysettings.FromTelemetry(ctx, r.Client)
 
 	defer func() {
-		if statusErr := r.updateStatus(ctx, pipeline.Name, lockAcquired); statusErr != nil {
+		if statusErr := r.updateStatus(ctx, pipeline.Name, lockAcquired, settings); statusErr != nil {
 			if err != nil {
 				err = fmt.Errorf("failed while updating status: %w: %w", statusErr, err)
 			} else {
@@ -120,10 +134,11 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 		}
 	}()
 
-	lock := k8sutils.NewResourceCountLock(r.Client, types.NamespacedName{
-		Name:      "telemetry-metricpipeline-lock",
-		Namespace: r.config.Gateway.Namespace,
-	}, r.config.MaxPipelines)
+	if err = tap.DeleteInactiveSamples(ctx, r.Client, r.config.Gateway.Namespace, tap.KindMetricPipelines, pipeline); err != nil {
+		return err
+	}
+
+	lock := r.pipelineLock()
 	if err = lock.TryAcquireLock(ctx, pipeline); err != nil {
 		lockAcquired = false
 		return err
@@ -143,19 +158,31 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 		return nil
 	}
 
-	if err = r.reconcileMetricGateway(ctx, pipeline, reconcilablePipelines); err != nil {
+	if err = r.reconcileMetricGateway(ctx, pipeline, reconcilablePipelines, settings.MetricGateway); err != nil {
 		return fmt.Errorf("failed to reconcile metric gateway: %w", err)
 	}
 
 	if isMetricAgentRequired(pipeline) {
-		if err = r.reconcileMetricAgents(ctx, pipeline, allPipelinesList.Items); err != nil {
+		if err = r.reconcileMetricAgents(ctx, pipeline, reconcilablePipelines, settings.MetricAgent); err != nil {
 			return fmt.Errorf("failed to reconcile metric agents: %w", err)
 		}
 	}
 
+	if err = r.reconcileClusterCollector(ctx, pipeline, allPipelinesList.Items); err != nil {
+		return fmt.Errorf("failed to reconcile metric cluster collector: %w", err)
+	}
+
 	return nil
 }
 
+// pipelineLock returns the lock that limits the number of deployed metric pipelines.
+func (r *Reconciler) pipelineLock() *k8sutils.ResourceCountLock {
+	return k8sutils.NewResourceCountLock(r.Client, types.NamespacedName{
+		Name:      "telemetry-metricpipeline-lock",
+		Namespace: r.config.Gateway.Namespace,
+	}, r.config.MaxPipelines)
+}
+
 // getReconcilablePipelines returns the list of metric pipelines that are ready to be rendered into the otel collector configuration. A pipeline is deployable if it is not being deleted, all secret references exist, and is not above the pipeline limit.
 func (r *Reconciler) getReconcilablePipelines(ctx context.Context, allPipelines []telemetryv1alpha1.MetricPipeline, lock *k8sutils.ResourceCountLock) ([]telemetryv1alpha1.MetricPipeline, error) {
 	var reconcilablePipelines []telemetryv1alpha1.MetricPipeline
@@ -182,10 +209,7 @@ func (r *Reconciler) isReconcilable(ctx context.Context, pipeline *telemetryv1al
 	}
 
 	if tlsCertValidationRequired(pipeline) {
-		cert := pipeline.Spec.Output.Otlp.TLS.Cert
-		key := pipeline.Spec.Output.Otlp.TLS.Key
-
-		if err := r.tlsCertValidator.ValidateCertificate(ctx, cert, key); err != nil {
+		if err := r.validateTLSCertificates(ctx, pipeline); err != nil {
 			if !tlscert.IsCertAboutToExpireError(err) {
 				return false, nil
 			}
@@ -207,13 +231,17 @@ func isMetricAgentRequired(pipeline *telemetryv1alpha1.MetricPipeline) bool {
 	return isRuntimeInputEnabled || isPrometheusInputEnabled || isIstioInputEnabled
 }
 
-func (r *Reconciler) reconcileMetricGateway(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, allPipelines []telemetryv1alpha1.MetricPipeline) error {
+func (r *Reconciler) reconcileMetricGateway(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, allPipelines []telemetryv1alpha1.MetricPipeline, settings telemetrysettings.GatewaySettings) error {
 	scaling := otelcollector.GatewayScalingConfig{
-		Replicas:                       r.getReplicaCountFromTelemetry(ctx),
+		Replicas:                       settings.Replicas,
 		ResourceRequirementsMultiplier: len(allPipelines),
+		Autoscaling:                    settings.Autoscaling,
 	}
+	persistentQueue := settings.PersistentQueue
 
-	collectorConfig, collectorEnvVars, err := gateway.MakeConfig(ctx, r.Client, allPipelines)
+	collectorConfig, collectorEnvVars, err := gateway.MakeConfig(ctx, r.Client, allPipelines, gateway.BuildOptions{
+		PersistentQueue: config.PersistentQueue{Enabled: persistentQueue.Enabled, VolumeSize: persistentQueue.Size},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create collector config: %w", err)
 	}
@@ -223,6 +251,12 @@ func (r *Reconciler) reconcileMetricGateway(ctx context.Context, pipeline *telem
 		return fmt.Errorf("failed to marshal collector config: %w", err)
 	}
 
+	gateway.AddTapExporters(collectorConfig, tap.ExporterTaps(ctx, r.Client, r.config.TapEndpoint, tap.KindMetricPipelines, allPipelines))
+	collectorConfigWithTapsYAML, err := yaml.Marshal(collectorConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collector config with tap exporters: %w", err)
+	}
+
 	isIstioActive := r.istioStatusChecker.IsIstioActive(ctx)
 
 	allowedPorts := getGatewayPorts()
@@ -232,8 +266,10 @@ func (r *Reconciler) reconcileMetricGateway(ctx context.Context, pipeline *telem
 
 	if err := otelcollector.ApplyGatewayResources(ctx,
 		k8sutils.NewOwnerReferenceSetter(r.Client, pipeline),
-		r.config.Gateway.WithScaling(scaling).WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars).
+		r.gatewayConfig(ctx, settings.ResourceOverrides).WithScaling(scaling).WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars).
+			WithTapExporters(string(collectorConfigWithTapsYAML)).
 			WithIstioConfig(fmt.Sprintf("%d", ports.Metrics), isIstioActive).
+			WithPersistentQueue(persistentQueue).
 			WithAllowedPorts(allowedPorts)); err != nil {
 		return fmt.Errorf("failed to apply gateway resources: %w", err)
 	}
@@ -241,12 +277,25 @@ func (r *Reconciler) reconcileMetricGateway(ctx context.Context, pipeline *telem
 	return nil
 }
 
-func (r *Reconciler) reconcileMetricAgents(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, allPipelines []telemetryv1alpha1.MetricPipeline) error {
+func (r *Reconciler) reconcileMetricAgents(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, allPipelines []telemetryv1alpha1.MetricPipeline, resourceOverrides commonresources.ResourceOverrides) error {
 	isIstioActive := r.istioStatusChecker.IsIstioActive(ctx)
+	buildOptions := configmetricagent.BuildOptions{IsIstioActive: isIstioActive}
+
+	if configmetricagent.IsPrometheusMonitorsEnabled(allPipelines) {
+		var err error
+		// Skipped monitors and endpoints are reported in the status of the pipelines
+		if buildOptions.ServiceMonitors, _, err = prometheusmonitor.ListServiceMonitors(ctx, r.Client); err != nil {
+			return fmt.Errorf("failed to list service monitors: %w", err)
+		}
+		if buildOptions.PodMonitors, _, err = prometheusmonitor.ListPodMonitors(ctx, r.Client); err != nil {
+			return fmt.Errorf("failed to list pod monitors: %w", err)
+		}
+	}
+
 	agentConfig := configmetricagent.MakeConfig(types.NamespacedName{
 		Namespace: r.config.Gateway.Namespace,
 		Name:      r.config.Gateway.OTLPServiceName,
-	}, allPipelines, isIstioActive)
+	}, allPipelines, buildOptions)
 
 	agentConfigYAML, err := yaml.Marshal(agentConfig)
 	if err != nil {
@@ -261,7 +310,7 @@ func (r *Reconciler) reconcileMetricAgents(ctx context.Context, pipeline *teleme
 
 	if err := otelcollector.ApplyAgentResources(ctx,
 		k8sutils.NewOwnerReferenceSetter(r.Client, pipeline),
-		r.config.Agent.WithCollectorConfig(string(agentConfigYAML)).
+		r.agentConfig(ctx, resourceOverrides).WithCollectorConfig(string(agentConfigYAML)).
 			WithAllowedPorts(allowedPorts)); err != nil {
 		return fmt.Errorf("failed to apply agent resources: %w", err)
 	}
@@ -269,35 +318,46 @@ func (r *Reconciler) reconcileMetricAgents(ctx context.Context, pipeline *teleme
 	return nil
 }
 
-func (r *Reconciler) getReplicaCountFromTelemetry(ctx context.Context) int32 {
-	var telemetries operatorv1alpha1.TelemetryList
-	if err := r.List(ctx, &telemetries); err != nil {
-		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default scaling")
-		return defaultReplicaCount
-	}
-	for i := range telemetries.Items {
-		telemetrySpec := telemetries.Items[i].Spec
-		if telemetrySpec.Metric == nil {
-			continue
+// reconcileClusterCollector deploys the singleton collector for the cluster state metrics if any pipeline enables the cluster input,
+// and removes it otherwise.
+func (r *Reconciler) reconcileClusterCollector(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, allPipelines []telemetryv1alpha1.MetricPipeline) error {
+	if !configmetricagent.IsClusterInputEnabled(allPipelines) {
+		if err := otelcollector.DeleteClusterCollectorResources(ctx, r.Client, &r.config.ClusterCollector); err != nil {
+			return fmt.Errorf("failed to delete cluster collector resources: %w", err)
 		}
+		return nil
+	}
 
-		scaling := telemetrySpec.Metric.Gateway.Scaling
-		if scaling.Type != operatorv1alpha1.StaticScalingStrategyType {
-			continue
-		}
+	collectorConfig := configmetricagent.MakeClusterConfig(types.NamespacedName{
+		Namespace: r.config.Gateway.Namespace,
+		Name:      r.config.Gateway.OTLPServiceName,
+	})
 
-		static := scaling.Static
-		if static != nil && static.Replicas > 0 {
-			return static.Replicas
-		}
+	collectorConfigYAML, err := yaml.Marshal(collectorConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collector config: %w", err)
 	}
-	return defaultReplicaCount
+
+	allowedPorts := getAgentPorts()
+	if r.istioStatusChecker.IsIstioActive(ctx) {
+		allowedPorts = append(allowedPorts, ports.IstioEnvoy)
+	}
+
+	if err := otelcollector.ApplyClusterCollectorResources(ctx,
+		k8sutils.NewOwnerReferenceSetter(r.Client, pipeline),
+		r.config.ClusterCollector.WithCollectorConfig(string(collectorConfigYAML)).
+			WithAllowedPorts(allowedPorts)); err != nil {
+		return fmt.Errorf("failed to apply cluster collector resources: %w", err)
+	}
+
+	return nil
 }
 
 func getAgentPorts() []int32 {
 	return []int32{
 		ports.Metrics,
 		ports.HealthCheck,
+		ports.ScrapeHealthMetrics,
 	}
 }
 
@@ -310,13 +370,38 @@ func getGatewayPorts() []int32 {
 	}
 }
 
-func tlsCertValidationRequired(pipeline *telemetryv1alpha1.MetricPipeline) bool {
-	otlp := pipeline.Spec.Output.Otlp
-	if otlp == nil {
-		return false
+// gatewayConfig returns the gateway config with the resource overrides of the Telemetry resource.
+// If the overrides are invalid, the gateway keeps its default resources and the pipeline status reports the error.
+func (r *Reconciler) gatewayConfig(ctx context.Context, overrides otelcollector.GatewayResourceOverrides) *otelcollector.GatewayConfig {
+	gatewayConfig := r.config.Gateway.WithResourceOverrides(overrides)
+	if err := gatewayConfig.ValidateResources(); err != nil {
+		logf.FromContext(ctx).V(1).Info("Ignoring invalid resource overrides of the metric gateway", "error", err.Error())
+		return &r.config.Gateway
 	}
-	if otlp.TLS == nil {
-		return false
+	return gatewayConfig
+}
+
+// agentConfig returns the agent config with the resource overrides of the Telemetry resource.
+// If the overrides are invalid, the agent keeps its default resources and the pipeline status reports the error.
+func (r *Reconciler) agentConfig(ctx context.Context, overrides commonresources.ResourceOverrides) *otelcollector.AgentConfig {
+	agentConfig := r.config.Agent.WithResourceOverrides(overrides)
+	if err := agentConfig.ValidateResources(); err != nil {
+		logf.FromContext(ctx).V(1).Info("Ignoring invalid resource overrides of the metric agent", "error", err.Error())
+		return &r.config.Agent
 	}
-	return otlp.TLS.Cert != nil || otlp.TLS.Key != nil
+	return agentConfig
+}
+
+func tlsCertValidationRequired(pipeline *telemetryv1alpha1.MetricPipeline) bool {
+	return len(clientCertificates(pipeline)) > 0
+}
+
+// validateTLSCertificates validates the client certificates of all outputs of the pipeline.
+func (r *Reconciler) validateTLSCertificates(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline) error {
+	return tlscert.ValidateCertificates(ctx, r.tlsCertValidator, clientCertificates(pipeline))
+}
+
+// clientCertificates returns the client certificates configured for the outputs of the pipeline.
+func clientCertificates(pipeline *telemetryv1alpha1.MetricPipeline) []tlscert.ClientCertificate {
+	return tlscert.OtlpClientCertificates(pipeline.Spec.Output.Otlp, pipeline.Spec.AdditionalOutputs)
 }
@@ -5,15 +5,25 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/istiostatus"
 	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+	"github.com/kyma-project/telemetry-manager/internal/prometheusmonitor"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/metricpipeline/mocks"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
 )
 
 var (
@@ -188,3 +198,118 @@ func TestGetDeployableMetricPipelinesWithoutLock(t *testing.T) {
 	require.NoError(t, err)
 	require.NotContains(t, deployablePipelines, pipeline1)
 }
+
+func TestReconcileClusterCollector(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+
+	collectorName := types.NamespacedName{Name: "cluster-collector", Namespace: "telemetry-system"}
+	config := Config{
+		Gateway: otelcollector.GatewayConfig{
+			Config:          otelcollector.Config{Namespace: "telemetry-system"},
+			OTLPServiceName: "otlp-metrics",
+		},
+		ClusterCollector: otelcollector.ClusterCollectorConfig{
+			Config: otelcollector.Config{BaseName: collectorName.Name, Namespace: collectorName.Namespace},
+		},
+	}
+
+	pipelineWithClusterInput := testutils.NewMetricPipelineBuilder().WithName("cluster").WithClusterInput(true).Build()
+	pipelineWithoutClusterInput := testutils.NewMetricPipelineBuilder().WithName("no-cluster").Build()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipelineWithClusterInput, &pipelineWithoutClusterInput).Build()
+	reconciler := Reconciler{
+		Client:             fakeClient,
+		config:             config,
+		istioStatusChecker: istiostatus.NewChecker(fakeClient),
+	}
+
+	t.Run("deploys the collector if a pipeline enables the cluster input", func(t *testing.T) {
+		err := reconciler.reconcileClusterCollector(ctx, &pipelineWithClusterInput, []telemetryv1alpha1.MetricPipeline{pipelineWithClusterInput, pipelineWithoutClusterInput})
+		require.NoError(t, err)
+
+		var deployment appsv1.Deployment
+		require.NoError(t, fakeClient.Get(ctx, collectorName, &deployment))
+		require.Equal(t, int32(1), *deployment.Spec.Replicas)
+
+		var cm corev1.ConfigMap
+		require.NoError(t, fakeClient.Get(ctx, collectorName, &cm))
+		require.Contains(t, cm.Data["relay.conf"], "k8s_cluster")
+		require.Contains(t, cm.Data["relay.conf"], "otlp-metrics.telemetry-system.svc.cluster.local:4317")
+	})
+
+	t.Run("removes the collector if no pipeline enables the cluster input", func(t *testing.T) {
+		err := reconciler.reconcileClusterCollector(ctx, &pipelineWithoutClusterInput, []telemetryv1alpha1.MetricPipeline{pipelineWithoutClusterInput})
+		require.NoError(t, err)
+
+		var deployment appsv1.Deployment
+		err = fakeClient.Get(ctx, collectorName, &deployment)
+		require.True(t, apierrors.IsNotFound(err))
+
+		var cm corev1.ConfigMap
+		err = fakeClient.Get(ctx, collectorName, &cm)
+		require.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestReconcileMetricAgentsWithPrometheusMonitors(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+
+	agentName := types.NamespacedName{Name: "metric-agent", Namespace: "telemetry-system"}
+	config := Config{
+		Gateway: otelcollector.GatewayConfig{
+			Config:          otelcollector.Config{Namespace: "telemetry-system"},
+			OTLPServiceName: "otlp-metrics",
+		},
+		Agent: otelcollector.AgentConfig{
+			Config: otelcollector.Config{BaseName: agentName.Name, Namespace: agentName.Namespace},
+		},
+	}
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(prometheusmonitor.ServiceMonitorGVK, meta.RESTScopeNamespace)
+
+	serviceMonitor := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"selector":  map[string]any{"matchLabels": map[string]any{"app": "sample"}},
+			"endpoints": []any{map[string]any{"port": "http-metrics"}},
+		},
+	}}
+	serviceMonitor.SetGroupVersionKind(prometheusmonitor.ServiceMonitorGVK)
+	serviceMonitor.SetNamespace("default")
+	serviceMonitor.SetName("sample")
+
+	pipelineWithMonitors := testutils.NewMetricPipelineBuilder().WithName("monitors").WithPrometheusInput(true).WithPrometheusInputMonitors(true).Build()
+	pipelineWithoutMonitors := testutils.NewMetricPipelineBuilder().WithName("no-monitors").WithPrometheusInput(true).Build()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).WithObjects(&pipelineWithMonitors, &pipelineWithoutMonitors, serviceMonitor).Build()
+	reconciler := Reconciler{
+		Client:             fakeClient,
+		config:             config,
+		istioStatusChecker: istiostatus.NewChecker(fakeClient),
+	}
+
+	t.Run("translates monitors if a pipeline enables them", func(t *testing.T) {
+		err := reconciler.reconcileMetricAgents(ctx, &pipelineWithMonitors, []telemetryv1alpha1.MetricPipeline{pipelineWithMonitors, pipelineWithoutMonitors}, commonresources.ResourceOverrides{})
+		require.NoError(t, err)
+
+		var cm corev1.ConfigMap
+		require.NoError(t, fakeClient.Get(ctx, agentName, &cm))
+		require.Contains(t, cm.Data["relay.conf"], "prometheus/monitors")
+		require.Contains(t, cm.Data["relay.conf"], "serviceMonitor/default/sample/0")
+	})
+
+	t.Run("ignores monitors if no pipeline enables them", func(t *testing.T) {
+		err := reconciler.reconcileMetricAgents(ctx, &pipelineWithoutMonitors, []telemetryv1alpha1.MetricPipeline{pipelineWithoutMonitors}, commonresources.ResourceOverrides{})
+		require.NoError(t, err)
+
+		var cm corev1.ConfigMap
+		require.NoError(t, fakeClient.Get(ctx, agentName, &cm))
+		require.NotContains(t, cm.Data["relay.conf"], "prometheus/monitors")
+	})
+}
@@ -3,6 +3,8 @@ package metricpipeline
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -12,11 +14,14 @@ import (
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	configmetricagent "github.com/kyma-project/telemetry-manager/internal/otelcollector/config/metric/agent"
+	"github.com/kyma-project/telemetry-manager/internal/prometheusmonitor"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/telemetrysettings"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
 )
 
-func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, withinPipelineCountLimit bool) error {
+func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, withinPipelineCountLimit bool, settings telemetrysettings.Settings) error {
 	var pipeline telemetryv1alpha1.MetricPipeline
 	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -34,10 +39,11 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, with
 
 	r.setAgentHealthyCondition(ctx, &pipeline)
 	r.setGatewayHealthyCondition(ctx, &pipeline)
-	r.setGatewayConfigGeneratedCondition(ctx, &pipeline, withinPipelineCountLimit)
+	r.setGatewayConfigGeneratedCondition(ctx, &pipeline, withinPipelineCountLimit, settings)
 
 	if r.flowHealthProbingEnabled {
 		r.setFlowHealthCondition(ctx, &pipeline)
+		pipeline.Status.DataFlow = prober.DataFlowStatus(ctx, r.dataFlowStatsProber, pipeline.Name, pipeline.Status.DataFlow)
 	}
 
 	if err := r.Status().Update(ctx, &pipeline); err != nil {
@@ -104,9 +110,9 @@ func (r *Reconciler) setGatewayHealthyCondition(ctx context.Context, pipeline *t
 	meta.SetStatusCondition(&pipeline.Status.Conditions, condition)
 }
 
-func (r *Reconciler) setGatewayConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, withinPipelineCountLimit bool) {
+func (r *Reconciler) setGatewayConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, withinPipelineCountLimit bool, settings telemetrysettings.Settings) {
 
-	status, reason, message := r.evaluateConfigGeneratedCondition(ctx, pipeline, withinPipelineCountLimit)
+	status, reason, message := r.evaluateConfigGeneratedCondition(ctx, pipeline, withinPipelineCountLimit, settings)
 	condition := metav1.Condition{
 		Type:               conditions.TypeConfigurationGenerated,
 		Status:             status,
@@ -118,7 +124,7 @@ func (r *Reconciler) setGatewayConfigGeneratedCondition(ctx context.Context, pip
 	meta.SetStatusCondition(&pipeline.Status.Conditions, condition)
 }
 
-func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, withinPipelineCountLimit bool) (status metav1.ConditionStatus, reason string, message string) {
+func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline, withinPipelineCountLimit bool, settings telemetrysettings.Settings) (status metav1.ConditionStatus, reason string, message string) {
 	if !withinPipelineCountLimit {
 		return metav1.ConditionFalse, conditions.ReasonMaxPipelinesExceeded, conditions.MessageForMetricPipeline(conditions.ReasonMaxPipelinesExceeded)
 	}
@@ -127,26 +133,108 @@ func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipel
 		return metav1.ConditionFalse, conditions.ReasonReferencedSecretMissing, conditions.MessageForMetricPipeline(conditions.ReasonReferencedSecretMissing)
 	}
 
+	if err := r.validateResources(pipeline, settings); err != nil {
+		return metav1.ConditionFalse, conditions.ReasonResourceRequirementsInvalid, conditions.MessageForResourceRequirementsInvalid(err)
+	}
+
 	if tlsCertValidationRequired(pipeline) {
-		cert := pipeline.Spec.Output.Otlp.TLS.Cert
-		key := pipeline.Spec.Output.Otlp.TLS.Key
+		err := r.validateTLSCertificates(ctx, pipeline)
+		status, reason, message = conditions.EvaluateTLSCertCondition(err)
+		if reason != conditions.ReasonConfigurationGenerated {
+			return status, reason, message
+		}
+	}
 
-		err := r.tlsCertValidator.ValidateCertificate(ctx, cert, key)
-		return conditions.EvaluateTLSCertCondition(err)
+	if unhonored := r.unhonoredScrapeSettings(ctx, pipeline); len(unhonored) > 0 {
+		return metav1.ConditionTrue, conditions.ReasonScrapeSettingsOverridden, fmt.Sprintf(conditions.MessageForMetricPipeline(conditions.ReasonScrapeSettingsOverridden), strings.Join(unhonored, "; "))
+	}
+
+	if skipped := r.skippedPrometheusMonitors(ctx, pipeline); len(skipped) > 0 {
+		return metav1.ConditionTrue, conditions.ReasonPrometheusMonitorsSkipped, fmt.Sprintf(conditions.MessageForMetricPipeline(conditions.ReasonPrometheusMonitorsSkipped), strings.Join(skipped, "; "))
 	}
 
 	return metav1.ConditionTrue, conditions.ReasonConfigurationGenerated, conditions.MessageForMetricPipeline(conditions.ReasonConfigurationGenerated)
 }
 
+// validateResources validates the resource overrides of the gateway, and of the agent if the pipeline requires it.
+func (r *Reconciler) validateResources(pipeline *telemetryv1alpha1.MetricPipeline, settings telemetrysettings.Settings) error {
+	if err := r.config.Gateway.WithResourceOverrides(settings.MetricGateway.ResourceOverrides).ValidateResources(); err != nil {
+		return fmt.Errorf("gateway: %w", err)
+	}
+	if isMetricAgentRequired(pipeline) {
+		if err := r.config.Agent.WithResourceOverrides(settings.MetricAgent).ValidateResources(); err != nil {
+			return fmt.Errorf("agent: %w", err)
+		}
+	}
+	return nil
+}
+
+// unhonoredScrapeSettings compares the scrape settings requested by the pipeline with the settings resolved across the reconcilable pipelines,
+// because the agent scrapes every Prometheus target only once and its configuration is built from these pipelines.
+func (r *Reconciler) unhonoredScrapeSettings(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline) []string {
+	if pipeline.Spec.Input.Prometheus == nil || !pipeline.Spec.Input.Prometheus.Enabled {
+		return nil
+	}
+
+	var allPipelines telemetryv1alpha1.MetricPipelineList
+	if err := r.List(ctx, &allPipelines); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list MetricPipelines to resolve the Prometheus scrape settings")
+		return nil
+	}
+
+	reconcilablePipelines, err := r.getReconcilablePipelines(ctx, allPipelines.Items, r.pipelineLock())
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to get reconcilable MetricPipelines to resolve the Prometheus scrape settings")
+		return nil
+	}
+
+	// A pipeline that is not deployed does not take part in the resolution, so none of its settings are honored or overridden
+	if !slices.ContainsFunc(reconcilablePipelines, func(p telemetryv1alpha1.MetricPipeline) bool { return p.Name == pipeline.Name }) {
+		return nil
+	}
+
+	resolved := configmetricagent.ResolvePrometheusScrapeSettings(reconcilablePipelines)
+	return configmetricagent.UnhonoredPrometheusScrapeSettings(pipeline, resolved)
+}
+
+// skippedPrometheusMonitors returns the ServiceMonitors and PodMonitors, or their endpoints, that the agent does not scrape
+// although the pipeline enables the monitors.
+func (r *Reconciler) skippedPrometheusMonitors(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline) []string {
+	if !configmetricagent.IsPrometheusMonitorsEnabled([]telemetryv1alpha1.MetricPipeline{*pipeline}) {
+		return nil
+	}
+
+	_, serviceMonitorIssues, err := prometheusmonitor.ListServiceMonitors(ctx, r.Client)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list ServiceMonitors to report the skipped monitors")
+		return nil
+	}
+	_, podMonitorIssues, err := prometheusmonitor.ListPodMonitors(ctx, r.Client)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list PodMonitors to report the skipped monitors")
+		return nil
+	}
+
+	var skipped []string
+	for _, issue := range append(serviceMonitorIssues, podMonitorIssues...) {
+		skipped = append(skipped, issue.String())
+	}
+	return skipped
+}
+
 func (r *Reconciler) setFlowHealthCondition(ctx context.Context, pipeline *telemetryv1alpha1.MetricPipeline) {
 	var reason string
 	var status metav1.ConditionStatus
+	var droppingOutputs []string
 
 	probeResult, err := r.flowHealthProber.Probe(ctx, pipeline.Name)
 	if err == nil {
 		logf.FromContext(ctx).V(1).Info("Probed flow health", "result", probeResult)
 
+		probeResult = withoutIrrelevantAgentResults(pipeline, probeResult)
+		probeResult.Healthy = probeResult.Healthy && !hasAgentIssues(probeResult)
 		reason = flowHealthReasonFor(probeResult)
+		droppingOutputs = probeResult.DroppingOutputs
 		if probeResult.Healthy {
 			status = metav1.ConditionTrue
 		} else {
@@ -163,7 +251,7 @@ func (r *Reconciler) setFlowHealthCondition(ctx context.Context, pipeline *telem
 		Type:               conditions.TypeFlowHealthy,
 		Status:             status,
 		Reason:             reason,
-		Message:            conditions.MessageForMetricPipeline(reason),
+		Message:            conditions.WithAffectedOutputs(conditions.MessageForMetricPipeline(reason), droppingOutputs),
 		ObservedGeneration: pipeline.Generation,
 	}
 
@@ -177,11 +265,48 @@ func flowHealthReasonFor(probeResult prober.OTelPipelineProbeResult) string {
 	if probeResult.SomeDataDropped {
 		return conditions.ReasonSelfMonSomeDataDropped
 	}
+	if probeResult.AgentAllDataDropped {
+		return conditions.ReasonSelfMonAgentAllDataDropped
+	}
+	if probeResult.AgentSomeDataDropped {
+		return conditions.ReasonSelfMonAgentSomeDataDropped
+	}
 	if probeResult.QueueAlmostFull {
 		return conditions.ReasonSelfMonBufferFillingUp
 	}
 	if probeResult.Throttling {
 		return conditions.ReasonSelfMonGatewayThrottling
 	}
+	if probeResult.ScrapeSampleLimitExceeded {
+		return conditions.ReasonSelfMonScrapeSampleLimitExceeded
+	}
+	if probeResult.ScrapeTargetsDown {
+		return conditions.ReasonSelfMonScrapeTargetsDown
+	}
 	return conditions.ReasonSelfMonFlowHealthy
 }
+
+// withoutIrrelevantAgentResults resets the metric agent results that do not affect the pipeline.
+// The agent is shared by all pipelines, so its alerts fire for every pipeline, including the ones that do not use an agent input.
+func withoutIrrelevantAgentResults(pipeline *telemetryv1alpha1.MetricPipeline, probeResult prober.OTelPipelineProbeResult) prober.OTelPipelineProbeResult {
+	if !isMetricAgentRequired(pipeline) {
+		probeResult.AgentAllDataDropped = false
+		probeResult.AgentSomeDataDropped = false
+	}
+
+	prometheus := pipeline.Spec.Input.Prometheus
+	if prometheus == nil || !prometheus.Enabled {
+		probeResult.ScrapeTargetsDown = false
+		probeResult.ScrapeSampleLimitExceeded = false
+	}
+
+	return probeResult
+}
+
+// hasAgentIssues returns true if the metric agent drops data of the pipeline.
+// Scrape targets that are down are not an issue of the pipeline, but of the workloads, so they are only reported as a reason of the healthy condition.
+func hasAgentIssues(probeResult prober.OTelPipelineProbeResult) bool {
+	return probeResult.AgentAllDataDropped ||
+		probeResult.AgentSomeDataDropped ||
+		probeResult.ScrapeSampleLimitExceeded
+}
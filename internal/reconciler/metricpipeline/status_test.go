@@ -11,15 +11,21 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	"github.com/kyma-project/telemetry-manager/internal/prometheusmonitor"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/metricpipeline/mocks"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/telemetrysettings"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
 	"github.com/kyma-project/telemetry-manager/internal/testutils"
@@ -49,7 +55,7 @@ func TestUpdateStatus(t *testing.T) {
 			}},
 			gatewayProber: gatewayProberMock,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -81,7 +87,7 @@ func TestUpdateStatus(t *testing.T) {
 			}},
 			gatewayProber: gatewayProberMock,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -113,7 +119,7 @@ func TestUpdateStatus(t *testing.T) {
 			}},
 			gatewayProber: gatewayProberMock,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -148,7 +154,7 @@ func TestUpdateStatus(t *testing.T) {
 			gatewayProber: gatewayProberStub,
 			agentProber:   agentProberMock,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -183,7 +189,7 @@ func TestUpdateStatus(t *testing.T) {
 			gatewayProber: gatewayProberStub,
 			agentProber:   agentProberMock,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -218,7 +224,7 @@ func TestUpdateStatus(t *testing.T) {
 			gatewayProber: gatewayProberStub,
 			agentProber:   agentProberMock,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -253,7 +259,7 @@ func TestUpdateStatus(t *testing.T) {
 			gatewayProber: gatewayProberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -278,7 +284,7 @@ func TestUpdateStatus(t *testing.T) {
 			gatewayProber: gatewayProberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -301,7 +307,7 @@ func TestUpdateStatus(t *testing.T) {
 			Client:        fakeClient,
 			gatewayProber: gatewayProberStub,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, false)
+		err := sut.updateStatus(context.Background(), pipeline.Name, false, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -313,13 +319,180 @@ func TestUpdateStatus(t *testing.T) {
 		require.Equal(t, conditions.ReasonMaxPipelinesExceeded, cond.Reason)
 	})
 
+	t.Run("invalid agent resource requirements", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithRuntimeInput(true).Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		agentProberStub := &mocks.DaemonSetProber{}
+		agentProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client:        fakeClient,
+			config:        Config{Agent: otelcollector.AgentConfig{DaemonSet: otelcollector.DaemonSetConfig{MemoryLimit: resource.MustParse("1200Mi")}}},
+			agentProber:   agentProberStub,
+			gatewayProber: gatewayProberStub,
+		}
+		settings := telemetrysettings.Settings{
+			MetricAgent: commonresources.ResourceOverrides{MemoryRequest: ptr.To(resource.MustParse("2Gi"))},
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, settings)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		cond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, cond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionFalse, cond.Status)
+		require.Equal(t, conditions.ReasonResourceRequirementsInvalid, cond.Reason)
+		require.Contains(t, cond.Message, "agent: memory request 2Gi exceeds memory limit 1200Mi")
+	})
+
+	t.Run("scrape settings overridden by another pipeline", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("pipeline").WithPrometheusInput(true).WithPrometheusInputScrapeInterval("1m").Build()
+		otherPipeline := testutils.NewMetricPipelineBuilder().WithName("other").WithPrometheusInput(true).WithPrometheusInputScrapeInterval("10s").Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline, &otherPipeline).WithStatusSubresource(&pipeline).Build()
+
+		agentProberStub := &mocks.DaemonSetProber{}
+		agentProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client:        fakeClient,
+			config:        Config{Gateway: otelcollector.GatewayConfig{Config: otelcollector.Config{Namespace: "kyma-system"}}},
+			agentProber:   agentProberStub,
+			gatewayProber: gatewayProberStub,
+		}
+		require.NoError(t, sut.pipelineLock().TryAcquireLock(context.Background(), &pipeline))
+		require.NoError(t, sut.pipelineLock().TryAcquireLock(context.Background(), &otherPipeline))
+
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		cond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, cond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionTrue, cond.Status)
+		require.Equal(t, conditions.ReasonScrapeSettingsOverridden, cond.Reason)
+		require.Equal(t, "Prometheus scrape settings are overridden by other pipelines: scrape interval 10s is used instead of 1m", cond.Message)
+	})
+
+	t.Run("scrape settings not overridden by a pipeline that is not reconcilable", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("pipeline").WithPrometheusInput(true).WithPrometheusInputScrapeInterval("1m").Build()
+		otherPipeline := testutils.NewMetricPipelineBuilder().WithName("other").WithPrometheusInput(true).WithPrometheusInputScrapeInterval("10s").Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline, &otherPipeline).WithStatusSubresource(&pipeline).Build()
+
+		agentProberStub := &mocks.DaemonSetProber{}
+		agentProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client:        fakeClient,
+			config:        Config{Gateway: otelcollector.GatewayConfig{Config: otelcollector.Config{Namespace: "kyma-system"}}},
+			agentProber:   agentProberStub,
+			gatewayProber: gatewayProberStub,
+		}
+		// The other pipeline exceeds the pipeline limit, so it is not deployed
+		require.NoError(t, sut.pipelineLock().TryAcquireLock(context.Background(), &pipeline))
+
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		cond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, cond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionTrue, cond.Status)
+		require.Equal(t, conditions.ReasonConfigurationGenerated, cond.Reason)
+	})
+
+	t.Run("scrape settings honored", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithName("pipeline").WithPrometheusInput(true).WithPrometheusInputScrapeInterval("10s").WithPrometheusInputSampleLimit(100000).Build()
+		otherPipeline := testutils.NewMetricPipelineBuilder().WithName("other").WithPrometheusInput(true).Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline, &otherPipeline).WithStatusSubresource(&pipeline).Build()
+
+		agentProberStub := &mocks.DaemonSetProber{}
+		agentProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client:        fakeClient,
+			config:        Config{Gateway: otelcollector.GatewayConfig{Config: otelcollector.Config{Namespace: "kyma-system"}}},
+			agentProber:   agentProberStub,
+			gatewayProber: gatewayProberStub,
+		}
+		require.NoError(t, sut.pipelineLock().TryAcquireLock(context.Background(), &pipeline))
+		require.NoError(t, sut.pipelineLock().TryAcquireLock(context.Background(), &otherPipeline))
+
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		cond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, cond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionTrue, cond.Status)
+		require.Equal(t, conditions.ReasonConfigurationGenerated, cond.Reason)
+	})
+
+	t.Run("prometheus monitors skipped", func(t *testing.T) {
+		pipeline := testutils.NewMetricPipelineBuilder().WithPrometheusInput(true).WithPrometheusInputMonitors(true).Build()
+
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(telemetryv1alpha1.GroupVersion.WithKind("MetricPipeline"), meta.RESTScopeRoot)
+		restMapper.Add(prometheusmonitor.ServiceMonitorGVK, meta.RESTScopeNamespace)
+		serviceMonitor := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{
+				"endpoints": []any{map[string]any{"port": "metrics", "bearerTokenSecret": map[string]any{"name": "token", "key": "token"}}},
+			},
+		}}
+		serviceMonitor.SetGroupVersionKind(prometheusmonitor.ServiceMonitorGVK)
+		serviceMonitor.SetNamespace("default")
+		serviceMonitor.SetName("backend")
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).WithObjects(&pipeline, serviceMonitor).WithStatusSubresource(&pipeline).Build()
+
+		agentProberStub := &mocks.DaemonSetProber{}
+		agentProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+		gatewayProberStub := &mocks.DeploymentProber{}
+		gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client:        fakeClient,
+			agentProber:   agentProberStub,
+			gatewayProber: gatewayProberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.MetricPipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		cond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, cond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionTrue, cond.Status)
+		require.Equal(t, conditions.ReasonPrometheusMonitorsSkipped, cond.Reason)
+		require.Equal(t, "Some ServiceMonitors or PodMonitors are not scraped: ServiceMonitor default/backend endpoint 0 uses the unsupported settings bearerTokenSecret", cond.Message)
+	})
+
 	t.Run("flow healthy", func(t *testing.T) {
 		tests := []struct {
-			name           string
-			probe          prober.OTelPipelineProbeResult
-			probeErr       error
-			expectedStatus metav1.ConditionStatus
-			expectedReason string
+			name            string
+			runtimeInput    bool
+			prometheusInput bool
+			probe           prober.OTelPipelineProbeResult
+			probeErr        error
+			expectedStatus  metav1.ConditionStatus
+			expectedReason  string
 		}{
 			{
 				name:           "prober fails",
@@ -394,26 +567,119 @@ func TestUpdateStatus(t *testing.T) {
 				expectedStatus: metav1.ConditionFalse,
 				expectedReason: conditions.ReasonSelfMonAllDataDropped,
 			},
+			{
+				name:         "agent all data dropped",
+				runtimeInput: true,
+				probe: prober.OTelPipelineProbeResult{
+					PipelineProbeResult: prober.PipelineProbeResult{Healthy: true},
+					AgentAllDataDropped: true,
+				},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonSelfMonAgentAllDataDropped,
+			},
+			{
+				name:         "agent some data dropped",
+				runtimeInput: true,
+				probe: prober.OTelPipelineProbeResult{
+					PipelineProbeResult:  prober.PipelineProbeResult{Healthy: true},
+					AgentSomeDataDropped: true,
+				},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonSelfMonAgentSomeDataDropped,
+			},
+			{
+				name: "agent data dropped without agent input",
+				probe: prober.OTelPipelineProbeResult{
+					PipelineProbeResult: prober.PipelineProbeResult{Healthy: true},
+					AgentAllDataDropped: true,
+				},
+				expectedStatus: metav1.ConditionTrue,
+				expectedReason: conditions.ReasonSelfMonFlowHealthy,
+			},
+			{
+				name:         "gateway all data dropped shadows agent data dropped",
+				runtimeInput: true,
+				probe: prober.OTelPipelineProbeResult{
+					PipelineProbeResult: prober.PipelineProbeResult{AllDataDropped: true},
+					AgentAllDataDropped: true,
+				},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonSelfMonAllDataDropped,
+			},
+			{
+				name:            "scrape targets down",
+				prometheusInput: true,
+				probe: prober.OTelPipelineProbeResult{
+					PipelineProbeResult: prober.PipelineProbeResult{Healthy: true},
+					ScrapeTargetsDown:   true,
+				},
+				expectedStatus: metav1.ConditionTrue,
+				expectedReason: conditions.ReasonSelfMonScrapeTargetsDown,
+			},
+			{
+				name:            "gateway issue shadows scrape targets down",
+				prometheusInput: true,
+				probe: prober.OTelPipelineProbeResult{
+					PipelineProbeResult: prober.PipelineProbeResult{Healthy: false},
+					Throttling:          true,
+					ScrapeTargetsDown:   true,
+				},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonSelfMonGatewayThrottling,
+			},
+			{
+				name:            "scrape sample limit exceeded shadows scrape targets down",
+				prometheusInput: true,
+				probe: prober.OTelPipelineProbeResult{
+					PipelineProbeResult:       prober.PipelineProbeResult{Healthy: true},
+					ScrapeTargetsDown:         true,
+					ScrapeSampleLimitExceeded: true,
+				},
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: conditions.ReasonSelfMonScrapeSampleLimitExceeded,
+			},
+			{
+				name:         "scrape targets down without prometheus input",
+				runtimeInput: true,
+				probe: prober.OTelPipelineProbeResult{
+					PipelineProbeResult:       prober.PipelineProbeResult{Healthy: true},
+					ScrapeTargetsDown:         true,
+					ScrapeSampleLimitExceeded: true,
+				},
+				expectedStatus: metav1.ConditionTrue,
+				expectedReason: conditions.ReasonSelfMonFlowHealthy,
+			},
 		}
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				pipeline := testutils.NewMetricPipelineBuilder().Build()
+				pipeline := testutils.NewMetricPipelineBuilder().
+					WithRuntimeInput(tt.runtimeInput).
+					WithPrometheusInput(tt.prometheusInput).
+					Build()
 				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
 
 				gatewayProberStub := &mocks.DeploymentProber{}
 				gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
 
+				agentProberStub := &mocks.DaemonSetProber{}
+				agentProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
 				flowHealthProberStub := &mocks.FlowHealthProber{}
 				flowHealthProberStub.On("Probe", mock.Anything, pipeline.Name).Return(tt.probe, tt.probeErr)
 
+				dataFlowStatsProberStub := &mocks.DataFlowStatsProber{}
+				dataFlowStatsProberStub.On("Stats", mock.Anything, pipeline.Name).Return(prober.PipelineStats{}, nil)
+
 				sut := Reconciler{
 					Client:                   fakeClient,
+					agentProber:              agentProberStub,
 					gatewayProber:            gatewayProberStub,
 					flowHealthProbingEnabled: true,
 					flowHealthProber:         flowHealthProberStub,
+					dataFlowStatsProber:      dataFlowStatsProberStub,
 				}
-				err := sut.updateStatus(context.Background(), pipeline.Name, false)
+				err := sut.updateStatus(context.Background(), pipeline.Name, false, telemetrysettings.Settings{})
 				require.NoError(t, err)
 
 				var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -426,6 +692,77 @@ func TestUpdateStatus(t *testing.T) {
 			})
 		}
 	})
+	t.Run("data flow stats", func(t *testing.T) {
+		now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+		utilization := 0.5
+		utilizationPercent := int32(50)
+		previous := &telemetryv1alpha1.DataFlowStatus{SentPerMinute: ptr.To(int64(600)), LastUpdateTime: metav1.NewTime(now.Add(-time.Minute))}
+
+		tests := []struct {
+			name     string
+			stats    prober.PipelineStats
+			statsErr error
+			expected *telemetryv1alpha1.DataFlowStatus
+		}{
+			{
+				name:  "prober succeeds",
+				stats: prober.PipelineStats{SentPerMinute: ptr.To(1200.0), DroppedPerMinute: ptr.To(30.0), QueueUtilization: &utilization, Timestamp: now},
+				expected: &telemetryv1alpha1.DataFlowStatus{
+					SentPerMinute:           ptr.To(int64(1200)),
+					DroppedPerMinute:        ptr.To(int64(30)),
+					QueueUtilizationPercent: &utilizationPercent,
+					LastUpdateTime:          metav1.NewTime(now),
+				},
+			},
+			{
+				name:     "no statistics collected",
+				stats:    prober.PipelineStats{Timestamp: now},
+				expected: &telemetryv1alpha1.DataFlowStatus{LastUpdateTime: metav1.NewTime(now)},
+			},
+			{
+				name:     "prober fails",
+				statsErr: assert.AnError,
+				expected: previous,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				pipeline := testutils.NewMetricPipelineBuilder().Build()
+				pipeline.Status.DataFlow = previous
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+				gatewayProberStub := &mocks.DeploymentProber{}
+				gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+				flowHealthProberStub := &mocks.FlowHealthProber{}
+				flowHealthProberStub.On("Probe", mock.Anything, pipeline.Name).Return(prober.OTelPipelineProbeResult{}, nil)
+
+				dataFlowStatsProberStub := &mocks.DataFlowStatsProber{}
+				dataFlowStatsProberStub.On("Stats", mock.Anything, pipeline.Name).Return(tt.stats, tt.statsErr)
+
+				sut := Reconciler{
+					Client:                   fakeClient,
+					gatewayProber:            gatewayProberStub,
+					flowHealthProbingEnabled: true,
+					flowHealthProber:         flowHealthProberStub,
+					dataFlowStatsProber:      dataFlowStatsProberStub,
+				}
+				err := sut.updateStatus(context.Background(), pipeline.Name, false, telemetrysettings.Settings{})
+				require.NoError(t, err)
+
+				var updatedPipeline telemetryv1alpha1.MetricPipeline
+				_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+				require.NotNil(t, updatedPipeline.Status.DataFlow)
+				require.Equal(t, tt.expected.SentPerMinute, updatedPipeline.Status.DataFlow.SentPerMinute)
+				require.Equal(t, tt.expected.DroppedPerMinute, updatedPipeline.Status.DataFlow.DroppedPerMinute)
+				require.Equal(t, tt.expected.QueueUtilizationPercent, updatedPipeline.Status.DataFlow.QueueUtilizationPercent)
+				require.True(t, tt.expected.LastUpdateTime.Equal(&updatedPipeline.Status.DataFlow.LastUpdateTime))
+			})
+		}
+	})
+
 	t.Run("tls conditions", func(t *testing.T) {
 		tests := []struct {
 			name           string
@@ -486,7 +823,7 @@ func TestUpdateStatus(t *testing.T) {
 					gatewayProber:    gatewayProberStub,
 				}
 
-				err := sut.updateStatus(context.Background(), pipeline.Name, true)
+				err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 				require.NoError(t, err)
 
 				var updatedPipeline telemetryv1alpha1.MetricPipeline
@@ -33,6 +33,7 @@ const (
 type Config struct {
 	Traces                 TracesConfig
 	Metrics                MetricsConfig
+	Logs                   LogsConfig
 	Webhook                WebhookConfig
 	OverridesConfigMapName types.NamespacedName
 	SelfMonitor            SelfMonitorConfig
@@ -41,11 +42,19 @@ type Config struct {
 type TracesConfig struct {
 	OTLPServiceName string
 	Namespace       string
+	GatewayName     string
 }
 
 type MetricsConfig struct {
 	OTLPServiceName string
 	Namespace       string
+	GatewayName     string
+	AgentName       string
+}
+
+type LogsConfig struct {
+	Namespace     string
+	FluentBitName string
 }
 
 type WebhookConfig struct {
@@ -58,6 +67,9 @@ type SelfMonitorConfig struct {
 	Config        selfmonitor.Config
 	WebhookURL    string
 	WebhookScheme string
+
+	// FluentBitFsBufferLimit is the filesystem buffer limit of Fluent Bit in bytes, from which the thresholds of the buffer rules are derived.
+	FluentBitFsBufferLimit int64
 }
 
 type healthCheckers struct {
@@ -152,7 +164,7 @@ func (r *Reconciler) reconcileSelfMonitor(ctx context.Context, telemetry operato
 		return fmt.Errorf("failed to marshal selfmonitor config: %w", err)
 	}
 
-	rules := config.MakeRules()
+	rules := config.MakeRules(r.makeRulesConfig(&telemetry))
 	rulesYAML, err := yaml.Marshal(rules)
 	if err != nil {
 		return fmt.Errorf("failed to marshal rules: %w", err)
@@ -170,6 +182,42 @@ func (r *Reconciler) reconcileSelfMonitor(ctx context.Context, telemetry operato
 	return nil
 }
 
+// makeRulesConfig applies the rule overrides of the Telemetry resource to the defaults derived from the component configuration.
+func (r *Reconciler) makeRulesConfig(telemetry *operatorv1alpha1.Telemetry) config.RulesConfig {
+	rulesConfig := config.RulesConfig{
+		FluentBitFsBufferLimit: r.config.SelfMonitor.FluentBitFsBufferLimit,
+	}
+
+	if telemetry.Spec.SelfMonitor == nil || telemetry.Spec.SelfMonitor.Rules == nil {
+		return rulesConfig
+	}
+
+	rules := telemetry.Spec.SelfMonitor.Rules
+	if rules.RateWindow != nil {
+		rulesConfig.RateWindow = rules.RateWindow.Duration
+	}
+	if rules.For != nil {
+		rulesConfig.For = rules.For.Duration
+	}
+	rulesConfig.LogAgentBufferInUse = makeThresholdRuleConfig(rules.LogAgentBufferInUse)
+	rulesConfig.LogAgentBufferFull = makeThresholdRuleConfig(rules.LogAgentBufferFull)
+	rulesConfig.GatewayExporterQueueAlmostFull = makeThresholdRuleConfig(rules.GatewayExporterQueueAlmostFull)
+
+	return rulesConfig
+}
+
+func makeThresholdRuleConfig(rule *operatorv1alpha1.SelfMonitorThresholdRule) config.ThresholdRuleConfig {
+	if rule == nil {
+		return config.ThresholdRuleConfig{}
+	}
+
+	ruleConfig := config.ThresholdRuleConfig{ThresholdPercent: int(rule.ThresholdPercent)}
+	if rule.For != nil {
+		ruleConfig.For = rule.For.Duration
+	}
+	return ruleConfig
+}
+
 func (r *Reconciler) checkPipelineExist(ctx context.Context) (bool, error) {
 	var allLogPipelines telemetryv1alpha1.LogPipelineList
 	if err := r.List(ctx, &allLogPipelines); err != nil {
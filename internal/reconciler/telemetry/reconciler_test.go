@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/config"
+)
+
+func TestMakeRulesConfig(t *testing.T) {
+	sut := Reconciler{config: Config{SelfMonitor: SelfMonitorConfig{FluentBitFsBufferLimit: 2000000000}}}
+
+	t.Run("without overrides", func(t *testing.T) {
+		rulesConfig := sut.makeRulesConfig(&operatorv1alpha1.Telemetry{})
+		require.Equal(t, config.RulesConfig{FluentBitFsBufferLimit: 2000000000}, rulesConfig)
+	})
+
+	t.Run("with overrides", func(t *testing.T) {
+		telemetry := &operatorv1alpha1.Telemetry{
+			Spec: operatorv1alpha1.TelemetrySpec{
+				SelfMonitor: &operatorv1alpha1.SelfMonitorSpec{
+					Rules: &operatorv1alpha1.SelfMonitorRules{
+						RateWindow: &metav1.Duration{Duration: 10 * time.Minute},
+						For:        &metav1.Duration{Duration: time.Minute},
+						LogAgentBufferFull: &operatorv1alpha1.SelfMonitorThresholdRule{
+							ThresholdPercent: 75,
+							For:              &metav1.Duration{Duration: 5 * time.Minute},
+						},
+						GatewayExporterQueueAlmostFull: &operatorv1alpha1.SelfMonitorThresholdRule{ThresholdPercent: 50},
+					},
+				},
+			},
+		}
+
+		rulesConfig := sut.makeRulesConfig(telemetry)
+		require.Equal(t, config.RulesConfig{
+			FluentBitFsBufferLimit:         2000000000,
+			RateWindow:                     10 * time.Minute,
+			For:                            time.Minute,
+			LogAgentBufferFull:             config.ThresholdRuleConfig{ThresholdPercent: 75, For: 5 * time.Minute},
+			GatewayExporterQueueAlmostFull: config.ThresholdRuleConfig{ThresholdPercent: 50},
+		}, rulesConfig)
+	})
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"slices"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
@@ -36,6 +39,10 @@ func (r *Reconciler) updateStatus(ctx context.Context, telemetry *operatorv1alph
 		return fmt.Errorf("failed to update gateway endpoints: %w", err)
 	}
 
+	if err := r.updateComponentResources(ctx, telemetry); err != nil {
+		return fmt.Errorf("failed to update component resources: %w", err)
+	}
+
 	if err := r.Status().Update(ctx, telemetry); err != nil {
 		return fmt.Errorf("failed to update status: %w", err)
 	}
@@ -126,6 +133,97 @@ func (r *Reconciler) metricEndpoints(ctx context.Context, config Config, telemet
 	return makeOTLPEndpoints(config.Metrics.OTLPServiceName, config.Metrics.Namespace), nil
 }
 
+// updateComponentResources reports the resources of the main container of each deployed component, which might differ from the Telemetry spec, for example, if values are not overridden.
+func (r *Reconciler) updateComponentResources(ctx context.Context, telemetry *operatorv1alpha1.Telemetry) error {
+	traceGateway, err := r.gatewayResources(ctx, r.config.Traces.Namespace, r.config.Traces.GatewayName)
+	if err != nil {
+		return fmt.Errorf("failed to get trace gateway resources: %w", err)
+	}
+
+	metricGateway, err := r.gatewayResources(ctx, r.config.Metrics.Namespace, r.config.Metrics.GatewayName)
+	if err != nil {
+		return fmt.Errorf("failed to get metric gateway resources: %w", err)
+	}
+
+	metricAgent, err := r.workloadResources(ctx, &appsv1.DaemonSet{}, r.config.Metrics.Namespace, r.config.Metrics.AgentName)
+	if err != nil {
+		return fmt.Errorf("failed to get metric agent resources: %w", err)
+	}
+
+	fluentBit, err := r.workloadResources(ctx, &appsv1.DaemonSet{}, r.config.Logs.Namespace, r.config.Logs.FluentBitName)
+	if err != nil {
+		return fmt.Errorf("failed to get fluent bit resources: %w", err)
+	}
+
+	telemetry.Status.Resources = nil
+	if traceGateway != nil || metricGateway != nil || metricAgent != nil || fluentBit != nil {
+		telemetry.Status.Resources = &operatorv1alpha1.ComponentResources{
+			TraceGateway:  traceGateway,
+			MetricGateway: metricGateway,
+			MetricAgent:   metricAgent,
+			FluentBit:     fluentBit,
+		}
+	}
+
+	return nil
+}
+
+// gatewayResources looks up the gateway StatefulSet, which is used if the sending queues are persisted, and falls back to the gateway Deployment.
+func (r *Reconciler) gatewayResources(ctx context.Context, namespace, name string) (*operatorv1alpha1.ResourceRequirements, error) {
+	resources, err := r.workloadResources(ctx, &appsv1.StatefulSet{}, namespace, name)
+	if err != nil || resources != nil {
+		return resources, err
+	}
+	return r.workloadResources(ctx, &appsv1.Deployment{}, namespace, name)
+}
+
+// workloadResources returns nil if the workload is not deployed.
+func (r *Reconciler) workloadResources(ctx context.Context, workload client.Object, namespace, name string) (*operatorv1alpha1.ResourceRequirements, error) {
+	if name == "" {
+		return nil, nil //nolint:nilnil //it is ok in this context, even if it is not go idiomatic
+	}
+
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, workload); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	var podSpec corev1.PodSpec
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		podSpec = w.Spec.Template.Spec
+	case *appsv1.DaemonSet:
+		podSpec = w.Spec.Template.Spec
+	case *appsv1.StatefulSet:
+		podSpec = w.Spec.Template.Spec
+	}
+
+	// The first container runs the component, further containers are helpers, such as the Fluent Bit exporter
+	if len(podSpec.Containers) == 0 {
+		return nil, nil //nolint:nilnil //it is ok in this context, even if it is not go idiomatic
+	}
+
+	resources := podSpec.Containers[0].Resources
+	return &operatorv1alpha1.ResourceRequirements{
+		Limits:   makeComputeResources(resources.Limits),
+		Requests: makeComputeResources(resources.Requests),
+	}, nil
+}
+
+func makeComputeResources(resources corev1.ResourceList) *operatorv1alpha1.ComputeResources {
+	var computeResources operatorv1alpha1.ComputeResources
+	if cpu, ok := resources[corev1.ResourceCPU]; ok {
+		computeResources.CPU = &cpu
+	}
+	if memory, ok := resources[corev1.ResourceMemory]; ok {
+		computeResources.Memory = &memory
+	}
+
+	if computeResources.CPU == nil && computeResources.Memory == nil {
+		return nil
+	}
+	return &computeResources
+}
+
 func makeOTLPEndpoints(serviceName, namespace string) *operatorv1alpha1.OTLPEndpoints {
 	return &operatorv1alpha1.OTLPEndpoints{
 		HTTP: fmt.Sprintf("http://%s.%s:%d", serviceName, namespace, ports.OTLPHTTP),
@@ -7,6 +7,9 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -302,6 +305,78 @@ func TestUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateComponentResources(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorv1alpha1.AddToScheme(scheme)
+
+	config := Config{
+		Traces:  TracesConfig{Namespace: "telemetry-system", GatewayName: "trace-gateway"},
+		Metrics: MetricsConfig{Namespace: "telemetry-system", GatewayName: "metric-gateway", AgentName: "metric-agent"},
+		Logs:    LogsConfig{Namespace: "telemetry-system", FluentBitName: "fluent-bit"},
+	}
+
+	t.Run("no resources if no component is deployed", func(t *testing.T) {
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), config: config}
+		telemetry := &operatorv1alpha1.Telemetry{}
+
+		require.NoError(t, r.updateComponentResources(ctx, telemetry))
+		require.Nil(t, telemetry.Status.Resources)
+	})
+
+	t.Run("resources of deployed components", func(t *testing.T) {
+		gatewayResources := corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1200m"),
+				corev1.ResourceMemory: resource.MustParse("2000Mi"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+		}
+		traceGateway := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "trace-gateway", Namespace: "telemetry-system"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "collector", Resources: gatewayResources}},
+			}}},
+		}
+		fluentBit := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "fluent-bit", Namespace: "telemetry-system"},
+			Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "fluent-bit", Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					}},
+					{Name: "exporter", Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("50Mi")},
+					}},
+				},
+			}}},
+		}
+		r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(traceGateway, fluentBit).Build(), config: config}
+		telemetry := &operatorv1alpha1.Telemetry{}
+
+		require.NoError(t, r.updateComponentResources(ctx, telemetry))
+		require.Equal(t, &operatorv1alpha1.ComponentResources{
+			TraceGateway: &operatorv1alpha1.ResourceRequirements{
+				Limits: &operatorv1alpha1.ComputeResources{
+					CPU:    pointerFrom(resource.MustParse("1200m")),
+					Memory: pointerFrom(resource.MustParse("2000Mi")),
+				},
+				Requests: &operatorv1alpha1.ComputeResources{
+					Memory: pointerFrom(resource.MustParse("32Mi")),
+				},
+			},
+			FluentBit: &operatorv1alpha1.ResourceRequirements{
+				Limits: &operatorv1alpha1.ComputeResources{
+					Memory: pointerFrom(resource.MustParse("1Gi")),
+				},
+			},
+		}, telemetry.Status.Resources)
+	})
+}
+
 func pointerFrom[T any](value T) *T {
 	return &value
 }
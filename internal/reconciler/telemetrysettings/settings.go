@@ -0,0 +1,164 @@
+package telemetrysettings
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+)
+
+const (
+	defaultReplicaCount int32 = 2
+
+	defaultMinReplicas                    int32 = 2
+	defaultMaxReplicas                    int32 = 10
+	defaultTargetCPUUtilizationPercentage int32 = 80
+)
+
+var defaultPersistentQueueSize = resource.MustParse("1Gi")
+
+// Settings contains the settings of the Telemetry resource, which the pipeline reconcilers apply to the workloads they deploy.
+// The settings are read once per reconciliation and passed to all steps that need them.
+type Settings struct {
+	TraceGateway  GatewaySettings
+	MetricGateway GatewaySettings
+	MetricAgent   commonresources.ResourceOverrides
+	FluentBit     commonresources.ResourceOverrides
+}
+
+// GatewaySettings contains the settings of a gateway, with defaults for all values that are not set in the Telemetry resource.
+type GatewaySettings struct {
+	// Replicas is the number of replicas if the gateway is scaled statically.
+	Replicas int32
+	// Autoscaling is nil if the gateway is scaled statically.
+	Autoscaling       *otelcollector.GatewayAutoscalingConfig
+	PersistentQueue   otelcollector.PersistentQueueConfig
+	ResourceOverrides otelcollector.GatewayResourceOverrides
+}
+
+// FromTelemetry reads the settings from the Telemetry resources. Every section is taken from the first Telemetry resource that defines it.
+// If the Telemetry resources cannot be listed, the defaults are used.
+func FromTelemetry(ctx context.Context, c client.Reader) Settings {
+	settings := Settings{
+		TraceGateway:  GatewaySettings{Replicas: defaultReplicaCount},
+		MetricGateway: GatewaySettings{Replicas: defaultReplicaCount},
+	}
+
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := c.List(ctx, &telemetries); err != nil {
+		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default settings")
+		return settings
+	}
+
+	var traceFound, metricFound, metricAgentFound, fluentBitFound bool
+	for i := range telemetries.Items {
+		spec := telemetries.Items[i].Spec
+
+		if spec.Trace != nil && !traceFound {
+			gateway := spec.Trace.Gateway
+			settings.TraceGateway = makeGatewaySettings(gateway.Scaling, gateway.Resources, gateway.PersistentQueue)
+			traceFound = true
+		}
+
+		if spec.Metric != nil && !metricFound {
+			gateway := spec.Metric.Gateway
+			settings.MetricGateway = makeGatewaySettings(gateway.Scaling, gateway.Resources, gateway.PersistentQueue)
+			metricFound = true
+		}
+
+		if spec.Metric != nil && spec.Metric.Agent != nil && !metricAgentFound {
+			settings.MetricAgent = commonresources.MakeResourceOverrides(spec.Metric.Agent.Resources)
+			metricAgentFound = true
+		}
+
+		if spec.Log != nil && spec.Log.FluentBit != nil && !fluentBitFound {
+			settings.FluentBit = commonresources.MakeResourceOverrides(spec.Log.FluentBit.Resources)
+			fluentBitFound = true
+		}
+	}
+
+	return settings
+}
+
+func makeGatewaySettings(scaling operatorv1alpha1.Scaling, resources *operatorv1alpha1.GatewayResources, persistentQueue *operatorv1alpha1.PersistentQueue) GatewaySettings {
+	return GatewaySettings{
+		Replicas:          makeReplicas(scaling),
+		Autoscaling:       makeAutoscaling(scaling),
+		PersistentQueue:   makePersistentQueue(persistentQueue),
+		ResourceOverrides: makeGatewayResourceOverrides(resources),
+	}
+}
+
+func makeReplicas(scaling operatorv1alpha1.Scaling) int32 {
+	if scaling.Type == operatorv1alpha1.StaticScalingStrategyType && scaling.Static != nil && scaling.Static.Replicas > 0 {
+		return scaling.Static.Replicas
+	}
+	return defaultReplicaCount
+}
+
+func makeAutoscaling(scaling operatorv1alpha1.Scaling) *otelcollector.GatewayAutoscalingConfig {
+	if scaling.Type != operatorv1alpha1.DynamicScalingStrategyType {
+		return nil
+	}
+
+	autoscaling := otelcollector.GatewayAutoscalingConfig{
+		MinReplicas:                    defaultMinReplicas,
+		MaxReplicas:                    defaultMaxReplicas,
+		TargetCPUUtilizationPercentage: defaultTargetCPUUtilizationPercentage,
+	}
+
+	dynamic := scaling.Dynamic
+	if dynamic == nil {
+		return &autoscaling
+	}
+
+	if dynamic.MinReplicas > 0 {
+		autoscaling.MinReplicas = dynamic.MinReplicas
+	}
+	if dynamic.MaxReplicas > 0 {
+		autoscaling.MaxReplicas = dynamic.MaxReplicas
+	}
+	if dynamic.TargetCPUUtilizationPercentage > 0 {
+		autoscaling.TargetCPUUtilizationPercentage = dynamic.TargetCPUUtilizationPercentage
+	}
+	autoscaling.TargetMemoryUtilizationPercentage = dynamic.TargetMemoryUtilizationPercentage
+
+	// Only one of the bounds might be set, so the defaults must not contradict it
+	if autoscaling.MaxReplicas < autoscaling.MinReplicas {
+		if dynamic.MaxReplicas > 0 {
+			autoscaling.MinReplicas = autoscaling.MaxReplicas
+		} else {
+			autoscaling.MaxReplicas = autoscaling.MinReplicas
+		}
+	}
+	return &autoscaling
+}
+
+// makePersistentQueue returns a disabled persistent queue if it is not enabled, so the gateway uses in-memory queues.
+func makePersistentQueue(persistentQueue *operatorv1alpha1.PersistentQueue) otelcollector.PersistentQueueConfig {
+	if persistentQueue == nil || !persistentQueue.Enabled {
+		return otelcollector.PersistentQueueConfig{}
+	}
+
+	size := defaultPersistentQueueSize
+	if persistentQueue.Size != nil && !persistentQueue.Size.IsZero() {
+		size = *persistentQueue.Size
+	}
+	return otelcollector.PersistentQueueConfig{Enabled: true, Size: size, StorageClassName: persistentQueue.StorageClassName}
+}
+
+func makeGatewayResourceOverrides(resources *operatorv1alpha1.GatewayResources) otelcollector.GatewayResourceOverrides {
+	if resources == nil {
+		return otelcollector.GatewayResourceOverrides{}
+	}
+
+	return otelcollector.GatewayResourceOverrides{
+		Base:        commonresources.MakeResourceOverrides(&resources.ResourceRequirements),
+		PerPipeline: commonresources.MakeResourceOverrides(resources.PerPipeline),
+	}
+}
@@ -0,0 +1,199 @@
+package telemetrysettings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+)
+
+func TestFromTelemetry(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorv1alpha1.AddToScheme(scheme)
+
+	makeTelemetry := func(spec operatorv1alpha1.TelemetrySpec) *operatorv1alpha1.Telemetry {
+		return &operatorv1alpha1.Telemetry{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "kyma-system"},
+			Spec:       spec,
+		}
+	}
+
+	t.Run("defaults if no telemetry exists", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		settings := FromTelemetry(ctx, fakeClient)
+		for _, gateway := range []GatewaySettings{settings.TraceGateway, settings.MetricGateway} {
+			require.Equal(t, int32(2), gateway.Replicas)
+			require.Nil(t, gateway.Autoscaling)
+			require.False(t, gateway.PersistentQueue.Enabled)
+			require.Equal(t, otelcollector.GatewayResourceOverrides{}, gateway.ResourceOverrides)
+		}
+		require.Equal(t, commonresources.ResourceOverrides{}, settings.MetricAgent)
+		require.Equal(t, commonresources.ResourceOverrides{}, settings.FluentBit)
+	})
+
+	t.Run("static scaling", func(t *testing.T) {
+		telemetry := makeTelemetry(operatorv1alpha1.TelemetrySpec{
+			Trace: &operatorv1alpha1.TraceSpec{
+				Gateway: operatorv1alpha1.TraceGatewaySpec{Scaling: operatorv1alpha1.Scaling{
+					Type:   operatorv1alpha1.StaticScalingStrategyType,
+					Static: &operatorv1alpha1.StaticScaling{Replicas: 3},
+				}},
+			},
+		})
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(telemetry).Build()
+
+		settings := FromTelemetry(ctx, fakeClient)
+		require.Equal(t, int32(3), settings.TraceGateway.Replicas)
+		require.Nil(t, settings.TraceGateway.Autoscaling)
+		require.Equal(t, int32(2), settings.MetricGateway.Replicas, "the metric gateway must not use the trace gateway settings")
+	})
+
+	t.Run("autoscaling with defaults", func(t *testing.T) {
+		telemetry := makeTelemetry(operatorv1alpha1.TelemetrySpec{
+			Metric: &operatorv1alpha1.MetricSpec{
+				Gateway: operatorv1alpha1.MetricGatewaySpec{Scaling: operatorv1alpha1.Scaling{Type: operatorv1alpha1.DynamicScalingStrategyType}},
+			},
+		})
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(telemetry).Build()
+
+		settings := FromTelemetry(ctx, fakeClient)
+		require.Equal(t, &otelcollector.GatewayAutoscalingConfig{
+			MinReplicas:                    2,
+			MaxReplicas:                    10,
+			TargetCPUUtilizationPercentage: 80,
+		}, settings.MetricGateway.Autoscaling)
+		require.Nil(t, settings.TraceGateway.Autoscaling, "the trace gateway must not use the metric gateway settings")
+	})
+
+	t.Run("autoscaling with custom settings", func(t *testing.T) {
+		telemetry := makeTelemetry(operatorv1alpha1.TelemetrySpec{
+			Trace: &operatorv1alpha1.TraceSpec{
+				Gateway: operatorv1alpha1.TraceGatewaySpec{Scaling: operatorv1alpha1.Scaling{
+					Type: operatorv1alpha1.DynamicScalingStrategyType,
+					Dynamic: &operatorv1alpha1.DynamicScaling{
+						MinReplicas:                       3,
+						MaxReplicas:                       6,
+						TargetCPUUtilizationPercentage:    60,
+						TargetMemoryUtilizationPercentage: 75,
+					},
+				}},
+			},
+		})
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(telemetry).Build()
+
+		require.Equal(t, &otelcollector.GatewayAutoscalingConfig{
+			MinReplicas:                       3,
+			MaxReplicas:                       6,
+			TargetCPUUtilizationPercentage:    60,
+			TargetMemoryUtilizationPercentage: 75,
+		}, FromTelemetry(ctx, fakeClient).TraceGateway.Autoscaling)
+	})
+
+	t.Run("default bound adapts to the configured bound", func(t *testing.T) {
+		telemetry := makeTelemetry(operatorv1alpha1.TelemetrySpec{
+			Trace: &operatorv1alpha1.TraceSpec{
+				Gateway: operatorv1alpha1.TraceGatewaySpec{Scaling: operatorv1alpha1.Scaling{
+					Type:    operatorv1alpha1.DynamicScalingStrategyType,
+					Dynamic: &operatorv1alpha1.DynamicScaling{MinReplicas: 15},
+				}},
+			},
+		})
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(telemetry).Build()
+
+		autoscaling := FromTelemetry(ctx, fakeClient).TraceGateway.Autoscaling
+		require.Equal(t, int32(15), autoscaling.MinReplicas)
+		require.Equal(t, int32(15), autoscaling.MaxReplicas)
+	})
+
+	t.Run("persistent queue with default size", func(t *testing.T) {
+		telemetry := makeTelemetry(operatorv1alpha1.TelemetrySpec{
+			Trace: &operatorv1alpha1.TraceSpec{
+				Gateway: operatorv1alpha1.TraceGatewaySpec{
+					PersistentQueue: &operatorv1alpha1.PersistentQueue{Enabled: true},
+				},
+			},
+		})
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(telemetry).Build()
+
+		persistentQueue := FromTelemetry(ctx, fakeClient).TraceGateway.PersistentQueue
+		require.True(t, persistentQueue.Enabled)
+		require.Equal(t, resource.MustParse("1Gi"), persistentQueue.Size)
+		require.Nil(t, persistentQueue.StorageClassName)
+	})
+
+	t.Run("persistent queue with custom size and storage class", func(t *testing.T) {
+		size := resource.MustParse("5Gi")
+		telemetry := makeTelemetry(operatorv1alpha1.TelemetrySpec{
+			Metric: &operatorv1alpha1.MetricSpec{
+				Gateway: operatorv1alpha1.MetricGatewaySpec{
+					PersistentQueue: &operatorv1alpha1.PersistentQueue{Enabled: true, Size: &size, StorageClassName: ptr.To("premium")},
+				},
+			},
+		})
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(telemetry).Build()
+
+		persistentQueue := FromTelemetry(ctx, fakeClient).MetricGateway.PersistentQueue
+		require.True(t, persistentQueue.Enabled)
+		require.Equal(t, size, persistentQueue.Size)
+		require.Equal(t, ptr.To("premium"), persistentQueue.StorageClassName)
+	})
+
+	t.Run("resource overrides", func(t *testing.T) {
+		memoryLimit := resource.MustParse("1Gi")
+		perPipelineCPULimit := resource.MustParse("200m")
+		agentCPURequest := resource.MustParse("100m")
+		fluentBitMemoryRequest := resource.MustParse("256Mi")
+		telemetry := makeTelemetry(operatorv1alpha1.TelemetrySpec{
+			Trace: &operatorv1alpha1.TraceSpec{
+				Gateway: operatorv1alpha1.TraceGatewaySpec{
+					Resources: &operatorv1alpha1.GatewayResources{
+						ResourceRequirements: operatorv1alpha1.ResourceRequirements{
+							Limits: &operatorv1alpha1.ComputeResources{Memory: &memoryLimit},
+						},
+						PerPipeline: &operatorv1alpha1.ResourceRequirements{
+							Limits: &operatorv1alpha1.ComputeResources{CPU: &perPipelineCPULimit},
+						},
+					},
+				},
+			},
+			Metric: &operatorv1alpha1.MetricSpec{
+				Agent: &operatorv1alpha1.MetricAgentSpec{
+					Resources: &operatorv1alpha1.ResourceRequirements{
+						Requests: &operatorv1alpha1.ComputeResources{CPU: &agentCPURequest},
+					},
+				},
+			},
+			Log: &operatorv1alpha1.LogSpec{
+				FluentBit: &operatorv1alpha1.FluentBitSpec{
+					Resources: &operatorv1alpha1.ResourceRequirements{
+						Requests: &operatorv1alpha1.ComputeResources{Memory: &fluentBitMemoryRequest},
+					},
+				},
+			},
+		})
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(telemetry).Build()
+
+		settings := FromTelemetry(ctx, fakeClient)
+		overrides := settings.TraceGateway.ResourceOverrides
+		require.Equal(t, &memoryLimit, overrides.Base.MemoryLimit)
+		require.Nil(t, overrides.Base.CPULimit)
+		require.Equal(t, &perPipelineCPULimit, overrides.PerPipeline.CPULimit)
+		require.Nil(t, overrides.PerPipeline.MemoryLimit)
+		require.Equal(t, otelcollector.GatewayResourceOverrides{}, settings.MetricGateway.ResourceOverrides)
+		require.Equal(t, &agentCPURequest, settings.MetricAgent.CPURequest)
+		require.Equal(t, &fluentBitMemoryRequest, settings.FluentBit.MemoryRequest)
+	})
+}
@@ -27,25 +27,29 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
-	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/istiostatus"
 	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/trace/gateway"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/ports"
 	"github.com/kyma-project/telemetry-manager/internal/overrides"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/telemetrysettings"
 	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
 	"github.com/kyma-project/telemetry-manager/internal/tlscert"
 )
 
-const defaultReplicaCount int32 = 2
-
 type Config struct {
 	Gateway                otelcollector.GatewayConfig
 	OverridesConfigMapName types.NamespacedName
 	MaxPipelines           int
+	// MetricGatewayServiceName is the OTLP Service of the metric gateway, to which the span metrics are sent.
+	MetricGatewayServiceName types.NamespacedName
+	// TapEndpoint is the endpoint of the telemetry manager, to which pipelines with an active tap send their data. The zero value disables the tap.
+	TapEndpoint tap.Endpoint
 }
 
 //go:generate mockery --name DeploymentProber --filename deployment_prober.go
@@ -58,6 +62,11 @@ type FlowHealthProber interface {
 	Probe(ctx context.Context, pipelineName string) (prober.OTelPipelineProbeResult, error)
 }
 
+//go:generate mockery --name DataFlowStatsProber --filename data_flow_stats_prober.go
+type DataFlowStatsProber interface {
+	Stats(ctx context.Context, pipelineName string) (prober.PipelineStats, error)
+}
+
 //go:generate mockery --name TLSCertValidator --filename tls_cert_validator.go
 type TLSCertValidator interface {
 	ValidateCertificate(ctx context.Context, cert, key *telemetryv1alpha1.ValueType) error
@@ -69,6 +78,7 @@ type Reconciler struct {
 	prober                     DeploymentProber
 	flowHealthProbingEnabled   bool
 	flowHealthProber           FlowHealthProber
+	dataFlowStatsProber        DataFlowStatsProber
 	overridesHandler           *overrides.Handler
 	istioStatusChecker         istiostatus.Checker
 	tlsCertValidator           TLSCertValidator
@@ -80,6 +90,7 @@ func NewReconciler(client client.Client,
 	prober DeploymentProber,
 	flowHealthProbingEnabled bool,
 	flowHealthProber FlowHealthProber,
+	dataFlowStatsProber DataFlowStatsProber,
 	overridesHandler *overrides.Handler) *Reconciler {
 	return &Reconciler{
 		Client:                   client,
@@ -87,6 +98,7 @@ func NewReconciler(client client.Client,
 		prober:                   prober,
 		flowHealthProbingEnabled: flowHealthProbingEnabled,
 		flowHealthProber:         flowHealthProber,
+		dataFlowStatsProber:      dataFlowStatsProber,
 		overridesHandler:         overridesHandler,
 		istioStatusChecker:       istiostatus.NewChecker(client),
 		tlsCertValidator:         tlscert.New(client),
@@ -117,9 +129,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline) error {
 	var err error
 	lockAcquired := true
+	settings := telemetrysettings.FromTelemetry(ctx, r.Client)
 
 	defer func() {
-		if statusErr := r.updateStatus(ctx, pipeline.Name, lockAcquired); statusErr != nil {
+		if statusErr := r.updateStatus(ctx, pipeline.Name, lockAcquired, settings); statusErr != nil {
 			if err != nil {
 				err = fmt.Errorf("failed while updating status: %w: %w", statusErr, err)
 			} else {
@@ -128,6 +141,10 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 		}
 	}()
 
+	if err = tap.DeleteInactiveSamples(ctx, r.Client, r.config.Gateway.Namespace, tap.KindTracePipelines, pipeline); err != nil {
+		return err
+	}
+
 	lock := k8sutils.NewResourceCountLock(r.Client, types.NamespacedName{
 		Name:      "telemetry-tracepipeline-lock",
 		Namespace: r.config.Gateway.Namespace,
@@ -155,7 +172,7 @@ func (r *Reconciler) doReconcile(ctx context.Context, pipeline *telemetryv1alpha
 		return nil
 	}
 
-	if err = r.reconcileTraceGateway(ctx, pipeline, reconcilablePipelines); err != nil {
+	if err = r.reconcileTraceGateway(ctx, pipeline, reconcilablePipelines, settings.TraceGateway); err != nil {
 		return fmt.Errorf("failed to reconcile trace gateway: %w", err)
 	}
 
@@ -187,11 +204,12 @@ func (r *Reconciler) isReconcilable(ctx context.Context, pipeline *telemetryv1al
 		return false, nil
 	}
 
-	if tlsCertValidationRequired(pipeline) {
-		cert := pipeline.Spec.Output.Otlp.TLS.Cert
-		key := pipeline.Spec.Output.Otlp.TLS.Key
+	if gateway.ValidateFilters(pipeline.Spec.Filters) != nil {
+		return false, nil
+	}
 
-		if err := r.tlsCertValidator.ValidateCertificate(ctx, cert, key); err != nil {
+	if tlsCertValidationRequired(pipeline) {
+		if err := r.validateTLSCertificates(ctx, pipeline); err != nil {
 			if !tlscert.IsCertAboutToExpireError(err) {
 				return false, nil
 			}
@@ -205,13 +223,24 @@ func (r *Reconciler) isReconcilable(ctx context.Context, pipeline *telemetryv1al
 
 }
 
-func (r *Reconciler) reconcileTraceGateway(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline, allPipelines []telemetryv1alpha1.TracePipeline) error {
+func (r *Reconciler) reconcileTraceGateway(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline, allPipelines []telemetryv1alpha1.TracePipeline, settings telemetrysettings.GatewaySettings) error {
 	scaling := otelcollector.GatewayScalingConfig{
-		Replicas:                       r.getReplicaCountFromTelemetry(ctx),
+		Replicas:                       settings.Replicas,
 		ResourceRequirementsMultiplier: len(allPipelines),
+		Autoscaling:                    settings.Autoscaling,
 	}
-
-	collectorConfig, collectorEnvVars, err := gateway.MakeConfig(ctx, r.Client, allPipelines)
+	persistentQueue := settings.PersistentQueue
+	spanMetricsCollected := r.isSpanMetricsCollected(ctx)
+
+	collectorConfig, collectorEnvVars, err := gateway.MakeConfig(ctx, r.Client, allPipelines, gateway.BuildOptions{
+		LoadBalancingServiceName: types.NamespacedName{
+			Name:      r.config.Gateway.LoadBalancingServiceName,
+			Namespace: r.config.Gateway.Namespace,
+		},
+		PersistentQueue:          config.PersistentQueue{Enabled: persistentQueue.Enabled, VolumeSize: persistentQueue.Size},
+		MetricGatewayServiceName: r.config.MetricGatewayServiceName,
+		SpanMetricsCollected:     spanMetricsCollected,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create collector config: %w", err)
 	}
@@ -221,8 +250,16 @@ func (r *Reconciler) reconcileTraceGateway(ctx context.Context, pipeline *teleme
 		return fmt.Errorf("failed to marshal collector config: %w", err)
 	}
 
+	gateway.AddTapExporters(collectorConfig, tap.ExporterTaps(ctx, r.Client, r.config.TapEndpoint, tap.KindTracePipelines, allPipelines))
+	collectorConfigWithTapsYAML, err := yaml.Marshal(collectorConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal collector config with tap exporters: %w", err)
+	}
+
 	isIstioActive := r.istioStatusChecker.IsIstioActive(ctx)
 
+	loadBalancing := gateway.RequiresLoadBalancing(allPipelines, spanMetricsCollected)
+
 	allowedPorts := []int32{
 		ports.OTLPHTTP,
 		ports.OTLPGRPC,
@@ -230,14 +267,21 @@ func (r *Reconciler) reconcileTraceGateway(ctx context.Context, pipeline *teleme
 		ports.HealthCheck,
 	}
 
+	if loadBalancing {
+		allowedPorts = append(allowedPorts, ports.OTLPGRPCLoadBalanced)
+	}
+
 	if isIstioActive {
 		allowedPorts = append(allowedPorts, ports.IstioEnvoy)
 	}
 
 	if err := otelcollector.ApplyGatewayResources(ctx,
 		k8sutils.NewOwnerReferenceSetter(r.Client, pipeline),
-		r.config.Gateway.WithScaling(scaling).WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars).
+		r.gatewayConfig(ctx, settings.ResourceOverrides).WithScaling(scaling).WithCollectorConfig(string(collectorConfigYAML), collectorEnvVars).
+			WithTapExporters(string(collectorConfigWithTapsYAML)).
 			WithIstioConfig(fmt.Sprintf("%d", ports.Metrics), isIstioActive).
+			WithPersistentQueue(persistentQueue).
+			WithLoadBalancing(loadBalancing).
 			WithAllowedPorts(allowedPorts)); err != nil {
 		return fmt.Errorf("failed to apply gateway resources: %w", err)
 	}
@@ -245,41 +289,47 @@ func (r *Reconciler) reconcileTraceGateway(ctx context.Context, pipeline *teleme
 	return nil
 }
 
-func (r *Reconciler) getReplicaCountFromTelemetry(ctx context.Context) int32 {
-	var telemetries operatorv1alpha1.TelemetryList
-	if err := r.List(ctx, &telemetries); err != nil {
-		logf.FromContext(ctx).V(1).Error(err, "Failed to list telemetry: using default scaling")
-		return defaultReplicaCount
+// isSpanMetricsCollected returns true if a MetricPipeline collects the span metrics, which are only generated in that case.
+func (r *Reconciler) isSpanMetricsCollected(ctx context.Context) bool {
+	var metricPipelines telemetryv1alpha1.MetricPipelineList
+	if err := r.List(ctx, &metricPipelines); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list MetricPipelines: span metrics are not generated")
+		return false
 	}
-	for i := range telemetries.Items {
-		telemetrySpec := telemetries.Items[i].Spec
-		if telemetrySpec.Trace == nil {
-			continue
-		}
 
-		scaling := telemetrySpec.Trace.Gateway.Scaling
-		if scaling.Type != operatorv1alpha1.StaticScalingStrategyType {
-			continue
+	for i := range metricPipelines.Items {
+		pipeline := &metricPipelines.Items[i]
+		spanMetrics := pipeline.Spec.Input.SpanMetrics
+		if pipeline.DeletionTimestamp == nil && spanMetrics != nil && spanMetrics.Enabled {
+			return true
 		}
+	}
+	return false
+}
 
-		static := scaling.Static
-		if static != nil && static.Replicas > 0 {
-			return static.Replicas
-		}
+// gatewayConfig returns the gateway config with the resource overrides of the Telemetry resource.
+// If the overrides are invalid, the gateway keeps its default resources and the pipeline status reports the error.
+func (r *Reconciler) gatewayConfig(ctx context.Context, overrides otelcollector.GatewayResourceOverrides) *otelcollector.GatewayConfig {
+	gatewayConfig := r.config.Gateway.WithResourceOverrides(overrides)
+	if err := gatewayConfig.ValidateResources(); err != nil {
+		logf.FromContext(ctx).V(1).Info("Ignoring invalid resource overrides of the trace gateway", "error", err.Error())
+		return &r.config.Gateway
 	}
-	return defaultReplicaCount
+	return gatewayConfig
 }
 
 func tlsCertValidationRequired(pipeline *telemetryv1alpha1.TracePipeline) bool {
-	otlp := pipeline.Spec.Output.Otlp
-	if otlp == nil {
-		return false
-	}
-	if otlp.TLS == nil {
-		return false
-	}
+	return len(clientCertificates(pipeline)) > 0
+}
+
+// validateTLSCertificates validates the client certificates of all outputs of the pipeline.
+func (r *Reconciler) validateTLSCertificates(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline) error {
+	return tlscert.ValidateCertificates(ctx, r.tlsCertValidator, clientCertificates(pipeline))
+}
 
-	return otlp.TLS.Cert != nil || otlp.TLS.Key != nil
+// clientCertificates returns the client certificates configured for the outputs of the pipeline.
+func clientCertificates(pipeline *telemetryv1alpha1.TracePipeline) []tlscert.ClientCertificate {
+	return tlscert.OtlpClientCertificates(pipeline.Spec.Output.Otlp, pipeline.Spec.AdditionalOutputs)
 }
 
 // clearPipelinesConditions clears the status conditions for all TracePipelines only in the 1st reconciliation
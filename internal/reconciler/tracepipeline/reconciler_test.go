@@ -5,15 +5,20 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/tracepipeline/mocks"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
+	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
 )
 
 var (
@@ -167,6 +172,29 @@ func TestGetDeployableTracePipelinesWithMissingSecretReference(t *testing.T) {
 	require.NotContains(t, deployablePipelines, pipelineWithSecretRef)
 }
 
+func TestGetDeployableTracePipelinesWithInvalidFilter(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	l := k8sutils.NewResourceCountLock(fakeClient, lockName, 2)
+
+	invalidPipeline := testutils.NewTracePipelineBuilder().WithName("invalid").WithFilterCondition(`attributes["x"] ==`).Build()
+	validPipeline := testutils.NewTracePipelineBuilder().WithName("valid").WithFilterCondition(`attributes["x"] == "y"`).Build()
+	require.NoError(t, l.TryAcquireLock(ctx, &invalidPipeline))
+	require.NoError(t, l.TryAcquireLock(ctx, &validPipeline))
+
+	reconciler := Reconciler{
+		Client:           fakeClient,
+		tlsCertValidator: &mocks.TLSCertValidator{},
+	}
+	deployablePipelines, err := reconciler.getReconcilablePipelines(ctx, []telemetryv1alpha1.TracePipeline{invalidPipeline, validPipeline}, l)
+	require.NoError(t, err)
+	require.Len(t, deployablePipelines, 1)
+	require.Equal(t, "valid", deployablePipelines[0].Name)
+}
+
 func TestGetDeployableTracePipelinesWithoutLock(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
@@ -188,3 +216,53 @@ func TestGetDeployableTracePipelinesWithoutLock(t *testing.T) {
 	require.NoError(t, err)
 	require.NotContains(t, deployablePipelines, pipeline1)
 }
+
+func TestGatewayConfig(t *testing.T) {
+	ctx := context.Background()
+	reconciler := Reconciler{config: Config{Gateway: otelcollector.GatewayConfig{
+		Deployment: otelcollector.DeploymentConfig{
+			BaseCPULimit:      resource.MustParse("700m"),
+			BaseCPURequest:    resource.MustParse("100m"),
+			BaseMemoryLimit:   resource.MustParse("500Mi"),
+			BaseMemoryRequest: resource.MustParse("32Mi"),
+		},
+	}}}
+
+	t.Run("valid overrides are applied", func(t *testing.T) {
+		gatewayConfig := reconciler.gatewayConfig(ctx, otelcollector.GatewayResourceOverrides{
+			Base: commonresources.ResourceOverrides{MemoryLimit: ptr.To(resource.MustParse("1Gi"))},
+		})
+		require.Equal(t, resource.MustParse("1Gi"), gatewayConfig.Deployment.BaseMemoryLimit)
+	})
+
+	t.Run("invalid overrides fall back to the defaults", func(t *testing.T) {
+		gatewayConfig := reconciler.gatewayConfig(ctx, otelcollector.GatewayResourceOverrides{
+			Base: commonresources.ResourceOverrides{MemoryLimit: ptr.To(resource.MustParse("16Mi"))},
+		})
+		require.Equal(t, resource.MustParse("500Mi"), gatewayConfig.Deployment.BaseMemoryLimit)
+	})
+}
+
+func TestIsSpanMetricsCollected(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+
+	t.Run("no metric pipeline collects span metrics", func(t *testing.T) {
+		metricPipeline := testutils.NewMetricPipelineBuilder().WithSpanMetricsInput(false).Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&metricPipeline).Build()
+
+		sut := Reconciler{Client: fakeClient}
+		require.False(t, sut.isSpanMetricsCollected(ctx))
+	})
+
+	t.Run("a metric pipeline collects span metrics", func(t *testing.T) {
+		metricPipeline := testutils.NewMetricPipelineBuilder().Build()
+		spanMetricsPipeline := testutils.NewMetricPipelineBuilder().WithSpanMetricsInput(true).Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&metricPipeline, &spanMetricsPipeline).Build()
+
+		sut := Reconciler{Client: fakeClient}
+		require.True(t, sut.isSpanMetricsCollected(ctx))
+	})
+}
@@ -12,11 +12,13 @@ import (
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/trace/gateway"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/telemetrysettings"
 	"github.com/kyma-project/telemetry-manager/internal/secretref"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
 )
 
-func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, withinPipelineCountLimit bool) error {
+func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, withinPipelineCountLimit bool, settings telemetrysettings.Settings) error {
 	var pipeline telemetryv1alpha1.TracePipeline
 	if err := r.Get(ctx, types.NamespacedName{Name: pipelineName}, &pipeline); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -33,9 +35,10 @@ func (r *Reconciler) updateStatus(ctx context.Context, pipelineName string, with
 	}
 
 	r.setGatewayHealthyCondition(ctx, &pipeline)
-	r.setGatewayConfigGeneratedCondition(ctx, &pipeline, withinPipelineCountLimit)
+	r.setGatewayConfigGeneratedCondition(ctx, &pipeline, withinPipelineCountLimit, settings.TraceGateway)
 	if r.flowHealthProbingEnabled {
 		r.setFlowHealthCondition(ctx, &pipeline)
+		pipeline.Status.DataFlow = prober.DataFlowStatus(ctx, r.dataFlowStatsProber, pipeline.Name, pipeline.Status.DataFlow)
 	}
 	r.setLegacyConditions(ctx, &pipeline, withinPipelineCountLimit)
 
@@ -71,8 +74,8 @@ func (r *Reconciler) setGatewayHealthyCondition(ctx context.Context, pipeline *t
 	meta.SetStatusCondition(&pipeline.Status.Conditions, condition)
 }
 
-func (r *Reconciler) setGatewayConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline, withinPipelineCountLimit bool) {
-	status, reason, message := r.evaluateConfigGeneratedCondition(ctx, pipeline, withinPipelineCountLimit)
+func (r *Reconciler) setGatewayConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline, withinPipelineCountLimit bool, settings telemetrysettings.GatewaySettings) {
+	status, reason, message := r.evaluateConfigGeneratedCondition(ctx, pipeline, withinPipelineCountLimit, settings)
 
 	condition := metav1.Condition{
 		Type:               conditions.TypeConfigurationGenerated,
@@ -85,7 +88,7 @@ func (r *Reconciler) setGatewayConfigGeneratedCondition(ctx context.Context, pip
 	meta.SetStatusCondition(&pipeline.Status.Conditions, condition)
 }
 
-func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline, withinPipelineCountLimit bool) (status metav1.ConditionStatus, reason string, message string) {
+func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline, withinPipelineCountLimit bool, settings telemetrysettings.GatewaySettings) (status metav1.ConditionStatus, reason string, message string) {
 	if !withinPipelineCountLimit {
 		return metav1.ConditionFalse, conditions.ReasonMaxPipelinesExceeded, conditions.MessageForTracePipeline(conditions.ReasonMaxPipelinesExceeded)
 	}
@@ -94,14 +97,17 @@ func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipel
 		return metav1.ConditionFalse, conditions.ReasonReferencedSecretMissing, conditions.MessageForTracePipeline(conditions.ReasonReferencedSecretMissing)
 	}
 
-	if tlsCertValidationRequired(pipeline) {
-		if tlsCertValidationRequired(pipeline) {
-			cert := pipeline.Spec.Output.Otlp.TLS.Cert
-			key := pipeline.Spec.Output.Otlp.TLS.Key
+	if err := gateway.ValidateFilters(pipeline.Spec.Filters); err != nil {
+		return metav1.ConditionFalse, conditions.ReasonFilterInvalid, fmt.Sprintf("%s (%s)", conditions.MessageForTracePipeline(conditions.ReasonFilterInvalid), err)
+	}
 
-			err := r.tlsCertValidator.ValidateCertificate(ctx, cert, key)
-			return conditions.EvaluateTLSCertCondition(err)
-		}
+	if err := r.config.Gateway.WithResourceOverrides(settings.ResourceOverrides).ValidateResources(); err != nil {
+		return metav1.ConditionFalse, conditions.ReasonResourceRequirementsInvalid, conditions.MessageForResourceRequirementsInvalid(err)
+	}
+
+	if tlsCertValidationRequired(pipeline) {
+		err := r.validateTLSCertificates(ctx, pipeline)
+		return conditions.EvaluateTLSCertCondition(err)
 	}
 
 	return metav1.ConditionTrue, conditions.ReasonConfigurationGenerated, conditions.MessageForTracePipeline(conditions.ReasonConfigurationGenerated)
@@ -110,12 +116,14 @@ func (r *Reconciler) evaluateConfigGeneratedCondition(ctx context.Context, pipel
 func (r *Reconciler) setFlowHealthCondition(ctx context.Context, pipeline *telemetryv1alpha1.TracePipeline) {
 	var reason string
 	var status metav1.ConditionStatus
+	var droppingOutputs []string
 
 	probeResult, err := r.flowHealthProber.Probe(ctx, pipeline.Name)
 	if err == nil {
 		logf.FromContext(ctx).V(1).Info("Probed flow health", "result", probeResult)
 
 		reason = flowHealthReasonFor(probeResult)
+		droppingOutputs = probeResult.DroppingOutputs
 		if probeResult.Healthy {
 			status = metav1.ConditionTrue
 		} else {
@@ -132,7 +140,7 @@ func (r *Reconciler) setFlowHealthCondition(ctx context.Context, pipeline *telem
 		Type:               conditions.TypeFlowHealthy,
 		Status:             status,
 		Reason:             reason,
-		Message:            conditions.MessageForTracePipeline(reason),
+		Message:            conditions.WithAffectedOutputs(conditions.MessageForTracePipeline(reason), droppingOutputs),
 		ObservedGeneration: pipeline.Generation,
 	}
 
@@ -2,6 +2,7 @@ package tracepipeline
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -10,15 +11,19 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/conditions"
+	"github.com/kyma-project/telemetry-manager/internal/reconciler/telemetrysettings"
 	"github.com/kyma-project/telemetry-manager/internal/reconciler/tracepipeline/mocks"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 	"github.com/kyma-project/telemetry-manager/internal/resources/otelcollector"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
 	"github.com/kyma-project/telemetry-manager/internal/testutils"
@@ -44,7 +49,7 @@ func TestUpdateStatus(t *testing.T) {
 			}},
 			prober: proberStub,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.TracePipeline
@@ -86,7 +91,7 @@ func TestUpdateStatus(t *testing.T) {
 			}},
 			prober: proberStub,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.TracePipeline
@@ -156,7 +161,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.TracePipeline
@@ -227,7 +232,7 @@ func TestUpdateStatus(t *testing.T) {
 			prober: proberStub,
 		}
 
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.TracePipeline
@@ -278,7 +283,7 @@ func TestUpdateStatus(t *testing.T) {
 			}},
 			prober: proberStub,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, false)
+		err := sut.updateStatus(context.Background(), pipeline.Name, false, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.TracePipeline
@@ -306,13 +311,87 @@ func TestUpdateStatus(t *testing.T) {
 		require.NotEmpty(t, pendingCond.LastTransitionTime)
 	})
 
+	t.Run("invalid resource requirements", func(t *testing.T) {
+		pipelineName := "pipeline"
+		pipeline := &telemetryv1alpha1.TracePipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       pipelineName,
+				Generation: 1,
+			},
+			Spec: telemetryv1alpha1.TracePipelineSpec{
+				Output: telemetryv1alpha1.TracePipelineOutput{
+					Otlp: &telemetryv1alpha1.OtlpOutput{
+						Endpoint: telemetryv1alpha1.ValueType{Value: "localhost"},
+					},
+				}},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{
+				Config: otelcollector.Config{BaseName: "trace-gateway"},
+				Deployment: otelcollector.DeploymentConfig{
+					BaseCPULimit:   resource.MustParse("700m"),
+					BaseCPURequest: resource.MustParse("100m"),
+				},
+			}},
+			prober: proberStub,
+		}
+		settings := telemetrysettings.Settings{TraceGateway: telemetrysettings.GatewaySettings{
+			ResourceOverrides: otelcollector.GatewayResourceOverrides{
+				Base: commonresources.ResourceOverrides{CPURequest: ptr.To(resource.MustParse("1"))},
+			},
+		}}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, settings)
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.TracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipelineName}, &updatedPipeline)
+
+		configurationGeneratedCond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, configurationGeneratedCond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionFalse, configurationGeneratedCond.Status)
+		require.Equal(t, conditions.ReasonResourceRequirementsInvalid, configurationGeneratedCond.Reason)
+		require.Equal(t, conditions.MessageForResourceRequirementsInvalid(errors.New("CPU request 1 exceeds CPU limit 700m")), configurationGeneratedCond.Message)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().WithName("pipeline").WithFilterCondition(`foo bar baz`).Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+		sut := Reconciler{
+			Client: fakeClient,
+			config: Config{Gateway: otelcollector.GatewayConfig{Config: otelcollector.Config{BaseName: "trace-gateway"}}},
+			prober: proberStub,
+		}
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.TracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+		configurationGeneratedCond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, configurationGeneratedCond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionFalse, configurationGeneratedCond.Status)
+		require.Equal(t, conditions.ReasonFilterInvalid, configurationGeneratedCond.Reason)
+		require.Contains(t, configurationGeneratedCond.Message, conditions.MessageForTracePipeline(conditions.ReasonFilterInvalid))
+	})
+
 	t.Run("flow healthy", func(t *testing.T) {
 		tests := []struct {
-			name           string
-			probe          prober.OTelPipelineProbeResult
-			probeErr       error
-			expectedStatus metav1.ConditionStatus
-			expectedReason string
+			name            string
+			probe           prober.OTelPipelineProbeResult
+			probeErr        error
+			expectedStatus  metav1.ConditionStatus
+			expectedReason  string
+			expectedMessage string
 		}{
 			{
 				name:           "prober fails",
@@ -378,6 +457,16 @@ func TestUpdateStatus(t *testing.T) {
 				expectedStatus: metav1.ConditionFalse,
 				expectedReason: conditions.ReasonSelfMonAllDataDropped,
 			},
+			{
+				name: "additional output dropped data",
+				probe: prober.OTelPipelineProbeResult{
+					PipelineProbeResult: prober.PipelineProbeResult{SomeDataDropped: true},
+					DroppingOutputs:     []string{"audit"},
+				},
+				expectedStatus:  metav1.ConditionFalse,
+				expectedReason:  conditions.ReasonSelfMonSomeDataDropped,
+				expectedMessage: "Some traces dropped: backend unreachable or rejecting (affected outputs: additionalOutputs[audit])",
+			},
 			{
 				name: "all data dropped shadows other problems",
 				probe: prober.OTelPipelineProbeResult{
@@ -400,13 +489,17 @@ func TestUpdateStatus(t *testing.T) {
 				flowHealthProberStub := &mocks.FlowHealthProber{}
 				flowHealthProberStub.On("Probe", mock.Anything, pipeline.Name).Return(tt.probe, tt.probeErr)
 
+				dataFlowStatsProberStub := &mocks.DataFlowStatsProber{}
+				dataFlowStatsProberStub.On("Stats", mock.Anything, pipeline.Name).Return(prober.PipelineStats{}, nil)
+
 				sut := Reconciler{
 					Client:                   fakeClient,
 					prober:                   gatewayProberStub,
 					flowHealthProbingEnabled: true,
 					flowHealthProber:         flowHealthProberStub,
+					dataFlowStatsProber:      dataFlowStatsProberStub,
 				}
-				err := sut.updateStatus(context.Background(), pipeline.Name, false)
+				err := sut.updateStatus(context.Background(), pipeline.Name, false, telemetrysettings.Settings{})
 				require.NoError(t, err)
 
 				var updatedPipeline telemetryv1alpha1.TracePipeline
@@ -416,6 +509,75 @@ func TestUpdateStatus(t *testing.T) {
 				require.NotNil(t, cond, "could not find condition of type %s", conditions.TypeFlowHealthy)
 				require.Equal(t, tt.expectedStatus, cond.Status)
 				require.Equal(t, tt.expectedReason, cond.Reason)
+				if tt.expectedMessage != "" {
+					require.Equal(t, tt.expectedMessage, cond.Message)
+				}
+			})
+		}
+	})
+
+	t.Run("data flow stats", func(t *testing.T) {
+		now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+		utilization := 0.5
+		utilizationPercent := int32(50)
+		previous := &telemetryv1alpha1.DataFlowStatus{SentPerMinute: ptr.To(int64(600)), LastUpdateTime: metav1.NewTime(now.Add(-time.Minute))}
+
+		tests := []struct {
+			name     string
+			stats    prober.PipelineStats
+			statsErr error
+			expected *telemetryv1alpha1.DataFlowStatus
+		}{
+			{
+				name:  "prober succeeds",
+				stats: prober.PipelineStats{SentPerMinute: ptr.To(1200.0), DroppedPerMinute: ptr.To(30.0), QueueUtilization: &utilization, Timestamp: now},
+				expected: &telemetryv1alpha1.DataFlowStatus{
+					SentPerMinute:           ptr.To(int64(1200)),
+					DroppedPerMinute:        ptr.To(int64(30)),
+					QueueUtilizationPercent: &utilizationPercent,
+					LastUpdateTime:          metav1.NewTime(now),
+				},
+			},
+			{
+				name:     "prober fails",
+				statsErr: assert.AnError,
+				expected: previous,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				pipeline := testutils.NewTracePipelineBuilder().Build()
+				pipeline.Status.DataFlow = previous
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+				gatewayProberStub := &mocks.DeploymentProber{}
+				gatewayProberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+
+				flowHealthProberStub := &mocks.FlowHealthProber{}
+				flowHealthProberStub.On("Probe", mock.Anything, pipeline.Name).Return(prober.OTelPipelineProbeResult{}, nil)
+
+				dataFlowStatsProberStub := &mocks.DataFlowStatsProber{}
+				dataFlowStatsProberStub.On("Stats", mock.Anything, pipeline.Name).Return(tt.stats, tt.statsErr)
+
+				sut := Reconciler{
+					Client:                   fakeClient,
+					prober:                   gatewayProberStub,
+					flowHealthProbingEnabled: true,
+					flowHealthProber:         flowHealthProberStub,
+					dataFlowStatsProber:      dataFlowStatsProberStub,
+				}
+				err := sut.updateStatus(context.Background(), pipeline.Name, false, telemetrysettings.Settings{})
+				require.NoError(t, err)
+
+				var updatedPipeline telemetryv1alpha1.TracePipeline
+				_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+
+				require.NotNil(t, updatedPipeline.Status.DataFlow)
+				require.Equal(t, tt.expected.SentPerMinute, updatedPipeline.Status.DataFlow.SentPerMinute)
+				require.Equal(t, tt.expected.DroppedPerMinute, updatedPipeline.Status.DataFlow.DroppedPerMinute)
+				require.Equal(t, tt.expected.QueueUtilizationPercent, updatedPipeline.Status.DataFlow.QueueUtilizationPercent)
+				require.True(t, tt.expected.LastUpdateTime.Equal(&updatedPipeline.Status.DataFlow.LastUpdateTime))
 			})
 		}
 	})
@@ -478,7 +640,7 @@ func TestUpdateStatus(t *testing.T) {
 			}},
 			prober: proberStub,
 		}
-		err := sut.updateStatus(context.Background(), pipeline.Name, true)
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 		require.NoError(t, err)
 
 		var updatedPipeline telemetryv1alpha1.TracePipeline
@@ -558,7 +720,7 @@ func TestUpdateStatus(t *testing.T) {
 					prober:           proberStub,
 				}
 
-				err := sut.updateStatus(context.Background(), pipeline.Name, true)
+				err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
 				require.NoError(t, err)
 
 				var updatedPipeline telemetryv1alpha1.TracePipeline
@@ -570,4 +732,34 @@ func TestUpdateStatus(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("tls conditions with additional outputs", func(t *testing.T) {
+		pipeline := testutils.NewTracePipelineBuilder().
+			WithOTLPOutput(testutils.OTLPClientTLS("ca", "primaryCert", "primaryKey")).
+			WithAdditionalOTLPOutput("audit", testutils.OTLPClientTLS("ca", "auditCert", "auditKey")).
+			Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).WithStatusSubresource(&pipeline).Build()
+
+		proberStub := &mocks.DeploymentProber{}
+		proberStub.On("IsReady", mock.Anything, mock.Anything).Return(true, nil)
+		tlsStub := &mocks.TLSCertValidator{}
+		tlsStub.On("ValidateCertificate", mock.Anything, &telemetryv1alpha1.ValueType{Value: "primaryCert"}, mock.Anything).Return(&tlscert.CertAboutToExpireError{Expiry: time.Now().Add(7 * 24 * time.Hour)})
+		tlsStub.On("ValidateCertificate", mock.Anything, &telemetryv1alpha1.ValueType{Value: "auditCert"}, mock.Anything).Return(tlscert.ErrCertParseFailed)
+
+		sut := Reconciler{
+			Client:           fakeClient,
+			tlsCertValidator: tlsStub,
+			prober:           proberStub,
+		}
+
+		err := sut.updateStatus(context.Background(), pipeline.Name, true, telemetrysettings.Settings{})
+		require.NoError(t, err)
+
+		var updatedPipeline telemetryv1alpha1.TracePipeline
+		_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: pipeline.Name}, &updatedPipeline)
+		cond := meta.FindStatusCondition(updatedPipeline.Status.Conditions, conditions.TypeConfigurationGenerated)
+		require.NotNil(t, cond, "could not find condition of type %s", conditions.TypeConfigurationGenerated)
+		require.Equal(t, metav1.ConditionFalse, cond.Status)
+		require.Equal(t, conditions.ReasonTLSCertificateInvalid, cond.Reason)
+	})
 }
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"fmt"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -11,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
 )
 
@@ -126,3 +128,53 @@ func makeNetworkPolicyPorts(ports []int32) []networkingv1.NetworkPolicyPort {
 
 	return networkPolicyPorts
 }
+
+// ResourceOverrides contains CPU and memory values that replace the configured defaults of a workload. Nil values keep the defaults.
+type ResourceOverrides struct {
+	CPULimit      *resource.Quantity
+	MemoryLimit   *resource.Quantity
+	CPURequest    *resource.Quantity
+	MemoryRequest *resource.Quantity
+}
+
+func MakeResourceOverrides(requirements *operatorv1alpha1.ResourceRequirements) ResourceOverrides {
+	var overrides ResourceOverrides
+	if requirements == nil {
+		return overrides
+	}
+
+	if limits := requirements.Limits; limits != nil {
+		overrides.CPULimit = limits.CPU
+		overrides.MemoryLimit = limits.Memory
+	}
+	if requests := requirements.Requests; requests != nil {
+		overrides.CPURequest = requests.CPU
+		overrides.MemoryRequest = requests.Memory
+	}
+	return overrides
+}
+
+// Apply replaces the given defaults with the overridden values.
+func (o ResourceOverrides) Apply(cpuLimit, memoryLimit, cpuRequest, memoryRequest *resource.Quantity) {
+	for _, value := range []struct{ target, override *resource.Quantity }{
+		{cpuLimit, o.CPULimit},
+		{memoryLimit, o.MemoryLimit},
+		{cpuRequest, o.CPURequest},
+		{memoryRequest, o.MemoryRequest},
+	} {
+		if value.override != nil {
+			*value.target = value.override.DeepCopy()
+		}
+	}
+}
+
+// ValidateResourceRequirements returns an error if a request exceeds its limit, because the API server rejects such a workload.
+func ValidateResourceRequirements(cpuLimit, memoryLimit, cpuRequest, memoryRequest resource.Quantity) error {
+	if cpuRequest.Cmp(cpuLimit) > 0 {
+		return fmt.Errorf("CPU request %s exceeds CPU limit %s", cpuRequest.String(), cpuLimit.String())
+	}
+	if memoryRequest.Cmp(memoryLimit) > 0 {
+		return fmt.Errorf("memory request %s exceeds memory limit %s", memoryRequest.String(), memoryLimit.String())
+	}
+	return nil
+}
@@ -7,6 +7,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 )
 
 func TestMakeServiceAccount(t *testing.T) {
@@ -48,3 +51,50 @@ func TestWithGoMemLimitEnvVar(t *testing.T) {
 	require.Equal(t, pod.Containers[0].Env[0].Name, "GOMEMLIMIT")
 	require.Equal(t, pod.Containers[0].Env[0].Value, "800")
 }
+
+func TestResourceOverrides(t *testing.T) {
+	cpuLimit := resource.MustParse("1")
+	memoryLimit := resource.MustParse("1Gi")
+	cpuRequest := resource.MustParse("100m")
+	memoryRequest := resource.MustParse("100Mi")
+
+	overrides := MakeResourceOverrides(&operatorv1alpha1.ResourceRequirements{
+		Limits:   &operatorv1alpha1.ComputeResources{Memory: ptr.To(resource.MustParse("2Gi"))},
+		Requests: &operatorv1alpha1.ComputeResources{CPU: ptr.To(resource.MustParse("200m"))},
+	})
+	overrides.Apply(&cpuLimit, &memoryLimit, &cpuRequest, &memoryRequest)
+
+	require.Equal(t, resource.MustParse("1"), cpuLimit)
+	require.Equal(t, resource.MustParse("2Gi"), memoryLimit)
+	require.Equal(t, resource.MustParse("200m"), cpuRequest)
+	require.Equal(t, resource.MustParse("100Mi"), memoryRequest)
+}
+
+func TestMakeResourceOverridesWithoutRequirements(t *testing.T) {
+	require.Equal(t, ResourceOverrides{}, MakeResourceOverrides(nil))
+}
+
+func TestValidateResourceRequirements(t *testing.T) {
+	tests := []struct {
+		name          string
+		cpuRequest    string
+		memoryRequest string
+		expectedErr   string
+	}{
+		{name: "requests below limits", cpuRequest: "100m", memoryRequest: "100Mi"},
+		{name: "requests equal to limits", cpuRequest: "1", memoryRequest: "1Gi"},
+		{name: "CPU request above limit", cpuRequest: "1500m", memoryRequest: "100Mi", expectedErr: "CPU request 1500m exceeds CPU limit 1"},
+		{name: "memory request above limit", cpuRequest: "100m", memoryRequest: "2Gi", expectedErr: "memory request 2Gi exceeds memory limit 1Gi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResourceRequirements(resource.MustParse("1"), resource.MustParse("1Gi"), resource.MustParse(tt.cpuRequest), resource.MustParse(tt.memoryRequest))
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
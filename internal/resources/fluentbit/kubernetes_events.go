@@ -0,0 +1,170 @@
+package fluentbit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/telemetry-manager/internal/fluentbit/ports"
+	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+)
+
+// KubernetesEventsConfig contains the resources of the Fluent Bit instance that collects the Kubernetes events. The instance processes
+// the events of the whole cluster in a single replica, so it is sized independently of the Fluent Bit DaemonSet.
+type KubernetesEventsConfig struct {
+	CPULimit      resource.Quantity
+	MemoryLimit   resource.Quantity
+	CPURequest    resource.Quantity
+	MemoryRequest resource.Quantity
+}
+
+// MakeKubernetesEventsDeployment creates the single-replica Deployment of the Fluent Bit instance that collects the Kubernetes events.
+// A single replica ensures that every event is shipped only once. The instance uses the image and priority class of the Fluent Bit
+// DaemonSet with the given name, and reuses its secrets, files, parsers, and Lua scripts, so that the pipelines behave the same for
+// events and application logs.
+func MakeKubernetesEventsDeployment(name types.NamespacedName, daemonSetName string, checksum string, dsConfig DaemonSetConfig, eventsConfig KubernetesEventsConfig) *appsv1.Deployment {
+	resources := makeResourceRequirements(eventsConfig.CPULimit, eventsConfig.MemoryLimit, eventsConfig.CPURequest, eventsConfig.MemoryRequest)
+
+	annotations := make(map[string]string)
+	annotations[checksumAnnotationKey] = checksum
+	annotations[istioExcludeInboundPorts] = strconv.Itoa(ports.HTTP)
+
+	podLabels := KubernetesEventsLabels()
+	podLabels["sidecar.istio.io/inject"] = "true"
+
+	fluentBitContainer := makeFluentBitContainer(dsConfig.FluentBitImage, daemonSetName, resources, []corev1.VolumeMount{
+		{MountPath: "/fluent-bit/etc/fluent-bit.conf", Name: "config", SubPath: "fluent-bit.conf"},
+		{MountPath: "/fluent-bit/etc/dynamic-parsers/", Name: "dynamic-parsers-config"},
+		{MountPath: "/fluent-bit/scripts/filter-script.lua", Name: "luascripts", SubPath: "filter-script.lua"},
+		{MountPath: "/data", Name: "data"},
+		{MountPath: "/files", Name: "dynamic-files"},
+		{MountPath: "/fluent-bit/etc/output-tls-config/", Name: "output-tls-config", ReadOnly: true},
+	})
+
+	volumes := append([]corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name.Name},
+				},
+			},
+		},
+		// The database of the kubernetes_events input is not persisted. After a restart, all events retained by the API Server are shipped again
+		{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}, makeSharedVolumes(daemonSetName)...)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    KubernetesEventsLabels(),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: KubernetesEventsLabels(),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: annotations,
+				},
+				Spec: makePodSpec(name.Name, dsConfig.PriorityClassName, []corev1.Container{fluentBitContainer}, volumes),
+			},
+		},
+	}
+}
+
+// DeleteKubernetesEventsResources deletes all resources of the Fluent Bit instance that collects the Kubernetes events.
+// Resources that do not exist are skipped, so that no delete requests are sent if the collector was never deployed.
+func DeleteKubernetesEventsResources(ctx context.Context, c client.Client, name types.NamespacedName) error {
+	objectMeta := metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace}
+	objects := []client.Object{
+		&networkingv1.NetworkPolicy{ObjectMeta: objectMeta},
+		&appsv1.Deployment{ObjectMeta: objectMeta},
+		&corev1.ConfigMap{ObjectMeta: objectMeta},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: objectMeta},
+		&rbacv1.ClusterRole{ObjectMeta: objectMeta},
+		&corev1.ServiceAccount{ObjectMeta: objectMeta},
+	}
+
+	for _, obj := range objects {
+		if err := k8sutils.DeleteIfExists(ctx, c, obj); err != nil {
+			return fmt.Errorf("failed to delete %T %s: %w", obj, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func MakeKubernetesEventsClusterRole(name types.NamespacedName) *rbacv1.ClusterRole {
+	clusterRole := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"events"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	return &clusterRole
+}
+
+// MakeKubernetesEventsConfigMap creates the ConfigMap with the complete configuration of the Fluent Bit instance that
+// collects the Kubernetes events. The sections contain the input and the pipelines with the kubernetesEvents input.
+func MakeKubernetesEventsConfigMap(name types.NamespacedName, sections string) *corev1.ConfigMap {
+	fluentBitConfig := fmt.Sprintf(`
+[SERVICE]
+    Daemon Off
+    Flush 1
+    Log_Level warn
+    Parsers_File dynamic-parsers/parsers.conf
+    HTTP_Server On
+    HTTP_Listen 0.0.0.0
+    HTTP_Port %d
+    storage.path /data/flb-storage/
+    storage.metrics on
+
+`, ports.HTTP)
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    KubernetesEventsLabels(),
+		},
+		Data: map[string]string{
+			"fluent-bit.conf": fluentBitConfig + sections,
+		},
+	}
+}
+
+func KubernetesEventsLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":     "fluent-bit-events",
+		"app.kubernetes.io/instance": "telemetry",
+	}
+}
@@ -0,0 +1,109 @@
+package fluentbit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestMakeKubernetesEventsDeployment(t *testing.T) {
+	name := types.NamespacedName{Name: "telemetry-fluent-bit-events", Namespace: "telemetry-system"}
+	checksum := "foo"
+	ds := DaemonSetConfig{
+		FluentBitImage:    "foo-fluenbit",
+		PriorityClassName: "foo-prio-class",
+		CPULimit:          resource.MustParse(".25"),
+		MemoryLimit:       resource.MustParse("400Mi"),
+		CPURequest:        resource.MustParse(".1"),
+		MemoryRequest:     resource.MustParse("100Mi"),
+	}
+	events := KubernetesEventsConfig{
+		CPULimit:      resource.MustParse("500m"),
+		MemoryLimit:   resource.MustParse("512Mi"),
+		CPURequest:    resource.MustParse("10m"),
+		MemoryRequest: resource.MustParse("50Mi"),
+	}
+
+	deployment := MakeKubernetesEventsDeployment(name, "telemetry-fluent-bit", checksum, ds, events)
+
+	require.NotNil(t, deployment)
+	require.Equal(t, name.Name, deployment.Name)
+	require.Equal(t, name.Namespace, deployment.Namespace)
+	require.Equal(t, int32(1), *deployment.Spec.Replicas, "must run a single replica to ship every event once")
+	require.Equal(t, appsv1.RecreateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	require.Equal(t, map[string]string{
+		"app.kubernetes.io/name":     "fluent-bit-events",
+		"app.kubernetes.io/instance": "telemetry",
+	}, deployment.Spec.Selector.MatchLabels)
+	require.Equal(t, map[string]string{
+		"checksum/logpipeline-config":                  checksum,
+		"traffic.sidecar.istio.io/excludeInboundPorts": "2020",
+	}, deployment.Spec.Template.ObjectMeta.Annotations)
+
+	podSpec := deployment.Spec.Template.Spec
+	require.Equal(t, name.Name, podSpec.ServiceAccountName)
+	require.Equal(t, "foo-prio-class", podSpec.PriorityClassName)
+	require.Len(t, podSpec.Containers, 1)
+
+	container := podSpec.Containers[0]
+	require.Equal(t, "foo-fluenbit", container.Image)
+	require.Equal(t, "telemetry-fluent-bit-env", container.EnvFrom[0].SecretRef.Name)
+	require.NotNil(t, container.LivenessProbe, "liveness probe must be defined")
+	require.NotNil(t, container.ReadinessProbe, "readiness probe must be defined")
+	require.Equal(t, events.CPURequest, *container.Resources.Requests.Cpu(), "must not use the resources of the daemon set")
+	require.Equal(t, events.MemoryRequest, *container.Resources.Requests.Memory())
+	require.Equal(t, events.CPULimit, *container.Resources.Limits.Cpu())
+	require.Equal(t, events.MemoryLimit, *container.Resources.Limits.Memory())
+	require.False(t, *container.SecurityContext.Privileged, "must not be privileged")
+	require.True(t, *container.SecurityContext.ReadOnlyRootFilesystem, "must use readonly fs")
+
+	volumeSources := make(map[string]string)
+	for _, volume := range podSpec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			volumeSources[volume.Name] = volume.ConfigMap.Name
+		case volume.Secret != nil:
+			volumeSources[volume.Name] = volume.Secret.SecretName
+		}
+	}
+	require.Equal(t, map[string]string{
+		"config":                 "telemetry-fluent-bit-events",
+		"luascripts":             "telemetry-fluent-bit-luascripts",
+		"dynamic-parsers-config": "telemetry-fluent-bit-parsers",
+		"dynamic-files":          "telemetry-fluent-bit-files",
+		"output-tls-config":      "telemetry-fluent-bit-output-tls-config",
+	}, volumeSources)
+}
+
+func TestMakeKubernetesEventsClusterRole(t *testing.T) {
+	name := types.NamespacedName{Name: "telemetry-fluent-bit-events", Namespace: "telemetry-system"}
+	clusterRole := MakeKubernetesEventsClusterRole(name)
+	expectedRules := []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"events"},
+			Verbs:     []string{"get", "list", "watch"},
+		},
+	}
+
+	require.NotNil(t, clusterRole)
+	require.Equal(t, name.Name, clusterRole.Name)
+	require.Equal(t, expectedRules, clusterRole.Rules)
+}
+
+func TestMakeKubernetesEventsConfigMap(t *testing.T) {
+	name := types.NamespacedName{Name: "telemetry-fluent-bit-events", Namespace: "telemetry-system"}
+	sections := "[INPUT]\n    name kubernetes_events\n\n"
+	cm := MakeKubernetesEventsConfigMap(name, sections)
+
+	require.NotNil(t, cm)
+	require.Equal(t, name.Name, cm.Name)
+	require.Equal(t, name.Namespace, cm.Namespace)
+	require.Contains(t, cm.Data["fluent-bit.conf"], "HTTP_Port 2020")
+	require.True(t, strings.HasSuffix(cm.Data["fluent-bit.conf"], sections))
+}
@@ -1,20 +1,25 @@
 package fluentbit
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kyma-project/telemetry-manager/internal/fluentbit/ports"
+	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 )
 
 const checksumAnnotationKey = "checksum/logpipeline-config"
@@ -29,19 +34,27 @@ type DaemonSetConfig struct {
 	MemoryLimit                 resource.Quantity
 	CPURequest                  resource.Quantity
 	MemoryRequest               resource.Quantity
+	// JournaldEnabled mounts the journal of the Node, which is only required if a pipeline collects logs from journald.
+	JournaldEnabled bool
+}
+
+func (cfg DaemonSetConfig) WithResourceOverrides(overrides commonresources.ResourceOverrides) DaemonSetConfig {
+	overrides.Apply(&cfg.CPULimit, &cfg.MemoryLimit, &cfg.CPURequest, &cfg.MemoryRequest)
+	return cfg
+}
+
+// ValidateResources returns an error if a resource request of Fluent Bit exceeds its limit.
+func (cfg DaemonSetConfig) ValidateResources() error {
+	return commonresources.ValidateResourceRequirements(cfg.CPULimit, cfg.MemoryLimit, cfg.CPURequest, cfg.MemoryRequest)
+}
+
+func (cfg DaemonSetConfig) WithJournald(enabled bool) DaemonSetConfig {
+	cfg.JournaldEnabled = enabled
+	return cfg
 }
 
 func MakeDaemonSet(name types.NamespacedName, checksum string, dsConfig DaemonSetConfig) *appsv1.DaemonSet {
-	resourcesFluentBit := corev1.ResourceRequirements{
-		Requests: map[corev1.ResourceName]resource.Quantity{
-			corev1.ResourceCPU:    dsConfig.CPURequest,
-			corev1.ResourceMemory: dsConfig.MemoryRequest,
-		},
-		Limits: map[corev1.ResourceName]resource.Quantity{
-			corev1.ResourceCPU:    dsConfig.CPULimit,
-			corev1.ResourceMemory: dsConfig.MemoryLimit,
-		},
-	}
+	resourcesFluentBit := makeResourceRequirements(dsConfig.CPULimit, dsConfig.MemoryLimit, dsConfig.CPURequest, dsConfig.MemoryRequest)
 
 	resourcesExporter := corev1.ResourceRequirements{
 		Requests: map[corev1.ResourceName]resource.Quantity{
@@ -60,7 +73,88 @@ func MakeDaemonSet(name types.NamespacedName, checksum string, dsConfig DaemonSe
 	podLabels := Labels()
 	podLabels["sidecar.istio.io/inject"] = "true"
 
-	return &appsv1.DaemonSet{
+	fluentBitContainer := makeFluentBitContainer(dsConfig.FluentBitImage, name.Name, resourcesFluentBit, []corev1.VolumeMount{
+		{MountPath: "/fluent-bit/etc", Name: "shared-fluent-bit-config"},
+		{MountPath: "/fluent-bit/etc/fluent-bit.conf", Name: "config", SubPath: "fluent-bit.conf"},
+		{MountPath: "/fluent-bit/etc/dynamic/", Name: "dynamic-config"},
+		{MountPath: "/fluent-bit/etc/dynamic-parsers/", Name: "dynamic-parsers-config"},
+		{MountPath: "/fluent-bit/etc/custom_parsers.conf", Name: "config", SubPath: "custom_parsers.conf"},
+		{MountPath: "/fluent-bit/scripts/filter-script.lua", Name: "luascripts", SubPath: "filter-script.lua"},
+		{MountPath: "/var/log", Name: "varlog", ReadOnly: true},
+		{MountPath: "/data", Name: "varfluentbit"},
+		{MountPath: "/files", Name: "dynamic-files"},
+		{MountPath: "/fluent-bit/etc/output-tls-config/", Name: "output-tls-config", ReadOnly: true},
+	})
+	fluentBitContainer.SecurityContext.Capabilities.Add = []corev1.Capability{"FOWNER"}
+
+	exporterContainer := corev1.Container{
+		Name:      "exporter",
+		Image:     dsConfig.ExporterImage,
+		Resources: resourcesExporter,
+		Args: []string{
+			"--storage-path=/data/flb-storage/",
+			"--metric-name=telemetry_fsbuffer_usage_bytes",
+		},
+		WorkingDir: "",
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "http-metrics",
+				ContainerPort: ports.ExporterMetrics,
+				Protocol:      "TCP",
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Privileged:               ptr.To(false),
+			ReadOnlyRootFilesystem:   ptr.To(true),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "varfluentbit", MountPath: "/data"},
+		},
+	}
+
+	volumes := append([]corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name.Name},
+				},
+			},
+		},
+		{
+			Name: "varlog",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"},
+			},
+		},
+		{
+			Name: "shared-fluent-bit-config",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: "dynamic-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-sections", name.Name)},
+					Optional:             ptr.To(true),
+				},
+			},
+		},
+		{
+			Name: "varfluentbit",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: fmt.Sprintf("/var/%s", name.Name)},
+			},
+		},
+	}, makeSharedVolumes(name.Name)...)
+
+	daemonSet := &appsv1.DaemonSet{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name.Name,
@@ -76,178 +170,187 @@ func MakeDaemonSet(name types.NamespacedName, checksum string, dsConfig DaemonSe
 					Labels:      podLabels,
 					Annotations: annotations,
 				},
-				Spec: corev1.PodSpec{
-					ServiceAccountName: name.Name,
-					PriorityClassName:  dsConfig.PriorityClassName,
-					SecurityContext: &corev1.PodSecurityContext{
-						RunAsNonRoot:   ptr.To(false),
-						SeccompProfile: &corev1.SeccompProfile{Type: "RuntimeDefault"},
-					},
-					Containers: []corev1.Container{
-						{
-							Name: "fluent-bit",
-							SecurityContext: &corev1.SecurityContext{
-								AllowPrivilegeEscalation: ptr.To(false),
-								Capabilities: &corev1.Capabilities{
-									Add:  []corev1.Capability{"FOWNER"},
-									Drop: []corev1.Capability{"ALL"},
-								},
-								Privileged:             ptr.To(false),
-								ReadOnlyRootFilesystem: ptr.To(true),
-							},
-							Image:           dsConfig.FluentBitImage,
-							ImagePullPolicy: "IfNotPresent",
-							EnvFrom: []corev1.EnvFromSource{
-								{
-									SecretRef: &corev1.SecretEnvSource{
-										LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-env", name.Name)},
-										Optional:             ptr.To(true),
-									},
-								},
-							},
-							Ports: []corev1.ContainerPort{
-								{
-									Name:          "http",
-									ContainerPort: ports.HTTP,
-									Protocol:      "TCP",
-								},
-							},
-							LivenessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/",
-										Port: intstr.FromString("http"),
-									},
-								},
-							},
-							ReadinessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/api/v1/health",
-										Port: intstr.FromString("http"),
-									},
-								},
-							},
-							Resources: resourcesFluentBit,
-							VolumeMounts: []corev1.VolumeMount{
-								{MountPath: "/fluent-bit/etc", Name: "shared-fluent-bit-config"},
-								{MountPath: "/fluent-bit/etc/fluent-bit.conf", Name: "config", SubPath: "fluent-bit.conf"},
-								{MountPath: "/fluent-bit/etc/dynamic/", Name: "dynamic-config"},
-								{MountPath: "/fluent-bit/etc/dynamic-parsers/", Name: "dynamic-parsers-config"},
-								{MountPath: "/fluent-bit/etc/custom_parsers.conf", Name: "config", SubPath: "custom_parsers.conf"},
-								{MountPath: "/fluent-bit/scripts/filter-script.lua", Name: "luascripts", SubPath: "filter-script.lua"},
-								{MountPath: "/var/log", Name: "varlog", ReadOnly: true},
-								{MountPath: "/data", Name: "varfluentbit"},
-								{MountPath: "/files", Name: "dynamic-files"},
-								{MountPath: "/fluent-bit/etc/output-tls-config/", Name: "output-tls-config", ReadOnly: true},
-							},
-						},
-						{
-							Name:      "exporter",
-							Image:     dsConfig.ExporterImage,
-							Resources: resourcesExporter,
-							Args: []string{
-								"--storage-path=/data/flb-storage/",
-								"--metric-name=telemetry_fsbuffer_usage_bytes",
-							},
-							WorkingDir: "",
-							Ports: []corev1.ContainerPort{
-								{
-									Name:          "http-metrics",
-									ContainerPort: ports.ExporterMetrics,
-									Protocol:      "TCP",
-								},
-							},
-							SecurityContext: &corev1.SecurityContext{
-								AllowPrivilegeEscalation: ptr.To(false),
-								Privileged:               ptr.To(false),
-								ReadOnlyRootFilesystem:   ptr.To(true),
-								Capabilities: &corev1.Capabilities{
-									Drop: []corev1.Capability{"ALL"},
-								},
-							},
-							VolumeMounts: []corev1.VolumeMount{
-								{Name: "varfluentbit", MountPath: "/data"},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: "config",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{Name: name.Name},
-								},
-							},
-						},
-						{
-							Name: "luascripts",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-luascripts", name.Name)},
-								},
-							},
-						},
-						{
-							Name: "varlog",
-							VolumeSource: corev1.VolumeSource{
-								HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"},
-							},
-						},
-						{
-							Name: "shared-fluent-bit-config",
-							VolumeSource: corev1.VolumeSource{
-								EmptyDir: &corev1.EmptyDirVolumeSource{},
-							},
-						},
-						{
-							Name: "dynamic-config",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-sections", name.Name)},
-									Optional:             ptr.To(true),
-								},
-							},
-						},
-						{
-							Name: "dynamic-parsers-config",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-parsers", name.Name)},
-									Optional:             ptr.To(true),
-								},
-							},
-						},
-						{
-							Name: "dynamic-files",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-files", name.Name)},
-									Optional:             ptr.To(true),
-								},
-							},
-						},
-						{
-							Name: "varfluentbit",
-							VolumeSource: corev1.VolumeSource{
-								HostPath: &corev1.HostPathVolumeSource{Path: fmt.Sprintf("/var/%s", name.Name)},
-							},
-						},
-						{
-							Name: "output-tls-config",
-							VolumeSource: corev1.VolumeSource{
-								Secret: &corev1.SecretVolumeSource{
-									SecretName: fmt.Sprintf("%s-output-tls-config", name.Name),
-								},
-							},
-						},
-					},
+				Spec: makePodSpec(name.Name, dsConfig.PriorityClassName, []corev1.Container{fluentBitContainer, exporterContainer}, volumes),
+			},
+		},
+	}
+
+	if dsConfig.JournaldEnabled {
+		addJournalVolumes(&daemonSet.Spec.Template.Spec)
+	}
+
+	return daemonSet
+}
+
+// makePodSpec creates the pod spec of a Fluent Bit workload. The DaemonSet and the Kubernetes events collector share it.
+func makePodSpec(serviceAccountName, priorityClassName string, containers []corev1.Container, volumes []corev1.Volume) corev1.PodSpec {
+	return corev1.PodSpec{
+		ServiceAccountName: serviceAccountName,
+		PriorityClassName:  priorityClassName,
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot:   ptr.To(false),
+			SeccompProfile: &corev1.SeccompProfile{Type: "RuntimeDefault"},
+		},
+		Containers: containers,
+		Volumes:    volumes,
+	}
+}
+
+// makeFluentBitContainer creates the Fluent Bit container, which reads the environment variables from the env Secret of the DaemonSet
+// with the given name.
+func makeFluentBitContainer(image, daemonSetName string, resources corev1.ResourceRequirements, volumeMounts []corev1.VolumeMount) corev1.Container {
+	return corev1.Container{
+		Name: "fluent-bit",
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			Privileged:             ptr.To(false),
+			ReadOnlyRootFilesystem: ptr.To(true),
+		},
+		Image:           image,
+		ImagePullPolicy: "IfNotPresent",
+		EnvFrom: []corev1.EnvFromSource{
+			{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-env", daemonSetName)},
+					Optional:             ptr.To(true),
+				},
+			},
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "http",
+				ContainerPort: ports.HTTP,
+				Protocol:      "TCP",
+			},
+		},
+		LivenessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/",
+					Port: intstr.FromString("http"),
+				},
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/api/v1/health",
+					Port: intstr.FromString("http"),
+				},
+			},
+		},
+		Resources:    resources,
+		VolumeMounts: volumeMounts,
+	}
+}
+
+// makeSharedVolumes creates the volumes of the Lua scripts, parsers, files, and output TLS configuration of the DaemonSet with the
+// given name. The Kubernetes events collector mounts them as well, so that the pipelines behave the same for events and application logs.
+func makeSharedVolumes(daemonSetName string) []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: "luascripts",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-luascripts", daemonSetName)},
+				},
+			},
+		},
+		{
+			Name: "dynamic-parsers-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-parsers", daemonSetName)},
+					Optional:             ptr.To(true),
+				},
+			},
+		},
+		{
+			Name: "dynamic-files",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-files", daemonSetName)},
+					Optional:             ptr.To(true),
+				},
+			},
+		},
+		{
+			Name: "output-tls-config",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: fmt.Sprintf("%s-output-tls-config", daemonSetName),
 				},
 			},
 		},
 	}
 }
 
+func makeResourceRequirements(cpuLimit, memoryLimit, cpuRequest, memoryRequest resource.Quantity) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: map[corev1.ResourceName]resource.Quantity{
+			corev1.ResourceCPU:    cpuRequest,
+			corev1.ResourceMemory: memoryRequest,
+		},
+		Limits: map[corev1.ResourceName]resource.Quantity{
+			corev1.ResourceCPU:    cpuLimit,
+			corev1.ResourceMemory: memoryLimit,
+		},
+	}
+}
+
+// addJournalVolumes mounts the volatile journal and the machine ID of the Node, which journald needs to find the journal of the
+// local machine. The persistent journal in /var/log/journal is already part of the mounted /var/log directory. journald creates
+// /run/log/journal at boot, so it is not created on the Node. If the Node has no machine ID, an empty file is mounted instead.
+func addJournalVolumes(podSpec *corev1.PodSpec) {
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts,
+		corev1.VolumeMount{MountPath: "/run/log/journal", Name: "runlogjournal", ReadOnly: true},
+		corev1.VolumeMount{MountPath: "/etc/machine-id", Name: "machine-id", ReadOnly: true},
+	)
+	podSpec.Volumes = append(podSpec.Volumes,
+		corev1.Volume{
+			Name: "runlogjournal",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: "/run/log/journal", Type: ptr.To(corev1.HostPathDirectory)},
+			},
+		},
+		corev1.Volume{
+			Name: "machine-id",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: "/etc/machine-id", Type: ptr.To(corev1.HostPathFileOrCreate)},
+			},
+		},
+	)
+}
+
+// DeleteResources deletes the Fluent Bit DaemonSet together with the resources that are only used by it, which includes
+// the ConfigMap with the Lua script. The ConfigMaps and Secrets that are synced from the pipelines are kept.
+// Resources that do not exist are skipped, so that no delete requests are sent if Fluent Bit was never deployed.
+func DeleteResources(ctx context.Context, c client.Client, name, luaConfigMap types.NamespacedName) error {
+	objectMeta := metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace}
+	objects := []client.Object{
+		&networkingv1.NetworkPolicy{ObjectMeta: objectMeta},
+		&appsv1.DaemonSet{ObjectMeta: objectMeta},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: luaConfigMap.Name, Namespace: luaConfigMap.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: objectMeta},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-metrics", name.Name), Namespace: name.Namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-exporter-metrics", name.Name), Namespace: name.Namespace}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: objectMeta},
+		&rbacv1.ClusterRole{ObjectMeta: objectMeta},
+		&corev1.ServiceAccount{ObjectMeta: objectMeta},
+	}
+
+	for _, obj := range objects {
+		if err := k8sutils.DeleteIfExists(ctx, c, obj); err != nil {
+			return fmt.Errorf("failed to delete %T %s: %w", obj, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
 func MakeClusterRole(name types.NamespacedName) *rbacv1.ClusterRole {
 	clusterRole := rbacv1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
@@ -393,7 +496,9 @@ func MakeParserConfigmap(name types.NamespacedName) *corev1.ConfigMap {
 	}
 }
 
-func MakeLuaConfigMap(name types.NamespacedName) *corev1.ConfigMap {
+// MakeLuaConfigMap creates the ConfigMap with the Lua script of the Fluent Bit filters. The redaction script contains
+// the pipeline-specific redaction functions, which are appended to the static helper functions.
+func MakeLuaConfigMap(name types.NamespacedName, redactionScript string) *corev1.ConfigMap {
 	//nolint:dupword // Ignore lua syntax code duplications.
 	luaFilter := `
 function kubernetes_map_keys(tag, timestamp, record)
@@ -424,7 +529,22 @@ function map_keys(table)
     table[key] = val
   end
 end
-`
+function kubernetes_event_metadata(tag, timestamp, record)
+  local object = record.involvedObject
+  if object == nil then
+    return 0
+  end
+  local kubernetes = {namespace_name = object.namespace}
+  if kubernetes.namespace_name == nil and record.metadata ~= nil then
+    kubernetes.namespace_name = record.metadata.namespace
+  end
+  if object.kind == "Pod" then
+    kubernetes.pod_name = object.name
+  end
+  record.kubernetes = kubernetes
+  return 1, timestamp, record
+end
+` + luaRedactionHelpers + redactionScript
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -436,6 +556,130 @@ end
 	}
 }
 
+// luaRedactionHelpers masks sensitive data in the string values of a log record. Built-in patterns that Lua patterns
+// cannot express precisely are matched broadly and then verified, for example, IP address octets and card number checksums.
+//
+//nolint:dupword // Ignore lua syntax code duplications.
+const luaRedactionHelpers = `
+local function replace_with(replacement)
+  return function()
+    return replacement
+  end
+end
+local function is_ipv4(candidate)
+  for octet in string.gmatch(candidate, "%d+") do
+    if #octet > 3 or tonumber(octet) > 255 then
+      return false
+    end
+  end
+  return true
+end
+local function is_ipv6(candidate)
+  if not string.find(candidate, "%x") then
+    return false
+  end
+  local _, colons = string.gsub(candidate, ":", "")
+  local _, compressions = string.gsub(candidate, "::", "")
+  if colons > 7 or compressions > 1 or (compressions == 0 and colons ~= 7) then
+    return false
+  end
+  for group in string.gmatch(candidate, "[^:]+") do
+    if #group > 4 then
+      return false
+    end
+  end
+  return true
+end
+local function has_card_prefix(digits)
+  local length = #digits
+  local prefix = tonumber(string.sub(digits, 1, 4))
+  if string.find(digits, "^4") then
+    return length == 13 or length == 16 or length == 19
+  end
+  if string.find(digits, "^5[1-5]") or (prefix >= 2221 and prefix <= 2720) then
+    return length == 16
+  end
+  if string.find(digits, "^3[47]") then
+    return length == 15
+  end
+  if string.find(digits, "^6011") or string.find(digits, "^65") or (prefix >= 6440 and prefix <= 6499) then
+    return length >= 16 and length <= 19
+  end
+  return false
+end
+local function is_card_number(candidate)
+  local digits = string.gsub(candidate, "[ %-]", "")
+  if #digits < 13 or #digits > 19 or not has_card_prefix(digits) then
+    return false
+  end
+  local sum = 0
+  local double = false
+  for i = #digits, 1, -1 do
+    local digit = tonumber(string.sub(digits, i, i))
+    if double then
+      digit = digit * 2
+      if digit > 9 then
+        digit = digit - 9
+      end
+    end
+    sum = sum + digit
+    double = not double
+  end
+  return sum % 10 == 0
+end
+local function replace_if(check, replacement)
+  return function(candidate)
+    if check(candidate) then
+      return replacement
+    end
+    return nil
+  end
+end
+local redactors = {
+  email = function(value, replacement)
+    return (string.gsub(value, "[%w%._%%%+%-]+@[%w%-]+%.[%w%.%-]*%w", replace_with(replacement)))
+  end,
+  ipv4 = function(value, replacement)
+    return (string.gsub(value, "%f[%w]%d+%.%d+%.%d+%.%d+%f[^%w]", replace_if(is_ipv4, replacement)))
+  end,
+  ipv6 = function(value, replacement)
+    return (string.gsub(value, "%f[%w:][%x:]+%f[^%w:]", replace_if(is_ipv6, replacement)))
+  end,
+  jwt = function(value, replacement)
+    return (string.gsub(value, "eyJ[%w%-_]+%.[%w%-_]+%.[%w%-_]*", replace_with(replacement)))
+  end,
+  creditcard = function(value, replacement)
+    local replace = replace_if(is_card_number, replacement)
+    value = string.gsub(value, "%f[%w]%d%d%d%d[ %-]%d%d%d%d[ %-]%d%d%d%d[ %-]%d%d%d%d%f[^%w]", replace)
+    value = string.gsub(value, "%f[%w]%d%d%d%d[ %-]%d%d%d%d%d%d[ %-]%d%d%d%d%d%f[^%w]", replace)
+    return (string.gsub(value, "%f[%w]%d+%f[^%w]", replace))
+  end,
+}
+function redact_record(timestamp, record, keys, builtins, patterns, replacement)
+  local modified = false
+  for _, key in ipairs(keys) do
+    local value = record[key]
+    if type(value) == "string" then
+      local redacted = value
+      for _, builtin in ipairs(builtins) do
+        redacted = redactors[builtin](redacted, replacement)
+      end
+      for _, pattern in ipairs(patterns) do
+        redacted = string.gsub(redacted, pattern, replace_with(replacement))
+      end
+      if redacted ~= value then
+        record[key] = redacted
+        modified = true
+      end
+    end
+  end
+  if not modified then
+    return 0, timestamp, record
+  end
+  return 2, timestamp, record
+end
+`
+
 func Labels() map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/name":     "fluent-bit",
@@ -2,6 +2,7 @@ package fluentbit
 
 import (
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -9,6 +10,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
 func TestMakeDaemonSet(t *testing.T) {
@@ -67,6 +69,26 @@ func TestMakeDaemonSet(t *testing.T) {
 	require.Equal(t, 10, len(volMounts), "volume mounts do not match")
 }
 
+func TestMakeDaemonSetWithJournald(t *testing.T) {
+	name := types.NamespacedName{Name: "telemetry-fluent-bit", Namespace: "telemetry-system"}
+
+	daemonSet := MakeDaemonSet(name, "foo", DaemonSetConfig{}.WithJournald(true))
+
+	volMounts := daemonSet.Spec.Template.Spec.Containers[0].VolumeMounts
+	require.Equal(t, 12, len(volMounts), "volume mounts do not match")
+	require.Contains(t, volMounts, corev1.VolumeMount{MountPath: "/run/log/journal", Name: "runlogjournal", ReadOnly: true})
+	require.Contains(t, volMounts, corev1.VolumeMount{MountPath: "/etc/machine-id", Name: "machine-id", ReadOnly: true})
+
+	hostPaths := make(map[string]corev1.HostPathVolumeSource)
+	for _, volume := range daemonSet.Spec.Template.Spec.Volumes {
+		if volume.HostPath != nil {
+			hostPaths[volume.Name] = *volume.HostPath
+		}
+	}
+	require.Equal(t, corev1.HostPathVolumeSource{Path: "/run/log/journal", Type: ptr.To(corev1.HostPathDirectory)}, hostPaths["runlogjournal"])
+	require.Equal(t, corev1.HostPathVolumeSource{Path: "/etc/machine-id", Type: ptr.To(corev1.HostPathFileOrCreate)}, hostPaths["machine-id"])
+}
+
 func TestMakeClusterRole(t *testing.T) {
 	name := types.NamespacedName{Name: "telemetry-fluent-bit", Namespace: "telemetry-system"}
 	clusterRole := MakeClusterRole(name)
@@ -139,10 +161,21 @@ func TestMakeConfigMap(t *testing.T) {
 
 func TestMakeLuaConfigMap(t *testing.T) {
 	name := types.NamespacedName{Name: "telemetry-fluent-bit-luascripts", Namespace: "telemetry-system"}
-	cm := MakeLuaConfigMap(name)
+	cm := MakeLuaConfigMap(name, "")
 
 	require.NotNil(t, cm)
 	require.Equal(t, cm.Name, name.Name)
 	require.Equal(t, cm.Namespace, name.Namespace)
 	require.NotEmpty(t, cm.Data["filter-script.lua"])
+	require.Contains(t, cm.Data["filter-script.lua"], "function kubernetes_map_keys(tag, timestamp, record)")
+	require.Contains(t, cm.Data["filter-script.lua"], "function kubernetes_event_metadata(tag, timestamp, record)")
+	require.Contains(t, cm.Data["filter-script.lua"], "function redact_record(timestamp, record, keys, builtins, patterns, replacement)")
+}
+
+func TestMakeLuaConfigMapWithRedactionScript(t *testing.T) {
+	name := types.NamespacedName{Name: "telemetry-fluent-bit-luascripts", Namespace: "telemetry-system"}
+	redactionScript := "function redact_foo(tag, timestamp, record)\n  return redact_record(timestamp, record, {\"log\"}, {\"email\"}, {}, \"***\")\nend\n"
+	cm := MakeLuaConfigMap(name, redactionScript)
+
+	require.True(t, strings.HasSuffix(cm.Data["filter-script.lua"], redactionScript))
 }
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kyma-project/telemetry-manager/internal/configchecksum"
@@ -31,6 +32,12 @@ func ApplyAgentResources(ctx context.Context, c client.Client, cfg *AgentConfig)
 		return fmt.Errorf("failed to create common resource: %w", err)
 	}
 
+	if cfg.ObserveBySelfMonitoring {
+		if err := k8sutils.CreateOrUpdateService(ctx, c, makeScrapeHealthService(name)); err != nil {
+			return fmt.Errorf("failed to create scrape health service: %w", err)
+		}
+	}
+
 	configMap := makeConfigMap(name, cfg.CollectorConfig)
 	if err := k8sutils.CreateOrUpdateConfigMap(ctx, c, configMap); err != nil {
 		return fmt.Errorf("failed to create configmap: %w", err)
@@ -133,7 +140,40 @@ proxyMetadata:
 `, istioCertPath),
 		"sidecar.istio.io/userVolumeMount":                 fmt.Sprintf(`[{"name": "%s", "mountPath": "%s"}]`, istioCertVolumeName, istioCertPath),
 		"traffic.sidecar.istio.io/includeOutboundPorts":    strconv.Itoa(ports.OTLPGRPC),
-		"traffic.sidecar.istio.io/excludeInboundPorts":     strconv.Itoa(ports.Metrics),
+		"traffic.sidecar.istio.io/excludeInboundPorts":     fmt.Sprintf("%d,%d", ports.Metrics, ports.ScrapeHealthMetrics),
 		"traffic.sidecar.istio.io/includeOutboundIPRanges": "",
 	}
 }
+
+// makeScrapeHealthService exposes the health of the Prometheus scrape targets of the agent to the self-monitor.
+// The self-monitor discovers the service by the same annotations as the metrics service.
+func makeScrapeHealthService(name types.NamespacedName) *corev1.Service {
+	labels := defaultLabels(name.Name)
+	selectorLabels := maps.Clone(labels)
+	labels["telemetry.kyma-project.io/self-monitor"] = "enabled"
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name + "-scrape-health",
+			Namespace: name.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				"prometheus.io/scrape": "true",
+				"prometheus.io/port":   strconv.Itoa(ports.ScrapeHealthMetrics),
+				"prometheus.io/scheme": "http",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http-scrape-health",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       ports.ScrapeHealthMetrics,
+					TargetPort: intstr.FromInt32(ports.ScrapeHealthMetrics),
+				},
+			},
+			Selector: selectorLabels,
+			Type:     corev1.ServiceTypeClusterIP,
+		},
+	}
+}
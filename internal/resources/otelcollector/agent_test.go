@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -75,7 +76,7 @@ func TestApplyAgentResources(t *testing.T) {
 		require.Equal(t, "[{\"name\": \"istio-certs\", \"mountPath\": \"/etc/istio-output-certs\"}]", podAnnotations["sidecar.istio.io/userVolumeMount"])
 		require.Equal(t, "", podAnnotations["traffic.sidecar.istio.io/includeInboundPorts"])
 		require.Equal(t, "4317", podAnnotations["traffic.sidecar.istio.io/includeOutboundPorts"])
-		require.Equal(t, "8888", podAnnotations["traffic.sidecar.istio.io/excludeInboundPorts"])
+		require.Equal(t, "8888,8889", podAnnotations["traffic.sidecar.istio.io/excludeInboundPorts"])
 		require.Equal(t, "", podAnnotations["traffic.sidecar.istio.io/includeOutboundIPRanges"])
 
 		//collector container
@@ -255,12 +256,8 @@ func TestApplyAgentResources_WithSelfMonEnabled(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("should create metrics service", func(t *testing.T) {
-		var svcs corev1.ServiceList
-		require.NoError(t, client.List(ctx, &svcs))
-		require.Len(t, svcs.Items, 1)
-
-		svc := svcs.Items[0]
-		require.NotNil(t, svc)
+		var svc corev1.Service
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Name: name + "-metrics", Namespace: namespace}, &svc))
 		require.Equal(t, name+"-metrics", svc.Name)
 		require.Equal(t, namespace, svc.Namespace)
 		require.Equal(t, map[string]string{
@@ -284,4 +281,28 @@ func TestApplyAgentResources_WithSelfMonEnabled(t *testing.T) {
 			TargetPort: intstr.FromInt32(8888),
 		}, svc.Spec.Ports[0])
 	})
+
+	t.Run("should create scrape health service", func(t *testing.T) {
+		var svc corev1.Service
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Name: name + "-scrape-health", Namespace: namespace}, &svc))
+
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name":                 name,
+			"telemetry.kyma-project.io/self-monitor": "enabled",
+		}, svc.Labels)
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, svc.Spec.Selector)
+		require.Equal(t, map[string]string{
+			"prometheus.io/port":   "8889",
+			"prometheus.io/scheme": "http",
+			"prometheus.io/scrape": "true",
+		}, svc.Annotations)
+		require.Equal(t, []corev1.ServicePort{{
+			Name:       "http-scrape-health",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       8889,
+			TargetPort: intstr.FromInt32(8889),
+		}}, svc.Spec.Ports)
+	})
 }
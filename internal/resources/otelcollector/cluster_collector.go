@@ -0,0 +1,150 @@
+package otelcollector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/telemetry-manager/internal/configchecksum"
+	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config"
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
+)
+
+func ApplyClusterCollectorResources(ctx context.Context, c client.Client, cfg *ClusterCollectorConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+
+	if err := applyCommonResources(ctx, c, name, makeClusterCollectorClusterRole(name), cfg.allowedPorts, cfg.ObserveBySelfMonitoring); err != nil {
+		return fmt.Errorf("failed to create common resource: %w", err)
+	}
+
+	configMap := makeConfigMap(name, cfg.CollectorConfig)
+	if err := k8sutils.CreateOrUpdateConfigMap(ctx, c, configMap); err != nil {
+		return fmt.Errorf("failed to create configmap: %w", err)
+	}
+
+	configChecksum := configchecksum.Calculate([]corev1.ConfigMap{*configMap}, []corev1.Secret{})
+	if err := k8sutils.CreateOrUpdateDeployment(ctx, c, makeClusterCollectorDeployment(cfg, configChecksum)); err != nil {
+		return fmt.Errorf("failed to create deployment: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteClusterCollectorResources removes all resources of the cluster collector, so that the state metrics are no longer collected.
+// Resources that do not exist are skipped, so that no delete requests are sent if the collector was never deployed.
+func DeleteClusterCollectorResources(ctx context.Context, c client.Client, cfg *ClusterCollectorConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+	objectMeta := metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace}
+
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: objectMeta},
+		&corev1.ConfigMap{ObjectMeta: objectMeta},
+	}
+	objects = append(objects, commonResourceObjects(name)...)
+
+	return deleteResources(ctx, c, objects...)
+}
+
+func makeClusterCollectorClusterRole(name types.NamespacedName) *rbacv1.ClusterRole {
+	clusterRole := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    defaultLabels(name.Name),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"namespaces", "namespaces/status", "nodes", "nodes/spec", "pods", "pods/status", "replicationcontrollers", "replicationcontrollers/status", "resourcequotas", "services"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"apps"},
+				Resources: []string{"daemonsets", "deployments", "replicasets", "statefulsets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"extensions"},
+				Resources: []string{"daemonsets", "deployments", "replicasets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"batch"},
+				Resources: []string{"jobs", "cronjobs"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"autoscaling"},
+				Resources: []string{"horizontalpodautoscalers"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	return &clusterRole
+}
+
+func makeClusterCollectorDeployment(cfg *ClusterCollectorConfig, configChecksum string) *appsv1.Deployment {
+	selectorLabels := defaultLabels(cfg.BaseName)
+	podLabels := maps.Clone(selectorLabels)
+	// The collector only talks to the API server and to the gateway, which accepts plain text connections
+	podLabels["sidecar.istio.io/inject"] = "false"
+
+	annotations := map[string]string{"checksum/config": configChecksum}
+
+	resources := makeClusterCollectorResourceRequirements(cfg)
+	podSpec := makePodSpec(cfg.BaseName, cfg.Deployment.Image,
+		commonresources.WithPriorityClass(cfg.Deployment.PriorityClassName),
+		commonresources.WithResources(resources),
+		withEnvVarFromSource(config.EnvVarCurrentPodIP, fieldPathPodIP),
+		withEnvVarFromSource(config.EnvVarCurrentNodeName, fieldPathNodeName),
+		commonresources.WithGoMemLimitEnvVar(cfg.Deployment.MemoryLimit),
+	)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.BaseName,
+			Namespace: cfg.Namespace,
+			Labels:    selectorLabels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			// A second replica would report every state metric twice
+			Replicas: ptr.To[int32](1),
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: annotations,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+func makeClusterCollectorResourceRequirements(cfg *ClusterCollectorConfig) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Limits: map[corev1.ResourceName]resource.Quantity{
+			corev1.ResourceCPU:    cfg.Deployment.CPULimit,
+			corev1.ResourceMemory: cfg.Deployment.MemoryLimit,
+		},
+		Requests: map[corev1.ResourceName]resource.Quantity{
+			corev1.ResourceCPU:    cfg.Deployment.CPURequest,
+			corev1.ResourceMemory: cfg.Deployment.MemoryRequest,
+		},
+	}
+}
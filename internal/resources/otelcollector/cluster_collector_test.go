@@ -0,0 +1,152 @@
+package otelcollector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestApplyClusterCollectorResources(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+	namespace := "my-namespace"
+	name := "my-cluster-collector"
+	cfg := "dummy otel collector config"
+
+	clusterCollectorConfig := &ClusterCollectorConfig{
+		allowedPorts: []int32{5555, 6666},
+		Config: Config{
+			BaseName:        name,
+			Namespace:       namespace,
+			CollectorConfig: cfg,
+		},
+		Deployment: ClusterCollectorDeploymentConfig{
+			Image:       "otel/collector",
+			MemoryLimit: resource.MustParse("500Mi"),
+		},
+	}
+
+	err := ApplyClusterCollectorResources(ctx, client, clusterCollectorConfig)
+	require.NoError(t, err)
+
+	t.Run("should create collector config configmap", func(t *testing.T) {
+		var cms corev1.ConfigMapList
+		require.NoError(t, client.List(ctx, &cms))
+		require.Len(t, cms.Items, 1)
+
+		cm := cms.Items[0]
+		require.Equal(t, name, cm.Name)
+		require.Equal(t, namespace, cm.Namespace)
+		require.Equal(t, cfg, cm.Data["relay.conf"])
+	})
+
+	t.Run("should create a single replica deployment", func(t *testing.T) {
+		var deps appsv1.DeploymentList
+		require.NoError(t, client.List(ctx, &deps))
+		require.Len(t, deps.Items, 1)
+
+		dep := deps.Items[0]
+		require.Equal(t, name, dep.Name)
+		require.Equal(t, namespace, dep.Namespace)
+		require.Equal(t, int32(1), *dep.Spec.Replicas)
+		require.Equal(t, appsv1.RecreateDeploymentStrategyType, dep.Spec.Strategy.Type)
+
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, dep.Spec.Selector.MatchLabels, "must have expected deployment selector labels")
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name":  name,
+			"sidecar.istio.io/inject": "false",
+		}, dep.Spec.Template.ObjectMeta.Labels, "must have expected pod labels")
+		require.NotEmpty(t, dep.Spec.Template.ObjectMeta.Annotations["checksum/config"])
+
+		require.Len(t, dep.Spec.Template.Spec.Containers, 1)
+		container := dep.Spec.Template.Spec.Containers[0]
+		require.Equal(t, "otel/collector", container.Image)
+		require.Equal(t, name, dep.Spec.Template.Spec.ServiceAccountName)
+
+		envVars := container.Env
+		require.Len(t, envVars, 3)
+		require.Equal(t, envVars[0].Name, "MY_POD_IP")
+		require.Equal(t, envVars[1].Name, "MY_NODE_NAME")
+		require.Equal(t, envVars[2].Name, "GOMEMLIMIT")
+	})
+
+	t.Run("should create clusterrole with read access to the observed objects", func(t *testing.T) {
+		var crs rbacv1.ClusterRoleList
+		require.NoError(t, client.List(ctx, &crs))
+		require.Len(t, crs.Items, 1)
+
+		cr := crs.Items[0]
+		require.Equal(t, name, cr.Name)
+		for _, rule := range cr.Rules {
+			require.Equal(t, []string{"get", "list", "watch"}, rule.Verbs)
+			require.NotContains(t, rule.Resources, "events", "the k8s_cluster receiver does not read events")
+		}
+		require.Contains(t, cr.Rules, rbacv1.PolicyRule{
+			APIGroups: []string{"apps"},
+			Resources: []string{"daemonsets", "deployments", "replicasets", "statefulsets"},
+			Verbs:     []string{"get", "list", "watch"},
+		})
+	})
+
+	t.Run("should create clusterrolebinding and serviceaccount", func(t *testing.T) {
+		var crbs rbacv1.ClusterRoleBindingList
+		require.NoError(t, client.List(ctx, &crbs))
+		require.Len(t, crbs.Items, 1)
+		require.Equal(t, name, crbs.Items[0].RoleRef.Name)
+
+		var sas corev1.ServiceAccountList
+		require.NoError(t, client.List(ctx, &sas))
+		require.Len(t, sas.Items, 1)
+		require.Equal(t, name, sas.Items[0].Name)
+	})
+
+	t.Run("should create networkpolicy", func(t *testing.T) {
+		var nps networkingv1.NetworkPolicyList
+		require.NoError(t, client.List(ctx, &nps))
+		require.Len(t, nps.Items, 1)
+		require.Equal(t, name, nps.Items[0].Name)
+	})
+
+	t.Run("should delete all resources", func(t *testing.T) {
+		require.NoError(t, DeleteClusterCollectorResources(ctx, client, clusterCollectorConfig))
+
+		var deps appsv1.DeploymentList
+		require.NoError(t, client.List(ctx, &deps))
+		require.Empty(t, deps.Items)
+
+		var cms corev1.ConfigMapList
+		require.NoError(t, client.List(ctx, &cms))
+		require.Empty(t, cms.Items)
+
+		var svcs corev1.ServiceList
+		require.NoError(t, client.List(ctx, &svcs))
+		require.Empty(t, svcs.Items)
+
+		var nps networkingv1.NetworkPolicyList
+		require.NoError(t, client.List(ctx, &nps))
+		require.Empty(t, nps.Items)
+
+		var crbs rbacv1.ClusterRoleBindingList
+		require.NoError(t, client.List(ctx, &crbs))
+		require.Empty(t, crbs.Items)
+
+		var crs rbacv1.ClusterRoleList
+		require.NoError(t, client.List(ctx, &crs))
+		require.Empty(t, crs.Items)
+
+		var sas corev1.ServiceAccountList
+		require.NoError(t, client.List(ctx, &sas))
+		require.Empty(t, sas.Items)
+
+		require.NoError(t, DeleteClusterCollectorResources(ctx, client, clusterCollectorConfig), "deleting missing resources must not fail")
+	})
+}
@@ -1,7 +1,11 @@
 package otelcollector
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/api/resource"
+
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 )
 
 type Config struct {
@@ -19,7 +23,22 @@ type GatewayConfig struct {
 	Scaling         GatewayScalingConfig
 	Istio           IstioConfig
 	OTLPServiceName string
-	allowedPorts    []int32
+	// LoadBalancingServiceName is the name of a headless Service resolving to the gateway replicas. If empty, no such Service is created.
+	LoadBalancingServiceName string
+	PersistentQueue          PersistentQueueConfig
+	allowedPorts             []int32
+	loadBalancing            bool
+	tapCollectorConfig       string
+}
+
+// PersistentQueueConfig defines the volume on which the gateway stores its sending queues.
+// If enabled, the gateway runs as a StatefulSet with a PersistentVolumeClaim per replica instead of a Deployment.
+type PersistentQueueConfig struct {
+	Enabled bool
+	// Size is the size of the PersistentVolumeClaim of each replica.
+	Size resource.Quantity
+	// StorageClassName is the StorageClass of the PersistentVolumeClaims. If nil, the default StorageClass is used.
+	StorageClassName *string
 }
 
 type IstioConfig struct {
@@ -40,6 +59,15 @@ func (cfg *GatewayConfig) WithCollectorConfig(collectorCfgYAML string, collector
 	return &cfgCopy
 }
 
+// WithTapExporters deploys the given collector config, which additionally contains the tap exporters, instead of the collector config.
+// The tap exporters are excluded from the config checksum, so that tapping a pipeline does not roll out the gateway.
+// Instead, a single replica is restarted whenever the tap exporters change.
+func (cfg *GatewayConfig) WithTapExporters(collectorCfgWithTapsYAML string) *GatewayConfig {
+	cfgCopy := *cfg
+	cfgCopy.tapCollectorConfig = collectorCfgWithTapsYAML
+	return &cfgCopy
+}
+
 func (cfg *GatewayConfig) WithIstioConfig(excludePorts string, istioEnabled bool) *GatewayConfig {
 	cfgCopy := *cfg
 	istioConfg := IstioConfig{
@@ -50,6 +78,46 @@ func (cfg *GatewayConfig) WithIstioConfig(excludePorts string, istioEnabled bool
 	return &cfgCopy
 }
 
+// WithLoadBalancing creates the load balancing Service if enabled, and deletes it otherwise.
+func (cfg *GatewayConfig) WithLoadBalancing(enabled bool) *GatewayConfig {
+	cfgCopy := *cfg
+	cfgCopy.loadBalancing = enabled
+	return &cfgCopy
+}
+
+func (cfg *GatewayConfig) WithPersistentQueue(pq PersistentQueueConfig) *GatewayConfig {
+	cfgCopy := *cfg
+	cfgCopy.PersistentQueue = pq
+	return &cfgCopy
+}
+
+// GatewayResourceOverrides replaces the configured resource requirements of the gateway deployment.
+type GatewayResourceOverrides struct {
+	Base        commonresources.ResourceOverrides
+	PerPipeline commonresources.ResourceOverrides
+}
+
+func (cfg *GatewayConfig) WithResourceOverrides(overrides GatewayResourceOverrides) *GatewayConfig {
+	cfgCopy := *cfg
+	deployment := &cfgCopy.Deployment
+	overrides.Base.Apply(&deployment.BaseCPULimit, &deployment.BaseMemoryLimit, &deployment.BaseCPURequest, &deployment.BaseMemoryRequest)
+	overrides.PerPipeline.Apply(&deployment.DynamicCPULimit, &deployment.DynamicMemoryLimit, &deployment.DynamicCPURequest, &deployment.DynamicMemoryRequest)
+	return &cfgCopy
+}
+
+// ValidateResources returns an error if a resource request of the gateway exceeds its limit.
+// The base and the per-pipeline resources are validated separately, so that the requests stay within the limits for any number of pipelines.
+func (cfg *GatewayConfig) ValidateResources() error {
+	deployment := cfg.Deployment
+	if err := commonresources.ValidateResourceRequirements(deployment.BaseCPULimit, deployment.BaseMemoryLimit, deployment.BaseCPURequest, deployment.BaseMemoryRequest); err != nil {
+		return err
+	}
+	if err := commonresources.ValidateResourceRequirements(deployment.DynamicCPULimit, deployment.DynamicMemoryLimit, deployment.DynamicCPURequest, deployment.DynamicMemoryRequest); err != nil {
+		return fmt.Errorf("per pipeline: %w", err)
+	}
+	return nil
+}
+
 func (cfg *GatewayConfig) WithAllowedPorts(ports []int32) *GatewayConfig {
 	cfgCopy := *cfg
 
@@ -79,6 +147,18 @@ type GatewayScalingConfig struct {
 	// This value is multiplied with a base resource requirement to calculate the actual CPU and memory limits.
 	// A value of 1 applies the base limits; values greater than 1 increase those limits proportionally.
 	ResourceRequirementsMultiplier int
+
+	// Autoscaling lets a HorizontalPodAutoscaler manage the number of gateway replicas. If nil, Replicas is used.
+	Autoscaling *GatewayAutoscalingConfig
+}
+
+type GatewayAutoscalingConfig struct {
+	MinReplicas int32
+	MaxReplicas int32
+	// TargetCPUUtilizationPercentage is the average CPU utilization relative to the requested CPU, at which the gateway is scaled.
+	TargetCPUUtilizationPercentage int32
+	// TargetMemoryUtilizationPercentage is the average memory utilization relative to the requested memory, at which the gateway is scaled. If 0, memory is not considered.
+	TargetMemoryUtilizationPercentage int32
 }
 
 type AgentConfig struct {
@@ -94,6 +174,19 @@ func (cfg *AgentConfig) WithCollectorConfig(collectorCfgYAML string) *AgentConfi
 	return &cp
 }
 
+func (cfg *AgentConfig) WithResourceOverrides(overrides commonresources.ResourceOverrides) *AgentConfig {
+	cfgCopy := *cfg
+	daemonSet := &cfgCopy.DaemonSet
+	overrides.Apply(&daemonSet.CPULimit, &daemonSet.MemoryLimit, &daemonSet.CPURequest, &daemonSet.MemoryRequest)
+	return &cfgCopy
+}
+
+// ValidateResources returns an error if a resource request of the agent exceeds its limit.
+func (cfg *AgentConfig) ValidateResources() error {
+	daemonSet := cfg.DaemonSet
+	return commonresources.ValidateResourceRequirements(daemonSet.CPULimit, daemonSet.MemoryLimit, daemonSet.CPURequest, daemonSet.MemoryRequest)
+}
+
 type DaemonSetConfig struct {
 	Image             string
 	PriorityClassName string
@@ -110,3 +203,32 @@ func (cfg *AgentConfig) WithAllowedPorts(ports []int32) *AgentConfig {
 	return &cfgCopy
 
 }
+
+// ClusterCollectorConfig configures the singleton collector that observes the state of the Kubernetes objects for the whole cluster.
+type ClusterCollectorConfig struct {
+	Config
+	allowedPorts []int32
+
+	Deployment ClusterCollectorDeploymentConfig
+}
+
+type ClusterCollectorDeploymentConfig struct {
+	Image             string
+	PriorityClassName string
+	CPULimit          resource.Quantity
+	CPURequest        resource.Quantity
+	MemoryLimit       resource.Quantity
+	MemoryRequest     resource.Quantity
+}
+
+func (cfg *ClusterCollectorConfig) WithCollectorConfig(collectorCfgYAML string) *ClusterCollectorConfig {
+	cfgCopy := *cfg
+	cfgCopy.CollectorConfig = collectorCfgYAML
+	return &cfgCopy
+}
+
+func (cfg *ClusterCollectorConfig) WithAllowedPorts(ports []int32) *ClusterCollectorConfig {
+	cfgCopy := *cfg
+	cfgCopy.allowedPorts = ports
+	return &cfgCopy
+}
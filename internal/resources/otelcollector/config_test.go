@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 )
 
 func TestGatewayConfig(t *testing.T) {
@@ -33,6 +37,32 @@ func TestGatewayConfig(t *testing.T) {
 	require.Equal(t, allowedPorts, gatewayConfig.allowedPorts)
 }
 
+func TestGatewayConfigValidateResources(t *testing.T) {
+	gatewayConfig := &GatewayConfig{
+		Deployment: DeploymentConfig{
+			BaseCPULimit:         resource.MustParse("700m"),
+			BaseCPURequest:       resource.MustParse("100m"),
+			BaseMemoryLimit:      resource.MustParse("500Mi"),
+			BaseMemoryRequest:    resource.MustParse("32Mi"),
+			DynamicCPULimit:      resource.MustParse("500m"),
+			DynamicCPURequest:    resource.MustParse("100m"),
+			DynamicMemoryLimit:   resource.MustParse("1500Mi"),
+			DynamicMemoryRequest: resource.MustParse("0"),
+		},
+	}
+	require.NoError(t, gatewayConfig.ValidateResources())
+
+	invalidBase := gatewayConfig.WithResourceOverrides(GatewayResourceOverrides{
+		Base: commonresources.ResourceOverrides{CPURequest: ptr.To(resource.MustParse("1"))},
+	})
+	require.EqualError(t, invalidBase.ValidateResources(), "CPU request 1 exceeds CPU limit 700m")
+
+	invalidPerPipeline := gatewayConfig.WithResourceOverrides(GatewayResourceOverrides{
+		PerPipeline: commonresources.ResourceOverrides{MemoryLimit: ptr.To(resource.MustParse("10Mi")), MemoryRequest: ptr.To(resource.MustParse("20Mi"))},
+	})
+	require.EqualError(t, invalidPerPipeline.ValidateResources(), "per pipeline: memory request 20Mi exceeds memory limit 10Mi")
+}
+
 func TestAgentConfig(t *testing.T) {
 	collectorCfgYAML := "test yaml"
 	allowedPorts := []int32{8000, 8080}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -44,6 +45,29 @@ func applyCommonResources(ctx context.Context, c client.Client, name types.Names
 	return nil
 }
 
+// deleteResources deletes the given objects, skipping the ones that do not exist.
+func deleteResources(ctx context.Context, c client.Client, objects ...client.Object) error {
+	for _, obj := range objects {
+		if err := k8sutils.DeleteIfExists(ctx, c, obj); err != nil {
+			return fmt.Errorf("failed to delete %T %s: %w", obj, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// commonResourceObjects returns empty objects identifying the resources created by applyCommonResources, in reverse creation order.
+func commonResourceObjects(name types.NamespacedName) []client.Object {
+	objectMeta := metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace}
+	return []client.Object{
+		&networkingv1.NetworkPolicy{ObjectMeta: objectMeta},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name.Name + "-metrics", Namespace: name.Namespace}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: objectMeta},
+		&rbacv1.ClusterRole{ObjectMeta: objectMeta},
+		&corev1.ServiceAccount{ObjectMeta: objectMeta},
+	}
+}
+
 func defaultLabels(baseName string) map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/name": baseName,
@@ -9,6 +9,7 @@ import (
 	istiotypev1beta1 "istio.io/api/type/v1beta1"
 	istiosecurityclientv1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -25,6 +26,8 @@ import (
 	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 )
 
+const persistentQueueVolumeName = "persistent-queue"
+
 func ApplyGatewayResources(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
 	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
 
@@ -38,19 +41,39 @@ func ApplyGatewayResources(ctx context.Context, c client.Client, cfg *GatewayCon
 	}
 
 	configMap := makeConfigMap(name, cfg.CollectorConfig)
+	configChecksum := configchecksum.Calculate([]corev1.ConfigMap{*configMap}, []corev1.Secret{*secret})
+	if cfg.tapCollectorConfig != "" {
+		configMap = makeConfigMap(name, cfg.tapCollectorConfig)
+	}
+
+	if err := annotateTapsChange(ctx, c, configMap, configChecksum); err != nil {
+		return err
+	}
+
 	if err := k8sutils.CreateOrUpdateConfigMap(ctx, c, configMap); err != nil {
 		return fmt.Errorf("failed to create configmap: %w", err)
 	}
 
-	configChecksum := configchecksum.Calculate([]corev1.ConfigMap{*configMap}, []corev1.Secret{*secret})
-	if err := k8sutils.CreateOrUpdateDeployment(ctx, c, makeGatewayDeployment(cfg, configChecksum, cfg.Istio)); err != nil {
-		return fmt.Errorf("failed to create deployment: %w", err)
+	if err := applyGatewayWorkload(ctx, c, cfg, configChecksum); err != nil {
+		return err
+	}
+
+	if err := restartTapReplica(ctx, c, cfg, configMap); err != nil {
+		return err
+	}
+
+	if err := applyGatewayAutoscaler(ctx, c, cfg); err != nil {
+		return err
 	}
 
 	if err := k8sutils.CreateOrUpdateService(ctx, c, makeOTLPService(cfg)); err != nil {
 		return fmt.Errorf("failed to create otlp service: %w", err)
 	}
 
+	if err := applyLoadBalancingService(ctx, c, cfg); err != nil {
+		return err
+	}
+
 	if cfg.Istio.Enabled {
 		if err := k8sutils.CreateOrUpdatePeerAuthentication(ctx, c, makePeerAuthentication(cfg)); err != nil {
 			return fmt.Errorf("failed to create peerauthentication: %w", err)
@@ -60,6 +83,36 @@ func ApplyGatewayResources(ctx context.Context, c client.Client, cfg *GatewayCon
 	return nil
 }
 
+// DeleteGatewayResources removes all resources of a gateway. The resources are owned by the pipelines using the gateway,
+// but a pipeline can stop using the gateway while it still exists, so they have to be removed explicitly.
+func DeleteGatewayResources(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
+	name := types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.BaseName}
+	objectMeta := metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace}
+
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: objectMeta},
+		&appsv1.StatefulSet{ObjectMeta: objectMeta},
+		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: objectMeta},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: cfg.OTLPServiceName, Namespace: cfg.Namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: headlessServiceName(cfg), Namespace: cfg.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: objectMeta},
+		&corev1.Secret{ObjectMeta: objectMeta},
+	}
+	if cfg.LoadBalancingServiceName != "" {
+		objects = append(objects, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: cfg.LoadBalancingServiceName, Namespace: cfg.Namespace}})
+	}
+	if cfg.Istio.Enabled {
+		objects = append(objects, &istiosecurityclientv1beta.PeerAuthentication{ObjectMeta: objectMeta})
+	}
+	objects = append(objects, commonResourceObjects(name)...)
+
+	if err := deleteResources(ctx, c, objects...); err != nil {
+		return err
+	}
+
+	return deletePersistentQueueClaims(ctx, c, cfg)
+}
+
 func makeGatewayClusterRole(name types.NamespacedName) *rbacv1.ClusterRole {
 	clusterRole := rbacv1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
@@ -83,14 +136,129 @@ func makeGatewayClusterRole(name types.NamespacedName) *rbacv1.ClusterRole {
 	return &clusterRole
 }
 
-func makeGatewayDeployment(cfg *GatewayConfig, configChecksum string, istioConfig IstioConfig) *appsv1.Deployment {
+// applyGatewayWorkload runs the gateway as a StatefulSet if the sending queues are persisted, so that every replica keeps its
+// PersistentVolumeClaim across restarts, and as a Deployment otherwise. The workload of the other kind is deleted.
+// The StatefulSet retains the claims when it is deleted, so they are deleted explicitly once the persistent queue is disabled.
+func applyGatewayWorkload(ctx context.Context, c client.Client, cfg *GatewayConfig, configChecksum string) error {
+	objectMeta := metav1.ObjectMeta{Name: cfg.BaseName, Namespace: cfg.Namespace}
+	headlessService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: headlessServiceName(cfg), Namespace: cfg.Namespace}}
+
+	if cfg.PersistentQueue.Enabled {
+		if err := k8sutils.CreateOrUpdateService(ctx, c, makeHeadlessService(cfg)); err != nil {
+			return fmt.Errorf("failed to create headless service: %w", err)
+		}
+		if err := k8sutils.CreateOrUpdateStatefulSet(ctx, c, makeGatewayStatefulSet(cfg, configChecksum)); err != nil {
+			return fmt.Errorf("failed to create statefulset: %w", err)
+		}
+		if err := k8sutils.DeleteIfExists(ctx, c, &appsv1.Deployment{ObjectMeta: objectMeta}); err != nil {
+			return fmt.Errorf("failed to delete deployment: %w", err)
+		}
+		return nil
+	}
+
+	if err := k8sutils.CreateOrUpdateDeployment(ctx, c, makeGatewayDeployment(cfg, configChecksum)); err != nil {
+		return fmt.Errorf("failed to create deployment: %w", err)
+	}
+	if err := k8sutils.DeleteIfExists(ctx, c, &appsv1.StatefulSet{ObjectMeta: objectMeta}); err != nil {
+		return fmt.Errorf("failed to delete statefulset: %w", err)
+	}
+	if err := k8sutils.DeleteIfExists(ctx, c, headlessService); err != nil {
+		return fmt.Errorf("failed to delete headless service: %w", err)
+	}
+	return deletePersistentQueueClaims(ctx, c, cfg)
+}
+
+// deletePersistentQueueClaims deletes the claims that the gateway StatefulSet retained. The claims are listed first,
+// so that no delete requests are sent if the persistent queue was never enabled.
+func deletePersistentQueueClaims(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
+	var claims corev1.PersistentVolumeClaimList
+	if err := c.List(ctx, &claims, client.InNamespace(cfg.Namespace), client.MatchingLabels(defaultLabels(cfg.BaseName))); err != nil {
+		return fmt.Errorf("failed to list persistent queue claims: %w", err)
+	}
+
+	for i := range claims.Items {
+		if err := client.IgnoreNotFound(c.Delete(ctx, &claims.Items[i])); err != nil {
+			return fmt.Errorf("failed to delete persistent queue claim %s: %w", claims.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func makeGatewayDeployment(cfg *GatewayConfig, configChecksum string) *appsv1.Deployment {
+	selectorLabels := defaultLabels(cfg.BaseName)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.BaseName,
+			Namespace: cfg.Namespace,
+			Labels:    selectorLabels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: makeGatewayReplicas(cfg),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			Template: makeGatewayPodTemplate(cfg, configChecksum),
+		},
+	}
+}
+
+func makeGatewayStatefulSet(cfg *GatewayConfig, configChecksum string) *appsv1.StatefulSet {
+	selectorLabels := defaultLabels(cfg.BaseName)
+
+	template := makeGatewayPodTemplate(cfg, configChecksum, withVolumeMount(corev1.VolumeMount{
+		Name:      persistentQueueVolumeName,
+		MountPath: config.FileStorageDirectory,
+	}))
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.BaseName,
+			Namespace: cfg.Namespace,
+			Labels:    selectorLabels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    makeGatewayReplicas(cfg),
+			ServiceName: headlessServiceName(cfg),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			// The replicas are interchangeable, so they are started and stopped in parallel like the pods of a Deployment.
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			Template:            template,
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   persistentQueueVolumeName,
+						Labels: selectorLabels,
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						StorageClassName: cfg.PersistentQueue.StorageClassName,
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: cfg.PersistentQueue.Size},
+						},
+					},
+				},
+			},
+			// A replica removed by scaling down keeps its claim, so that its buffered data is sent once it is scaled up again.
+			// The claims are also kept when the StatefulSet is recreated to change the claim template, so that the new replicas send the buffered data.
+			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			},
+		},
+	}
+}
+
+func makeGatewayPodTemplate(cfg *GatewayConfig, configChecksum string, extraOpts ...podSpecOption) corev1.PodTemplateSpec {
 	selectorLabels := defaultLabels(cfg.BaseName)
 	podLabels := maps.Clone(selectorLabels)
-	podLabels["sidecar.istio.io/inject"] = fmt.Sprintf("%t", istioConfig.Enabled)
+	podLabels["sidecar.istio.io/inject"] = fmt.Sprintf("%t", cfg.Istio.Enabled)
 
 	annotations := map[string]string{"checksum/config": configChecksum}
-	if istioConfig.Enabled {
-		annotations["traffic.sidecar.istio.io/excludeInboundPorts"] = istioConfig.ExcludePorts
+	if cfg.Istio.Enabled {
+		annotations["traffic.sidecar.istio.io/excludeInboundPorts"] = cfg.Istio.ExcludePorts
 		// When a workload is outside the istio mesh and communicates with pod in service mesh, the envoy proxy does not
 		// preserve the source IP and destination IP. To preserve source/destination IP we need TPROXY interception mode.
 		// More info: https://istio.io/latest/docs/reference/config/istio.mesh.v1alpha1/#ProxyConfig-InboundInterceptionMode
@@ -99,32 +267,93 @@ func makeGatewayDeployment(cfg *GatewayConfig, configChecksum string, istioConfi
 	resources := makeGatewayResourceRequirements(cfg)
 	affinity := makePodAffinity(selectorLabels)
 
-	podSpec := makePodSpec(cfg.BaseName, cfg.Deployment.Image,
+	opts := []podSpecOption{
 		commonresources.WithPriorityClass(cfg.Deployment.PriorityClassName),
 		commonresources.WithResources(resources),
 		withAffinity(affinity),
 		withEnvVarFromSource(config.EnvVarCurrentPodIP, fieldPathPodIP),
 		withEnvVarFromSource(config.EnvVarCurrentNodeName, fieldPathNodeName),
 		commonresources.WithGoMemLimitEnvVar(resources.Limits[corev1.ResourceMemory]),
-	)
+	}
+	opts = append(opts, extraOpts...)
 
-	return &appsv1.Deployment{
+	podSpec := makePodSpec(cfg.BaseName, cfg.Deployment.Image, opts...)
+
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      podLabels,
+			Annotations: annotations,
+		},
+		Spec: podSpec,
+	}
+}
+
+// makeGatewayReplicas returns nil if the replicas are managed by a HorizontalPodAutoscaler.
+func makeGatewayReplicas(cfg *GatewayConfig) *int32 {
+	if cfg.Scaling.Autoscaling != nil {
+		return nil
+	}
+	return ptr.To(cfg.Scaling.Replicas)
+}
+
+func applyGatewayAutoscaler(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
+	if cfg.Scaling.Autoscaling == nil {
+		hpa := autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: cfg.BaseName, Namespace: cfg.Namespace}}
+		if err := k8sutils.DeleteIfExists(ctx, c, &hpa); err != nil {
+			return fmt.Errorf("failed to delete horizontal pod autoscaler: %w", err)
+		}
+		return nil
+	}
+
+	if err := k8sutils.CreateOrUpdateHorizontalPodAutoscaler(ctx, c, makeGatewayAutoscaler(cfg)); err != nil {
+		return fmt.Errorf("failed to create horizontal pod autoscaler: %w", err)
+	}
+	return nil
+}
+
+func makeGatewayAutoscaler(cfg *GatewayConfig) *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := cfg.Scaling.Autoscaling
+	metrics := []autoscalingv2.MetricSpec{
+		makeResourceUtilizationMetric(corev1.ResourceCPU, autoscaling.TargetCPUUtilizationPercentage),
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage > 0 {
+		metrics = append(metrics, makeResourceUtilizationMetric(corev1.ResourceMemory, autoscaling.TargetMemoryUtilizationPercentage))
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cfg.BaseName,
 			Namespace: cfg.Namespace,
-			Labels:    selectorLabels,
+			Labels:    defaultLabels(cfg.BaseName),
 		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: ptr.To(cfg.Scaling.Replicas),
-			Selector: &metav1.LabelSelector{
-				MatchLabels: selectorLabels,
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       makeGatewayWorkloadKind(cfg),
+				Name:       cfg.BaseName,
 			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels:      podLabels,
-					Annotations: annotations,
-				},
-				Spec: podSpec,
+			MinReplicas: ptr.To(autoscaling.MinReplicas),
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+func makeGatewayWorkloadKind(cfg *GatewayConfig) string {
+	if cfg.PersistentQueue.Enabled {
+		return "StatefulSet"
+	}
+	return "Deployment"
+}
+
+func makeResourceUtilizationMetric(name corev1.ResourceName, targetUtilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: ptr.To(targetUtilization),
 			},
 		},
 	}
@@ -214,6 +443,63 @@ func makeOTLPService(cfg *GatewayConfig) *corev1.Service {
 	}
 }
 
+func headlessServiceName(cfg *GatewayConfig) string {
+	return cfg.BaseName + "-headless"
+}
+
+// makeHeadlessService creates the governing Service of the gateway StatefulSet, which gives every replica a stable network identity.
+func makeHeadlessService(cfg *GatewayConfig) *corev1.Service {
+	service := makeOTLPService(cfg)
+	service.Name = headlessServiceName(cfg)
+	service.Spec.ClusterIP = corev1.ClusterIPNone
+	return service
+}
+
+func applyLoadBalancingService(ctx context.Context, c client.Client, cfg *GatewayConfig) error {
+	if cfg.LoadBalancingServiceName == "" {
+		return nil
+	}
+
+	if !cfg.loadBalancing {
+		service := corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: cfg.LoadBalancingServiceName, Namespace: cfg.Namespace}}
+		if err := k8sutils.DeleteIfExists(ctx, c, &service); err != nil {
+			return fmt.Errorf("failed to delete load balancing service: %w", err)
+		}
+		return nil
+	}
+
+	if err := k8sutils.CreateOrUpdateService(ctx, c, makeLoadBalancingService(cfg)); err != nil {
+		return fmt.Errorf("failed to create load balancing service: %w", err)
+	}
+	return nil
+}
+
+// makeLoadBalancingService creates a headless Service, which lets the gateway replicas discover each other to route spans by trace ID.
+func makeLoadBalancingService(cfg *GatewayConfig) *corev1.Service {
+	labels := defaultLabels(cfg.BaseName)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfg.LoadBalancingServiceName,
+			Namespace: cfg.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "grpc-load-balancing",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       ports.OTLPGRPCLoadBalanced,
+					TargetPort: intstr.FromInt32(ports.OTLPGRPCLoadBalanced),
+				},
+			},
+			Selector:  labels,
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone,
+		},
+	}
+}
+
 func makePeerAuthentication(cfg *GatewayConfig) *istiosecurityclientv1beta.PeerAuthentication {
 	selectorLabels := defaultLabels(cfg.BaseName)
 
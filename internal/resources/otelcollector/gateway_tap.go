@@ -0,0 +1,79 @@
+package otelcollector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// configChecksumAnnotation records the checksum of the config, which the gateway replicas are rolled out with.
+	configChecksumAnnotation = "telemetry.kyma-project.io/config-checksum"
+	// tapsChangedAtAnnotation records when the deployed config changed last without a change of the checksum, which is
+	// the case if only the tap exporters changed.
+	tapsChangedAtAnnotation = "telemetry.kyma-project.io/taps-changed-at"
+)
+
+// annotateTapsChange sets the annotations of the gateway ConfigMap, which restartTapReplica relies on.
+func annotateTapsChange(ctx context.Context, c client.Client, configMap *corev1.ConfigMap, configChecksum string) error {
+	configMap.Annotations = map[string]string{configChecksumAnnotation: configChecksum}
+
+	var existing corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKeyFromObject(configMap), &existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	// A changed checksum rolls out all replicas anyway
+	if existing.Annotations[configChecksumAnnotation] == configChecksum && !maps.Equal(existing.Data, configMap.Data) {
+		configMap.Annotations[tapsChangedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	return nil
+}
+
+// restartTapReplica restarts a single gateway replica after the tap exporters changed, so that it loads the current tap
+// exporters without rolling out all replicas. The replica started last before the change is restarted, because it is the
+// one running the previous tap exporters, if any. The other replicas keep their config until the next rollout.
+func restartTapReplica(ctx context.Context, c client.Client, cfg *GatewayConfig, configMap *corev1.ConfigMap) error {
+	value, found := configMap.Annotations[tapsChangedAtAnnotation]
+	if !found {
+		return nil
+	}
+
+	tapsChangedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s annotation: %w", tapsChangedAtAnnotation, err)
+	}
+
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.InNamespace(cfg.Namespace), client.MatchingLabels(defaultLabels(cfg.BaseName))); err != nil {
+		return fmt.Errorf("failed to list gateway pods: %w", err)
+	}
+
+	var latest *corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		// A replica started after the change runs the current tap exporters, and a terminating replica is about to do so
+		if pod.DeletionTimestamp != nil || !pod.CreationTimestamp.Time.Before(tapsChangedAt) {
+			return nil
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			latest = pod
+		}
+	}
+
+	if latest == nil {
+		return nil
+	}
+
+	logf.FromContext(ctx).Info("Restarting gateway replica to apply the changed tap exporters", "pod", latest.Name)
+	if err := c.Delete(ctx, latest); err != nil {
+		return fmt.Errorf("failed to restart gateway replica: %w", client.IgnoreNotFound(err))
+	}
+	return nil
+}
@@ -3,20 +3,29 @@ package otelcollector
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	istiosecurityv1beta "istio.io/api/security/v1beta1"
 	istiosecurityclientv1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	commonresources "github.com/kyma-project/telemetry-manager/internal/resources/common"
 )
 
 var (
@@ -31,8 +40,9 @@ var (
 		"BASIC_AUTH_HEADER": []byte("basicAuthHeader"),
 		"OTLP_ENDPOINT":     []byte("otlpEndpoint"),
 	}
-	otlpServiceName       = "telemetry"
-	replicas        int32 = 3
+	otlpServiceName                = "telemetry"
+	loadBalancingServiceName       = "telemetry-load-balancing"
+	replicas                 int32 = 3
 )
 
 func TestApplyGatewayResources(t *testing.T) {
@@ -285,7 +295,284 @@ func TestApplyGatewayResources(t *testing.T) {
 			TargetPort: intstr.FromInt32(4318),
 		}, svc.Spec.Ports[1])
 	})
+
+	t.Run("should not create load balancing service", func(t *testing.T) {
+		var svc corev1.Service
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: loadBalancingServiceName}, &svc)
+		require.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestApplyGatewayResourcesWithLoadBalancing(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+
+	gatewayConfig := createGatewayConfig(false, false)
+	gatewayConfig.LoadBalancingServiceName = loadBalancingServiceName
+	err := ApplyGatewayResources(ctx, client, gatewayConfig.WithLoadBalancing(true))
+	require.NoError(t, err)
+
+	t.Run("should create headless load balancing service", func(t *testing.T) {
+		var svc corev1.Service
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: loadBalancingServiceName}, &svc))
+
+		require.Equal(t, map[string]string{
+			"app.kubernetes.io/name": name,
+		}, svc.Spec.Selector)
+		require.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+		require.Len(t, svc.Spec.Ports, 1)
+		require.Equal(t, corev1.ServicePort{
+			Name:       "grpc-load-balancing",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       4319,
+			TargetPort: intstr.FromInt32(4319),
+		}, svc.Spec.Ports[0])
+	})
+
+	t.Run("should delete load balancing service when no longer required", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithLoadBalancing(false)))
+
+		var svc corev1.Service
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: loadBalancingServiceName}, &svc)
+		require.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestApplyGatewayResourcesWithPersistentQueue(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+
+	gatewayConfig := createGatewayConfig(false, false)
+	require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+	persistentQueueConfig := gatewayConfig.WithPersistentQueue(PersistentQueueConfig{
+		Enabled:          true,
+		Size:             resource.MustParse("2Gi"),
+		StorageClassName: ptr.To("standard"),
+	})
+	require.NoError(t, ApplyGatewayResources(ctx, client, persistentQueueConfig))
+
+	t.Run("should replace the deployment with a stateful set", func(t *testing.T) {
+		var dep appsv1.Deployment
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep)
+		require.True(t, apierrors.IsNotFound(err))
+
+		var sts appsv1.StatefulSet
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &sts))
+		require.Equal(t, ptr.To(replicas), sts.Spec.Replicas)
+		require.Equal(t, name+"-headless", sts.Spec.ServiceName)
+		require.Equal(t, map[string]string{"app.kubernetes.io/name": name}, sts.Spec.Selector.MatchLabels)
+		require.Equal(t, appsv1.RetainPersistentVolumeClaimRetentionPolicyType, sts.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted)
+		require.Equal(t, appsv1.RetainPersistentVolumeClaimRetentionPolicyType, sts.Spec.PersistentVolumeClaimRetentionPolicy.WhenScaled)
+	})
+
+	t.Run("should govern the stateful set by a headless service", func(t *testing.T) {
+		var svc corev1.Service
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-headless"}, &svc))
+		require.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+		require.Equal(t, map[string]string{"app.kubernetes.io/name": name}, svc.Spec.Selector)
+		require.Len(t, svc.Spec.Ports, 2)
+	})
+
+	t.Run("should mount a persistent volume claim per replica", func(t *testing.T) {
+		var sts appsv1.StatefulSet
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &sts))
+
+		require.Len(t, sts.Spec.VolumeClaimTemplates, 1)
+		claim := sts.Spec.VolumeClaimTemplates[0]
+		require.Equal(t, "persistent-queue", claim.Name)
+		require.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, claim.Spec.AccessModes)
+		require.Equal(t, ptr.To("standard"), claim.Spec.StorageClassName)
+		require.Equal(t, resource.MustParse("2Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+
+		podSpec := sts.Spec.Template.Spec
+		require.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "persistent-queue",
+			MountPath: "/var/lib/otelcol/file_storage",
+		})
+		for _, volume := range podSpec.Volumes {
+			require.NotEqual(t, "persistent-queue", volume.Name)
+		}
+	})
+
+	t.Run("should replace the stateful set with a deployment when disabled", func(t *testing.T) {
+		claim := corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:      "persistent-queue-" + name + "-0",
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/name": name},
+		}}
+		require.NoError(t, client.Create(ctx, &claim))
+
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+		var sts appsv1.StatefulSet
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &sts)
+		require.True(t, apierrors.IsNotFound(err))
+
+		var dep appsv1.Deployment
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+
+		err = client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name + "-headless"}, &corev1.Service{})
+		require.True(t, apierrors.IsNotFound(err))
+
+		err = client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: claim.Name}, &corev1.PersistentVolumeClaim{})
+		require.True(t, apierrors.IsNotFound(err), "retained claims must be deleted with the persistent queue")
+	})
+}
+
+func TestApplyGatewayResourcesWithTapExporters(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+
+	gatewayConfig := createGatewayConfig(false, false)
+	require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithTapExporters(cfg)))
+
+	var dep appsv1.Deployment
+	require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+	checksum := dep.Spec.Template.Annotations["checksum/config"]
+
+	makePod := func(podName string, created time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              podName,
+				Namespace:         namespace,
+				Labels:            map[string]string{"app.kubernetes.io/name": name},
+				CreationTimestamp: metav1.NewTime(created),
+			},
+		}
+	}
+	podExists := func(podName string) bool {
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: podName}, &corev1.Pod{})
+		return !apierrors.IsNotFound(err)
+	}
+	require.NoError(t, client.Create(ctx, makePod("older", time.Now().Add(-2*time.Hour))))
+	require.NoError(t, client.Create(ctx, makePod("newer", time.Now().Add(-time.Hour))))
+
+	t.Run("should not restart a replica without tap changes", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithTapExporters(cfg)))
+
+		require.True(t, podExists("older"))
+		require.True(t, podExists("newer"))
+	})
+
+	t.Run("should restart a single replica instead of rolling out when the taps change", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithTapExporters(cfg+" with taps")))
+
+		var cm corev1.ConfigMap
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &cm))
+		require.Equal(t, cfg+" with taps", cm.Data["relay.conf"])
+
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+		require.Equal(t, checksum, dep.Spec.Template.Annotations["checksum/config"], "tap exporters must not change the config checksum")
+
+		require.True(t, podExists("older"))
+		require.False(t, podExists("newer"), "the replica started last must be restarted")
+	})
+
+	t.Run("should not restart another replica once a replica runs the current taps", func(t *testing.T) {
+		require.NoError(t, client.Create(ctx, makePod("restarted", time.Now().Add(time.Second))))
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig.WithTapExporters(cfg+" with taps")))
+
+		require.True(t, podExists("older"))
+		require.True(t, podExists("restarted"))
+	})
 }
+
+func TestApplyGatewayResourcesWithoutAutoscalingDoesNotDeleteMissingAutoscaler(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if _, isAutoscaler := obj.(*autoscalingv2.HorizontalPodAutoscaler); isAutoscaler {
+				t.Errorf("unexpected delete of horizontal pod autoscaler %s", obj.GetName())
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+	}).Build()
+
+	require.NoError(t, ApplyGatewayResources(ctx, fakeClient, createGatewayConfig(false, false)))
+}
+
+func TestApplyGatewayResourcesWithAutoscaling(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+
+	gatewayConfig := createGatewayConfig(false, false)
+	autoscaledConfig := gatewayConfig.WithScaling(GatewayScalingConfig{
+		ResourceRequirementsMultiplier: 1,
+		Autoscaling: &GatewayAutoscalingConfig{
+			MinReplicas:                       2,
+			MaxReplicas:                       5,
+			TargetCPUUtilizationPercentage:    80,
+			TargetMemoryUtilizationPercentage: 70,
+		},
+	})
+	err := ApplyGatewayResources(ctx, client, autoscaledConfig)
+	require.NoError(t, err)
+
+	t.Run("should create horizontal pod autoscaler", func(t *testing.T) {
+		var hpa autoscalingv2.HorizontalPodAutoscaler
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &hpa))
+
+		require.Equal(t, map[string]string{"app.kubernetes.io/name": name}, hpa.Labels)
+		require.Equal(t, autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: name}, hpa.Spec.ScaleTargetRef)
+		require.Equal(t, ptr.To(int32(2)), hpa.Spec.MinReplicas)
+		require.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		require.Len(t, hpa.Spec.Metrics, 2)
+		require.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+		require.Equal(t, ptr.To(int32(80)), hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+		require.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[1].Resource.Name)
+		require.Equal(t, ptr.To(int32(70)), hpa.Spec.Metrics[1].Resource.Target.AverageUtilization)
+	})
+
+	t.Run("should keep replicas set by the autoscaler", func(t *testing.T) {
+		var dep appsv1.Deployment
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+		dep.Spec.Replicas = ptr.To(int32(4))
+		require.NoError(t, client.Update(ctx, &dep))
+
+		require.NoError(t, ApplyGatewayResources(ctx, client, autoscaledConfig))
+
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+		require.Equal(t, ptr.To(int32(4)), dep.Spec.Replicas)
+	})
+
+	t.Run("should delete horizontal pod autoscaler when switching to static scaling", func(t *testing.T) {
+		require.NoError(t, ApplyGatewayResources(ctx, client, gatewayConfig))
+
+		var hpa autoscalingv2.HorizontalPodAutoscaler
+		err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &hpa)
+		require.True(t, apierrors.IsNotFound(err))
+
+		var dep appsv1.Deployment
+		require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+		require.Equal(t, ptr.To(replicas), dep.Spec.Replicas)
+	})
+}
+
+func TestApplyGatewayResourcesWithResourceOverrides(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().Build()
+
+	gatewayConfig := createGatewayConfig(false, false).WithScaling(GatewayScalingConfig{Replicas: replicas, ResourceRequirementsMultiplier: 2}).
+		WithResourceOverrides(GatewayResourceOverrides{
+			Base:        commonresources.ResourceOverrides{CPULimit: ptr.To(resource.MustParse("1"))},
+			PerPipeline: commonresources.ResourceOverrides{MemoryLimit: ptr.To(resource.MustParse("500Mi"))},
+		})
+	err := ApplyGatewayResources(ctx, client, gatewayConfig)
+	require.NoError(t, err)
+
+	var dep appsv1.Deployment
+	require.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &dep))
+
+	expectedMemoryLimit := baseMemoryLimit.DeepCopy()
+	expectedMemoryLimit.Add(resource.MustParse("1000Mi"))
+
+	resources := dep.Spec.Template.Spec.Containers[0].Resources
+	require.True(t, resources.Limits.Cpu().Equal(resource.MustParse("1")), "cpu limit should be overridden")
+	require.True(t, resources.Limits.Memory().Equal(expectedMemoryLimit), "memory limit should be the base value plus the overridden per-pipeline value for each pipeline")
+	require.True(t, resources.Requests.Cpu().Equal(baseCPURequest), "cpu request should not be overridden")
+}
+
 func TestApplyGatewayResourcesWithIstioEnabled(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
@@ -326,6 +613,49 @@ func TestApplyGatewayResourcesWithIstioEnabled(t *testing.T) {
 	})
 }
 
+func TestDeleteGatewayResources(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, istiosecurityclientv1beta.AddToScheme(scheme))
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	gatewayConfig := createGatewayConfig(true, true)
+	gatewayConfig.LoadBalancingServiceName = loadBalancingServiceName
+	gatewayConfig = gatewayConfig.WithLoadBalancing(true)
+	require.NoError(t, ApplyGatewayResources(ctx, fakeClient, gatewayConfig))
+
+	require.NoError(t, DeleteGatewayResources(ctx, fakeClient, gatewayConfig))
+
+	t.Run("should delete all resources", func(t *testing.T) {
+		objects := []struct {
+			name string
+			obj  client.Object
+		}{
+			{name, &appsv1.Deployment{}},
+			{otlpServiceName, &corev1.Service{}},
+			{loadBalancingServiceName, &corev1.Service{}},
+			{name + "-metrics", &corev1.Service{}},
+			{name, &corev1.ConfigMap{}},
+			{name, &corev1.Secret{}},
+			{name, &corev1.ServiceAccount{}},
+			{name, &rbacv1.ClusterRole{}},
+			{name, &rbacv1.ClusterRoleBinding{}},
+			{name, &networkingv1.NetworkPolicy{}},
+			{name, &istiosecurityclientv1beta.PeerAuthentication{}},
+		}
+
+		for _, o := range objects {
+			err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: o.name}, o.obj)
+			require.True(t, apierrors.IsNotFound(err), "%T %s should be deleted", o.obj, o.name)
+		}
+	})
+
+	t.Run("should succeed if nothing exists", func(t *testing.T) {
+		require.NoError(t, DeleteGatewayResources(ctx, fakeClient, gatewayConfig))
+	})
+}
+
 func TestApplyGatewayResourcesWithSelfMonEnabled(t *testing.T) {
 	ctx := context.Background()
 	scheme := runtime.NewScheme()
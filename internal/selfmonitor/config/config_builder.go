@@ -96,10 +96,23 @@ func makeScrapeConfig(scrapeNamespace string) []ScrapeConfig {
 					Action:       Keep,
 					Regex:        scrapableMetricsRegex(),
 				},
+				// The tap exporters and outputs of pipelines only send samples to the telemetry manager for debugging, so their metrics must not influence the pipeline health.
+				// Their IDs contain a dot, which cannot be part of the pipeline name extracted below.
+				{
+					SourceLabels: []string{"__name__", "exporter"},
+					Action:       Drop,
+					Regex:        "otelcol_.+;[^/]+/tap\\..+",
+				},
+				{
+					SourceLabels: []string{"__name__", "name"},
+					Action:       Drop,
+					Regex:        "fluentbit_.+;tap\\..+",
+				},
 				// The following relabel configs add an artificial pipeline_name label to the Fluent Bit and OTel Collector metrics to simplify pipeline matching
 				// For Fluent Bit metrics, the pipeline_name is based on the name label. Note that a regex group matching Kubernetes resource names (alphanumerical chars and hyphens) is used to extract the pipeline name.
 				// It allows to filter out timeseries with technical names (storage_backend.0, tail.0, etc.)
-				// For OTel Collector metrics, the pipeline_name is extracted from the exporter label, which has the format [otlp|otlphttp]/<pipeline_name>
+				// For OTel Collector metrics, the pipeline_name is extracted from the exporter label, which has the format [otlp|otlphttp]/<pipeline_name>[/<output_name>]
+				// Exporters of additional pipeline outputs get an additional output_name label, so that each output is evaluated separately
 				{
 					SourceLabels: []string{"__name__", "name"},
 					Action:       Replace,
@@ -109,9 +122,15 @@ func makeScrapeConfig(scrapeNamespace string) []ScrapeConfig {
 				{
 					SourceLabels: []string{"__name__", "exporter"},
 					Action:       Replace,
-					Regex:        "otelcol_.+;.+/([a-zA-Z0-9-]+)",
+					Regex:        "otelcol_.+;[^/]+/([a-zA-Z0-9-]+)(/[a-zA-Z0-9-]+)?",
 					TargetLabel:  "pipeline_name",
 				},
+				{
+					SourceLabels: []string{"__name__", "exporter"},
+					Action:       Replace,
+					Regex:        "otelcol_.+;[^/]+/[a-zA-Z0-9-]+/([a-zA-Z0-9-]+)",
+					TargetLabel:  "output_name",
+				},
 			},
 			KubernetesDiscoveryConfigs: []KubernetesDiscoveryConfig{{
 				Role:       RoleEndpoints,
@@ -124,16 +143,16 @@ func makeScrapeConfig(scrapeNamespace string) []ScrapeConfig {
 func scrapableMetricsRegex() string {
 	fluentBitMetrics := []string{
 		metricFluentBitOutputProcBytesTotal,
+		metricFluentBitOutputProcRecordsTotal,
 		metricFluentBitOutputDroppedRecordsTotal,
 		metricFluentBitInputBytesTotal,
 		metricFluentBitBufferUsageBytes,
 	}
 
+	// OTel Collector metrics are suffixed with the data type, for example, otelcol_exporter_sent_spans
 	otelCollectorMetrics := []string{
 		metricOtelCollectorExporterSent,
 		metricOtelCollectorExporterSendFailed,
-		metricOtelCollectorExporterQueueSize,
-		metricOtelCollectorExporterQueueCapacity,
 		metricOtelCollectorExporterEnqueueFailed,
 		metricOtelCollectorReceiverRefused,
 	}
@@ -142,5 +161,20 @@ func scrapableMetricsRegex() string {
 		otelCollectorMetrics[i] += "_.*"
 	}
 
-	return strings.Join(append(fluentBitMetrics, otelCollectorMetrics...), "|")
+	// The sending queue metrics are not suffixed, because the queue is shared by all data types of an exporter.
+	// Both are needed to relate the queue usage to the capacity, which can be configured per output.
+	otelCollectorQueueMetrics := []string{
+		metricOtelCollectorExporterQueueSize,
+		metricOtelCollectorExporterQueueCapacity,
+	}
+
+	// The scrape health metrics are exposed by the metric agent for its Prometheus scrape targets
+	scrapeHealthMetrics := []string{
+		metricScrapeHealthTargetsDown,
+		metricScrapeHealthTargetsSampleLimitExceeded,
+	}
+
+	metrics := append(fluentBitMetrics, otelCollectorMetrics...)
+	metrics = append(metrics, otelCollectorQueueMetrics...)
+	return strings.Join(append(metrics, scrapeHealthMetrics...), "|")
 }
@@ -4,9 +4,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-)
+	"time"
 
-const defaultRateDuration = "5m"
+	"github.com/prometheus/common/model"
+)
 
 type exprBuilder struct {
 	expr string
@@ -31,13 +32,13 @@ func instant(metric string, selectors ...labelSelector) *exprBuilder {
 	return eb
 }
 
-func rate(metric string, selectors ...labelSelector) *exprBuilder {
+func rate(metric string, window time.Duration, selectors ...labelSelector) *exprBuilder {
 	for _, s := range selectors {
 		metric = s(metric)
 	}
 
 	eb := &exprBuilder{
-		expr: fmt.Sprintf("rate(%s[%s])", metric, defaultRateDuration),
+		expr: fmt.Sprintf("rate(%s[%s])", metric, model.Duration(window)),
 	}
 	return eb
 }
@@ -64,6 +65,11 @@ func (eb *exprBuilder) maxBy(labels ...string) *exprBuilder {
 	return eb
 }
 
+func (eb *exprBuilder) sum() *exprBuilder {
+	eb.expr = fmt.Sprintf("sum(%s)", eb.expr)
+	return eb
+}
+
 func (eb *exprBuilder) greaterThan(value float64) *exprBuilder {
 	eb.expr = fmt.Sprintf("%s > %s", eb.expr, strconv.FormatFloat(value, 'f', -1, 64))
 	return eb
@@ -5,15 +5,14 @@ const (
 	fluentBitSidecarMetricsServiceName = "telemetry-fluent-bit-exporter-metrics"
 
 	metricFluentBitOutputProcBytesTotal      = "fluentbit_output_proc_bytes_total"
+	metricFluentBitOutputProcRecordsTotal    = "fluentbit_output_proc_records_total"
 	metricFluentBitInputBytesTotal           = "fluentbit_input_bytes_total"
 	metricFluentBitOutputDroppedRecordsTotal = "fluentbit_output_dropped_records_total"
 	metricFluentBitBufferUsageBytes          = "telemetry_fsbuffer_usage_bytes"
-
-	bufferUsage300MB = 300000000
-	bufferUsage900MB = 900000000
 )
 
 type fluentBitRuleBuilder struct {
+	cfg RulesConfig
 }
 
 func (rb fluentBitRuleBuilder) rules() []Rule {
@@ -29,7 +28,8 @@ func (rb fluentBitRuleBuilder) rules() []Rule {
 func (rb fluentBitRuleBuilder) exporterSentRule() Rule {
 	return Rule{
 		Alert: rb.namePrefix() + RuleNameLogAgentExporterSentLogs,
-		Expr: rate(metricFluentBitOutputProcBytesTotal, selectService(fluentBitMetricsServiceName)).
+		For:   rb.cfg.For,
+		Expr: rate(metricFluentBitOutputProcBytesTotal, rb.cfg.RateWindow, selectService(fluentBitMetricsServiceName)).
 			sumBy(labelPipelineName).
 			greaterThan(0).
 			build(),
@@ -39,7 +39,8 @@ func (rb fluentBitRuleBuilder) exporterSentRule() Rule {
 func (rb fluentBitRuleBuilder) receiverReadRule() Rule {
 	return Rule{
 		Alert: rb.namePrefix() + RuleNameLogAgentReceiverReadLogs,
-		Expr: rate(metricFluentBitInputBytesTotal, selectService(fluentBitMetricsServiceName)).
+		For:   rb.cfg.For,
+		Expr: rate(metricFluentBitInputBytesTotal, rb.cfg.RateWindow, selectService(fluentBitMetricsServiceName)).
 			sumBy(labelPipelineName).
 			greaterThan(0).
 			build(),
@@ -49,7 +50,8 @@ func (rb fluentBitRuleBuilder) receiverReadRule() Rule {
 func (rb fluentBitRuleBuilder) exporterDroppedRule() Rule {
 	return Rule{
 		Alert: rb.namePrefix() + RuleNameLogAgentExporterDroppedLogs,
-		Expr: rate(metricFluentBitOutputDroppedRecordsTotal, selectService(fluentBitMetricsServiceName)).
+		For:   rb.cfg.For,
+		Expr: rate(metricFluentBitOutputDroppedRecordsTotal, rb.cfg.RateWindow, selectService(fluentBitMetricsServiceName)).
 			sumBy(labelPipelineName).
 			greaterThan(0).
 			build(),
@@ -59,8 +61,9 @@ func (rb fluentBitRuleBuilder) exporterDroppedRule() Rule {
 func (rb fluentBitRuleBuilder) bufferInUseRule() Rule {
 	return Rule{
 		Alert: rb.namePrefix() + RuleNameLogAgentBufferInUse,
+		For:   rb.cfg.LogAgentBufferInUse.For,
 		Expr: instant(metricFluentBitBufferUsageBytes, selectService(fluentBitSidecarMetricsServiceName)).
-			greaterThan(bufferUsage300MB).
+			greaterThan(rb.bufferThreshold(rb.cfg.LogAgentBufferInUse)).
 			build(),
 	}
 }
@@ -68,12 +71,18 @@ func (rb fluentBitRuleBuilder) bufferInUseRule() Rule {
 func (rb fluentBitRuleBuilder) bufferFullRule() Rule {
 	return Rule{
 		Alert: rb.namePrefix() + RuleNameLogAgentBufferFull,
+		For:   rb.cfg.LogAgentBufferFull.For,
 		Expr: instant(metricFluentBitBufferUsageBytes, selectService(fluentBitSidecarMetricsServiceName)).
-			greaterThan(bufferUsage900MB).
+			greaterThan(rb.bufferThreshold(rb.cfg.LogAgentBufferFull)).
 			build(),
 	}
 }
 
+// bufferThreshold returns the buffer usage in bytes at which the rule fires.
+func (rb fluentBitRuleBuilder) bufferThreshold(rule ThresholdRuleConfig) float64 {
+	return float64(rb.cfg.FluentBitFsBufferLimit * int64(rule.ThresholdPercent) / 100)
+}
+
 func (rb fluentBitRuleBuilder) namePrefix() string {
 	return ruleNamePrefix(typeLogPipeline)
 }
@@ -0,0 +1,90 @@
+package config
+
+const (
+	metricAgentMetricsServiceName      = "telemetry-metric-agent-metrics"
+	metricAgentScrapeHealthServiceName = "telemetry-metric-agent-scrape-health"
+
+	// The metric agent exposes the number of its Prometheus scrape targets that are down or exceed the sample limit per job with the scrape_health prefix
+	metricScrapeHealthTargetsDown                = "scrape_health_targets_down"
+	metricScrapeHealthTargetsSampleLimitExceeded = "scrape_health_targets_sample_limit_exceeded"
+
+	// The job label of a scrape target is renamed by the self-monitor, because it clashes with the job label of the self-monitor's own target
+	labelExportedJob = "exported_job"
+)
+
+// metricAgentRuleBuilder builds the rules for the metric agent. The agent exports the data of all pipelines with a single exporter,
+// so the alerts do not have a pipeline_name label and apply to all metric pipelines.
+type metricAgentRuleBuilder struct {
+	cfg RulesConfig
+}
+
+func (rb metricAgentRuleBuilder) rules() []Rule {
+	return []Rule{
+		rb.exporterSentRule(),
+		rb.exporterDroppedRule(),
+		rb.exporterEnqueueFailedRule(),
+		rb.scrapeTargetDownRule(),
+		rb.scrapeSampleLimitExceededRule(),
+	}
+}
+
+func (rb metricAgentRuleBuilder) exporterSentRule() Rule {
+	return Rule{
+		Alert: rb.namePrefix() + RuleNameMetricAgentExporterSentData,
+		For:   rb.cfg.For,
+		Expr: rate(metricOtelCollectorExporterSent+"_metric_points", rb.cfg.RateWindow, selectService(metricAgentMetricsServiceName)).
+			sum().
+			greaterThan(0).
+			build(),
+	}
+}
+
+func (rb metricAgentRuleBuilder) exporterDroppedRule() Rule {
+	return Rule{
+		Alert: rb.namePrefix() + RuleNameMetricAgentExporterDroppedData,
+		For:   rb.cfg.For,
+		Expr: rate(metricOtelCollectorExporterSendFailed+"_metric_points", rb.cfg.RateWindow, selectService(metricAgentMetricsServiceName)).
+			sum().
+			greaterThan(0).
+			build(),
+	}
+}
+
+func (rb metricAgentRuleBuilder) exporterEnqueueFailedRule() Rule {
+	return Rule{
+		Alert: rb.namePrefix() + RuleNameMetricAgentExporterEnqueueFailed,
+		For:   rb.cfg.For,
+		Expr: rate(metricOtelCollectorExporterEnqueueFailed+"_metric_points", rb.cfg.RateWindow, selectService(metricAgentMetricsServiceName)).
+			sum().
+			greaterThan(0).
+			build(),
+	}
+}
+
+func (rb metricAgentRuleBuilder) scrapeTargetDownRule() Rule {
+	return Rule{
+		Alert: rb.namePrefix() + RuleNameMetricAgentScrapeTargetDown,
+		For:   rb.cfg.For,
+		Expr: instant(metricScrapeHealthTargetsDown, selectService(metricAgentScrapeHealthServiceName)).
+			sumBy(labelExportedJob).
+			greaterThan(0).
+			build(),
+	}
+}
+
+// scrapeSampleLimitExceededRule detects targets whose scrape fails although they returned samples.
+// Prometheus discards the whole scrape of a target that exceeds the sample limit, so the metric agent counts these targets separately.
+func (rb metricAgentRuleBuilder) scrapeSampleLimitExceededRule() Rule {
+	return Rule{
+		Alert: rb.namePrefix() + RuleNameMetricAgentScrapeSampleLimitExceeded,
+		For:   rb.cfg.For,
+		Expr: instant(metricScrapeHealthTargetsSampleLimitExceeded, selectService(metricAgentScrapeHealthServiceName)).
+			sumBy(labelExportedJob).
+			greaterThan(0).
+			build(),
+	}
+}
+
+func (rb metricAgentRuleBuilder) namePrefix() string {
+	return ruleNamePrefix(typeMetricPipeline)
+}
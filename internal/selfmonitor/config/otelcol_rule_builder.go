@@ -5,6 +5,14 @@ import (
 )
 
 const (
+	metricGatewayMetricsServiceName = "telemetry-metric-gateway-metrics"
+	traceGatewayMetricsServiceName  = "telemetry-trace-collector-metrics"
+	logGatewayMetricsServiceName    = "telemetry-log-gateway-metrics"
+
+	dataTypeMetricPoints = "metric_points"
+	dataTypeSpans        = "spans"
+	dataTypeLogRecords   = "log_records"
+
 	metricOtelCollectorExporterSent          = "otelcol_exporter_sent"
 	metricOtelCollectorExporterSendFailed    = "otelcol_exporter_send_failed"
 	metricOtelCollectorExporterQueueSize     = "otelcol_exporter_queue_size"
@@ -17,6 +25,7 @@ type otelCollectorRuleBuilder struct {
 	serviceName string
 	dataType    string
 	namePrefix  string
+	cfg         RulesConfig
 }
 
 func (rb otelCollectorRuleBuilder) rules() []Rule {
@@ -37,8 +46,9 @@ func (rb otelCollectorRuleBuilder) exporterSentRule() Rule {
 	metric := rb.formatMetricName(metricOtelCollectorExporterSent)
 	return Rule{
 		Alert: rb.namePrefix + RuleNameGatewayExporterSentData,
-		Expr: rate(metric, selectService(rb.serviceName)).
-			sumBy(labelPipelineName).
+		For:   rb.cfg.For,
+		Expr: rate(metric, rb.cfg.RateWindow, selectService(rb.serviceName)).
+			sumBy(labelPipelineName, labelOutputName).
 			greaterThan(0).
 			build(),
 	}
@@ -48,8 +58,9 @@ func (rb otelCollectorRuleBuilder) exporterDroppedRule() Rule {
 	metric := rb.formatMetricName(metricOtelCollectorExporterSendFailed)
 	return Rule{
 		Alert: rb.namePrefix + RuleNameGatewayExporterDroppedData,
-		Expr: rate(metric, selectService(rb.serviceName)).
-			sumBy(labelPipelineName).
+		For:   rb.cfg.For,
+		Expr: rate(metric, rb.cfg.RateWindow, selectService(rb.serviceName)).
+			sumBy(labelPipelineName, labelOutputName).
 			greaterThan(0).
 			build(),
 	}
@@ -58,9 +69,10 @@ func (rb otelCollectorRuleBuilder) exporterDroppedRule() Rule {
 func (rb otelCollectorRuleBuilder) exporterQueueAlmostFullRule() Rule {
 	return Rule{
 		Alert: rb.namePrefix + RuleNameGatewayExporterQueueAlmostFull,
+		For:   rb.cfg.GatewayExporterQueueAlmostFull.For,
 		Expr: div(metricOtelCollectorExporterQueueSize, metricOtelCollectorExporterQueueCapacity, selectService(rb.serviceName)).
-			maxBy(labelPipelineName).
-			greaterThan(0.8).
+			maxBy(labelPipelineName, labelOutputName).
+			greaterThan(float64(rb.cfg.GatewayExporterQueueAlmostFull.ThresholdPercent) / 100).
 			build(),
 	}
 }
@@ -69,8 +81,9 @@ func (rb otelCollectorRuleBuilder) exporterEnqueueFailedRule() Rule {
 	metric := rb.formatMetricName(metricOtelCollectorExporterEnqueueFailed)
 	return Rule{
 		Alert: rb.namePrefix + RuleNameGatewayExporterEnqueueFailed,
-		Expr: rate(metric, selectService(rb.serviceName)).
-			sumBy(labelPipelineName).
+		For:   rb.cfg.For,
+		Expr: rate(metric, rb.cfg.RateWindow, selectService(rb.serviceName)).
+			sumBy(labelPipelineName, labelOutputName).
 			greaterThan(0).
 			build(),
 	}
@@ -80,7 +93,8 @@ func (rb otelCollectorRuleBuilder) receiverRefusedRule() Rule {
 	metric := rb.formatMetricName(metricOtelCollectorReceiverRefused)
 	return Rule{
 		Alert: rb.namePrefix + RuleNameGatewayReceiverRefusedData,
-		Expr: rate(metric, selectService(rb.serviceName)).
+		For:   rb.cfg.For,
+		Expr: rate(metric, rb.cfg.RateWindow, selectService(rb.serviceName)).
 			sumBy(labelReceiver).
 			greaterThan(0).
 			build(),
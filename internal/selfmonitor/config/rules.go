@@ -8,13 +8,20 @@ import (
 )
 
 const (
-	// OTEL Collector rule names. Note that the actual full names will be prefixed with Metric or Trace
+	// OTEL Collector rule names. Note that the actual full names will be prefixed with Metric, Trace, or Log
 	RuleNameGatewayExporterSentData        = "GatewayExporterSentData"
 	RuleNameGatewayExporterDroppedData     = "GatewayExporterDroppedData"
 	RuleNameGatewayExporterQueueAlmostFull = "GatewayExporterQueueAlmostFull"
 	RuleNameGatewayExporterEnqueueFailed   = "GatewayExporterEnqueueFailed"
 	RuleNameGatewayReceiverRefusedData     = "GatewayReceiverRefusedData"
 
+	// Metric agent rule names. Note that the actual full names will be prefixed with Metric
+	RuleNameMetricAgentExporterSentData          = "AgentExporterSentData"
+	RuleNameMetricAgentExporterDroppedData       = "AgentExporterDroppedData"
+	RuleNameMetricAgentExporterEnqueueFailed     = "AgentExporterEnqueueFailed"
+	RuleNameMetricAgentScrapeTargetDown          = "AgentScrapeTargetDown"
+	RuleNameMetricAgentScrapeSampleLimitExceeded = "AgentScrapeSampleLimitExceeded"
+
 	// Fluent Bit rule names. Note that the actual full names will be prefixed with Log
 	RuleNameLogAgentExporterSentLogs    = "AgentExporterSentLogs"
 	RuleNameLogAgentReceiverReadLogs    = "AgentReceiverReadLogs"
@@ -27,7 +34,8 @@ const (
 	labelPipelineName = "pipeline_name"
 
 	// OTel Collector rule labels
-	labelReceiver = "receiver"
+	labelReceiver   = "receiver"
+	labelOutputName = "output_name"
 )
 
 // RuleGroups is a set of rule groups that are typically exposed in a file.
@@ -58,26 +66,96 @@ const (
 	typeLogPipeline
 )
 
-func MakeRules() RuleGroups {
+const (
+	defaultFluentBitFsBufferLimit                  = 1 << 30
+	defaultRateWindow                              = 5 * time.Minute
+	defaultLogAgentBufferInUseThresholdPercent     = 30
+	defaultLogAgentBufferFullThresholdPercent      = 90
+	defaultGatewayExporterQueueAlmostFullThreshold = 80
+)
+
+// RulesConfig configures the alerting rules. Fields with a zero value keep their defaults.
+type RulesConfig struct {
+	// FluentBitFsBufferLimit is the filesystem buffer limit of Fluent Bit in bytes. The thresholds of the buffer rules are percentages of it.
+	FluentBitFsBufferLimit int64
+
+	// RateWindow is the time window over which the rates of the rules are computed.
+	RateWindow time.Duration
+
+	// For is the time for which the condition of a rule must be met before the alert fires.
+	For time.Duration
+
+	LogAgentBufferInUse            ThresholdRuleConfig
+	LogAgentBufferFull             ThresholdRuleConfig
+	GatewayExporterQueueAlmostFull ThresholdRuleConfig
+}
+
+// ThresholdRuleConfig configures a rule that fires when a usage exceeds a percentage of the capacity.
+type ThresholdRuleConfig struct {
+	ThresholdPercent int
+
+	// For overrides the For duration of RulesConfig for the rule.
+	For time.Duration
+}
+
+func (c RulesConfig) withDefaults() RulesConfig {
+	if c.FluentBitFsBufferLimit <= 0 {
+		c.FluentBitFsBufferLimit = defaultFluentBitFsBufferLimit
+	}
+	if c.RateWindow <= 0 {
+		c.RateWindow = defaultRateWindow
+	}
+	c.LogAgentBufferInUse = c.LogAgentBufferInUse.withDefaults(defaultLogAgentBufferInUseThresholdPercent, c.For)
+	c.LogAgentBufferFull = c.LogAgentBufferFull.withDefaults(defaultLogAgentBufferFullThresholdPercent, c.For)
+	c.GatewayExporterQueueAlmostFull = c.GatewayExporterQueueAlmostFull.withDefaults(defaultGatewayExporterQueueAlmostFullThreshold, c.For)
+	return c
+}
+
+func (c ThresholdRuleConfig) withDefaults(thresholdPercent int, forDuration time.Duration) ThresholdRuleConfig {
+	if c.ThresholdPercent <= 0 {
+		c.ThresholdPercent = thresholdPercent
+	}
+	if c.For <= 0 {
+		c.For = forDuration
+	}
+	return c
+}
+
+func MakeRules(cfg RulesConfig) RuleGroups {
 	var rules []Rule
+	cfg = cfg.withDefaults()
 
 	metricRuleBuilder := otelCollectorRuleBuilder{
-		dataType:    "metric_points",
-		serviceName: "telemetry-metric-gateway-metrics",
+		dataType:    dataTypeMetricPoints,
+		serviceName: metricGatewayMetricsServiceName,
 		namePrefix:  ruleNamePrefix(typeMetricPipeline),
+		cfg:         cfg,
 	}
 	rules = append(rules, metricRuleBuilder.rules()...)
 
 	traceRuleBuilder := otelCollectorRuleBuilder{
-		dataType:    "spans",
-		serviceName: "telemetry-trace-collector-metrics",
+		dataType:    dataTypeSpans,
+		serviceName: traceGatewayMetricsServiceName,
 		namePrefix:  ruleNamePrefix(typeTracePipeline),
+		cfg:         cfg,
 	}
 	rules = append(rules, traceRuleBuilder.rules()...)
 
-	logRuleBuilder := fluentBitRuleBuilder{}
+	logRuleBuilder := fluentBitRuleBuilder{cfg: cfg}
 	rules = append(rules, logRuleBuilder.rules()...)
 
+	// LogPipelines with OTLP output run in the log gateway. The Gateway infix of its rule names keeps them apart from the Fluent Bit (Agent) rules.
+	logGatewayRuleBuilder := otelCollectorRuleBuilder{
+		dataType:    dataTypeLogRecords,
+		serviceName: logGatewayMetricsServiceName,
+		namePrefix:  ruleNamePrefix(typeLogPipeline),
+		cfg:         cfg,
+	}
+	rules = append(rules, logGatewayRuleBuilder.rules()...)
+
+	metricAgentRuleBuilder := metricAgentRuleBuilder{cfg: cfg}
+	rules = append(rules, metricAgentRuleBuilder.rules()...)
+
 	return RuleGroups{
 		Groups: []RuleGroup{
 			{
@@ -123,6 +201,12 @@ func MatchesTracePipelineRule(labelSet map[string]string, unprefixedRuleName str
 	return matchesRule(labelSet, unprefixedRuleName, pipelineName, typeTracePipeline)
 }
 
+// OutputName returns the name of the additional pipeline output the given alert label set refers to.
+// It is empty for alerts referring to the primary output of a pipeline.
+func OutputName(labelSet map[string]string) string {
+	return labelSet[labelOutputName]
+}
+
 func matchesRule(labelSet map[string]string, unprefixedRuleName string, pipelineName string, t pipelineType) bool {
 	if !matchesRuleName(labelSet, unprefixedRuleName, t) {
 		return false
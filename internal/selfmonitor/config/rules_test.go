@@ -2,45 +2,46 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 func TestMakeRules(t *testing.T) {
-	rules := MakeRules()
+	rules := MakeRules(RulesConfig{})
 
 	require.Len(t, rules.Groups, 1)
 
 	ruleGroup := rules.Groups[0]
 	require.Equal(t, "default", ruleGroup.Name)
 
-	require.Len(t, ruleGroup.Rules, 15)
+	require.Len(t, ruleGroup.Rules, 25)
 	require.Equal(t, "MetricGatewayExporterSentData", ruleGroup.Rules[0].Alert)
-	require.Equal(t, "sum by (pipeline_name) (rate(otelcol_exporter_sent_metric_points{service=\"telemetry-metric-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[0].Expr)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_sent_metric_points{service=\"telemetry-metric-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[0].Expr)
 
 	require.Equal(t, "MetricGatewayExporterDroppedData", ruleGroup.Rules[1].Alert)
-	require.Equal(t, "sum by (pipeline_name) (rate(otelcol_exporter_send_failed_metric_points{service=\"telemetry-metric-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[1].Expr)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_send_failed_metric_points{service=\"telemetry-metric-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[1].Expr)
 
 	require.Equal(t, "MetricGatewayExporterQueueAlmostFull", ruleGroup.Rules[2].Alert)
-	require.Equal(t, "max by (pipeline_name) (otelcol_exporter_queue_size{service=\"telemetry-metric-gateway-metrics\"} / otelcol_exporter_queue_capacity{service=\"telemetry-metric-gateway-metrics\"}) > 0.8", ruleGroup.Rules[2].Expr)
+	require.Equal(t, "max by (pipeline_name,output_name) (otelcol_exporter_queue_size{service=\"telemetry-metric-gateway-metrics\"} / otelcol_exporter_queue_capacity{service=\"telemetry-metric-gateway-metrics\"}) > 0.8", ruleGroup.Rules[2].Expr)
 
 	require.Equal(t, "MetricGatewayExporterEnqueueFailed", ruleGroup.Rules[3].Alert)
-	require.Equal(t, "sum by (pipeline_name) (rate(otelcol_exporter_enqueue_failed_metric_points{service=\"telemetry-metric-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[3].Expr)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_enqueue_failed_metric_points{service=\"telemetry-metric-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[3].Expr)
 
 	require.Equal(t, "MetricGatewayReceiverRefusedData", ruleGroup.Rules[4].Alert)
 	require.Equal(t, "sum by (receiver) (rate(otelcol_receiver_refused_metric_points{service=\"telemetry-metric-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[4].Expr)
 
 	require.Equal(t, "TraceGatewayExporterSentData", ruleGroup.Rules[5].Alert)
-	require.Equal(t, "sum by (pipeline_name) (rate(otelcol_exporter_sent_spans{service=\"telemetry-trace-collector-metrics\"}[5m])) > 0", ruleGroup.Rules[5].Expr)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_sent_spans{service=\"telemetry-trace-collector-metrics\"}[5m])) > 0", ruleGroup.Rules[5].Expr)
 
 	require.Equal(t, "TraceGatewayExporterDroppedData", ruleGroup.Rules[6].Alert)
-	require.Equal(t, "sum by (pipeline_name) (rate(otelcol_exporter_send_failed_spans{service=\"telemetry-trace-collector-metrics\"}[5m])) > 0", ruleGroup.Rules[6].Expr)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_send_failed_spans{service=\"telemetry-trace-collector-metrics\"}[5m])) > 0", ruleGroup.Rules[6].Expr)
 
 	require.Equal(t, "TraceGatewayExporterQueueAlmostFull", ruleGroup.Rules[7].Alert)
-	require.Equal(t, "max by (pipeline_name) (otelcol_exporter_queue_size{service=\"telemetry-trace-collector-metrics\"} / otelcol_exporter_queue_capacity{service=\"telemetry-trace-collector-metrics\"}) > 0.8", ruleGroup.Rules[7].Expr)
+	require.Equal(t, "max by (pipeline_name,output_name) (otelcol_exporter_queue_size{service=\"telemetry-trace-collector-metrics\"} / otelcol_exporter_queue_capacity{service=\"telemetry-trace-collector-metrics\"}) > 0.8", ruleGroup.Rules[7].Expr)
 
 	require.Equal(t, "TraceGatewayExporterEnqueueFailed", ruleGroup.Rules[8].Alert)
-	require.Equal(t, "sum by (pipeline_name) (rate(otelcol_exporter_enqueue_failed_spans{service=\"telemetry-trace-collector-metrics\"}[5m])) > 0", ruleGroup.Rules[8].Expr)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_enqueue_failed_spans{service=\"telemetry-trace-collector-metrics\"}[5m])) > 0", ruleGroup.Rules[8].Expr)
 
 	require.Equal(t, "TraceGatewayReceiverRefusedData", ruleGroup.Rules[9].Alert)
 	require.Equal(t, "sum by (receiver) (rate(otelcol_receiver_refused_spans{service=\"telemetry-trace-collector-metrics\"}[5m])) > 0", ruleGroup.Rules[9].Expr)
@@ -55,10 +56,71 @@ func TestMakeRules(t *testing.T) {
 	require.Equal(t, "sum by (pipeline_name) (rate(fluentbit_output_dropped_records_total{service=\"telemetry-fluent-bit-metrics\"}[5m])) > 0", ruleGroup.Rules[12].Expr)
 
 	require.Equal(t, "LogAgentBufferInUse", ruleGroup.Rules[13].Alert)
-	require.Equal(t, "telemetry_fsbuffer_usage_bytes{service=\"telemetry-fluent-bit-exporter-metrics\"} > 300000000", ruleGroup.Rules[13].Expr)
+	require.Equal(t, "telemetry_fsbuffer_usage_bytes{service=\"telemetry-fluent-bit-exporter-metrics\"} > 322122547", ruleGroup.Rules[13].Expr)
 
 	require.Equal(t, "LogAgentBufferFull", ruleGroup.Rules[14].Alert)
-	require.Equal(t, "telemetry_fsbuffer_usage_bytes{service=\"telemetry-fluent-bit-exporter-metrics\"} > 900000000", ruleGroup.Rules[14].Expr)
+	require.Equal(t, "telemetry_fsbuffer_usage_bytes{service=\"telemetry-fluent-bit-exporter-metrics\"} > 966367641", ruleGroup.Rules[14].Expr)
+
+	require.Equal(t, "LogGatewayExporterSentData", ruleGroup.Rules[15].Alert)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_sent_log_records{service=\"telemetry-log-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[15].Expr)
+
+	require.Equal(t, "LogGatewayExporterDroppedData", ruleGroup.Rules[16].Alert)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_send_failed_log_records{service=\"telemetry-log-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[16].Expr)
+
+	require.Equal(t, "LogGatewayExporterQueueAlmostFull", ruleGroup.Rules[17].Alert)
+	require.Equal(t, "max by (pipeline_name,output_name) (otelcol_exporter_queue_size{service=\"telemetry-log-gateway-metrics\"} / otelcol_exporter_queue_capacity{service=\"telemetry-log-gateway-metrics\"}) > 0.8", ruleGroup.Rules[17].Expr)
+
+	require.Equal(t, "LogGatewayExporterEnqueueFailed", ruleGroup.Rules[18].Alert)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_enqueue_failed_log_records{service=\"telemetry-log-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[18].Expr)
+
+	require.Equal(t, "LogGatewayReceiverRefusedData", ruleGroup.Rules[19].Alert)
+	require.Equal(t, "sum by (receiver) (rate(otelcol_receiver_refused_log_records{service=\"telemetry-log-gateway-metrics\"}[5m])) > 0", ruleGroup.Rules[19].Expr)
+
+	require.Equal(t, "MetricAgentExporterSentData", ruleGroup.Rules[20].Alert)
+	require.Equal(t, "sum(rate(otelcol_exporter_sent_metric_points{service=\"telemetry-metric-agent-metrics\"}[5m])) > 0", ruleGroup.Rules[20].Expr)
+
+	require.Equal(t, "MetricAgentExporterDroppedData", ruleGroup.Rules[21].Alert)
+	require.Equal(t, "sum(rate(otelcol_exporter_send_failed_metric_points{service=\"telemetry-metric-agent-metrics\"}[5m])) > 0", ruleGroup.Rules[21].Expr)
+
+	require.Equal(t, "MetricAgentExporterEnqueueFailed", ruleGroup.Rules[22].Alert)
+	require.Equal(t, "sum(rate(otelcol_exporter_enqueue_failed_metric_points{service=\"telemetry-metric-agent-metrics\"}[5m])) > 0", ruleGroup.Rules[22].Expr)
+
+	require.Equal(t, "MetricAgentScrapeTargetDown", ruleGroup.Rules[23].Alert)
+	require.Equal(t, "sum by (exported_job) (scrape_health_targets_down{service=\"telemetry-metric-agent-scrape-health\"}) > 0", ruleGroup.Rules[23].Expr)
+
+	require.Equal(t, "MetricAgentScrapeSampleLimitExceeded", ruleGroup.Rules[24].Alert)
+	require.Equal(t, "sum by (exported_job) (scrape_health_targets_sample_limit_exceeded{service=\"telemetry-metric-agent-scrape-health\"}) > 0", ruleGroup.Rules[24].Expr)
+}
+
+func TestMakeRulesWithConfig(t *testing.T) {
+	rules := MakeRules(RulesConfig{
+		FluentBitFsBufferLimit: 2000000000,
+		RateWindow:             10 * time.Minute,
+		For:                    time.Minute,
+		LogAgentBufferFull:     ThresholdRuleConfig{ThresholdPercent: 75, For: 5 * time.Minute},
+		GatewayExporterQueueAlmostFull: ThresholdRuleConfig{
+			ThresholdPercent: 50,
+		},
+	})
+
+	ruleGroup := rules.Groups[0]
+	require.Len(t, ruleGroup.Rules, 25)
+
+	require.Equal(t, "MetricGatewayExporterSentData", ruleGroup.Rules[0].Alert)
+	require.Equal(t, "sum by (pipeline_name,output_name) (rate(otelcol_exporter_sent_metric_points{service=\"telemetry-metric-gateway-metrics\"}[10m])) > 0", ruleGroup.Rules[0].Expr)
+	require.Equal(t, time.Minute, ruleGroup.Rules[0].For)
+
+	require.Equal(t, "MetricGatewayExporterQueueAlmostFull", ruleGroup.Rules[2].Alert)
+	require.Equal(t, "max by (pipeline_name,output_name) (otelcol_exporter_queue_size{service=\"telemetry-metric-gateway-metrics\"} / otelcol_exporter_queue_capacity{service=\"telemetry-metric-gateway-metrics\"}) > 0.5", ruleGroup.Rules[2].Expr)
+	require.Equal(t, time.Minute, ruleGroup.Rules[2].For)
+
+	require.Equal(t, "LogAgentBufferInUse", ruleGroup.Rules[13].Alert)
+	require.Equal(t, "telemetry_fsbuffer_usage_bytes{service=\"telemetry-fluent-bit-exporter-metrics\"} > 600000000", ruleGroup.Rules[13].Expr)
+	require.Equal(t, time.Minute, ruleGroup.Rules[13].For)
+
+	require.Equal(t, "LogAgentBufferFull", ruleGroup.Rules[14].Alert)
+	require.Equal(t, "telemetry_fsbuffer_usage_bytes{service=\"telemetry-fluent-bit-exporter-metrics\"} > 1500000000", ruleGroup.Rules[14].Expr)
+	require.Equal(t, 5*time.Minute, ruleGroup.Rules[14].For)
 }
 
 func TestMatchesLogPipelineRule(t *testing.T) {
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// statsRateWindow is the window over which the data flow statistics are averaged
+const statsRateWindow = 5 * time.Minute
+
+// StatsQueries are the PromQL queries for the data flow statistics of a single pipeline.
+// Every query returns a single value, or no value if the self-monitor has not collected the underlying metrics.
+type StatsQueries struct {
+	// SentPerMinute is the number of items per minute that the pipeline sends. Every output of a pipeline receives the same items,
+	// so it is the number of the output that sends the most items.
+	SentPerMinute string
+	// DroppedPerMinute is the number of items per minute that the pipeline drops, either because they are rejected or because the export queue is full.
+	DroppedPerMinute string
+	// QueueUtilization is the ratio of the used and the total capacity of the fullest export queue of the pipeline.
+	// It is empty if the pipeline has no export queue.
+	QueueUtilization string
+}
+
+func MakeMetricPipelineStatsQueries(pipelineName string) StatsQueries {
+	return makeOTelCollectorStatsQueries(metricGatewayMetricsServiceName, dataTypeMetricPoints, pipelineName)
+}
+
+func MakeTracePipelineStatsQueries(pipelineName string) StatsQueries {
+	return makeOTelCollectorStatsQueries(traceGatewayMetricsServiceName, dataTypeSpans, pipelineName)
+}
+
+// MakeLogGatewayPipelineStatsQueries returns the queries for a log pipeline with OTLP output, which runs in the log gateway.
+func MakeLogGatewayPipelineStatsQueries(pipelineName string) StatsQueries {
+	return makeOTelCollectorStatsQueries(logGatewayMetricsServiceName, dataTypeLogRecords, pipelineName)
+}
+
+// MakeLogPipelineStatsQueries returns the queries for a log pipeline. Fluent Bit buffers the data of all pipelines in a shared filesystem buffer,
+// so there is no queue utilization for a single pipeline.
+func MakeLogPipelineStatsQueries(pipelineName string) StatsQueries {
+	matchers := pipelineMatchers(fluentBitMetricsServiceName, pipelineName)
+	return StatsQueries{
+		SentPerMinute:    perMinute(fmt.Sprintf("%s{%s}", metricFluentBitOutputProcRecordsTotal, matchers)),
+		DroppedPerMinute: perMinute(fmt.Sprintf("%s{%s}", metricFluentBitOutputDroppedRecordsTotal, matchers)),
+	}
+}
+
+func makeOTelCollectorStatsQueries(serviceName, dataType, pipelineName string) StatsQueries {
+	matchers := pipelineMatchers(serviceName, pipelineName)
+	sent := fmt.Sprintf("%s_%s", metricOtelCollectorExporterSent, dataType)
+	sendFailed := fmt.Sprintf("%s_%s", metricOtelCollectorExporterSendFailed, dataType)
+	enqueueFailed := fmt.Sprintf("%s_%s", metricOtelCollectorExporterEnqueueFailed, dataType)
+
+	return StatsQueries{
+		SentPerMinute: perMinuteOfBusiestOutput(fmt.Sprintf("%s{%s}", sent, matchers)),
+		// Matching both metrics by name keeps the sum defined if only one of them has been reported
+		DroppedPerMinute: perMinute(fmt.Sprintf("{%s=~\"%s|%s\",%s}", model.MetricNameLabel, sendFailed, enqueueFailed, matchers)),
+		QueueUtilization: fmt.Sprintf("max(%s{%s} / %s{%s})",
+			metricOtelCollectorExporterQueueSize, matchers, metricOtelCollectorExporterQueueCapacity, matchers),
+	}
+}
+
+func pipelineMatchers(serviceName, pipelineName string) string {
+	return fmt.Sprintf("%s=\"%s\",%s=\"%s\"", labelService, serviceName, labelPipelineName, pipelineName)
+}
+
+func perMinute(selector string) string {
+	return fmt.Sprintf("sum(rate(%s[%s])) * 60", selector, model.Duration(statsRateWindow))
+}
+
+func perMinuteOfBusiestOutput(selector string) string {
+	return fmt.Sprintf("max(sum by (%s) (rate(%s[%s]))) * 60", labelOutputName, selector, model.Duration(statsRateWindow))
+}
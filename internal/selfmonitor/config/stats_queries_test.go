@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeStatsQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		queries  StatsQueries
+		expected StatsQueries
+	}{
+		{
+			name:    "metric pipeline",
+			queries: MakeMetricPipelineStatsQueries("cls"),
+			expected: StatsQueries{
+				SentPerMinute:    "max(sum by (output_name) (rate(otelcol_exporter_sent_metric_points{service=\"telemetry-metric-gateway-metrics\",pipeline_name=\"cls\"}[5m]))) * 60",
+				DroppedPerMinute: "sum(rate({__name__=~\"otelcol_exporter_send_failed_metric_points|otelcol_exporter_enqueue_failed_metric_points\",service=\"telemetry-metric-gateway-metrics\",pipeline_name=\"cls\"}[5m])) * 60",
+				QueueUtilization: "max(otelcol_exporter_queue_size{service=\"telemetry-metric-gateway-metrics\",pipeline_name=\"cls\"} / otelcol_exporter_queue_capacity{service=\"telemetry-metric-gateway-metrics\",pipeline_name=\"cls\"})",
+			},
+		},
+		{
+			name:    "trace pipeline",
+			queries: MakeTracePipelineStatsQueries("cls"),
+			expected: StatsQueries{
+				SentPerMinute:    "max(sum by (output_name) (rate(otelcol_exporter_sent_spans{service=\"telemetry-trace-collector-metrics\",pipeline_name=\"cls\"}[5m]))) * 60",
+				DroppedPerMinute: "sum(rate({__name__=~\"otelcol_exporter_send_failed_spans|otelcol_exporter_enqueue_failed_spans\",service=\"telemetry-trace-collector-metrics\",pipeline_name=\"cls\"}[5m])) * 60",
+				QueueUtilization: "max(otelcol_exporter_queue_size{service=\"telemetry-trace-collector-metrics\",pipeline_name=\"cls\"} / otelcol_exporter_queue_capacity{service=\"telemetry-trace-collector-metrics\",pipeline_name=\"cls\"})",
+			},
+		},
+		{
+			name:    "log pipeline with otlp output",
+			queries: MakeLogGatewayPipelineStatsQueries("cls"),
+			expected: StatsQueries{
+				SentPerMinute:    "max(sum by (output_name) (rate(otelcol_exporter_sent_log_records{service=\"telemetry-log-gateway-metrics\",pipeline_name=\"cls\"}[5m]))) * 60",
+				DroppedPerMinute: "sum(rate({__name__=~\"otelcol_exporter_send_failed_log_records|otelcol_exporter_enqueue_failed_log_records\",service=\"telemetry-log-gateway-metrics\",pipeline_name=\"cls\"}[5m])) * 60",
+				QueueUtilization: "max(otelcol_exporter_queue_size{service=\"telemetry-log-gateway-metrics\",pipeline_name=\"cls\"} / otelcol_exporter_queue_capacity{service=\"telemetry-log-gateway-metrics\",pipeline_name=\"cls\"})",
+			},
+		},
+		{
+			name:    "log pipeline",
+			queries: MakeLogPipelineStatsQueries("cls"),
+			expected: StatsQueries{
+				SentPerMinute:    "sum(rate(fluentbit_output_proc_records_total{service=\"telemetry-fluent-bit-metrics\",pipeline_name=\"cls\"}[5m])) * 60",
+				DroppedPerMinute: "sum(rate(fluentbit_output_dropped_records_total{service=\"telemetry-fluent-bit-metrics\",pipeline_name=\"cls\"}[5m])) * 60",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.queries)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package prober
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -21,6 +22,16 @@ type OTelPipelineProbeResult struct {
 
 	QueueAlmostFull bool
 	Throttling      bool
+	// DroppingOutputs are the names of the outputs that drop data, sorted by name. The primary output has an empty name.
+	DroppingOutputs []string
+
+	// The following results only apply to metric pipelines. They refer to the metric agent, which is shared by all pipelines.
+	// Healthy only covers the gateway, because the prober does not know whether the pipeline has an agent input.
+	// The reconciler discards the results that don't apply to the pipeline and combines the remaining ones with Healthy.
+	AgentAllDataDropped       bool
+	AgentSomeDataDropped      bool
+	ScrapeTargetsDown         bool
+	ScrapeSampleLimitExceeded bool
 }
 
 func NewMetricPipelineProber(selfMonitorName types.NamespacedName) (*OTelPipelineProber, error) {
@@ -31,6 +42,11 @@ func NewTracePipelineProber(selfMonitorName types.NamespacedName) (*OTelPipeline
 	return newOTelPipelineProber(selfMonitorName, config.MatchesTracePipelineRule)
 }
 
+// NewLogGatewayPipelineProber creates a prober for LogPipelines with OTLP output, which run in the log gateway instead of Fluent Bit.
+func NewLogGatewayPipelineProber(selfMonitorName types.NamespacedName) (*OTelPipelineProber, error) {
+	return newOTelPipelineProber(selfMonitorName, config.MatchesLogPipelineRule)
+}
+
 func newOTelPipelineProber(selfMonitorName types.NamespacedName, matcher matcherFunc) (*OTelPipelineProber, error) {
 	promClient, err := newPrometheusClient(selfMonitorName)
 	if err != nil {
@@ -49,31 +65,57 @@ func (p *OTelPipelineProber) Probe(ctx context.Context, pipelineName string) (OT
 		return OTelPipelineProbeResult{}, fmt.Errorf("failed to retrieve alerts: %w", err)
 	}
 
+	droppingOutputs := p.droppingOutputs(alerts, pipelineName)
+	sending := p.isFiring(alerts, config.RuleNameGatewayExporterSentData, pipelineName)
+
 	return OTelPipelineProbeResult{
 		PipelineProbeResult: PipelineProbeResult{
-			AllDataDropped:  p.allDataDropped(alerts, pipelineName),
-			SomeDataDropped: p.someDataDropped(alerts, pipelineName),
+			AllDataDropped:  len(droppingOutputs) > 0 && !sending,
+			SomeDataDropped: len(droppingOutputs) > 0 && sending,
 			Healthy:         p.healthy(alerts, pipelineName),
 		},
-		QueueAlmostFull: p.queueAlmostFull(alerts, pipelineName),
-		Throttling:      p.throttling(alerts, pipelineName),
+		QueueAlmostFull:           p.queueAlmostFull(alerts, pipelineName),
+		Throttling:                p.throttling(alerts, pipelineName),
+		DroppingOutputs:           droppingOutputs,
+		AgentAllDataDropped:       p.agentDropping(alerts, pipelineName) && !p.isFiring(alerts, config.RuleNameMetricAgentExporterSentData, pipelineName),
+		AgentSomeDataDropped:      p.agentDropping(alerts, pipelineName) && p.isFiring(alerts, config.RuleNameMetricAgentExporterSentData, pipelineName),
+		ScrapeTargetsDown:         p.isFiring(alerts, config.RuleNameMetricAgentScrapeTargetDown, pipelineName),
+		ScrapeSampleLimitExceeded: p.isFiring(alerts, config.RuleNameMetricAgentScrapeSampleLimitExceeded, pipelineName),
 	}, nil
 }
 
-func (p *OTelPipelineProber) allDataDropped(alerts []promv1.Alert, pipelineName string) bool {
-	exporterSentData := p.isFiring(alerts, config.RuleNameGatewayExporterSentData, pipelineName)
-	exporterDroppedData := p.isFiring(alerts, config.RuleNameGatewayExporterDroppedData, pipelineName)
-	exporterEnqueueFailed := p.isFiring(alerts, config.RuleNameGatewayExporterEnqueueFailed, pipelineName)
+// droppingOutputs returns the sorted names of the pipeline outputs with firing drop or enqueue failure alerts.
+// The primary output has an empty name. Every output has its own exporter, so the alerts are evaluated per output,
+// but the pipeline only drops all of its data if none of its outputs sends any data.
+func (p *OTelPipelineProber) droppingOutputs(alerts []promv1.Alert, pipelineName string) []string {
+	var outputNames []string
+	seen := make(map[string]bool)
+	for _, alert := range alerts {
+		if alert.State != promv1.AlertStateFiring {
+			continue
+		}
+
+		labels := toRawLabels(alert.Labels)
+		if !p.matcher(labels, config.RuleNameGatewayExporterDroppedData, pipelineName) &&
+			!p.matcher(labels, config.RuleNameGatewayExporterEnqueueFailed, pipelineName) {
+			continue
+		}
+
+		outputName := config.OutputName(labels)
+		if !seen[outputName] {
+			seen[outputName] = true
+			outputNames = append(outputNames, outputName)
+		}
+	}
+	slices.Sort(outputNames)
 
-	return !exporterSentData && (exporterDroppedData || exporterEnqueueFailed)
+	return outputNames
 }
 
-func (p *OTelPipelineProber) someDataDropped(alerts []promv1.Alert, pipelineName string) bool {
-	exporterSentData := p.isFiring(alerts, config.RuleNameGatewayExporterSentData, pipelineName)
-	exporterDroppedData := p.isFiring(alerts, config.RuleNameGatewayExporterDroppedData, pipelineName)
-	exporterEnqueueFailed := p.isFiring(alerts, config.RuleNameGatewayExporterEnqueueFailed, pipelineName)
-
-	return exporterSentData && (exporterDroppedData || exporterEnqueueFailed)
+// agentDropping returns true if the metric agent fails to send data to the metric gateway.
+func (p *OTelPipelineProber) agentDropping(alerts []promv1.Alert, pipelineName string) bool {
+	return p.isFiring(alerts, config.RuleNameMetricAgentExporterDroppedData, pipelineName) ||
+		p.isFiring(alerts, config.RuleNameMetricAgentExporterEnqueueFailed, pipelineName)
 }
 
 func (p *OTelPipelineProber) queueAlmostFull(alerts []promv1.Alert, pipelineName string) bool {
@@ -78,6 +78,7 @@ func TestOTelPipelineProber(t *testing.T) {
 				PipelineProbeResult: PipelineProbeResult{
 					AllDataDropped: true,
 				},
+				DroppingOutputs: []string{""},
 			},
 		},
 		{
@@ -165,6 +166,7 @@ func TestOTelPipelineProber(t *testing.T) {
 				PipelineProbeResult: PipelineProbeResult{
 					AllDataDropped: true,
 				},
+				DroppingOutputs: []string{""},
 			},
 		},
 		{
@@ -192,6 +194,7 @@ func TestOTelPipelineProber(t *testing.T) {
 				PipelineProbeResult: PipelineProbeResult{
 					SomeDataDropped: true,
 				},
+				DroppingOutputs: []string{""},
 			},
 		},
 		{
@@ -219,6 +222,7 @@ func TestOTelPipelineProber(t *testing.T) {
 				PipelineProbeResult: PipelineProbeResult{
 					SomeDataDropped: true,
 				},
+				DroppingOutputs: []string{""},
 			},
 		},
 		{
@@ -253,6 +257,7 @@ func TestOTelPipelineProber(t *testing.T) {
 				PipelineProbeResult: PipelineProbeResult{
 					SomeDataDropped: true,
 				},
+				DroppingOutputs: []string{""},
 			},
 		},
 		{
@@ -305,6 +310,72 @@ func TestOTelPipelineProber(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "primary output sent data and additional output dropped data firing",
+			pipelineName: "cls",
+			alerts: promv1.AlertsResult{
+				Alerts: []promv1.Alert{
+					{
+						Labels: model.LabelSet{
+							"alertname":     "TraceGatewayExporterSentData",
+							"pipeline_name": "cls",
+						},
+						State: promv1.AlertStateFiring,
+					},
+					{
+						Labels: model.LabelSet{
+							"alertname":     "TraceGatewayExporterDroppedData",
+							"pipeline_name": "cls",
+							"output_name":   "audit",
+						},
+						State: promv1.AlertStateFiring,
+					},
+				},
+			},
+			expected: OTelPipelineProbeResult{
+				PipelineProbeResult: PipelineProbeResult{
+					SomeDataDropped: true,
+				},
+				DroppingOutputs: []string{"audit"},
+			},
+		},
+		{
+			name:         "additional output sent data and dropped data firing",
+			pipelineName: "cls",
+			alerts: promv1.AlertsResult{
+				Alerts: []promv1.Alert{
+					{
+						Labels: model.LabelSet{
+							"alertname":     "TraceGatewayExporterSentData",
+							"pipeline_name": "cls",
+						},
+						State: promv1.AlertStateFiring,
+					},
+					{
+						Labels: model.LabelSet{
+							"alertname":     "TraceGatewayExporterSentData",
+							"pipeline_name": "cls",
+							"output_name":   "audit",
+						},
+						State: promv1.AlertStateFiring,
+					},
+					{
+						Labels: model.LabelSet{
+							"alertname":     "TraceGatewayExporterDroppedData",
+							"pipeline_name": "cls",
+							"output_name":   "audit",
+						},
+						State: promv1.AlertStateFiring,
+					},
+				},
+			},
+			expected: OTelPipelineProbeResult{
+				PipelineProbeResult: PipelineProbeResult{
+					SomeDataDropped: true,
+				},
+				DroppingOutputs: []string{"audit"},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -331,3 +402,163 @@ func TestOTelPipelineProber(t *testing.T) {
 		})
 	}
 }
+
+func TestMetricPipelineProberAgentAlerts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		alerts   []promv1.Alert
+		expected OTelPipelineProbeResult
+	}{
+		{
+			name: "agent exporter dropped data firing",
+			alerts: []promv1.Alert{
+				{
+					Labels: model.LabelSet{"alertname": "MetricAgentExporterDroppedData"},
+					State:  promv1.AlertStateFiring,
+				},
+			},
+			expected: OTelPipelineProbeResult{
+				PipelineProbeResult: PipelineProbeResult{Healthy: true},
+				AgentAllDataDropped: true,
+			},
+		},
+		{
+			name: "agent exporter sent data and exporter enqueue failed firing",
+			alerts: []promv1.Alert{
+				{
+					Labels: model.LabelSet{"alertname": "MetricAgentExporterSentData"},
+					State:  promv1.AlertStateFiring,
+				},
+				{
+					Labels: model.LabelSet{"alertname": "MetricAgentExporterEnqueueFailed"},
+					State:  promv1.AlertStateFiring,
+				},
+			},
+			expected: OTelPipelineProbeResult{
+				PipelineProbeResult:  PipelineProbeResult{Healthy: true},
+				AgentSomeDataDropped: true,
+			},
+		},
+		{
+			name: "scrape target down pending",
+			alerts: []promv1.Alert{
+				{
+					Labels: model.LabelSet{"alertname": "MetricAgentScrapeTargetDown", "exported_job": "app-pods"},
+					State:  promv1.AlertStatePending,
+				},
+			},
+			expected: OTelPipelineProbeResult{
+				PipelineProbeResult: PipelineProbeResult{Healthy: true},
+			},
+		},
+		{
+			name: "scrape target down and sample limit exceeded firing",
+			alerts: []promv1.Alert{
+				{
+					Labels: model.LabelSet{"alertname": "MetricAgentScrapeTargetDown", "exported_job": "app-pods"},
+					State:  promv1.AlertStateFiring,
+				},
+				{
+					Labels: model.LabelSet{"alertname": "MetricAgentScrapeSampleLimitExceeded", "exported_job": "app-pods"},
+					State:  promv1.AlertStateFiring,
+				},
+			},
+			expected: OTelPipelineProbeResult{
+				PipelineProbeResult:       PipelineProbeResult{Healthy: true},
+				ScrapeTargetsDown:         true,
+				ScrapeSampleLimitExceeded: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sut, err := NewMetricPipelineProber(types.NamespacedName{Name: "test"})
+			require.NoError(t, err)
+
+			alertGetterMock := &mocks.AlertGetter{}
+			alertGetterMock.On("Alerts", mock.Anything).Return(promv1.AlertsResult{Alerts: tc.alerts}, nil)
+			sut.getter = alertGetterMock
+
+			result, err := sut.Probe(context.Background(), "cls")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestLogGatewayPipelineProber(t *testing.T) {
+	testCases := []struct {
+		name     string
+		alerts   []promv1.Alert
+		expected OTelPipelineProbeResult
+	}{
+		{
+			name: "log gateway exporter dropped data firing",
+			alerts: []promv1.Alert{
+				{
+					Labels: model.LabelSet{
+						"alertname":     "LogGatewayExporterDroppedData",
+						"pipeline_name": "cls",
+					},
+					State: promv1.AlertStateFiring,
+				},
+			},
+			expected: OTelPipelineProbeResult{
+				PipelineProbeResult: PipelineProbeResult{
+					AllDataDropped: true,
+				},
+				DroppingOutputs: []string{""},
+			},
+		},
+		{
+			name: "fluent bit alert firing",
+			alerts: []promv1.Alert{
+				{
+					Labels: model.LabelSet{
+						"alertname":     "LogAgentBufferFull",
+						"pipeline_name": "cls",
+					},
+					State: promv1.AlertStateFiring,
+				},
+			},
+			expected: OTelPipelineProbeResult{
+				PipelineProbeResult: PipelineProbeResult{
+					Healthy: true,
+				},
+			},
+		},
+		{
+			name: "trace gateway alert firing",
+			alerts: []promv1.Alert{
+				{
+					Labels: model.LabelSet{
+						"alertname":     "TraceGatewayExporterDroppedData",
+						"pipeline_name": "cls",
+					},
+					State: promv1.AlertStateFiring,
+				},
+			},
+			expected: OTelPipelineProbeResult{
+				PipelineProbeResult: PipelineProbeResult{
+					Healthy: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sut, err := NewLogGatewayPipelineProber(types.NamespacedName{Name: "test"})
+			require.NoError(t, err)
+
+			alertGetterMock := &mocks.AlertGetter{}
+			alertGetterMock.On("Alerts", mock.Anything).Return(promv1.AlertsResult{Alerts: tc.alerts}, nil)
+			sut.getter = alertGetterMock
+
+			result, err := sut.Probe(context.Background(), "cls")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
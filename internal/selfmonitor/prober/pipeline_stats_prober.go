@@ -0,0 +1,191 @@
+package prober
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/config"
+)
+
+// statsResolution is the granularity of the evaluation time of the stats queries.
+// Pipelines are reconciled at least once per minute, and evaluating all queries of a minute at the same time keeps the results stable,
+// so that repeated reconciliations do not cause status updates.
+const statsResolution = time.Minute
+
+// The statistics are evaluated with a single query, which labels every result with the name of its statistic
+const (
+	labelStat            = "stat"
+	statSent             = "sent"
+	statDropped          = "dropped"
+	statQueueUtilization = "queue_utilization"
+)
+
+//go:generate mockery --name querier --filename=querier.go --exported
+type querier interface {
+	Query(ctx context.Context, query string, ts time.Time, opts ...promv1.Option) (model.Value, promv1.Warnings, error)
+}
+
+// PipelineStatsProber queries the data flow statistics of pipelines from the self-monitor
+type PipelineStatsProber struct {
+	querier     querier
+	makeQueries func(pipelineName string) config.StatsQueries
+	now         func() time.Time
+}
+
+// PipelineStats are the data flow statistics of a pipeline. A statistic is nil if the self-monitor has not collected its metrics yet.
+type PipelineStats struct {
+	SentPerMinute    *float64
+	DroppedPerMinute *float64
+	// QueueUtilization is also nil if the pipeline has no export queue.
+	QueueUtilization *float64
+	Timestamp        time.Time
+}
+
+// DataFlowStatus converts the statistics to the status of a pipeline. The rates are rounded to whole items.
+func (s PipelineStats) DataFlowStatus() *telemetryv1alpha1.DataFlowStatus {
+	status := &telemetryv1alpha1.DataFlowStatus{
+		LastUpdateTime: metav1.NewTime(s.Timestamp),
+	}
+
+	if s.SentPerMinute != nil {
+		sent := int64(math.Round(*s.SentPerMinute))
+		status.SentPerMinute = &sent
+	}
+
+	if s.DroppedPerMinute != nil {
+		dropped := int64(math.Round(*s.DroppedPerMinute))
+		status.DroppedPerMinute = &dropped
+	}
+
+	if s.QueueUtilization != nil {
+		percent := int32(math.Round(*s.QueueUtilization * 100))
+		status.QueueUtilizationPercent = &percent
+	}
+
+	return status
+}
+
+type statsGetter interface {
+	Stats(ctx context.Context, pipelineName string) (PipelineStats, error)
+}
+
+// DataFlowStatus returns the data flow status of a pipeline. If the statistics cannot be probed, it returns the last reported status,
+// whose update time shows that it is outdated.
+func DataFlowStatus(ctx context.Context, getter statsGetter, pipelineName string, lastStatus *telemetryv1alpha1.DataFlowStatus) *telemetryv1alpha1.DataFlowStatus {
+	stats, err := getter.Stats(ctx, pipelineName)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to probe data flow statistics")
+		return lastStatus
+	}
+
+	return stats.DataFlowStatus()
+}
+
+func NewMetricPipelineStatsProber(selfMonitorName types.NamespacedName) (*PipelineStatsProber, error) {
+	return newPipelineStatsProber(selfMonitorName, config.MakeMetricPipelineStatsQueries)
+}
+
+func NewTracePipelineStatsProber(selfMonitorName types.NamespacedName) (*PipelineStatsProber, error) {
+	return newPipelineStatsProber(selfMonitorName, config.MakeTracePipelineStatsQueries)
+}
+
+func NewLogPipelineStatsProber(selfMonitorName types.NamespacedName) (*PipelineStatsProber, error) {
+	return newPipelineStatsProber(selfMonitorName, config.MakeLogPipelineStatsQueries)
+}
+
+func NewLogGatewayPipelineStatsProber(selfMonitorName types.NamespacedName) (*PipelineStatsProber, error) {
+	return newPipelineStatsProber(selfMonitorName, config.MakeLogGatewayPipelineStatsQueries)
+}
+
+func newPipelineStatsProber(selfMonitorName types.NamespacedName, makeQueries func(string) config.StatsQueries) (*PipelineStatsProber, error) {
+	promClient, err := newPrometheusClient(selfMonitorName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PipelineStatsProber{
+		querier:     promClient,
+		makeQueries: makeQueries,
+		now:         time.Now,
+	}, nil
+}
+
+// Stats evaluates all statistics of the pipeline with a single query.
+func (p *PipelineStatsProber) Stats(ctx context.Context, pipelineName string) (PipelineStats, error) {
+	queries := p.makeQueries(pipelineName)
+	stats := PipelineStats{
+		Timestamp: p.now().Truncate(statsResolution),
+	}
+
+	namedQueries := []namedStatsQuery{
+		{name: statSent, query: queries.SentPerMinute},
+		{name: statDropped, query: queries.DroppedPerMinute},
+	}
+	if queries.QueueUtilization != "" {
+		namedQueries = append(namedQueries, namedStatsQuery{name: statQueueUtilization, query: queries.QueueUtilization})
+	}
+
+	values, err := p.query(ctx, batchQuery(namedQueries), stats.Timestamp)
+	if err != nil {
+		return PipelineStats{}, fmt.Errorf("failed to query data flow statistics: %w", err)
+	}
+
+	stats.SentPerMinute = values[statSent]
+	stats.DroppedPerMinute = values[statDropped]
+	stats.QueueUtilization = values[statQueueUtilization]
+
+	return stats, nil
+}
+
+type namedStatsQuery struct {
+	name  string
+	query string
+}
+
+// batchQuery combines queries that return a single value each. The results are told apart by the stat label.
+func batchQuery(queries []namedStatsQuery) string {
+	parts := make([]string, 0, len(queries))
+	for _, q := range queries {
+		parts = append(parts, fmt.Sprintf(`label_replace(%s, "%s", "%s", "", "")`, q.query, labelStat, q.name))
+	}
+	return strings.Join(parts, " or ")
+}
+
+// query evaluates a batched query and returns the values by the name of their statistic.
+// A statistic has no value if the query has no valid result, which is the case if the self-monitor has not collected the underlying metrics.
+func (p *PipelineStatsProber) query(ctx context.Context, query string, ts time.Time) (map[string]*float64, error) {
+	result, warnings, err := p.querier.Query(ctx, query, ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus: %w", err)
+	}
+
+	if len(warnings) > 0 {
+		logf.FromContext(ctx).V(1).Info("Prometheus query returned warnings", "query", query, "warnings", warnings)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected query result type: %T", result)
+	}
+
+	values := make(map[string]*float64)
+	for _, sample := range vector {
+		value := float64(sample.Value)
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		values[string(sample.Metric[labelStat])] = &value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,191 @@
+package prober
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/config"
+	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober/mocks"
+)
+
+func TestPipelineStatsProber(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 42, 0, time.UTC)
+	evaluationTime := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	sampleOf := func(stat string, value float64) *model.Sample {
+		return &model.Sample{Metric: model.Metric{labelStat: model.LabelValue(stat)}, Value: model.SampleValue(value)}
+	}
+
+	testCases := []struct {
+		name      string
+		result    model.Value
+		queryErr  error
+		expected  PipelineStats
+		expectErr bool
+	}{
+		{
+			name: "all values reported",
+			result: model.Vector{
+				sampleOf(statSent, 1200),
+				sampleOf(statDropped, 30),
+				sampleOf(statQueueUtilization, 0.25),
+			},
+			expected: PipelineStats{
+				SentPerMinute:    ptr.To(1200.0),
+				DroppedPerMinute: ptr.To(30.0),
+				QueueUtilization: ptr.To(0.25),
+				Timestamp:        evaluationTime,
+			},
+		},
+		{
+			name:   "no values reported",
+			result: model.Vector{},
+			expected: PipelineStats{
+				Timestamp: evaluationTime,
+			},
+		},
+		{
+			name: "queue capacity zero",
+			result: model.Vector{
+				sampleOf(statSent, 1200),
+				sampleOf(statDropped, 0),
+				sampleOf(statQueueUtilization, math.NaN()),
+			},
+			expected: PipelineStats{
+				SentPerMinute:    ptr.To(1200.0),
+				DroppedPerMinute: ptr.To(0.0),
+				Timestamp:        evaluationTime,
+			},
+		},
+		{
+			name:      "unexpected result type",
+			result:    &model.Scalar{Value: 1},
+			expectErr: true,
+		},
+		{
+			name:      "query fails",
+			queryErr:  assert.AnError,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sut, err := NewTracePipelineStatsProber(types.NamespacedName{Name: "test"})
+			require.NoError(t, err)
+
+			querierMock := &mocks.Querier{}
+			querierMock.On("Query", mock.Anything, mock.Anything, evaluationTime).Return(tc.result, nil, tc.queryErr)
+			sut.querier = querierMock
+			sut.now = func() time.Time { return now }
+
+			result, err := sut.Stats(context.Background(), "cls")
+
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+			querierMock.AssertNumberOfCalls(t, "Query", 1)
+		})
+	}
+}
+
+func TestPipelineStatsProberBatchesQueries(t *testing.T) {
+	sut, err := NewTracePipelineStatsProber(types.NamespacedName{Name: "test"})
+	require.NoError(t, err)
+
+	queries := config.MakeTracePipelineStatsQueries("cls")
+	expectedQuery := `label_replace(` + queries.SentPerMinute + `, "stat", "sent", "", "")` +
+		` or label_replace(` + queries.DroppedPerMinute + `, "stat", "dropped", "", "")` +
+		` or label_replace(` + queries.QueueUtilization + `, "stat", "queue_utilization", "", "")`
+	querierMock := &mocks.Querier{}
+	querierMock.On("Query", mock.Anything, expectedQuery, mock.Anything).Return(model.Vector{}, nil, nil)
+	sut.querier = querierMock
+
+	_, err = sut.Stats(context.Background(), "cls")
+	require.NoError(t, err)
+	querierMock.AssertExpectations(t)
+}
+
+func TestLogPipelineStatsProberSkipsQueueUtilization(t *testing.T) {
+	sut, err := NewLogPipelineStatsProber(types.NamespacedName{Name: "test"})
+	require.NoError(t, err)
+
+	queries := config.MakeLogPipelineStatsQueries("cls")
+	expectedQuery := `label_replace(` + queries.SentPerMinute + `, "stat", "sent", "", "")` +
+		` or label_replace(` + queries.DroppedPerMinute + `, "stat", "dropped", "", "")`
+	querierMock := &mocks.Querier{}
+	querierMock.On("Query", mock.Anything, expectedQuery, mock.Anything).Return(model.Vector{
+		{Metric: model.Metric{labelStat: statSent}, Value: 600},
+		{Metric: model.Metric{labelStat: statDropped}, Value: 6},
+	}, nil, nil)
+	sut.querier = querierMock
+
+	result, err := sut.Stats(context.Background(), "cls")
+	require.NoError(t, err)
+	require.Equal(t, 600.0, *result.SentPerMinute)
+	require.Equal(t, 6.0, *result.DroppedPerMinute)
+	require.Nil(t, result.QueueUtilization)
+}
+
+func TestPipelineStatsDataFlowStatus(t *testing.T) {
+	sent := 1199.6
+	dropped := 0.4
+	utilization := 0.426
+	timestamp := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	status := PipelineStats{
+		SentPerMinute:    &sent,
+		DroppedPerMinute: &dropped,
+		QueueUtilization: &utilization,
+		Timestamp:        timestamp,
+	}.DataFlowStatus()
+
+	require.Equal(t, ptr.To(int64(1200)), status.SentPerMinute)
+	require.Equal(t, ptr.To(int64(0)), status.DroppedPerMinute)
+	require.Equal(t, ptr.To(int32(43)), status.QueueUtilizationPercent)
+	require.True(t, status.LastUpdateTime.Time.Equal(timestamp))
+
+	empty := PipelineStats{}.DataFlowStatus()
+	require.Nil(t, empty.SentPerMinute)
+	require.Nil(t, empty.DroppedPerMinute)
+	require.Nil(t, empty.QueueUtilizationPercent)
+}
+
+type statsGetterStub struct {
+	stats PipelineStats
+	err   error
+}
+
+func (s statsGetterStub) Stats(context.Context, string) (PipelineStats, error) {
+	return s.stats, s.err
+}
+
+func TestDataFlowStatus(t *testing.T) {
+	sent := 1200.0
+	timestamp := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	lastStatus := &telemetryv1alpha1.DataFlowStatus{SentPerMinute: ptr.To(int64(600))}
+
+	t.Run("probed", func(t *testing.T) {
+		status := DataFlowStatus(context.Background(), statsGetterStub{stats: PipelineStats{SentPerMinute: &sent, Timestamp: timestamp}}, "cls", lastStatus)
+		require.Equal(t, ptr.To(int64(1200)), status.SentPerMinute)
+		require.True(t, status.LastUpdateTime.Time.Equal(timestamp))
+	})
+
+	t.Run("probe fails", func(t *testing.T) {
+		status := DataFlowStatus(context.Background(), statsGetterStub{err: assert.AnError}, "cls", lastStatus)
+		require.Same(t, lastStatus, status)
+	})
+}
@@ -0,0 +1,252 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/secretref"
+)
+
+const (
+	defaultRepeatInterval = 15 * time.Minute
+	forwardTimeout        = 10 * time.Second
+	alertsPath            = "/api/v2/alerts"
+)
+
+// alertForwarder sends the alerts of the self-monitor to an external Alertmanager.
+// Prometheus resends firing alerts at every evaluation, so the forwarder deduplicates them and forwards a firing alert again only after the repeat interval.
+type alertForwarder struct {
+	c          client.Reader
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	endpoint string
+	// lastForwarded contains the time at which a firing alert was last forwarded, keyed by the fingerprint of its labels
+	lastForwarded map[uint64]time.Time
+}
+
+func newAlertForwarder(c client.Reader) *alertForwarder {
+	return &alertForwarder{
+		c:             c,
+		httpClient:    &http.Client{Timeout: forwardTimeout},
+		lastForwarded: make(map[uint64]time.Time),
+	}
+}
+
+func (f *alertForwarder) forward(ctx context.Context, alerting *operatorv1alpha1.SelfMonitorAlerting, alerts []Alert, now time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// The deduplication state is only valid for the endpoint that received the alerts
+	if alerting.Endpoint != f.endpoint {
+		f.endpoint = alerting.Endpoint
+		f.lastForwarded = make(map[uint64]time.Time)
+	}
+
+	repeatInterval := repeatIntervalOf(alerting)
+	f.forgetExpired(now, repeatInterval)
+
+	var toForward []Alert
+	forwarded := make(map[uint64]bool)
+	for _, alert := range alerts {
+		fingerprint := model.LabelsToSignature(alert.Labels)
+		last, seen := f.lastForwarded[fingerprint]
+
+		if !alert.isFiring(now) {
+			// A resolved alert is only forwarded if the firing alert was forwarded before
+			if seen {
+				toForward = append(toForward, withLabels(alert, alerting.Labels))
+				forwarded[fingerprint] = false
+			}
+			continue
+		}
+
+		if seen && now.Sub(last) < repeatInterval {
+			continue
+		}
+
+		// Alertmanager resolves an alert once its end time has passed, so the end time must outlast the next forwarding
+		alert.EndsAt = now.Add(2 * repeatInterval)
+		toForward = append(toForward, withLabels(alert, alerting.Labels))
+		forwarded[fingerprint] = true
+	}
+
+	if len(toForward) == 0 {
+		return nil
+	}
+
+	if err := f.send(ctx, alerting, toForward); err != nil {
+		return err
+	}
+
+	for fingerprint, firing := range forwarded {
+		if firing {
+			f.lastForwarded[fingerprint] = now
+		} else {
+			delete(f.lastForwarded, fingerprint)
+		}
+	}
+
+	return nil
+}
+
+// forgetExpired removes the alerts that have not been resolved explicitly, but have already expired in Alertmanager.
+func (f *alertForwarder) forgetExpired(now time.Time, repeatInterval time.Duration) {
+	for fingerprint, last := range f.lastForwarded {
+		if now.Sub(last) >= 2*repeatInterval {
+			delete(f.lastForwarded, fingerprint)
+		}
+	}
+}
+
+func (f *alertForwarder) send(ctx context.Context, alerting *operatorv1alpha1.SelfMonitorAlerting, alerts []Alert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alerts: %w", err)
+	}
+
+	url := strings.TrimSuffix(alerting.Endpoint, "/") + alertsPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if err := f.authenticate(ctx, req, alerting.Authentication); err != nil {
+		return err
+	}
+
+	httpClient, err := f.makeHTTPClient(ctx, alerting.TLS)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alerts to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send alerts to %s: unexpected status code %d", url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// authenticate sets the credentials of the Alertmanager on the request. The Secrets are read at every forwarding, so that rotated credentials are picked up.
+func (f *alertForwarder) authenticate(ctx context.Context, req *http.Request, auth *operatorv1alpha1.AlertingAuthentication) error {
+	if auth == nil {
+		return nil
+	}
+
+	if auth.Basic != nil {
+		user, err := f.secretValue(ctx, auth.Basic.User)
+		if err != nil {
+			return err
+		}
+		password, err := f.secretValue(ctx, auth.Basic.Password)
+		if err != nil {
+			return err
+		}
+		req.SetBasicAuth(string(user), string(password))
+	}
+
+	if auth.BearerToken != nil {
+		token, err := f.secretValue(ctx, *auth.BearerToken)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+string(token))
+	}
+
+	return nil
+}
+
+// makeHTTPClient returns a client that verifies the server certificate with the configured CA and presents the configured client certificate.
+// Without a TLS configuration, the system CAs are used.
+func (f *alertForwarder) makeHTTPClient(ctx context.Context, tlsSpec *operatorv1alpha1.AlertingTLS) (*http.Client, error) {
+	if tlsSpec == nil {
+		return f.httpClient, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if tlsSpec.CA != nil {
+		ca, err := f.secretValue(ctx, *tlsSpec.CA)
+		if err != nil {
+			return nil, err
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New("failed to parse the CA certificate of the Alertmanager")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if tlsSpec.Cert != nil && tlsSpec.Key != nil {
+		cert, err := f.secretValue(ctx, *tlsSpec.Cert)
+		if err != nil {
+			return nil, err
+		}
+		key, err := f.secretValue(ctx, *tlsSpec.Key)
+		if err != nil {
+			return nil, err
+		}
+		clientCert, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the client certificate for the Alertmanager: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return &http.Client{
+		Timeout: forwardTimeout,
+		// The client is created for every forwarding, so its connections must not be kept open
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: true},
+	}, nil
+}
+
+func (f *alertForwarder) secretValue(ctx context.Context, ref operatorv1alpha1.SecretKeyRef) ([]byte, error) {
+	value, err := secretref.GetValue(ctx, f.c, telemetryv1alpha1.SecretKeyRef{
+		Name:      ref.Name,
+		Namespace: ref.Namespace,
+		Key:       ref.Key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the Alertmanager credentials: %w", err)
+	}
+	return value, nil
+}
+
+// repeatIntervalOf returns the minimum time after which a firing alert is forwarded and recorded as an Event again.
+func repeatIntervalOf(alerting *operatorv1alpha1.SelfMonitorAlerting) time.Duration {
+	if alerting != nil && alerting.RepeatInterval != nil && alerting.RepeatInterval.Duration > 0 {
+		return alerting.RepeatInterval.Duration
+	}
+	return defaultRepeatInterval
+}
+
+// withLabels returns a copy of the alert with the given labels added. The given labels override the labels of the alert.
+func withLabels(alert Alert, labels map[string]string) Alert {
+	merged := make(map[string]string, len(alert.Labels)+len(labels))
+	maps.Copy(merged, alert.Labels)
+	maps.Copy(merged, labels)
+	alert.Labels = merged
+	return alert
+}
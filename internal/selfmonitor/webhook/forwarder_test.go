@@ -0,0 +1,291 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+type alertmanagerStub struct {
+	server         *httptest.Server
+	requests       [][]Alert
+	authorizations []string
+	statusCode     int
+}
+
+func newAlertmanagerStub(t *testing.T) *alertmanagerStub {
+	stub := &alertmanagerStub{statusCode: http.StatusOK}
+	stub.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, alertsPath, r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var alerts []Alert
+		require.NoError(t, json.Unmarshal(body, &alerts))
+		stub.requests = append(stub.requests, alerts)
+		stub.authorizations = append(stub.authorizations, r.Header.Get("Authorization"))
+
+		w.WriteHeader(stub.statusCode)
+	}))
+	t.Cleanup(stub.server.Close)
+
+	return stub
+}
+
+func TestForward(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	firing := Alert{
+		Labels: map[string]string{"alertname": "MetricGatewayExporterDroppedData", "pipeline_name": "cls"},
+		EndsAt: now.Add(time.Hour),
+	}
+	resolved := Alert{
+		Labels: map[string]string{"alertname": "MetricGatewayExporterDroppedData", "pipeline_name": "cls"},
+		EndsAt: now.Add(-time.Minute),
+	}
+
+	t.Run("adds configured labels and extends end time", func(t *testing.T) {
+		stub := newAlertmanagerStub(t)
+		sut := newAlertForwarder(fake.NewClientBuilder().Build())
+		alerting := &operatorv1alpha1.SelfMonitorAlerting{
+			Endpoint: stub.server.URL + "/",
+			Labels:   map[string]string{"cluster": "prod", "pipeline_name": "overridden"},
+		}
+
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{firing}, now))
+
+		require.Len(t, stub.requests, 1)
+		require.Len(t, stub.requests[0], 1)
+		require.Equal(t, map[string]string{
+			"alertname":     "MetricGatewayExporterDroppedData",
+			"pipeline_name": "overridden",
+			"cluster":       "prod",
+		}, stub.requests[0][0].Labels)
+		require.True(t, stub.requests[0][0].EndsAt.Equal(now.Add(2*defaultRepeatInterval)))
+		require.Equal(t, "cls", firing.Labels["pipeline_name"], "the received alert must not be modified")
+	})
+
+	t.Run("deduplicates firing alerts within repeat interval", func(t *testing.T) {
+		stub := newAlertmanagerStub(t)
+		sut := newAlertForwarder(fake.NewClientBuilder().Build())
+		alerting := &operatorv1alpha1.SelfMonitorAlerting{
+			Endpoint:       stub.server.URL,
+			RepeatInterval: &metav1.Duration{Duration: 10 * time.Minute},
+		}
+
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{firing}, now))
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{firing}, now.Add(5*time.Minute)))
+		require.Len(t, stub.requests, 1)
+
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{firing}, now.Add(10*time.Minute)))
+		require.Len(t, stub.requests, 2)
+	})
+
+	t.Run("forwards resolved alert only if firing alert was forwarded", func(t *testing.T) {
+		stub := newAlertmanagerStub(t)
+		sut := newAlertForwarder(fake.NewClientBuilder().Build())
+		alerting := &operatorv1alpha1.SelfMonitorAlerting{Endpoint: stub.server.URL}
+
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{resolved}, now))
+		require.Empty(t, stub.requests)
+
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{firing}, now.Add(-2*time.Minute)))
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{resolved}, now))
+		require.Len(t, stub.requests, 2)
+		require.True(t, stub.requests[1][0].EndsAt.Equal(resolved.EndsAt))
+
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{resolved}, now))
+		require.Len(t, stub.requests, 2)
+	})
+
+	t.Run("forwards again after failure", func(t *testing.T) {
+		stub := newAlertmanagerStub(t)
+		stub.statusCode = http.StatusInternalServerError
+		sut := newAlertForwarder(fake.NewClientBuilder().Build())
+		alerting := &operatorv1alpha1.SelfMonitorAlerting{Endpoint: stub.server.URL}
+
+		require.Error(t, sut.forward(context.Background(), alerting, []Alert{firing}, now))
+
+		stub.statusCode = http.StatusOK
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{firing}, now))
+		require.Len(t, stub.requests, 2)
+	})
+
+	t.Run("forwards again after endpoint change", func(t *testing.T) {
+		stub := newAlertmanagerStub(t)
+		otherStub := newAlertmanagerStub(t)
+		sut := newAlertForwarder(fake.NewClientBuilder().Build())
+
+		require.NoError(t, sut.forward(context.Background(), &operatorv1alpha1.SelfMonitorAlerting{Endpoint: stub.server.URL}, []Alert{firing}, now))
+		require.NoError(t, sut.forward(context.Background(), &operatorv1alpha1.SelfMonitorAlerting{Endpoint: otherStub.server.URL}, []Alert{firing}, now))
+		require.Len(t, stub.requests, 1)
+		require.Len(t, otherStub.requests, 1)
+	})
+}
+
+func TestForwardWithAuthentication(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	firing := Alert{Labels: map[string]string{"alertname": "LogAgentBufferFull"}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "alertmanager", Namespace: "kyma-system"},
+		Data: map[string][]byte{
+			"user":     []byte("admin"),
+			"password": []byte("secret"),
+			"token":    []byte("abc"),
+		},
+	}
+	secretKeyRef := func(key string) operatorv1alpha1.SecretKeyRef {
+		return operatorv1alpha1.SecretKeyRef{Name: "alertmanager", Namespace: "kyma-system", Key: key}
+	}
+
+	tests := []struct {
+		name                  string
+		authentication        *operatorv1alpha1.AlertingAuthentication
+		expectedAuthorization string
+		expectError           bool
+	}{
+		{
+			name:                  "no authentication",
+			expectedAuthorization: "",
+		},
+		{
+			name: "basic authentication",
+			authentication: &operatorv1alpha1.AlertingAuthentication{
+				Basic: &operatorv1alpha1.AlertingBasicAuth{User: secretKeyRef("user"), Password: secretKeyRef("password")},
+			},
+			expectedAuthorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:secret")),
+		},
+		{
+			name: "bearer token",
+			authentication: &operatorv1alpha1.AlertingAuthentication{
+				BearerToken: ptr.To(secretKeyRef("token")),
+			},
+			expectedAuthorization: "Bearer abc",
+		},
+		{
+			name: "missing secret key",
+			authentication: &operatorv1alpha1.AlertingAuthentication{
+				BearerToken: ptr.To(secretKeyRef("missing")),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stub := newAlertmanagerStub(t)
+			sut := newAlertForwarder(fake.NewClientBuilder().WithObjects(secret).Build())
+			alerting := &operatorv1alpha1.SelfMonitorAlerting{
+				Endpoint:       stub.server.URL,
+				Authentication: tc.authentication,
+			}
+
+			err := sut.forward(context.Background(), alerting, []Alert{firing}, now)
+			if tc.expectError {
+				require.Error(t, err)
+				require.Empty(t, stub.requests)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, []string{tc.expectedAuthorization}, stub.authorizations)
+		})
+	}
+}
+
+func TestForwardWithTLS(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	firing := Alert{Labels: map[string]string{"alertname": "LogAgentBufferFull"}}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "alertmanager-tls", Namespace: "kyma-system"},
+		Data: map[string][]byte{
+			"ca.crt":    serverCA,
+			"other.crt": []byte("invalid"),
+		},
+	}
+	sut := newAlertForwarder(fake.NewClientBuilder().WithObjects(secret).Build())
+
+	t.Run("system CAs do not trust the server", func(t *testing.T) {
+		alerting := &operatorv1alpha1.SelfMonitorAlerting{Endpoint: server.URL}
+		require.Error(t, sut.forward(context.Background(), alerting, []Alert{firing}, now))
+	})
+
+	t.Run("invalid CA", func(t *testing.T) {
+		alerting := &operatorv1alpha1.SelfMonitorAlerting{
+			Endpoint: server.URL,
+			TLS: &operatorv1alpha1.AlertingTLS{
+				CA: &operatorv1alpha1.SecretKeyRef{Name: "alertmanager-tls", Namespace: "kyma-system", Key: "other.crt"},
+			},
+		}
+		require.Error(t, sut.forward(context.Background(), alerting, []Alert{firing}, now))
+	})
+
+	t.Run("configured CA trusts the server", func(t *testing.T) {
+		alerting := &operatorv1alpha1.SelfMonitorAlerting{
+			Endpoint: server.URL,
+			TLS: &operatorv1alpha1.AlertingTLS{
+				CA: &operatorv1alpha1.SecretKeyRef{Name: "alertmanager-tls", Namespace: "kyma-system", Key: "ca.crt"},
+			},
+		}
+		require.NoError(t, sut.forward(context.Background(), alerting, []Alert{firing}, now))
+	})
+}
+
+func TestHandlerForwardsAlerts(t *testing.T) {
+	stub := newAlertmanagerStub(t)
+	telemetry := &operatorv1alpha1.Telemetry{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "kyma-system"},
+		Spec: operatorv1alpha1.TelemetrySpec{
+			SelfMonitor: &operatorv1alpha1.SelfMonitorSpec{
+				Alerting: &operatorv1alpha1.SelfMonitorAlerting{Endpoint: stub.server.URL},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	_ = operatorv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(telemetry).Build()
+	handler := NewHandler(fakeClient,
+		WithMetricPipelineSubscriber(make(chan event.GenericEvent, 1024)),
+		WithTracePipelineSubscriber(make(chan event.GenericEvent, 1024)),
+		WithLogPipelineSubscriber(make(chan event.GenericEvent, 1024)))
+
+	req, err := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`[{"labels":{"alertname":"LogAgentBufferFull"}}]`))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Len(t, stub.requests, 1)
+	require.Equal(t, "LogAgentBufferFull", stub.requests[0][0].Labels["alertname"])
+}
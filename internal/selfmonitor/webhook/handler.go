@@ -3,14 +3,21 @@ package webhook
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	operatorv1alpha1 "github.com/kyma-project/telemetry-manager/apis/operator/v1alpha1"
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/config"
 )
@@ -19,6 +26,20 @@ type Handler struct {
 	c           client.Reader
 	subscribers map[subscriberType]chan<- event.GenericEvent
 	logger      logr.Logger
+	recorder    record.EventRecorder
+	forwarder   *alertForwarder
+	clock       func() time.Time
+
+	eventsMu sync.Mutex
+	// lastRecorded contains the time at which an Event was last recorded for a firing alert on a pipeline
+	lastRecorded map[recordedEvent]time.Time
+}
+
+// recordedEvent identifies an Event by the pipeline and the fingerprint of the labels of the alert.
+type recordedEvent struct {
+	subType     subscriberType
+	pipeline    string
+	fingerprint uint64
 }
 
 type Option = func(*Handler)
@@ -55,15 +76,27 @@ func WithLogger(logger logr.Logger) Option {
 	}
 }
 
+// WithEventRecorder makes the handler record a Kubernetes Event on every pipeline that is affected by a firing alert.
+// Like the forwarded alerts, an Event is recorded again for the same alert only after the repeat interval.
+func WithEventRecorder(recorder record.EventRecorder) Option {
+	return func(h *Handler) {
+		h.recorder = recorder
+	}
+}
+
 // NewHandler creates a new self-monitor webhook handler.
 // This handler serves an endpoint that mimics Alertmanager, allowing Prometheus to send alerts to it.
 // The handler then notifies the subscribers, typically controllers, about the alerts that match the pipelines.
 // Subsequently, the subscribers reconcile the pipelines based on the received alerts.
+// Additionally, the handler records Kubernetes Events on the affected pipelines and forwards the alerts to the Alertmanager that is configured in the Telemetry resource.
 func NewHandler(c client.Reader, opts ...Option) *Handler {
 	h := &Handler{
-		c:           c,
-		logger:      logr.New(logf.NullLogSink{}),
-		subscribers: make(map[subscriberType]chan<- event.GenericEvent),
+		c:            c,
+		logger:       logr.New(logf.NullLogSink{}),
+		subscribers:  make(map[subscriberType]chan<- event.GenericEvent),
+		forwarder:    newAlertForwarder(c),
+		lastRecorded: make(map[recordedEvent]time.Time),
+		clock:        time.Now,
 	}
 
 	for _, opt := range opts {
@@ -73,8 +106,36 @@ func NewHandler(c client.Reader, opts ...Option) *Handler {
 	return h
 }
 
+// Alert is an alert in the format of the Alertmanager API v2.
 type Alert struct {
-	Labels map[string]string `json:"labels"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// isFiring returns true if the alert is not resolved. Prometheus sets the end time of a firing alert to the future, and an alert without an end time is firing as well.
+func (a Alert) isFiring(now time.Time) bool {
+	return a.EndsAt.IsZero() || a.EndsAt.After(now)
+}
+
+func (a Alert) name() string {
+	return a.Labels[model.AlertNameLabel]
+}
+
+func (a Alert) message() string {
+	msg := fmt.Sprintf("Self-monitor alert %s is firing", a.name())
+	if outputName := config.OutputName(a.Labels); outputName != "" {
+		msg += fmt.Sprintf(" for output %s", outputName)
+	}
+	return msg
+}
+
+// pipelineAlert is an alert that matches a pipeline.
+type pipelineAlert struct {
+	pipeline client.Object
+	alert    Alert
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -101,92 +162,153 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	metricPipelineEvents := h.toMetricPipelineReconcileEvents(r.Context(), alerts)
-	tracePipelineEvents := h.toTracePipelineReconcileEvents(r.Context(), alerts)
-	logPipelineEvents := h.toLogPipelineReconcileEvents(r.Context(), alerts)
+	metricPipelineMatches := h.matchMetricPipelines(r.Context(), alerts)
+	tracePipelineMatches := h.matchTracePipelines(r.Context(), alerts)
+	logPipelineMatches := h.matchLogPipelines(r.Context(), alerts)
 	h.logger.V(1).Info("Webhook called. Notifying the subscribers.",
 		"request", alerts,
-		"metricPipelines", retrieveNames(metricPipelineEvents),
-		"tracePipelines", retrieveNames(tracePipelineEvents),
-		"logPipelines", retrieveNames(logPipelineEvents),
+		"metricPipelines", retrieveNames(metricPipelineMatches),
+		"tracePipelines", retrieveNames(tracePipelineMatches),
+		"logPipelines", retrieveNames(logPipelineMatches),
 	)
 
-	for _, ev := range metricPipelineEvents {
-		h.subscribers[subscriberMetricPipeline] <- ev
-	}
+	alerting := h.retrieveAlertingConfig(r.Context())
+	now := h.clock()
+	repeatInterval := repeatIntervalOf(alerting)
 
-	for _, ev := range tracePipelineEvents {
-		h.subscribers[subscriberTracePipeline] <- ev
-	}
+	h.notify(metricPipelineMatches, subscriberMetricPipeline, now, repeatInterval)
+	h.notify(tracePipelineMatches, subscriberTracePipeline, now, repeatInterval)
+	h.notify(logPipelineMatches, subscriberLogPipeline, now, repeatInterval)
 
-	for _, ev := range logPipelineEvents {
-		h.subscribers[subscriberLogPipeline] <- ev
+	// Forwarding is best effort: a failure must not make Prometheus resend the alerts, which would trigger the reconciliations again
+	if alerting != nil {
+		if err := h.forwarder.forward(r.Context(), alerting, alerts, now); err != nil {
+			h.logger.Error(err, "Failed to forward alerts")
+		}
 	}
 
 	w.WriteHeader(http.StatusOK)
 }
 
-func (h *Handler) toMetricPipelineReconcileEvents(ctx context.Context, alerts []Alert) []event.GenericEvent { //nolint:dupl // The functions are similar but not identical
-	var events []event.GenericEvent
+func (h *Handler) matchMetricPipelines(ctx context.Context, alerts []Alert) []pipelineAlert { //nolint:dupl // The functions are similar but not identical
+	var matches []pipelineAlert
 	var metricPipelines telemetryv1alpha1.MetricPipelineList
 	if err := h.c.List(ctx, &metricPipelines); err != nil {
-		return events
+		return matches
 	}
 
 	for i := range metricPipelines.Items {
 		pipelineName := metricPipelines.Items[i].GetName()
 		for _, alert := range alerts {
 			if config.MatchesMetricPipelineRule(alert.Labels, config.RulesAny, pipelineName) {
-				events = append(events, event.GenericEvent{Object: &metricPipelines.Items[i]})
+				matches = append(matches, pipelineAlert{pipeline: &metricPipelines.Items[i], alert: alert})
 			}
 		}
 	}
 
-	return events
+	return matches
 }
 
-func (h *Handler) toTracePipelineReconcileEvents(ctx context.Context, alerts []Alert) []event.GenericEvent { //nolint:dupl // The functions are similar but not identical
-	var events []event.GenericEvent
+func (h *Handler) matchTracePipelines(ctx context.Context, alerts []Alert) []pipelineAlert { //nolint:dupl // The functions are similar but not identical
+	var matches []pipelineAlert
 	var tracePipelines telemetryv1alpha1.TracePipelineList
 	if err := h.c.List(ctx, &tracePipelines); err != nil {
-		return events
+		return matches
 	}
 
 	for i := range tracePipelines.Items {
 		pipelineName := tracePipelines.Items[i].GetName()
 		for _, alert := range alerts {
 			if config.MatchesTracePipelineRule(alert.Labels, config.RulesAny, pipelineName) {
-				events = append(events, event.GenericEvent{Object: &tracePipelines.Items[i]})
+				matches = append(matches, pipelineAlert{pipeline: &tracePipelines.Items[i], alert: alert})
 			}
 		}
 	}
 
-	return events
+	return matches
 }
 
-func (h *Handler) toLogPipelineReconcileEvents(ctx context.Context, alerts []Alert) []event.GenericEvent { //nolint:dupl // The functions are similar but not identical
-	var events []event.GenericEvent
+func (h *Handler) matchLogPipelines(ctx context.Context, alerts []Alert) []pipelineAlert { //nolint:dupl // The functions are similar but not identical
+	var matches []pipelineAlert
 	var logPipelines telemetryv1alpha1.LogPipelineList
 	if err := h.c.List(ctx, &logPipelines); err != nil {
-		return events
+		return matches
 	}
 
 	for i := range logPipelines.Items {
 		pipelineName := logPipelines.Items[i].GetName()
 		for _, alert := range alerts {
 			if config.MatchesLogPipelineRule(alert.Labels, config.RulesAny, pipelineName) {
-				events = append(events, event.GenericEvent{Object: &logPipelines.Items[i]})
+				matches = append(matches, pipelineAlert{pipeline: &logPipelines.Items[i], alert: alert})
 			}
 		}
 	}
 
-	return events
+	return matches
+}
+
+// notify sends a reconcile event to the subscriber and, if the alert is firing, records a Kubernetes Event on the pipeline.
+func (h *Handler) notify(matches []pipelineAlert, subType subscriberType, now time.Time, repeatInterval time.Duration) {
+	for _, match := range matches {
+		h.subscribers[subType] <- event.GenericEvent{Object: match.pipeline}
+
+		if h.recorder != nil && h.shouldRecordEvent(match, subType, now, repeatInterval) {
+			h.recorder.Event(match.pipeline, corev1.EventTypeWarning, match.alert.name(), match.alert.message())
+		}
+	}
+}
+
+// shouldRecordEvent returns true if the alert is firing and no Event has been recorded for it on the pipeline within the repeat interval.
+// Prometheus resends firing alerts at every evaluation, so without deduplication the same Event would be recorded over and over.
+func (h *Handler) shouldRecordEvent(match pipelineAlert, subType subscriberType, now time.Time, repeatInterval time.Duration) bool {
+	h.eventsMu.Lock()
+	defer h.eventsMu.Unlock()
+
+	for key, last := range h.lastRecorded {
+		if now.Sub(last) >= repeatInterval {
+			delete(h.lastRecorded, key)
+		}
+	}
+
+	key := recordedEvent{
+		subType:     subType,
+		pipeline:    match.pipeline.GetName(),
+		fingerprint: model.LabelsToSignature(match.alert.Labels),
+	}
+
+	if !match.alert.isFiring(now) {
+		delete(h.lastRecorded, key)
+		return false
+	}
+
+	if _, recorded := h.lastRecorded[key]; recorded {
+		return false
+	}
+
+	h.lastRecorded[key] = now
+	return true
+}
+
+// retrieveAlertingConfig returns the alerting configuration of the Telemetry resource, or nil if alerts must not be forwarded.
+func (h *Handler) retrieveAlertingConfig(ctx context.Context) *operatorv1alpha1.SelfMonitorAlerting {
+	var telemetries operatorv1alpha1.TelemetryList
+	if err := h.c.List(ctx, &telemetries); err != nil {
+		h.logger.V(1).Error(err, "Failed to list telemetry: not forwarding alerts")
+		return nil
+	}
+	for i := range telemetries.Items {
+		selfMonitor := telemetries.Items[i].Spec.SelfMonitor
+		if selfMonitor != nil && selfMonitor.Alerting != nil {
+			return selfMonitor.Alerting
+		}
+	}
+	return nil
 }
 
-func retrieveNames(events []event.GenericEvent) []string {
+func retrieveNames(matches []pipelineAlert) []string {
 	var names []string
-	for _, ev := range events {
-		names = append(names, ev.Object.GetName())
+	for _, match := range matches {
+		names = append(names, match.pipeline.GetName())
 	}
 	return names
 }
@@ -6,12 +6,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -190,6 +192,83 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestHandlerRecordsEvents(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		resources      []client.Object
+		expectedEvents []string
+	}{
+		{
+			name:        "firing alert with output name",
+			requestBody: `[{"labels":{"alertname":"MetricGatewayExporterDroppedData","pipeline_name":"cls","output_name":"backup"},"endsAt":"2024-06-01T12:05:00Z"}]`,
+			resources: []client.Object{
+				ptr.To(testutils.NewMetricPipelineBuilder().WithName("cls").Build()),
+				ptr.To(testutils.NewMetricPipelineBuilder().WithName("dynatrace").Build()),
+			},
+			expectedEvents: []string{"Warning MetricGatewayExporterDroppedData Self-monitor alert MetricGatewayExporterDroppedData is firing for output backup"},
+		},
+		{
+			name:        "firing alert without end time matches all pipelines",
+			requestBody: `[{"labels":{"alertname":"TraceGatewayReceiverRefusedData"}}]`,
+			resources: []client.Object{
+				ptr.To(testutils.NewTracePipelineBuilder().WithName("cls").Build()),
+				ptr.To(testutils.NewTracePipelineBuilder().WithName("dynatrace").Build()),
+			},
+			expectedEvents: []string{
+				"Warning TraceGatewayReceiverRefusedData Self-monitor alert TraceGatewayReceiverRefusedData is firing",
+				"Warning TraceGatewayReceiverRefusedData Self-monitor alert TraceGatewayReceiverRefusedData is firing",
+			},
+		},
+		{
+			name:        "resolved alert",
+			requestBody: `[{"labels":{"alertname":"LogAgentBufferFull","pipeline_name":"cls"},"endsAt":"2024-06-01T11:55:00Z"}]`,
+			resources: []client.Object{
+				ptr.To(testutils.NewLogPipelineBuilder().WithName("cls").Build()),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = clientgoscheme.AddToScheme(scheme)
+			_ = telemetryv1alpha1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.resources...).Build()
+			recorder := record.NewFakeRecorder(1024)
+			handler := NewHandler(fakeClient,
+				WithMetricPipelineSubscriber(make(chan event.GenericEvent, 1024)),
+				WithTracePipelineSubscriber(make(chan event.GenericEvent, 1024)),
+				WithLogPipelineSubscriber(make(chan event.GenericEvent, 1024)),
+				WithEventRecorder(recorder))
+			handler.clock = func() time.Time { return now }
+
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tc.requestBody))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.ElementsMatch(t, tc.expectedEvents, readAllEventsFromChannel(recorder.Events))
+		})
+	}
+}
+
+func readAllEventsFromChannel(ch <-chan string) []string {
+	var events []string
+	for {
+		select {
+		case ev := <-ch:
+			events = append(events, ev)
+		default:
+			return events
+		}
+	}
+}
+
 func readAllNamesFromChannel(ch <-chan event.GenericEvent) []string {
 	var names []string
 	for {
@@ -201,3 +280,51 @@ func readAllNamesFromChannel(ch <-chan event.GenericEvent) []string {
 		}
 	}
 }
+
+func TestHandlerDeduplicatesEvents(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	firing := `[{"labels":{"alertname":"MetricGatewayExporterDroppedData","pipeline_name":"cls"}}]`
+	resolved := `[{"labels":{"alertname":"MetricGatewayExporterDroppedData","pipeline_name":"cls"},"endsAt":"2024-06-01T11:55:00Z"}]`
+	expectedEvent := "Warning MetricGatewayExporterDroppedData Self-monitor alert MetricGatewayExporterDroppedData is firing"
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = telemetryv1alpha1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		ptr.To(testutils.NewMetricPipelineBuilder().WithName("cls").Build()),
+	).Build()
+	recorder := record.NewFakeRecorder(1024)
+	handler := NewHandler(fakeClient,
+		WithMetricPipelineSubscriber(make(chan event.GenericEvent, 1024)),
+		WithEventRecorder(recorder))
+
+	steps := []struct {
+		name          string
+		after         time.Duration
+		requestBody   string
+		expectedEvent bool
+	}{
+		{name: "first firing alert", after: 0, requestBody: firing, expectedEvent: true},
+		{name: "firing alert resent within repeat interval", after: time.Minute, requestBody: firing, expectedEvent: false},
+		{name: "firing alert resent after repeat interval", after: defaultRepeatInterval, requestBody: firing, expectedEvent: true},
+		{name: "resolved alert", after: defaultRepeatInterval + time.Minute, requestBody: resolved, expectedEvent: false},
+		{name: "firing alert after resolution", after: defaultRepeatInterval + 2*time.Minute, requestBody: firing, expectedEvent: true},
+	}
+
+	for _, step := range steps {
+		handler.clock = func() time.Time { return start.Add(step.after) }
+
+		req, err := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(step.requestBody))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, step.name)
+
+		var expectedEvents []string
+		if step.expectedEvent {
+			expectedEvents = []string{expectedEvent}
+		}
+		require.Equal(t, expectedEvents, readAllEventsFromChannel(recorder.Events), step.name)
+	}
+}
@@ -0,0 +1,267 @@
+package tap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+const (
+	// maxBodyBytes limits the size of a request body that is read for sampling.
+	maxBodyBytes = 4 << 20
+	// maxItemsPerSample is the number of spans, metrics, or log records that are kept from a request.
+	maxItemsPerSample = 10
+	// maxSamplesBytes limits the total size of the samples in the ConfigMap of a pipeline, which must stay below the 1 MiB limit
+	// of Kubernetes objects including its metadata. Older samples are removed.
+	maxSamplesBytes = 768 << 10
+	// minSampleInterval is the minimum time between two samples of the same pipeline. Requests in between are accepted but discarded.
+	minSampleInterval = 10 * time.Second
+
+	sampleKeyTimeFormat = "20060102T150405.000Z"
+)
+
+type Handler struct {
+	c         client.Client
+	namespace string
+	logger    logr.Logger
+	clock     func() time.Time
+
+	mu          sync.Mutex
+	lastSampled map[string]time.Time
+}
+
+type Option = func(*Handler)
+
+func WithLogger(logger logr.Logger) Option {
+	return func(h *Handler) {
+		h.logger = logger
+	}
+}
+
+// NewHandler creates a new tap handler.
+// The handler receives the data that the tap exporters of the telemetry gateways and the tap outputs of Fluent Bit send for pipelines with an active tap.
+// It keeps a small sample of the data and stores it in a ConfigMap per pipeline in the given namespace, where it can be inspected.
+// The ConfigMap is owned by the pipeline, so it is removed together with the pipeline. Otherwise, the pipeline reconcilers remove it when the tap ends.
+func NewHandler(c client.Client, namespace string, opts ...Option) *Handler {
+	h := &Handler{
+		c:           c,
+		namespace:   namespace,
+		logger:      logr.New(logf.NullLogSink{}),
+		clock:       time.Now,
+		lastSampled: make(map[string]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Security-Policy", "default-src 'self'")
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPost {
+		h.logger.Info("Invalid method", "method", r.Method)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	kind, pipelineName, sig, ok := parsePath(r.URL.Path)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pipeline, err := h.getPipeline(r.Context(), kind, pipelineName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			h.logger.Error(err, "Failed to get pipeline", "kind", kind, "name", pipelineName)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	now := h.clock()
+	if !IsActive(pipeline, now) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// The tap must never cause retries or backpressure in the pipeline, so data that is not sampled is acknowledged as well
+	if !h.shouldSample(string(kind)+"/"+pipelineName, now) {
+		_, _ = io.Copy(io.Discard, io.LimitReader(r.Body, maxBodyBytes))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes))
+	if err != nil {
+		h.logger.Error(err, "Failed to read request body")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	sampled, err := sample(sig, mediaType(r.Header.Get("Content-Type")), body, maxItemsPerSample)
+	if err != nil {
+		h.logger.V(1).Info("Failed to sample request body", "kind", kind, "name", pipelineName, "error", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.storeSample(r.Context(), kind, pipeline, sig, sampled, now); err != nil {
+		h.logger.Error(err, "Failed to store sample", "kind", kind, "name", pipelineName)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mt
+}
+
+// parsePath extracts the pipeline kind, the pipeline name, and the signal from a request path.
+// OTLP/HTTP exporters send to /tap/<kind>/<name>/v1/<signal>, and the Fluent Bit HTTP output sends to /tap/logpipelines/<name>.
+func parsePath(path string) (Kind, string, signal, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, PathPrefix), "/")
+
+	switch {
+	case len(parts) == 2 && Kind(parts[0]) == KindLogPipelines:
+		return KindLogPipelines, parts[1], signalRecords, parts[1] != ""
+	case len(parts) == 4 && parts[2] == "v1":
+		kind, sig := Kind(parts[0]), signal(parts[3])
+		if !isValidSignal(kind, sig) {
+			return "", "", "", false
+		}
+		return kind, parts[1], sig, parts[1] != ""
+	default:
+		return "", "", "", false
+	}
+}
+
+func isValidSignal(kind Kind, sig signal) bool {
+	switch kind {
+	case KindTracePipelines:
+		return sig == signalTraces
+	case KindMetricPipelines:
+		return sig == signalMetrics
+	case KindLogPipelines:
+		return sig == signalLogs
+	default:
+		return false
+	}
+}
+
+func (h *Handler) getPipeline(ctx context.Context, kind Kind, name string) (client.Object, error) {
+	var pipeline client.Object
+	switch kind {
+	case KindTracePipelines:
+		pipeline = &telemetryv1alpha1.TracePipeline{}
+	case KindMetricPipelines:
+		pipeline = &telemetryv1alpha1.MetricPipeline{}
+	case KindLogPipelines:
+		pipeline = &telemetryv1alpha1.LogPipeline{}
+	default:
+		return nil, fmt.Errorf("unknown pipeline kind %s", kind)
+	}
+
+	if err := h.c.Get(ctx, types.NamespacedName{Name: name}, pipeline); err != nil {
+		return nil, err
+	}
+	return pipeline, nil
+}
+
+func (h *Handler) shouldSample(key string, now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if last, ok := h.lastSampled[key]; ok && now.Sub(last) < minSampleInterval {
+		return false
+	}
+	h.lastSampled[key] = now
+	return true
+}
+
+func (h *Handler) storeSample(ctx context.Context, kind Kind, pipeline client.Object, sig signal, sampled []byte, now time.Time) error {
+	if len(sampled) > maxSamplesBytes {
+		return fmt.Errorf("sample size of %d bytes exceeds the limit of %d bytes", len(sampled), maxSamplesBytes)
+	}
+
+	name := types.NamespacedName{Name: SamplesConfigMapName(kind, pipeline.GetName()), Namespace: h.namespace}
+
+	var cm corev1.ConfigMap
+	err := h.c.Get(ctx, name, &cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get samples configmap: %w", err)
+	}
+
+	exists := err == nil
+	if !exists {
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name.Name,
+				Namespace: name.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/name":       "telemetry-tap",
+					"app.kubernetes.io/managed-by": "telemetry-manager",
+				},
+			},
+		}
+		if err := controllerutil.SetOwnerReference(pipeline, &cm, h.c.Scheme()); err != nil {
+			return fmt.Errorf("failed to set owner reference: %w", err)
+		}
+	}
+
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[fmt.Sprintf("%s-%s.json", now.UTC().Format(sampleKeyTimeFormat), sig)] = string(sampled)
+	removeOldestSamples(cm.Data, maxSamplesBytes)
+
+	if !exists {
+		return h.c.Create(ctx, &cm)
+	}
+	return h.c.Update(ctx, &cm)
+}
+
+// removeOldestSamples keeps the newest entries, whose keys and values have a total size of at most maxBytes.
+// The keys start with a sortable timestamp.
+func removeOldestSamples(data map[string]string, maxBytes int) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	size := 0
+	for _, key := range keys {
+		size += len(key) + len(data[key])
+		if size > maxBytes {
+			delete(data, key)
+		}
+	}
+}
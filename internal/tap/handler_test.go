@@ -0,0 +1,331 @@
+package tap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestHandler(t *testing.T) {
+	tracePipeline := testutils.NewTracePipelineBuilder().WithName("cls").Build()
+	tracePipeline.Annotations = map[string]string{AnnotationKey: "2024-05-01T12:10:00Z"}
+	metricPipeline := testutils.NewMetricPipelineBuilder().WithName("cls").Build()
+	metricPipeline.Annotations = map[string]string{AnnotationKey: "2024-05-01T12:10:00Z"}
+	logPipeline := testutils.NewLogPipelineBuilder().WithName("cls").Build()
+	logPipeline.Annotations = map[string]string{AnnotationKey: "2024-05-01T12:10:00Z"}
+	expiredPipeline := testutils.NewTracePipelineBuilder().WithName("expired").Build()
+	expiredPipeline.Annotations = map[string]string{AnnotationKey: "2024-05-01T11:10:00Z"}
+
+	tests := []struct {
+		name                  string
+		requestMethod         string
+		requestPath           string
+		contentType           string
+		requestBody           []byte
+		expectedStatus        int
+		expectedConfigMapName string
+		expectedKey           string
+	}{
+		{
+			name:                  "trace pipeline with json encoding",
+			requestMethod:         http.MethodPost,
+			requestPath:           "/tap/tracepipelines/cls/v1/traces",
+			contentType:           "application/json",
+			requestBody:           makeTracesJSON(t, 20),
+			expectedStatus:        http.StatusOK,
+			expectedConfigMapName: "telemetry-tap-tracepipelines-cls",
+			expectedKey:           "20240501T120000.000Z-traces.json",
+		},
+		{
+			name:                  "metric pipeline with protobuf encoding",
+			requestMethod:         http.MethodPost,
+			requestPath:           "/tap/metricpipelines/cls/v1/metrics",
+			contentType:           "application/x-protobuf",
+			requestBody:           makeMetricsProto(t, 20),
+			expectedStatus:        http.StatusOK,
+			expectedConfigMapName: "telemetry-tap-metricpipelines-cls",
+			expectedKey:           "20240501T120000.000Z-metrics.json",
+		},
+		{
+			name:                  "log pipeline with otlp output",
+			requestMethod:         http.MethodPost,
+			requestPath:           "/tap/logpipelines/cls/v1/logs",
+			contentType:           "application/json; charset=utf-8",
+			requestBody:           makeLogsJSON(t, 20),
+			expectedStatus:        http.StatusOK,
+			expectedConfigMapName: "telemetry-tap-logpipelines-cls",
+			expectedKey:           "20240501T120000.000Z-logs.json",
+		},
+		{
+			name:                  "log pipeline with fluent bit output",
+			requestMethod:         http.MethodPost,
+			requestPath:           "/tap/logpipelines/cls",
+			contentType:           "application/json",
+			requestBody:           makeRecordsJSON(20),
+			expectedStatus:        http.StatusOK,
+			expectedConfigMapName: "telemetry-tap-logpipelines-cls",
+			expectedKey:           "20240501T120000.000Z-records.json",
+		},
+		{
+			name:           "invalid method",
+			requestMethod:  http.MethodGet,
+			requestPath:    "/tap/tracepipelines/cls/v1/traces",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "signal does not match pipeline kind",
+			requestMethod:  http.MethodPost,
+			requestPath:    "/tap/tracepipelines/cls/v1/metrics",
+			requestBody:    makeMetricsProto(t, 1),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unknown pipeline",
+			requestMethod:  http.MethodPost,
+			requestPath:    "/tap/tracepipelines/unknown/v1/traces",
+			requestBody:    makeTracesJSON(t, 1),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "expired tap",
+			requestMethod:  http.MethodPost,
+			requestPath:    "/tap/tracepipelines/expired/v1/traces",
+			requestBody:    makeTracesJSON(t, 1),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "malformed body",
+			requestMethod:  http.MethodPost,
+			requestPath:    "/tap/tracepipelines/cls/v1/traces",
+			contentType:    "application/json",
+			requestBody:    []byte("{invalid"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, telemetryv1alpha1.AddToScheme(scheme))
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				ptr.To(tracePipeline), ptr.To(metricPipeline), ptr.To(logPipeline), ptr.To(expiredPipeline),
+			).Build()
+
+			handler := NewHandler(fakeClient, "kyma-system")
+			handler.clock = func() time.Time { return now }
+
+			req := httptest.NewRequest(tt.requestMethod, tt.requestPath, bytes.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", tt.contentType)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+
+			var configMaps corev1.ConfigMapList
+			require.NoError(t, fakeClient.List(context.Background(), &configMaps))
+			if tt.expectedConfigMapName == "" {
+				require.Empty(t, configMaps.Items)
+				return
+			}
+
+			require.Len(t, configMaps.Items, 1)
+			cm := configMaps.Items[0]
+			require.Equal(t, tt.expectedConfigMapName, cm.Name)
+			require.Equal(t, "kyma-system", cm.Namespace)
+			require.Len(t, cm.OwnerReferences, 1)
+			require.Equal(t, "cls", cm.OwnerReferences[0].Name)
+			require.Contains(t, cm.Data, tt.expectedKey)
+		})
+	}
+}
+
+func TestHandlerSampling(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, telemetryv1alpha1.AddToScheme(scheme))
+
+	pipeline := testutils.NewTracePipelineBuilder().WithName("cls").Build()
+	pipeline.Annotations = map[string]string{AnnotationKey: "2024-05-01T13:00:00Z"}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&pipeline).Build()
+
+	clock := now
+	handler := NewHandler(fakeClient, "kyma-system")
+	handler.clock = func() time.Time { return clock }
+
+	send := func() {
+		req := httptest.NewRequest(http.MethodPost, "/tap/tracepipelines/cls/v1/traces", bytes.NewReader(makeTracesJSON(t, 20)))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+	}
+
+	getSamples := func() map[string]string {
+		var cm corev1.ConfigMap
+		require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: "telemetry-tap-tracepipelines-cls", Namespace: "kyma-system"}, &cm))
+		return cm.Data
+	}
+
+	t.Run("keeps only the first spans of a request", func(t *testing.T) {
+		send()
+
+		samples := getSamples()
+		require.Len(t, samples, 1)
+
+		traces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces([]byte(samples["20240501T120000.000Z-traces.json"]))
+		require.NoError(t, err)
+		require.Equal(t, maxItemsPerSample, traces.SpanCount())
+	})
+
+	t.Run("discards requests within the minimum sample interval", func(t *testing.T) {
+		clock = clock.Add(minSampleInterval / 2)
+		send()
+
+		require.Len(t, getSamples(), 1)
+	})
+
+	t.Run("keeps only the newest samples within the size limit", func(t *testing.T) {
+		sampleSize := len(getSamples()["20240501T120000.000Z-traces.json"])
+		for i := 0; i < maxSamplesBytes/sampleSize+5; i++ {
+			clock = clock.Add(minSampleInterval)
+			// Keep the tap active while sending enough samples to exceed the size limit
+			pipeline.Annotations[AnnotationKey] = clock.Add(time.Minute).Format(time.RFC3339)
+			require.NoError(t, fakeClient.Update(context.Background(), &pipeline))
+			send()
+		}
+
+		samples := getSamples()
+		totalSize := 0
+		for key, value := range samples {
+			totalSize += len(key) + len(value)
+		}
+		require.LessOrEqual(t, totalSize, maxSamplesBytes)
+		require.NotContains(t, samples, "20240501T120000.000Z-traces.json")
+		require.Contains(t, samples, clock.Format(sampleKeyTimeFormat)+"-traces.json")
+	})
+}
+
+func TestRemoveOldestSamples(t *testing.T) {
+	data := map[string]string{
+		"20240501T120000.000Z-traces.json": "aaaa",
+		"20240501T120010.000Z-traces.json": "b",
+		"20240501T120020.000Z-traces.json": "cc",
+	}
+	keySize := len("20240501T120000.000Z-traces.json")
+
+	removeOldestSamples(data, 2*keySize+3)
+	require.Equal(t, map[string]string{
+		"20240501T120010.000Z-traces.json": "b",
+		"20240501T120020.000Z-traces.json": "cc",
+	}, data)
+
+	removeOldestSamples(data, 2*keySize+2)
+	require.Equal(t, map[string]string{
+		"20240501T120020.000Z-traces.json": "cc",
+	}, data)
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path           string
+		expectedKind   Kind
+		expectedName   string
+		expectedSignal signal
+		expectedOK     bool
+	}{
+		{path: "/tap/tracepipelines/cls/v1/traces", expectedKind: KindTracePipelines, expectedName: "cls", expectedSignal: signalTraces, expectedOK: true},
+		{path: "/tap/metricpipelines/cls/v1/metrics", expectedKind: KindMetricPipelines, expectedName: "cls", expectedSignal: signalMetrics, expectedOK: true},
+		{path: "/tap/logpipelines/cls/v1/logs", expectedKind: KindLogPipelines, expectedName: "cls", expectedSignal: signalLogs, expectedOK: true},
+		{path: "/tap/logpipelines/cls", expectedKind: KindLogPipelines, expectedName: "cls", expectedSignal: signalRecords, expectedOK: true},
+		{path: "/tap/tracepipelines/cls"},
+		{path: "/tap/logpipelines/"},
+		{path: "/tap/tracepipelines//v1/traces"},
+		{path: "/tap/unknown/cls/v1/traces"},
+		{path: "/tap/metricpipelines/cls/v1/logs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			kind, name, sig, ok := parsePath(tt.path)
+			require.Equal(t, tt.expectedOK, ok)
+			if !tt.expectedOK {
+				return
+			}
+			require.Equal(t, tt.expectedKind, kind)
+			require.Equal(t, tt.expectedName, name)
+			require.Equal(t, tt.expectedSignal, sig)
+		})
+	}
+}
+
+func makeTracesJSON(t *testing.T, spanCount int) []byte {
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < spanCount; i++ {
+		spans.AppendEmpty().SetName(fmt.Sprintf("span-%d", i))
+	}
+
+	data, err := (&ptrace.JSONMarshaler{}).MarshalTraces(traces)
+	require.NoError(t, err)
+	return data
+}
+
+func makeMetricsProto(t *testing.T, metricCount int) []byte {
+	metrics := pmetric.NewMetrics()
+	ms := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for i := 0; i < metricCount; i++ {
+		m := ms.AppendEmpty()
+		m.SetName(fmt.Sprintf("metric-%d", i))
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(int64(i))
+	}
+
+	data, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(metrics)
+	require.NoError(t, err)
+	return data
+}
+
+func makeLogsJSON(t *testing.T, recordCount int) []byte {
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < recordCount; i++ {
+		records.AppendEmpty().Body().SetStr(fmt.Sprintf("log-%d", i))
+	}
+
+	data, err := (&plog.JSONMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	return data
+}
+
+func makeRecordsJSON(recordCount int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i := 0; i < recordCount; i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `{"date":1714564800.0,"log":"log-%d"}`, i)
+	}
+	buf.WriteString("]")
+	return buf.Bytes()
+}
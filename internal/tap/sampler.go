@@ -0,0 +1,183 @@
+package tap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const contentTypeProtobuf = "application/x-protobuf"
+
+// signal is the type of the tapped data. It is derived from the request path.
+type signal string
+
+const (
+	signalTraces  signal = "traces"
+	signalMetrics signal = "metrics"
+	signalLogs    signal = "logs"
+	// signalRecords are the log records sent by the Fluent Bit HTTP output in JSON format.
+	signalRecords signal = "records"
+)
+
+var errUnknownSignal = errors.New("unknown signal")
+
+// sample decodes the request body of the given signal and returns the first maxItems spans, metrics, or log records as indented JSON.
+// OTLP payloads are accepted in JSON and protobuf encoding, and are always stored in the OTLP JSON encoding.
+func sample(sig signal, contentType string, body []byte, maxItems int) ([]byte, error) {
+	switch sig {
+	case signalTraces:
+		return sampleTraces(contentType, body, maxItems)
+	case signalMetrics:
+		return sampleMetrics(contentType, body, maxItems)
+	case signalLogs:
+		return sampleLogs(contentType, body, maxItems)
+	case signalRecords:
+		return sampleRecords(body, maxItems)
+	default:
+		return nil, errUnknownSignal
+	}
+}
+
+func sampleTraces(contentType string, body []byte, maxItems int) ([]byte, error) {
+	var unmarshaler ptrace.Unmarshaler = &ptrace.JSONUnmarshaler{}
+	if contentType == contentTypeProtobuf {
+		unmarshaler = &ptrace.ProtoUnmarshaler{}
+	}
+
+	traces, err := unmarshaler.UnmarshalTraces(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal traces: %w", err)
+	}
+
+	sampled := ptrace.NewTraces()
+	remaining := maxItems
+	for i := 0; i < traces.ResourceSpans().Len() && remaining > 0; i++ {
+		srcResourceSpans := traces.ResourceSpans().At(i)
+		resourceSpans := sampled.ResourceSpans().AppendEmpty()
+		srcResourceSpans.Resource().CopyTo(resourceSpans.Resource())
+		resourceSpans.SetSchemaUrl(srcResourceSpans.SchemaUrl())
+
+		for j := 0; j < srcResourceSpans.ScopeSpans().Len() && remaining > 0; j++ {
+			srcScopeSpans := srcResourceSpans.ScopeSpans().At(j)
+			scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+			srcScopeSpans.Scope().CopyTo(scopeSpans.Scope())
+			scopeSpans.SetSchemaUrl(srcScopeSpans.SchemaUrl())
+
+			for k := 0; k < srcScopeSpans.Spans().Len() && remaining > 0; k++ {
+				srcScopeSpans.Spans().At(k).CopyTo(scopeSpans.Spans().AppendEmpty())
+				remaining--
+			}
+		}
+	}
+
+	marshaled, err := (&ptrace.JSONMarshaler{}).MarshalTraces(sampled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal traces: %w", err)
+	}
+	return indent(marshaled)
+}
+
+func sampleMetrics(contentType string, body []byte, maxItems int) ([]byte, error) {
+	var unmarshaler pmetric.Unmarshaler = &pmetric.JSONUnmarshaler{}
+	if contentType == contentTypeProtobuf {
+		unmarshaler = &pmetric.ProtoUnmarshaler{}
+	}
+
+	metrics, err := unmarshaler.UnmarshalMetrics(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metrics: %w", err)
+	}
+
+	sampled := pmetric.NewMetrics()
+	remaining := maxItems
+	for i := 0; i < metrics.ResourceMetrics().Len() && remaining > 0; i++ {
+		srcResourceMetrics := metrics.ResourceMetrics().At(i)
+		resourceMetrics := sampled.ResourceMetrics().AppendEmpty()
+		srcResourceMetrics.Resource().CopyTo(resourceMetrics.Resource())
+		resourceMetrics.SetSchemaUrl(srcResourceMetrics.SchemaUrl())
+
+		for j := 0; j < srcResourceMetrics.ScopeMetrics().Len() && remaining > 0; j++ {
+			srcScopeMetrics := srcResourceMetrics.ScopeMetrics().At(j)
+			scopeMetrics := resourceMetrics.ScopeMetrics().AppendEmpty()
+			srcScopeMetrics.Scope().CopyTo(scopeMetrics.Scope())
+			scopeMetrics.SetSchemaUrl(srcScopeMetrics.SchemaUrl())
+
+			for k := 0; k < srcScopeMetrics.Metrics().Len() && remaining > 0; k++ {
+				srcScopeMetrics.Metrics().At(k).CopyTo(scopeMetrics.Metrics().AppendEmpty())
+				remaining--
+			}
+		}
+	}
+
+	marshaled, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(sampled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metrics: %w", err)
+	}
+	return indent(marshaled)
+}
+
+func sampleLogs(contentType string, body []byte, maxItems int) ([]byte, error) {
+	var unmarshaler plog.Unmarshaler = &plog.JSONUnmarshaler{}
+	if contentType == contentTypeProtobuf {
+		unmarshaler = &plog.ProtoUnmarshaler{}
+	}
+
+	logs, err := unmarshaler.UnmarshalLogs(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal logs: %w", err)
+	}
+
+	sampled := plog.NewLogs()
+	remaining := maxItems
+	for i := 0; i < logs.ResourceLogs().Len() && remaining > 0; i++ {
+		srcResourceLogs := logs.ResourceLogs().At(i)
+		resourceLogs := sampled.ResourceLogs().AppendEmpty()
+		srcResourceLogs.Resource().CopyTo(resourceLogs.Resource())
+		resourceLogs.SetSchemaUrl(srcResourceLogs.SchemaUrl())
+
+		for j := 0; j < srcResourceLogs.ScopeLogs().Len() && remaining > 0; j++ {
+			srcScopeLogs := srcResourceLogs.ScopeLogs().At(j)
+			scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+			srcScopeLogs.Scope().CopyTo(scopeLogs.Scope())
+			scopeLogs.SetSchemaUrl(srcScopeLogs.SchemaUrl())
+
+			for k := 0; k < srcScopeLogs.LogRecords().Len() && remaining > 0; k++ {
+				srcScopeLogs.LogRecords().At(k).CopyTo(scopeLogs.LogRecords().AppendEmpty())
+				remaining--
+			}
+		}
+	}
+
+	marshaled, err := (&plog.JSONMarshaler{}).MarshalLogs(sampled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal logs: %w", err)
+	}
+	return indent(marshaled)
+}
+
+// sampleRecords samples the JSON array of log records that is sent by the Fluent Bit HTTP output with format json.
+func sampleRecords(body []byte, maxItems int) ([]byte, error) {
+	var records []json.RawMessage
+	if err := json.Unmarshal(body, &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal log records: %w", err)
+	}
+
+	if len(records) > maxItems {
+		records = records[:maxItems]
+	}
+
+	return json.MarshalIndent(records, "", "  ")
+}
+
+func indent(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
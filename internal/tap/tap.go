@@ -0,0 +1,146 @@
+package tap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/otlpexporter"
+	"github.com/kyma-project/telemetry-manager/internal/webhookcert"
+)
+
+const (
+	// AnnotationKey is the pipeline annotation that activates the tap of a pipeline.
+	// The value is the RFC3339 timestamp until which the tap stays active, for example, 2024-05-01T12:00:00Z.
+	AnnotationKey = "telemetry.kyma-project.io/tap-until"
+
+	// MaxWindow is the longest time window for which a tap can be activated.
+	// A tap with an expiry further in the future is ignored, so that a forgotten annotation cannot keep sampling data forever.
+	MaxWindow = time.Hour
+
+	// PathPrefix is the path of the telemetry manager webhook server, under which the tap endpoint is served.
+	PathPrefix = "/tap/"
+)
+
+// Kind is the plural resource name of a pipeline type. It is part of the tap endpoint path and of the samples ConfigMap name.
+type Kind string
+
+const (
+	KindTracePipelines  Kind = "tracepipelines"
+	KindMetricPipelines Kind = "metricpipelines"
+	KindLogPipelines    Kind = "logpipelines"
+)
+
+// ActiveUntil returns the time until which the tap of the given pipeline is active.
+// The second return value is false if the tap annotation is missing, malformed, expired, or exceeds MaxWindow.
+func ActiveUntil(pipeline metav1.Object, now time.Time) (time.Time, bool) {
+	value, ok := pipeline.GetAnnotations()[AnnotationKey]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if !until.After(now) || until.After(now.Add(MaxWindow)) {
+		return time.Time{}, false
+	}
+
+	return until, true
+}
+
+// IsActive returns true if the tap of the given pipeline is active at the given time.
+func IsActive(pipeline metav1.Object, now time.Time) bool {
+	_, active := ActiveUntil(pipeline, now)
+	return active
+}
+
+// Endpoint is the address of the telemetry manager webhook server, which receives the tapped data.
+// The zero value disables the tap.
+type Endpoint struct {
+	Host string
+	Port int32
+	// CASecretName is the Secret with the CA certificate of the webhook server, which the senders of the tapped data trust.
+	CASecretName types.NamespacedName
+}
+
+// IsEnabled returns true if the endpoint is configured.
+func (e Endpoint) IsEnabled() bool {
+	return e.Host != ""
+}
+
+// Path returns the path, to which the tapped data of the given pipeline is sent.
+func (e Endpoint) Path(kind Kind, pipelineName string) string {
+	return fmt.Sprintf("%s%s/%s", PathPrefix, kind, pipelineName)
+}
+
+// URL returns the base URL, to which the tapped data of the given pipeline is sent.
+// An OTLP/HTTP exporter appends the signal-specific path, for example, /v1/traces.
+func (e Endpoint) URL(kind Kind, pipelineName string) string {
+	return fmt.Sprintf("https://%s:%d%s", e.Host, e.Port, e.Path(kind, pipelineName))
+}
+
+// CACert returns the PEM-encoded CA certificate, which the senders of the tapped data trust.
+func (e Endpoint) CACert(ctx context.Context, c client.Reader) ([]byte, error) {
+	return webhookcert.CACert(ctx, c, e.CASecretName)
+}
+
+// ExporterTaps returns the settings of the gateway tap exporters for the given pipelines of the given kind.
+// If the CA certificate cannot be read, no pipeline is tapped, because the tap must never break the actual pipelines.
+func ExporterTaps[T any, P interface {
+	*T
+	metav1.Object
+}](ctx context.Context, c client.Reader, endpoint Endpoint, kind Kind, pipelines []T) otlpexporter.Taps {
+	if !endpoint.IsEnabled() {
+		return otlpexporter.Taps{}
+	}
+
+	now := time.Now()
+	endpoints := make(map[string]string)
+	for i := range pipelines {
+		pipeline := P(&pipelines[i])
+		if IsActive(pipeline, now) {
+			endpoints[pipeline.GetName()] = endpoint.URL(kind, pipeline.GetName())
+		}
+	}
+
+	if len(endpoints) == 0 {
+		return otlpexporter.Taps{}
+	}
+
+	caCert, err := endpoint.CACert(ctx, c)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to get the tap CA certificate: no pipeline is tapped")
+		return otlpexporter.Taps{}
+	}
+
+	return otlpexporter.Taps{Endpoints: endpoints, CAPem: string(caCert)}
+}
+
+// DeleteInactiveSamples deletes the samples ConfigMap of the given pipeline, unless its tap is active.
+// The samples are only kept during the time window of the tap.
+func DeleteInactiveSamples(ctx context.Context, c client.Client, namespace string, kind Kind, pipeline metav1.Object) error {
+	if IsActive(pipeline, time.Now()) {
+		return nil
+	}
+
+	samples := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: SamplesConfigMapName(kind, pipeline.GetName()), Namespace: namespace}}
+	if err := k8sutils.DeleteIfExists(ctx, c, samples); err != nil {
+		return fmt.Errorf("failed to delete tap samples: %w", err)
+	}
+	return nil
+}
+
+// SamplesConfigMapName returns the name of the ConfigMap, in which the samples of the given pipeline are stored.
+func SamplesConfigMapName(kind Kind, pipelineName string) string {
+	return fmt.Sprintf("telemetry-tap-%s-%s", kind, pipelineName)
+}
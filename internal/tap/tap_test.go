@@ -0,0 +1,137 @@
+package tap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestActiveUntil(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expectedUntil time.Time
+		expectActive  bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:        "malformed timestamp",
+			annotations: map[string]string{AnnotationKey: "in 10 minutes"},
+		},
+		{
+			name:        "expired",
+			annotations: map[string]string{AnnotationKey: "2024-05-01T11:59:00Z"},
+		},
+		{
+			name:          "active",
+			annotations:   map[string]string{AnnotationKey: "2024-05-01T12:15:00Z"},
+			expectedUntil: time.Date(2024, 5, 1, 12, 15, 0, 0, time.UTC),
+			expectActive:  true,
+		},
+		{
+			name:          "active with time zone offset",
+			annotations:   map[string]string{AnnotationKey: "2024-05-01T14:15:00+02:00"},
+			expectedUntil: time.Date(2024, 5, 1, 12, 15, 0, 0, time.UTC),
+			expectActive:  true,
+		},
+		{
+			name:          "exactly max window",
+			annotations:   map[string]string{AnnotationKey: "2024-05-01T13:00:00Z"},
+			expectedUntil: time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC),
+			expectActive:  true,
+		},
+		{
+			name:        "exceeds max window",
+			annotations: map[string]string{AnnotationKey: "2024-05-01T13:00:01Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline := &metav1.ObjectMeta{Annotations: tt.annotations}
+
+			until, active := ActiveUntil(pipeline, now)
+			require.Equal(t, tt.expectActive, active)
+			require.True(t, tt.expectedUntil.Equal(until))
+			require.Equal(t, tt.expectActive, IsActive(pipeline, now))
+		})
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	require.False(t, Endpoint{}.IsEnabled())
+
+	endpoint := Endpoint{Host: "telemetry-manager-webhook.kyma-system.svc", Port: 443}
+	require.True(t, endpoint.IsEnabled())
+	require.Equal(t, "/tap/logpipelines/cls", endpoint.Path(KindLogPipelines, "cls"))
+	require.Equal(t, "https://telemetry-manager-webhook.kyma-system.svc:443/tap/tracepipelines/cls", endpoint.URL(KindTracePipelines, "cls"))
+	require.Equal(t, "telemetry-tap-metricpipelines-cls", SamplesConfigMapName(KindMetricPipelines, "cls"))
+}
+
+func TestExporterTaps(t *testing.T) {
+	tapped := testutils.NewMetricPipelineBuilder().WithName("tapped").Build()
+	tapped.Annotations = map[string]string{AnnotationKey: time.Now().Add(10 * time.Minute).UTC().Format(time.RFC3339)}
+	expired := testutils.NewMetricPipelineBuilder().WithName("expired").Build()
+	expired.Annotations = map[string]string{AnnotationKey: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)}
+	untapped := testutils.NewMetricPipelineBuilder().WithName("untapped").Build()
+	pipelines := []telemetryv1alpha1.MetricPipeline{tapped, expired, untapped}
+
+	caSecretName := types.NamespacedName{Name: "telemetry-webhook-cert", Namespace: "kyma-system"}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: caSecretName.Name, Namespace: caSecretName.Namespace},
+		Data:       map[string][]byte{"ca.crt": []byte("ca")},
+	}
+	endpoint := Endpoint{Host: "telemetry-manager-webhook.kyma-system.svc", Port: 443, CASecretName: caSecretName}
+
+	t.Run("tap disabled", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithObjects(caSecret).Build()
+		require.Empty(t, ExporterTaps(context.Background(), fakeClient, Endpoint{}, KindMetricPipelines, pipelines).Endpoints)
+	})
+
+	t.Run("only pipelines with an active tap", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithObjects(caSecret).Build()
+		taps := ExporterTaps(context.Background(), fakeClient, endpoint, KindMetricPipelines, pipelines)
+		require.Equal(t, map[string]string{
+			"tapped": "https://telemetry-manager-webhook.kyma-system.svc:443/tap/metricpipelines/tapped",
+		}, taps.Endpoints)
+		require.Equal(t, "ca", taps.CAPem)
+	})
+
+	t.Run("missing ca certificate", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().Build()
+		require.Empty(t, ExporterTaps(context.Background(), fakeClient, endpoint, KindMetricPipelines, pipelines).Endpoints)
+	})
+}
+
+func TestDeleteInactiveSamples(t *testing.T) {
+	ctx := context.Background()
+	samplesName := types.NamespacedName{Name: "telemetry-tap-tracepipelines-cls", Namespace: "kyma-system"}
+	samples := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: samplesName.Name, Namespace: samplesName.Namespace}}
+	fakeClient := fake.NewClientBuilder().WithObjects(samples).Build()
+
+	pipeline := testutils.NewTracePipelineBuilder().WithName("cls").Build()
+	pipeline.Annotations = map[string]string{AnnotationKey: time.Now().Add(10 * time.Minute).UTC().Format(time.RFC3339)}
+	require.NoError(t, DeleteInactiveSamples(ctx, fakeClient, "kyma-system", KindTracePipelines, &pipeline))
+	require.NoError(t, fakeClient.Get(ctx, samplesName, &corev1.ConfigMap{}), "samples must be kept while the tap is active")
+
+	pipeline.Annotations[AnnotationKey] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	require.NoError(t, DeleteInactiveSamples(ctx, fakeClient, "kyma-system", KindTracePipelines, &pipeline))
+	err := fakeClient.Get(ctx, samplesName, &corev1.ConfigMap{})
+	require.True(t, apierrors.IsNotFound(err), "samples must be deleted when the tap expires")
+
+	require.NoError(t, DeleteInactiveSamples(ctx, fakeClient, "kyma-system", KindTracePipelines, &pipeline))
+}
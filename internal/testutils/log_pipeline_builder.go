@@ -24,6 +24,9 @@ type LogPipelineBuilder struct {
 	httpOutput   *telemetryv1alpha1.HTTPOutput
 	lokiOutput   *telemetryv1alpha1.LokiOutput
 	customOutput string
+	otlpOutput   *telemetryv1alpha1.OtlpOutput
+
+	additionalOutputs []telemetryv1alpha1.AdditionalOtlpOutput
 
 	statusConditions []metav1.Condition
 }
@@ -80,6 +83,11 @@ func (b *LogPipelineBuilder) WithDropLabels(drop bool) *LogPipelineBuilder {
 	return b
 }
 
+func (b *LogPipelineBuilder) WithKubernetesEventsInput(types ...telemetryv1alpha1.KubernetesEventType) *LogPipelineBuilder {
+	b.input.KubernetesEvents = &telemetryv1alpha1.KubernetesEventsInput{Types: types}
+	return b
+}
+
 func (b *LogPipelineBuilder) WithCustomFilter(filter string) *LogPipelineBuilder {
 	b.filters = append(b.filters, telemetryv1alpha1.Filter{Custom: filter})
 	return b
@@ -103,6 +111,21 @@ func (b *LogPipelineBuilder) WithCustomOutput(custom string) *LogPipelineBuilder
 	return b
 }
 
+func (b *LogPipelineBuilder) WithOTLPOutput(opts ...OTLPOutputOption) *LogPipelineBuilder {
+	b.otlpOutput = &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "https://localhost:4317"},
+	}
+	for _, opt := range opts {
+		opt(b.otlpOutput)
+	}
+	return b
+}
+
+func (b *LogPipelineBuilder) WithAdditionalOTLPOutput(name string, opts ...OTLPOutputOption) *LogPipelineBuilder {
+	b.additionalOutputs = append(b.additionalOutputs, newAdditionalOTLPOutput(name, opts...))
+	return b
+}
+
 func (b *LogPipelineBuilder) WithDeletionTimeStamp(ts metav1.Time) *LogPipelineBuilder {
 	b.deletionTimeStamp = ts
 	return b
@@ -117,7 +140,7 @@ func (b *LogPipelineBuilder) Build() telemetryv1alpha1.LogPipeline {
 	if b.name == "" {
 		b.name = fmt.Sprintf("test-%d", b.randSource.Int63())
 	}
-	if b.httpOutput == nil && b.lokiOutput == nil && b.customOutput == "" {
+	if b.httpOutput == nil && b.lokiOutput == nil && b.customOutput == "" && b.otlpOutput == nil {
 		b.httpOutput = defaultHTTPOutput()
 	}
 
@@ -133,7 +156,9 @@ func (b *LogPipelineBuilder) Build() telemetryv1alpha1.LogPipeline {
 				HTTP:   b.httpOutput,
 				Loki:   b.lokiOutput,
 				Custom: b.customOutput,
+				Otlp:   b.otlpOutput,
 			},
+			AdditionalOutputs: b.additionalOutputs,
 		},
 		Status: telemetryv1alpha1.LogPipelineStatus{
 			Conditions: b.statusConditions,
@@ -16,12 +16,18 @@ type MetricPipelineBuilder struct {
 	name   string
 	labels map[string]string
 
-	inRuntime    *telemetryv1alpha1.MetricPipelineRuntimeInput
-	inPrometheus *telemetryv1alpha1.MetricPipelinePrometheusInput
-	inIstio      *telemetryv1alpha1.MetricPipelineIstioInput
-	inOTLP       *telemetryv1alpha1.MetricPipelineOtlpInput
+	inRuntime     *telemetryv1alpha1.MetricPipelineRuntimeInput
+	inPrometheus  *telemetryv1alpha1.MetricPipelinePrometheusInput
+	inIstio       *telemetryv1alpha1.MetricPipelineIstioInput
+	inCluster     *telemetryv1alpha1.MetricPipelineClusterInput
+	inSpanMetrics *telemetryv1alpha1.MetricPipelineSpanMetricsInput
+	inOTLP        *telemetryv1alpha1.MetricPipelineOtlpInput
 
-	outOTLP *telemetryv1alpha1.OtlpOutput
+	filters    *telemetryv1alpha1.MetricPipelineFilters
+	transforms []telemetryv1alpha1.TransformRule
+
+	additionalOutputs []telemetryv1alpha1.AdditionalOtlpOutput
+	outOTLP           *telemetryv1alpha1.OtlpOutput
 
 	statusConditions []metav1.Condition
 }
@@ -80,6 +86,40 @@ func (b *MetricPipelineBuilder) WithRuntimeInput(enable bool, opts ...InputOptio
 	return b
 }
 
+func (b *MetricPipelineBuilder) WithRuntimeInputContainerMetrics(enable bool) *MetricPipelineBuilder {
+	b.initRuntimeInputResources()
+	b.inRuntime.Resources.Container = &telemetryv1alpha1.MetricPipelineRuntimeInputResourceEnabledByDefault{Enabled: &enable}
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithRuntimeInputPodMetrics(enable bool) *MetricPipelineBuilder {
+	b.initRuntimeInputResources()
+	b.inRuntime.Resources.Pod = &telemetryv1alpha1.MetricPipelineRuntimeInputResourceEnabledByDefault{Enabled: &enable}
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithRuntimeInputNodeMetrics(enable bool) *MetricPipelineBuilder {
+	b.initRuntimeInputResources()
+	b.inRuntime.Resources.Node = &telemetryv1alpha1.MetricPipelineRuntimeInputResourceDisabledByDefault{Enabled: enable}
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithRuntimeInputVolumeMetrics(enable bool) *MetricPipelineBuilder {
+	b.initRuntimeInputResources()
+	b.inRuntime.Resources.Volume = &telemetryv1alpha1.MetricPipelineRuntimeInputResourceDisabledByDefault{Enabled: enable}
+	return b
+}
+
+func (b *MetricPipelineBuilder) initRuntimeInputResources() {
+	if b.inRuntime == nil {
+		b.inRuntime = &telemetryv1alpha1.MetricPipelineRuntimeInput{}
+	}
+
+	if b.inRuntime.Resources == nil {
+		b.inRuntime.Resources = &telemetryv1alpha1.MetricPipelineRuntimeInputResources{}
+	}
+}
+
 func (b *MetricPipelineBuilder) WithPrometheusInput(enable bool, opts ...InputOptions) *MetricPipelineBuilder {
 	if b.inPrometheus == nil {
 		b.inPrometheus = &telemetryv1alpha1.MetricPipelinePrometheusInput{}
@@ -131,6 +171,36 @@ func (b *MetricPipelineBuilder) WithPrometheusInputDiagnosticMetrics(enable bool
 	return b
 }
 
+func (b *MetricPipelineBuilder) WithPrometheusInputScrapeInterval(interval string) *MetricPipelineBuilder {
+	if b.inPrometheus == nil {
+		b.inPrometheus = &telemetryv1alpha1.MetricPipelinePrometheusInput{}
+	}
+
+	b.inPrometheus.ScrapeInterval = interval
+
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithPrometheusInputSampleLimit(limit int) *MetricPipelineBuilder {
+	if b.inPrometheus == nil {
+		b.inPrometheus = &telemetryv1alpha1.MetricPipelinePrometheusInput{}
+	}
+
+	b.inPrometheus.SampleLimit = limit
+
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithPrometheusInputMonitors(enable bool) *MetricPipelineBuilder {
+	if b.inPrometheus == nil {
+		b.inPrometheus = &telemetryv1alpha1.MetricPipelinePrometheusInput{}
+	}
+
+	b.inPrometheus.Monitors = &telemetryv1alpha1.MetricPipelinePrometheusInputMonitors{Enabled: enable}
+
+	return b
+}
+
 func (b *MetricPipelineBuilder) WithIstioInputDiagnosticMetrics(enable bool) *MetricPipelineBuilder {
 	if b.inIstio == nil {
 		b.inIstio = &telemetryv1alpha1.MetricPipelineIstioInput{}
@@ -145,6 +215,16 @@ func (b *MetricPipelineBuilder) WithIstioInputDiagnosticMetrics(enable bool) *Me
 	return b
 }
 
+func (b *MetricPipelineBuilder) WithClusterInput(enable bool) *MetricPipelineBuilder {
+	b.inCluster = &telemetryv1alpha1.MetricPipelineClusterInput{Enabled: enable}
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithSpanMetricsInput(enable bool) *MetricPipelineBuilder {
+	b.inSpanMetrics = &telemetryv1alpha1.MetricPipelineSpanMetricsInput{Enabled: enable}
+	return b
+}
+
 func (b *MetricPipelineBuilder) WithOTLPInput(enable bool, opts ...InputOptions) *MetricPipelineBuilder {
 	if b.inOTLP == nil {
 		b.inOTLP = &telemetryv1alpha1.MetricPipelineOtlpInput{}
@@ -164,6 +244,35 @@ func (b *MetricPipelineBuilder) WithOTLPInput(enable bool, opts ...InputOptions)
 	return b
 }
 
+func (b *MetricPipelineBuilder) WithIncludeMetrics(patterns ...string) *MetricPipelineBuilder {
+	b.initFilters()
+	b.filters.Include = append(b.filters.Include, patterns...)
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithExcludeMetrics(patterns ...string) *MetricPipelineBuilder {
+	b.initFilters()
+	b.filters.Exclude = append(b.filters.Exclude, patterns...)
+	return b
+}
+
+func (b *MetricPipelineBuilder) WithDataPointFilter(filter telemetryv1alpha1.MetricPipelineAttributeFilter) *MetricPipelineBuilder {
+	b.initFilters()
+	b.filters.DataPoints = append(b.filters.DataPoints, filter)
+	return b
+}
+
+func (b *MetricPipelineBuilder) initFilters() {
+	if b.filters == nil {
+		b.filters = &telemetryv1alpha1.MetricPipelineFilters{}
+	}
+}
+
+func (b *MetricPipelineBuilder) WithTransform(rule telemetryv1alpha1.TransformRule) *MetricPipelineBuilder {
+	b.transforms = append(b.transforms, rule)
+	return b
+}
+
 func (b *MetricPipelineBuilder) WithOTLPOutput(opts ...OTLPOutputOption) *MetricPipelineBuilder {
 	for _, opt := range opts {
 		opt(b.outOTLP)
@@ -171,6 +280,11 @@ func (b *MetricPipelineBuilder) WithOTLPOutput(opts ...OTLPOutputOption) *Metric
 	return b
 }
 
+func (b *MetricPipelineBuilder) WithAdditionalOTLPOutput(name string, opts ...OTLPOutputOption) *MetricPipelineBuilder {
+	b.additionalOutputs = append(b.additionalOutputs, newAdditionalOTLPOutput(name, opts...))
+	return b
+}
+
 func (b *MetricPipelineBuilder) WithStatusCondition(cond metav1.Condition) *MetricPipelineBuilder {
 	b.statusConditions = append(b.statusConditions, cond)
 	return b
@@ -192,14 +306,19 @@ func (b *MetricPipelineBuilder) Build() telemetryv1alpha1.MetricPipeline {
 		},
 		Spec: telemetryv1alpha1.MetricPipelineSpec{
 			Input: telemetryv1alpha1.MetricPipelineInput{
-				Runtime:    b.inRuntime,
-				Prometheus: b.inPrometheus,
-				Istio:      b.inIstio,
-				Otlp:       b.inOTLP,
+				Runtime:     b.inRuntime,
+				Prometheus:  b.inPrometheus,
+				Istio:       b.inIstio,
+				Cluster:     b.inCluster,
+				SpanMetrics: b.inSpanMetrics,
+				Otlp:        b.inOTLP,
 			},
+			Filters:    b.filters,
+			Transforms: b.transforms,
 			Output: telemetryv1alpha1.MetricPipelineOutput{
 				Otlp: b.outOTLP,
 			},
+			AdditionalOutputs: b.additionalOutputs,
 		},
 	}
 
@@ -8,6 +8,17 @@ import (
 
 type OTLPOutputOption func(*telemetryv1alpha1.OtlpOutput)
 
+func newAdditionalOTLPOutput(name string, opts ...OTLPOutputOption) telemetryv1alpha1.AdditionalOtlpOutput {
+	output := &telemetryv1alpha1.OtlpOutput{
+		Endpoint: telemetryv1alpha1.ValueType{Value: "https://localhost:4317"},
+	}
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	return telemetryv1alpha1.AdditionalOtlpOutput{Name: name, Otlp: output}
+}
+
 func OTLPEndpoint(endpoint string) OTLPOutputOption {
 	return func(output *telemetryv1alpha1.OtlpOutput) {
 		output.Endpoint = telemetryv1alpha1.ValueType{Value: endpoint}
@@ -66,6 +77,27 @@ func OTLPBasicAuthFromSecret(secretName, secretNamespace, userKey, passwordKey s
 	}
 }
 
+func OTLPOAuth2(tokenURL, clientID, clientSecret string, scopes ...string) OTLPOutputOption {
+	return func(output *telemetryv1alpha1.OtlpOutput) {
+		output.Authentication = &telemetryv1alpha1.AuthenticationOptions{
+			OAuth2: &telemetryv1alpha1.OAuth2Options{
+				TokenURL:     telemetryv1alpha1.ValueType{Value: tokenURL},
+				ClientID:     telemetryv1alpha1.ValueType{Value: clientID},
+				ClientSecret: telemetryv1alpha1.ValueType{Value: clientSecret},
+				Scopes:       scopes,
+			},
+		}
+	}
+}
+
+func OTLPBearerToken(token string) OTLPOutputOption {
+	return func(output *telemetryv1alpha1.OtlpOutput) {
+		output.Authentication = &telemetryv1alpha1.AuthenticationOptions{
+			BearerToken: &telemetryv1alpha1.ValueType{Value: token},
+		}
+	}
+}
+
 func OTLPCustomHeader(name, value, prefix string) OTLPOutputOption {
 	return func(output *telemetryv1alpha1.OtlpOutput) {
 		output.Headers = append(output.Headers, telemetryv1alpha1.Header{
@@ -16,8 +16,14 @@ type TracePipelineBuilder struct {
 	name   string
 	labels map[string]string
 
-	statusConditions []metav1.Condition
-	outOTLP          *telemetryv1alpha1.OtlpOutput
+	statusConditions  []metav1.Condition
+	inOTLP            *telemetryv1alpha1.TracePipelineOtlpInput
+	sampling          *telemetryv1alpha1.TracePipelineSampling
+	spanMetrics       *telemetryv1alpha1.TracePipelineSpanMetrics
+	filters           []telemetryv1alpha1.TracePipelineFilter
+	transforms        []telemetryv1alpha1.TransformRule
+	additionalOutputs []telemetryv1alpha1.AdditionalOtlpOutput
+	outOTLP           *telemetryv1alpha1.OtlpOutput
 }
 
 func NewTracePipelineBuilder() *TracePipelineBuilder {
@@ -44,6 +50,65 @@ func (b *TracePipelineBuilder) WithStatusCondition(cond metav1.Condition) *Trace
 	return b
 }
 
+func (b *TracePipelineBuilder) WithOTLPInputIncludeNamespaces(namespaces ...string) *TracePipelineBuilder {
+	b.inOTLP = &telemetryv1alpha1.TracePipelineOtlpInput{
+		Namespaces: &telemetryv1alpha1.TracePipelineInputNamespaceSelector{
+			Include: namespaces,
+		},
+	}
+	return b
+}
+
+func (b *TracePipelineBuilder) WithOTLPInputExcludeNamespaces(namespaces ...string) *TracePipelineBuilder {
+	b.inOTLP = &telemetryv1alpha1.TracePipelineOtlpInput{
+		Namespaces: &telemetryv1alpha1.TracePipelineInputNamespaceSelector{
+			Exclude: namespaces,
+		},
+	}
+	return b
+}
+
+func (b *TracePipelineBuilder) WithFilterCondition(condition string) *TracePipelineBuilder {
+	b.filters = append(b.filters, telemetryv1alpha1.TracePipelineFilter{Condition: condition})
+	return b
+}
+
+func (b *TracePipelineBuilder) WithAttributeFilter(attribute telemetryv1alpha1.TracePipelineAttributeFilter) *TracePipelineBuilder {
+	b.filters = append(b.filters, telemetryv1alpha1.TracePipelineFilter{Attribute: &attribute})
+	return b
+}
+
+func (b *TracePipelineBuilder) WithTransform(rule telemetryv1alpha1.TransformRule) *TracePipelineBuilder {
+	b.transforms = append(b.transforms, rule)
+	return b
+}
+
+func (b *TracePipelineBuilder) WithProbabilisticSampling(percentage string) *TracePipelineBuilder {
+	b.sampling = &telemetryv1alpha1.TracePipelineSampling{
+		Probabilistic: &telemetryv1alpha1.ProbabilisticSampling{
+			Percentage: percentage,
+		},
+	}
+	return b
+}
+
+func (b *TracePipelineBuilder) WithTailSampling(decisionWait string, policies ...telemetryv1alpha1.TailSamplingPolicy) *TracePipelineBuilder {
+	b.sampling = &telemetryv1alpha1.TracePipelineSampling{
+		Tail: &telemetryv1alpha1.TailSampling{
+			DecisionWait: decisionWait,
+			Policies:     policies,
+		},
+	}
+	return b
+}
+
+func (b *TracePipelineBuilder) WithSpanMetrics(dimensions ...string) *TracePipelineBuilder {
+	b.spanMetrics = &telemetryv1alpha1.TracePipelineSpanMetrics{
+		Dimensions: dimensions,
+	}
+	return b
+}
+
 func (b *TracePipelineBuilder) WithOTLPOutput(opts ...OTLPOutputOption) *TracePipelineBuilder {
 	for _, opt := range opts {
 		opt(b.outOTLP)
@@ -51,6 +116,11 @@ func (b *TracePipelineBuilder) WithOTLPOutput(opts ...OTLPOutputOption) *TracePi
 	return b
 }
 
+func (b *TracePipelineBuilder) WithAdditionalOTLPOutput(name string, opts ...OTLPOutputOption) *TracePipelineBuilder {
+	b.additionalOutputs = append(b.additionalOutputs, newAdditionalOTLPOutput(name, opts...))
+	return b
+}
+
 func (b *TracePipelineBuilder) Build() telemetryv1alpha1.TracePipeline {
 	name := b.name
 	if name == "" {
@@ -64,9 +134,17 @@ func (b *TracePipelineBuilder) Build() telemetryv1alpha1.TracePipeline {
 			Labels:     b.labels,
 		},
 		Spec: telemetryv1alpha1.TracePipelineSpec{
+			Input: telemetryv1alpha1.TracePipelineInput{
+				Otlp: b.inOTLP,
+			},
+			Filters:     b.filters,
+			Sampling:    b.sampling,
+			SpanMetrics: b.spanMetrics,
+			Transforms:  b.transforms,
 			Output: telemetryv1alpha1.TracePipelineOutput{
 				Otlp: b.outOTLP,
 			},
+			AdditionalOutputs: b.additionalOutputs,
 		},
 		Status: telemetryv1alpha1.TracePipelineStatus{
 			Conditions: b.statusConditions,
@@ -0,0 +1,55 @@
+package tlscert
+
+import (
+	"context"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+// ClientCertificate is a client certificate and its private key, as configured for the output of a pipeline.
+type ClientCertificate struct {
+	Cert *telemetryv1alpha1.ValueType
+	Key  *telemetryv1alpha1.ValueType
+}
+
+type certificateValidator interface {
+	ValidateCertificate(ctx context.Context, cert, key *telemetryv1alpha1.ValueType) error
+}
+
+// OtlpClientCertificates returns the client certificates of an OTLP output and of the additional outputs of a pipeline.
+// Outputs without a client certificate are skipped.
+func OtlpClientCertificates(otlpOutput *telemetryv1alpha1.OtlpOutput, additionalOutputs []telemetryv1alpha1.AdditionalOtlpOutput) []ClientCertificate {
+	otlpOutputs := []*telemetryv1alpha1.OtlpOutput{otlpOutput}
+	for i := range additionalOutputs {
+		otlpOutputs = append(otlpOutputs, additionalOutputs[i].Otlp)
+	}
+
+	var certs []ClientCertificate
+	for _, otlp := range otlpOutputs {
+		if otlp != nil && otlp.TLS != nil && (otlp.TLS.Cert != nil || otlp.TLS.Key != nil) {
+			certs = append(certs, ClientCertificate{Cert: otlp.TLS.Cert, Key: otlp.TLS.Key})
+		}
+	}
+
+	return certs
+}
+
+// ValidateCertificates validates the client certificates of all outputs of a pipeline.
+// An invalid or expired certificate takes precedence over one that is about to expire.
+func ValidateCertificates(ctx context.Context, validator certificateValidator, certs []ClientCertificate) error {
+	var aboutToExpireErr error
+	for _, c := range certs {
+		err := validator.ValidateCertificate(ctx, c.Cert, c.Key)
+		if err == nil {
+			continue
+		}
+		if !IsCertAboutToExpireError(err) {
+			return err
+		}
+		if aboutToExpireErr == nil {
+			aboutToExpireErr = err
+		}
+	}
+
+	return aboutToExpireErr
+}
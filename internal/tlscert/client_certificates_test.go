@@ -0,0 +1,63 @@
+package tlscert
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+)
+
+type validatorStub map[string]error
+
+func (v validatorStub) ValidateCertificate(_ context.Context, cert, _ *telemetryv1alpha1.ValueType) error {
+	return v[cert.Value]
+}
+
+func TestOtlpClientCertificates(t *testing.T) {
+	primary := &telemetryv1alpha1.OtlpOutput{
+		TLS: &telemetryv1alpha1.OtlpTLS{Cert: &telemetryv1alpha1.ValueType{Value: "primary"}, Key: &telemetryv1alpha1.ValueType{Value: "key"}},
+	}
+	additionalOutputs := []telemetryv1alpha1.AdditionalOtlpOutput{
+		{Name: "plain", Otlp: &telemetryv1alpha1.OtlpOutput{}},
+		{Name: "audit", Otlp: &telemetryv1alpha1.OtlpOutput{
+			TLS: &telemetryv1alpha1.OtlpTLS{Cert: &telemetryv1alpha1.ValueType{Value: "audit"}},
+		}},
+	}
+
+	certs := OtlpClientCertificates(primary, additionalOutputs)
+	require.Len(t, certs, 2)
+	require.Equal(t, "primary", certs[0].Cert.Value)
+	require.Equal(t, "audit", certs[1].Cert.Value)
+	require.Nil(t, certs[1].Key)
+
+	require.Empty(t, OtlpClientCertificates(nil, nil))
+}
+
+func TestValidateCertificates(t *testing.T) {
+	certs := []ClientCertificate{
+		{Cert: &telemetryv1alpha1.ValueType{Value: "about-to-expire"}},
+		{Cert: &telemetryv1alpha1.ValueType{Value: "valid"}},
+		{Cert: &telemetryv1alpha1.ValueType{Value: "expired"}},
+	}
+
+	validator := validatorStub{
+		"about-to-expire": &CertAboutToExpireError{},
+		"expired":         &CertExpiredError{},
+	}
+
+	t.Run("invalid certificate takes precedence", func(t *testing.T) {
+		err := ValidateCertificates(context.Background(), validator, certs)
+		require.True(t, IsCertExpiredError(err))
+	})
+
+	t.Run("certificate about to expire", func(t *testing.T) {
+		err := ValidateCertificates(context.Background(), validator, certs[:2])
+		require.True(t, IsCertAboutToExpireError(err))
+	})
+
+	t.Run("valid certificates", func(t *testing.T) {
+		require.NoError(t, ValidateCertificates(context.Background(), validator, certs[1:2]))
+	})
+}
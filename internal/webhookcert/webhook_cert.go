@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +41,22 @@ func EnsureCertificate(ctx context.Context, client client.Client, config Config)
 	return k8sutils.CreateOrUpdateValidatingWebhookConfiguration(ctx, client, &validatingWebhookConfig)
 }
 
+// CACert returns the PEM-encoded CA certificate of the webhook server, which clients of the webhook server have to trust.
+// It does not create the certificate, which is done by EnsureCertificate.
+func CACert(ctx context.Context, client client.Reader, caSecretName types.NamespacedName) ([]byte, error) {
+	var caSecret corev1.Secret
+	if err := client.Get(ctx, caSecretName, &caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get ca cert secret: %w", err)
+	}
+
+	caCertPEM, found := caSecret.Data[caCertFile]
+	if !found {
+		return nil, fmt.Errorf("ca cert secret has no %s key", caCertFile)
+	}
+
+	return caCertPEM, nil
+}
+
 func dnsNames(webhookService types.NamespacedName) (host string, alternativeDNSNames []string) {
 	host = fmt.Sprintf("%s.%s.svc", webhookService.Name, webhookService.Namespace)
 	alternativeDNSNames = []string{
@@ -53,6 +70,8 @@ func dnsNames(webhookService types.NamespacedName) (host string, alternativeDNSN
 func makeValidatingWebhookConfig(certificate []byte, config Config) admissionregistrationv1.ValidatingWebhookConfiguration {
 	logPipelinePath := "/validate-logpipeline"
 	logParserPath := "/validate-logparser"
+	tracePipelinePath := "/validate-tracepipeline"
+	metricPipelinePath := "/validate-metricpipeline"
 	failurePolicy := admissionregistrationv1.Fail
 	matchPolicy := admissionregistrationv1.Exact
 	sideEffects := admissionregistrationv1.SideEffectClassNone
@@ -135,6 +154,62 @@ func makeValidatingWebhookConfig(certificate []byte, config Config) admissionreg
 					},
 				},
 			},
+			{
+				AdmissionReviewVersions: []string{"v1beta1", "v1"},
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Name:      config.ServiceName.Name,
+						Namespace: config.ServiceName.Namespace,
+						Port:      &servicePort,
+						Path:      &tracePipelinePath,
+					},
+					CABundle: certificate,
+				},
+				FailurePolicy:  &failurePolicy,
+				MatchPolicy:    &matchPolicy,
+				Name:           "validation.tracepipelines.telemetry.kyma-project.io",
+				SideEffects:    &sideEffects,
+				TimeoutSeconds: &timeout,
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: operations,
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   apiGroups,
+							APIVersions: apiVersions,
+							Scope:       &scope,
+							Resources:   []string{"tracepipelines"},
+						},
+					},
+				},
+			},
+			{
+				AdmissionReviewVersions: []string{"v1beta1", "v1"},
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Name:      config.ServiceName.Name,
+						Namespace: config.ServiceName.Namespace,
+						Port:      &servicePort,
+						Path:      &metricPipelinePath,
+					},
+					CABundle: certificate,
+				},
+				FailurePolicy:  &failurePolicy,
+				MatchPolicy:    &matchPolicy,
+				Name:           "validation.metricpipelines.telemetry.kyma-project.io",
+				SideEffects:    &sideEffects,
+				TimeoutSeconds: &timeout,
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: operations,
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   apiGroups,
+							APIVersions: apiVersions,
+							Scope:       &scope,
+							Resources:   []string{"metricpipelines"},
+						},
+					},
+				},
+			},
 		},
 	}
 }
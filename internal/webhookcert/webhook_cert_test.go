@@ -65,10 +65,12 @@ func TestEnsureCertificate(t *testing.T) {
 	require.Equal(t, name, validatingWebhookConfiguration.Name)
 	require.Equal(t, labels, validatingWebhookConfiguration.Labels)
 
-	require.Equal(t, 2, len(validatingWebhookConfiguration.Webhooks))
+	require.Equal(t, 4, len(validatingWebhookConfiguration.Webhooks))
 
 	require.Equal(t, int32(15), *validatingWebhookConfiguration.Webhooks[0].TimeoutSeconds)
 	require.Equal(t, int32(15), *validatingWebhookConfiguration.Webhooks[1].TimeoutSeconds)
+	require.Equal(t, int32(15), *validatingWebhookConfiguration.Webhooks[2].TimeoutSeconds)
+	require.Equal(t, int32(15), *validatingWebhookConfiguration.Webhooks[3].TimeoutSeconds)
 
 	var chainChecker certChainCheckerImpl
 	certValid, err := chainChecker.checkRoot(context.Background(), serverCert, validatingWebhookConfiguration.Webhooks[0].ClientConfig.CABundle)
@@ -81,24 +83,38 @@ func TestEnsureCertificate(t *testing.T) {
 
 	require.Equal(t, webhookService.Name, validatingWebhookConfiguration.Webhooks[0].ClientConfig.Service.Name)
 	require.Equal(t, webhookService.Name, validatingWebhookConfiguration.Webhooks[1].ClientConfig.Service.Name)
+	require.Equal(t, webhookService.Name, validatingWebhookConfiguration.Webhooks[2].ClientConfig.Service.Name)
+	require.Equal(t, webhookService.Name, validatingWebhookConfiguration.Webhooks[3].ClientConfig.Service.Name)
 
 	require.Equal(t, webhookService.Namespace, validatingWebhookConfiguration.Webhooks[0].ClientConfig.Service.Namespace)
 	require.Equal(t, webhookService.Namespace, validatingWebhookConfiguration.Webhooks[1].ClientConfig.Service.Namespace)
+	require.Equal(t, webhookService.Namespace, validatingWebhookConfiguration.Webhooks[2].ClientConfig.Service.Namespace)
+	require.Equal(t, webhookService.Namespace, validatingWebhookConfiguration.Webhooks[3].ClientConfig.Service.Namespace)
 
 	require.Equal(t, int32(443), *validatingWebhookConfiguration.Webhooks[0].ClientConfig.Service.Port)
 	require.Equal(t, int32(443), *validatingWebhookConfiguration.Webhooks[1].ClientConfig.Service.Port)
+	require.Equal(t, int32(443), *validatingWebhookConfiguration.Webhooks[2].ClientConfig.Service.Port)
+	require.Equal(t, int32(443), *validatingWebhookConfiguration.Webhooks[3].ClientConfig.Service.Port)
 
 	require.Equal(t, "/validate-logpipeline", *validatingWebhookConfiguration.Webhooks[0].ClientConfig.Service.Path)
 	require.Equal(t, "/validate-logparser", *validatingWebhookConfiguration.Webhooks[1].ClientConfig.Service.Path)
+	require.Equal(t, "/validate-tracepipeline", *validatingWebhookConfiguration.Webhooks[2].ClientConfig.Service.Path)
+	require.Equal(t, "/validate-metricpipeline", *validatingWebhookConfiguration.Webhooks[3].ClientConfig.Service.Path)
 
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[0].Rules[0].APIGroups, "telemetry.kyma-project.io")
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[1].Rules[0].APIGroups, "telemetry.kyma-project.io")
+	require.Contains(t, validatingWebhookConfiguration.Webhooks[2].Rules[0].APIGroups, "telemetry.kyma-project.io")
+	require.Contains(t, validatingWebhookConfiguration.Webhooks[3].Rules[0].APIGroups, "telemetry.kyma-project.io")
 
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[0].Rules[0].APIVersions, "v1alpha1")
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[1].Rules[0].APIVersions, "v1alpha1")
+	require.Contains(t, validatingWebhookConfiguration.Webhooks[2].Rules[0].APIVersions, "v1alpha1")
+	require.Contains(t, validatingWebhookConfiguration.Webhooks[3].Rules[0].APIVersions, "v1alpha1")
 
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[0].Rules[0].Resources, "logpipelines")
 	require.Contains(t, validatingWebhookConfiguration.Webhooks[1].Rules[0].Resources, "logparsers")
+	require.Contains(t, validatingWebhookConfiguration.Webhooks[2].Rules[0].Resources, "tracepipelines")
+	require.Contains(t, validatingWebhookConfiguration.Webhooks[3].Rules[0].Resources, "metricpipelines")
 
 }
 
@@ -286,3 +302,34 @@ func TestReuseExistingCertificate(t *testing.T) {
 	require.Equal(t, newValidatingWebhookConfiguration.Webhooks[1].ClientConfig.CABundle,
 		updatedValidatingWebhookConfiguration.Webhooks[1].ClientConfig.CABundle)
 }
+
+func TestCACert(t *testing.T) {
+	client := fake.NewClientBuilder().Build()
+
+	_, err := CACert(context.Background(), client, caBundleSecret)
+	require.Error(t, err)
+
+	certDir, err := os.MkdirTemp("", "certificate")
+	require.NoError(t, err)
+	defer func(path string) {
+		deleteErr := os.RemoveAll(path)
+		require.NoError(t, deleteErr)
+	}(certDir)
+	config := Config{
+		CertDir:      certDir,
+		ServiceName:  webhookService,
+		CASecretName: caBundleSecret,
+		WebhookName:  webhookName,
+	}
+
+	err = EnsureCertificate(context.Background(), client, config)
+	require.NoError(t, err)
+
+	var secret corev1.Secret
+	err = client.Get(context.Background(), config.CASecretName, &secret)
+	require.NoError(t, err)
+
+	caCertPEM, err := CACert(context.Background(), client, caBundleSecret)
+	require.NoError(t, err)
+	require.Equal(t, secret.Data["ca.crt"], caCertPEM)
+}
@@ -30,6 +30,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	istiosecurityclientv1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -52,6 +53,7 @@ import (
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/controllers/operator"
 	telemetrycontrollers "github.com/kyma-project/telemetry-manager/controllers/telemetry"
+	fluentbitconfig "github.com/kyma-project/telemetry-manager/internal/fluentbit/config"
 	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config/builder"
 	"github.com/kyma-project/telemetry-manager/internal/k8sutils"
 	"github.com/kyma-project/telemetry-manager/internal/logger"
@@ -66,11 +68,14 @@ import (
 	"github.com/kyma-project/telemetry-manager/internal/resources/selfmonitor"
 	"github.com/kyma-project/telemetry-manager/internal/selfmonitor/prober"
 	selfmonitorwebhook "github.com/kyma-project/telemetry-manager/internal/selfmonitor/webhook"
+	"github.com/kyma-project/telemetry-manager/internal/tap"
 	"github.com/kyma-project/telemetry-manager/internal/webhookcert"
 	"github.com/kyma-project/telemetry-manager/webhook/dryrun"
 	logparserwebhook "github.com/kyma-project/telemetry-manager/webhook/logparser"
 	logpipelinewebhook "github.com/kyma-project/telemetry-manager/webhook/logpipeline"
 	"github.com/kyma-project/telemetry-manager/webhook/logpipeline/validation"
+	metricpipelinewebhook "github.com/kyma-project/telemetry-manager/webhook/metricpipeline"
+	tracepipelinewebhook "github.com/kyma-project/telemetry-manager/webhook/tracepipeline"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -104,12 +109,27 @@ var (
 	traceGatewayMemoryRequest        string
 	traceGatewayDynamicMemoryRequest string
 
+	logGatewayImage                string
+	logGatewayPriorityClass        string
+	logGatewayCPULimit             string
+	logGatewayDynamicCPULimit      string
+	logGatewayMemoryLimit          string
+	logGatewayDynamicMemoryLimit   string
+	logGatewayCPURequest           string
+	logGatewayDynamicCPURequest    string
+	logGatewayMemoryRequest        string
+	logGatewayDynamicMemoryRequest string
+
 	fluentBitMemoryBufferLimit         string
 	fluentBitFsBufferLimit             string
 	fluentBitCPULimit                  string
 	fluentBitMemoryLimit               string
 	fluentBitCPURequest                string
 	fluentBitMemoryRequest             string
+	fluentBitEventsCPULimit            string
+	fluentBitEventsMemoryLimit         string
+	fluentBitEventsCPURequest          string
+	fluentBitEventsMemoryRequest       string
 	fluentBitImage                     string
 	fluentBitExporterImage             string
 	fluentBitConfigPrepperImageVersion string
@@ -148,10 +168,19 @@ const (
 
 	fluentBitDaemonSet = "telemetry-fluent-bit"
 	webhookServiceName = "telemetry-manager-webhook"
+	webhookServicePort = 443
+	webhookCertSecret  = "telemetry-webhook-cert"
+
+	logOTLPServiceName = "telemetry-otlp-logs"
 
 	metricOTLPServiceName = "telemetry-otlp-metrics"
+	metricGatewayName     = "telemetry-metric-gateway"
+	metricAgentName       = "telemetry-metric-agent"
+	metricClusterName     = "telemetry-metric-cluster-collector"
 
-	traceOTLPServiceName = "telemetry-otlp-traces"
+	traceOTLPServiceName          = "telemetry-otlp-traces"
+	traceGatewayName              = "telemetry-trace-collector"
+	traceLoadBalancingServiceName = "telemetry-trace-collector-load-balancing"
 
 	selfMonitorName = "telemetry-self-monitor"
 )
@@ -196,22 +225,39 @@ func getEnvOrDefault(envVar string, defaultValue string) string {
 
 //+kubebuilder:rbac:groups="",namespace=system,resources=secrets,verbs=create;update;patch;delete
 //+kubebuilder:rbac:groups="",namespace=system,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",namespace=system,resources=persistentvolumeclaims,verbs=get;list;watch;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=nodes/metrics,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=nodes/stats,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",namespace=system,resources=pods,verbs=delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=nodes/spec,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=replicationcontrollers,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=replicationcontrollers/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
 //+kubebuilder:rbac:urls=/metrics,verbs=get
 //+kubebuilder:rbac:urls=/metrics/cadvisor,verbs=get
 
 //+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;list;watch
+
 //+kubebuilder:rbac:groups=apps,namespace=system,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,namespace=system,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,namespace=system,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=daemonsets;deployments;statefulsets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=extensions,resources=daemonsets;deployments;replicasets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch
+
+//+kubebuilder:rbac:groups=autoscaling,namespace=system,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
 
 //+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
 
@@ -227,7 +273,7 @@ func getEnvOrDefault(envVar string, defaultValue string) string {
 // +kubebuilder:rbac:groups=security.istio.io,resources=peerauthentications,verbs=get;list;watch
 // +kubebuilder:rbac:groups=security.istio.io,namespace=system,resources=peerauthentications,verbs=create;update;patch;delete
 
-// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
 
 func main() {
 	flag.StringVar(&logLevel, "log-level", getEnvOrDefault("APP_LOG_LEVEL", "debug"), "Log level (debug, info, warn, error, fatal)")
@@ -258,6 +304,17 @@ func main() {
 	flag.StringVar(&metricGatewayDynamicMemoryRequest, "metric-gateway-dynamic-memory-request", "0", "Additional memory request for metrics OpenTelemetry Collector per MetricPipeline")
 	flag.IntVar(&maxMetricPipelines, "metric-gateway-pipelines", 3, "Maximum number of MetricPipelines to be created. If 0, no limit is applied.")
 
+	flag.StringVar(&logGatewayImage, "log-gateway-image", defaultOtelImage, "Image for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayPriorityClass, "log-gateway-priority-class", "", "Priority class name for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayCPULimit, "log-gateway-cpu-limit", "700m", "CPU limit for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayDynamicCPULimit, "log-gateway-dynamic-cpu-limit", "500m", "Additional CPU limit for logs OpenTelemetry Collector per LogPipeline with OTLP output")
+	flag.StringVar(&logGatewayMemoryLimit, "log-gateway-memory-limit", "500Mi", "Memory limit for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayDynamicMemoryLimit, "log-gateway-dynamic-memory-limit", "1500Mi", "Additional memory limit for logs OpenTelemetry Collector per LogPipeline with OTLP output")
+	flag.StringVar(&logGatewayCPURequest, "log-gateway-cpu-request", "100m", "CPU request for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayDynamicCPURequest, "log-gateway-dynamic-cpu-request", "100m", "Additional CPU request for logs OpenTelemetry Collector per LogPipeline with OTLP output")
+	flag.StringVar(&logGatewayMemoryRequest, "log-gateway-memory-request", "32Mi", "Memory request for logs OpenTelemetry Collector")
+	flag.StringVar(&logGatewayDynamicMemoryRequest, "log-gateway-dynamic-memory-request", "0", "Additional memory request for logs OpenTelemetry Collector per LogPipeline with OTLP output")
+
 	flag.StringVar(&fluentBitMemoryBufferLimit, "fluent-bit-memory-buffer-limit", "10M", "Fluent Bit memory buffer limit per log pipeline")
 	flag.StringVar(&fluentBitFsBufferLimit, "fluent-bit-filesystem-buffer-limit", "1G", "Fluent Bit filesystem buffer limit per log pipeline")
 	flag.StringVar(&deniedFilterPlugins, "fluent-bit-denied-filter-plugins", "kubernetes,rewrite_tag,multiline", "Comma separated list of denied filter plugins even if allowUnsupportedPlugins is enabled. If empty, all filter plugins are allowed.")
@@ -265,6 +322,10 @@ func main() {
 	flag.StringVar(&fluentBitMemoryLimit, "fluent-bit-memory-limit", "1Gi", "Memory limit for fluent-bit")
 	flag.StringVar(&fluentBitCPURequest, "fluent-bit-cpu-request", "100m", "CPU request for fluent-bit")
 	flag.StringVar(&fluentBitMemoryRequest, "fluent-bit-memory-request", "50Mi", "Memory request for fluent-bit")
+	flag.StringVar(&fluentBitEventsCPULimit, "fluent-bit-events-cpu-limit", "500m", "CPU limit for the fluent-bit instance that collects Kubernetes events")
+	flag.StringVar(&fluentBitEventsMemoryLimit, "fluent-bit-events-memory-limit", "512Mi", "Memory limit for the fluent-bit instance that collects Kubernetes events")
+	flag.StringVar(&fluentBitEventsCPURequest, "fluent-bit-events-cpu-request", "10m", "CPU request for the fluent-bit instance that collects Kubernetes events")
+	flag.StringVar(&fluentBitEventsMemoryRequest, "fluent-bit-events-memory-request", "50Mi", "Memory request for the fluent-bit instance that collects Kubernetes events")
 	flag.StringVar(&fluentBitImage, "fluent-bit-image", defaultFluentBitImage, "Image for fluent-bit")
 	flag.StringVar(&fluentBitExporterImage, "fluent-bit-exporter-image", defaultFluentBitExporterImage, "Image for exporting fluent bit filesystem usage")
 	flag.StringVar(&fluentBitPriorityClassName, "fluent-bit-priority-class-name", "", "Name of the priority class of fluent bit ")
@@ -280,7 +341,7 @@ func main() {
 	flag.StringVar(&deniedOutputPlugins, "fluent-bit-denied-output-plugins", "", "Comma separated list of denied output plugins even if allowUnsupportedPlugins is enabled. If empty, all output plugins are allowed.")
 	flag.IntVar(&maxLogPipelines, "fluent-bit-max-pipelines", 5, "Maximum number of LogPipelines to be created. If 0, no limit is applied.")
 
-	flag.BoolVar(&enableWebhook, "validating-webhook-enabled", false, "Create validating webhook for LogPipelines and LogParsers.")
+	flag.BoolVar(&enableWebhook, "validating-webhook-enabled", false, "Create validating webhook for LogPipelines, LogParsers, TracePipelines, and MetricPipelines.")
 
 	flag.Parse()
 	if err := validateFlags(); err != nil {
@@ -324,15 +385,18 @@ func main() {
 			// The operator handles various resource that are namespace-scoped, and additionally some resources that are cluster-scoped (clusterroles, clusterrolebindings, etc.).
 			// For namespace-scoped resources we want to restrict the operator permissions to only fetch resources from a given namespace.
 			ByObject: map[client.Object]cache.ByObject{
-				&appsv1.Deployment{}:          {Field: setNamespaceFieldSelector()},
-				&appsv1.ReplicaSet{}:          {Field: setNamespaceFieldSelector()},
-				&appsv1.DaemonSet{}:           {Field: setNamespaceFieldSelector()},
-				&corev1.ConfigMap{}:           {Field: setNamespaceFieldSelector()},
-				&corev1.ServiceAccount{}:      {Field: setNamespaceFieldSelector()},
-				&corev1.Service{}:             {Field: setNamespaceFieldSelector()},
-				&networkingv1.NetworkPolicy{}: {Field: setNamespaceFieldSelector()},
-				&corev1.Secret{}:              {Field: setNamespaceFieldSelector()},
-				&operatorv1alpha1.Telemetry{}: {Field: setNamespaceFieldSelector()},
+				&appsv1.Deployment{}:                     {Field: setNamespaceFieldSelector()},
+				&appsv1.ReplicaSet{}:                     {Field: setNamespaceFieldSelector()},
+				&appsv1.DaemonSet{}:                      {Field: setNamespaceFieldSelector()},
+				&appsv1.StatefulSet{}:                    {Field: setNamespaceFieldSelector()},
+				&autoscalingv2.HorizontalPodAutoscaler{}: {Field: setNamespaceFieldSelector()},
+				&corev1.ConfigMap{}:                      {Field: setNamespaceFieldSelector()},
+				&corev1.ServiceAccount{}:                 {Field: setNamespaceFieldSelector()},
+				&corev1.PersistentVolumeClaim{}:          {Field: setNamespaceFieldSelector()},
+				&corev1.Service{}:                        {Field: setNamespaceFieldSelector()},
+				&networkingv1.NetworkPolicy{}:            {Field: setNamespaceFieldSelector()},
+				&corev1.Secret{}:                         {Field: setNamespaceFieldSelector()},
+				&operatorv1alpha1.Telemetry{}:            {Field: setNamespaceFieldSelector()},
 			},
 		},
 		Client: client.Options{
@@ -381,6 +445,10 @@ func main() {
 
 	if enableWebhook {
 		enableWebhookServer(mgr, webhookConfig)
+		mgr.GetWebhookServer().Register(tap.PathPrefix, tap.NewHandler(
+			mgr.GetClient(),
+			telemetryNamespace,
+			tap.WithLogger(ctrl.Log.WithName("tap"))))
 	}
 
 	if enableWebhook && enableSelfMonitor {
@@ -389,6 +457,7 @@ func main() {
 			selfmonitorwebhook.WithTracePipelineSubscriber(tracePipelineReconcileTriggerChan),
 			selfmonitorwebhook.WithMetricPipelineSubscriber(metricPipelineReconcileTriggerChan),
 			selfmonitorwebhook.WithLogPipelineSubscriber(logPipelineReconcileTriggerChan),
+			selfmonitorwebhook.WithEventRecorder(mgr.GetEventRecorderFor("telemetry-manager")),
 			selfmonitorwebhook.WithLogger(ctrl.Log.WithName("self-monitor-webhook"))))
 	}
 
@@ -410,16 +479,31 @@ func enableTelemetryModuleController(mgr manager.Manager, webhookConfig telemetr
 func enableLoggingController(mgr manager.Manager, reconcileTriggerChan <-chan event.GenericEvent) {
 	setupLog.Info("Starting with logging controllers")
 
-	mgr.GetWebhookServer().Register("/validate-logpipeline", &webhook.Admission{Handler: createLogPipelineValidator(mgr.GetClient())})
-	mgr.GetWebhookServer().Register("/validate-logparser", &webhook.Admission{Handler: createLogParserValidator(mgr.GetClient())})
-
 	flowHealthProber, err := prober.NewLogPipelineProber(types.NamespacedName{Name: selfMonitorName, Namespace: telemetryNamespace})
 	if err != nil {
 		setupLog.Error(err, "Failed to create flow health prober")
 		os.Exit(1)
 	}
 
-	if err := createLogPipelineController(mgr.GetClient(), reconcileTriggerChan, flowHealthProber).SetupWithManager(mgr); err != nil {
+	dataFlowStatsProber, err := prober.NewLogPipelineStatsProber(types.NamespacedName{Name: selfMonitorName, Namespace: telemetryNamespace})
+	if err != nil {
+		setupLog.Error(err, "Failed to create data flow stats prober")
+		os.Exit(1)
+	}
+
+	gatewayFlowHealthProber, err := prober.NewLogGatewayPipelineProber(types.NamespacedName{Name: selfMonitorName, Namespace: telemetryNamespace})
+	if err != nil {
+		setupLog.Error(err, "Failed to create log gateway flow health prober")
+		os.Exit(1)
+	}
+
+	gatewayDataFlowStatsProber, err := prober.NewLogGatewayPipelineStatsProber(types.NamespacedName{Name: selfMonitorName, Namespace: telemetryNamespace})
+	if err != nil {
+		setupLog.Error(err, "Failed to create log gateway data flow stats prober")
+		os.Exit(1)
+	}
+
+	if err := createLogPipelineController(mgr.GetClient(), reconcileTriggerChan, flowHealthProber, dataFlowStatsProber, gatewayFlowHealthProber, gatewayDataFlowStatsProber).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Failed to create controller", "controller", "LogPipeline")
 		os.Exit(1)
 	}
@@ -432,6 +516,7 @@ func enableLoggingController(mgr manager.Manager, reconcileTriggerChan <-chan ev
 
 func enableTracingController(mgr manager.Manager, reconcileTriggerChan <-chan event.GenericEvent) {
 	setupLog.Info("Starting with tracing controller")
+
 	var err error
 	var flowHealthProber *prober.OTelPipelineProber
 	if flowHealthProber, err = prober.NewTracePipelineProber(types.NamespacedName{Name: selfMonitorName, Namespace: telemetryNamespace}); err != nil {
@@ -439,7 +524,13 @@ func enableTracingController(mgr manager.Manager, reconcileTriggerChan <-chan ev
 		os.Exit(1)
 	}
 
-	if err := createTracePipelineController(mgr.GetClient(), reconcileTriggerChan, flowHealthProber).SetupWithManager(mgr); err != nil {
+	var dataFlowStatsProber *prober.PipelineStatsProber
+	if dataFlowStatsProber, err = prober.NewTracePipelineStatsProber(types.NamespacedName{Name: selfMonitorName, Namespace: telemetryNamespace}); err != nil {
+		setupLog.Error(err, "Failed to create data flow stats prober")
+		os.Exit(1)
+	}
+
+	if err := createTracePipelineController(mgr.GetClient(), reconcileTriggerChan, flowHealthProber, dataFlowStatsProber).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Failed to create controller", "controller", "TracePipeline")
 		os.Exit(1)
 	}
@@ -447,6 +538,7 @@ func enableTracingController(mgr manager.Manager, reconcileTriggerChan <-chan ev
 
 func enableMetricsController(mgr manager.Manager, reconcileTriggerChan <-chan event.GenericEvent) {
 	setupLog.Info("Starting with metrics controller")
+
 	var err error
 	var flowHealthProber *prober.OTelPipelineProber
 	if flowHealthProber, err = prober.NewMetricPipelineProber(types.NamespacedName{Name: selfMonitorName, Namespace: telemetryNamespace}); err != nil {
@@ -454,7 +546,13 @@ func enableMetricsController(mgr manager.Manager, reconcileTriggerChan <-chan ev
 		os.Exit(1)
 	}
 
-	if err := createMetricPipelineController(mgr.GetClient(), reconcileTriggerChan, flowHealthProber).SetupWithManager(mgr); err != nil {
+	var dataFlowStatsProber *prober.PipelineStatsProber
+	if dataFlowStatsProber, err = prober.NewMetricPipelineStatsProber(types.NamespacedName{Name: selfMonitorName, Namespace: telemetryNamespace}); err != nil {
+		setupLog.Error(err, "Failed to create data flow stats prober")
+		os.Exit(1)
+	}
+
+	if err := createMetricPipelineController(mgr.GetClient(), reconcileTriggerChan, flowHealthProber, dataFlowStatsProber).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "Failed to create controller", "controller", "MetricPipeline")
 		os.Exit(1)
 	}
@@ -476,6 +574,11 @@ func enableWebhookServer(mgr manager.Manager, webhookConfig telemetry.WebhookCon
 		os.Exit(1)
 	}
 	setupLog.Info("Ensured webhook cert")
+
+	mgr.GetWebhookServer().Register("/validate-logpipeline", &webhook.Admission{Handler: createLogPipelineValidator(mgr.GetClient())})
+	mgr.GetWebhookServer().Register("/validate-logparser", &webhook.Admission{Handler: createLogParserValidator(mgr.GetClient())})
+	mgr.GetWebhookServer().Register("/validate-tracepipeline", &webhook.Admission{Handler: createTracePipelineValidator()})
+	mgr.GetWebhookServer().Register("/validate-metricpipeline", &webhook.Admission{Handler: createMetricPipelineValidator()})
 }
 
 func setNamespaceFieldSelector() fields.Selector {
@@ -487,20 +590,26 @@ func validateFlags() error {
 	if logLevel != "debug" && logLevel != "info" && logLevel != "warn" && logLevel != "error" && logLevel != "fatal" {
 		return errors.New("--log-level has to be one of debug, info, warn, error, fatal")
 	}
+
+	if _, err := fluentbitconfig.ParseSize(fluentBitFsBufferLimit); err != nil {
+		return fmt.Errorf("--fluent-bit-filesystem-buffer-limit is invalid: %w", err)
+	}
 	return nil
 }
 
-func createLogPipelineController(client client.Client, reconcileTriggerChan <-chan event.GenericEvent, flowHealthProber *prober.LogPipelineProber) *telemetrycontrollers.LogPipelineController {
+func createLogPipelineController(client client.Client, reconcileTriggerChan <-chan event.GenericEvent, flowHealthProber *prober.LogPipelineProber, dataFlowStatsProber *prober.PipelineStatsProber,
+	gatewayFlowHealthProber *prober.OTelPipelineProber, gatewayDataFlowStatsProber *prober.PipelineStatsProber) *telemetrycontrollers.LogPipelineController {
 	config := logpipeline.Config{
-		SectionsConfigMap:     types.NamespacedName{Name: "telemetry-fluent-bit-sections", Namespace: telemetryNamespace},
-		FilesConfigMap:        types.NamespacedName{Name: "telemetry-fluent-bit-files", Namespace: telemetryNamespace},
-		LuaConfigMap:          types.NamespacedName{Name: "telemetry-fluent-bit-luascripts", Namespace: telemetryNamespace},
-		ParsersConfigMap:      types.NamespacedName{Name: "telemetry-fluent-bit-parsers", Namespace: telemetryNamespace},
-		EnvSecret:             types.NamespacedName{Name: "telemetry-fluent-bit-env", Namespace: telemetryNamespace},
-		OutputTLSConfigSecret: types.NamespacedName{Name: "telemetry-fluent-bit-output-tls-config", Namespace: telemetryNamespace},
-		DaemonSet:             types.NamespacedName{Name: fluentBitDaemonSet, Namespace: telemetryNamespace},
-		OverrideConfigMap:     types.NamespacedName{Name: overridesConfigMapName, Namespace: telemetryNamespace},
-		PipelineDefaults:      createPipelineDefaults(),
+		SectionsConfigMap:         types.NamespacedName{Name: "telemetry-fluent-bit-sections", Namespace: telemetryNamespace},
+		FilesConfigMap:            types.NamespacedName{Name: "telemetry-fluent-bit-files", Namespace: telemetryNamespace},
+		LuaConfigMap:              types.NamespacedName{Name: "telemetry-fluent-bit-luascripts", Namespace: telemetryNamespace},
+		ParsersConfigMap:          types.NamespacedName{Name: "telemetry-fluent-bit-parsers", Namespace: telemetryNamespace},
+		EnvSecret:                 types.NamespacedName{Name: "telemetry-fluent-bit-env", Namespace: telemetryNamespace},
+		OutputTLSConfigSecret:     types.NamespacedName{Name: "telemetry-fluent-bit-output-tls-config", Namespace: telemetryNamespace},
+		DaemonSet:                 types.NamespacedName{Name: fluentBitDaemonSet, Namespace: telemetryNamespace},
+		KubernetesEventsCollector: types.NamespacedName{Name: "telemetry-fluent-bit-events", Namespace: telemetryNamespace},
+		OverrideConfigMap:         types.NamespacedName{Name: overridesConfigMapName, Namespace: telemetryNamespace},
+		PipelineDefaults:          createPipelineDefaults(),
 		DaemonSetConfig: fluentbit.DaemonSetConfig{
 			FluentBitImage:              fluentBitImage,
 			FluentBitConfigPrepperImage: fluentBitConfigPrepperImageVersion,
@@ -511,7 +620,34 @@ func createLogPipelineController(client client.Client, reconcileTriggerChan <-ch
 			CPURequest:                  resource.MustParse(fluentBitCPURequest),
 			MemoryRequest:               resource.MustParse(fluentBitMemoryRequest),
 		},
+		KubernetesEventsCollectorConfig: fluentbit.KubernetesEventsConfig{
+			CPULimit:      resource.MustParse(fluentBitEventsCPULimit),
+			MemoryLimit:   resource.MustParse(fluentBitEventsMemoryLimit),
+			CPURequest:    resource.MustParse(fluentBitEventsCPURequest),
+			MemoryRequest: resource.MustParse(fluentBitEventsMemoryRequest),
+		},
+		Gateway: otelcollector.GatewayConfig{
+			Config: otelcollector.Config{
+				Namespace:               telemetryNamespace,
+				BaseName:                "telemetry-log-gateway",
+				ObserveBySelfMonitoring: enableSelfMonitor,
+			},
+			Deployment: otelcollector.DeploymentConfig{
+				Image:                logGatewayImage,
+				PriorityClassName:    logGatewayPriorityClass,
+				BaseCPULimit:         resource.MustParse(logGatewayCPULimit),
+				DynamicCPULimit:      resource.MustParse(logGatewayDynamicCPULimit),
+				BaseMemoryLimit:      resource.MustParse(logGatewayMemoryLimit),
+				DynamicMemoryLimit:   resource.MustParse(logGatewayDynamicMemoryLimit),
+				BaseCPURequest:       resource.MustParse(logGatewayCPURequest),
+				DynamicCPURequest:    resource.MustParse(logGatewayDynamicCPURequest),
+				BaseMemoryRequest:    resource.MustParse(logGatewayMemoryRequest),
+				DynamicMemoryRequest: resource.MustParse(logGatewayDynamicMemoryRequest),
+			},
+			OTLPServiceName: logOTLPServiceName,
+		},
 		ObserveBySelfMonitoring: enableSelfMonitor,
+		TapEndpoint:             createTapEndpoint(),
 	}
 
 	return telemetrycontrollers.NewLogPipelineController(
@@ -521,8 +657,12 @@ func createLogPipelineController(client client.Client, reconcileTriggerChan <-ch
 			client,
 			config,
 			&k8sutils.DaemonSetProber{Client: client},
+			&k8sutils.DeploymentProber{Client: client},
 			enableSelfMonitor,
 			flowHealthProber,
+			dataFlowStatsProber,
+			gatewayFlowHealthProber,
+			gatewayDataFlowStatsProber,
 			overridesHandler,
 		))
 }
@@ -564,12 +704,20 @@ func createLogParserValidator(client client.Client) *logparserwebhook.Validating
 		admission.NewDecoder(scheme))
 }
 
-func createTracePipelineController(client client.Client, reconcileTriggerChan <-chan event.GenericEvent, flowHealthProber *prober.OTelPipelineProber) *telemetrycontrollers.TracePipelineController {
+func createMetricPipelineValidator() *metricpipelinewebhook.ValidatingWebhookHandler {
+	return metricpipelinewebhook.NewValidatingWebhookHandler(admission.NewDecoder(scheme))
+}
+
+func createTracePipelineValidator() *tracepipelinewebhook.ValidatingWebhookHandler {
+	return tracepipelinewebhook.NewValidatingWebhookHandler(admission.NewDecoder(scheme))
+}
+
+func createTracePipelineController(client client.Client, reconcileTriggerChan <-chan event.GenericEvent, flowHealthProber *prober.OTelPipelineProber, dataFlowStatsProber *prober.PipelineStatsProber) *telemetrycontrollers.TracePipelineController {
 	config := tracepipeline.Config{
 		Gateway: otelcollector.GatewayConfig{
 			Config: otelcollector.Config{
 				Namespace:               telemetryNamespace,
-				BaseName:                "telemetry-trace-collector",
+				BaseName:                traceGatewayName,
 				ObserveBySelfMonitoring: enableSelfMonitor,
 			},
 			Deployment: otelcollector.DeploymentConfig{
@@ -584,10 +732,13 @@ func createTracePipelineController(client client.Client, reconcileTriggerChan <-
 				BaseMemoryRequest:    resource.MustParse(traceGatewayMemoryRequest),
 				DynamicMemoryRequest: resource.MustParse(traceGatewayDynamicMemoryRequest),
 			},
-			OTLPServiceName: traceOTLPServiceName,
+			OTLPServiceName:          traceOTLPServiceName,
+			LoadBalancingServiceName: traceLoadBalancingServiceName,
 		},
-		OverridesConfigMapName: types.NamespacedName{Name: overridesConfigMapName, Namespace: telemetryNamespace},
-		MaxPipelines:           maxTracePipelines,
+		OverridesConfigMapName:   types.NamespacedName{Name: overridesConfigMapName, Namespace: telemetryNamespace},
+		MaxPipelines:             maxTracePipelines,
+		MetricGatewayServiceName: types.NamespacedName{Name: metricOTLPServiceName, Namespace: telemetryNamespace},
+		TapEndpoint:              createTapEndpoint(),
 	}
 
 	return telemetrycontrollers.NewTracePipelineController(
@@ -596,19 +747,20 @@ func createTracePipelineController(client client.Client, reconcileTriggerChan <-
 		tracepipeline.NewReconciler(
 			client,
 			config,
-			&k8sutils.DeploymentProber{Client: client},
+			&k8sutils.GatewayProber{Client: client},
 			enableSelfMonitor,
 			flowHealthProber,
+			dataFlowStatsProber,
 			overridesHandler),
 	)
 }
 
-func createMetricPipelineController(client client.Client, reconcileTriggerChan <-chan event.GenericEvent, flowHealthProber *prober.OTelPipelineProber) *telemetrycontrollers.MetricPipelineController {
+func createMetricPipelineController(client client.Client, reconcileTriggerChan <-chan event.GenericEvent, flowHealthProber *prober.OTelPipelineProber, dataFlowStatsProber *prober.PipelineStatsProber) *telemetrycontrollers.MetricPipelineController {
 	config := metricpipeline.Config{
 		Agent: otelcollector.AgentConfig{
 			Config: otelcollector.Config{
 				Namespace:               telemetryNamespace,
-				BaseName:                "telemetry-metric-agent",
+				BaseName:                metricAgentName,
 				ObserveBySelfMonitoring: enableSelfMonitor,
 			},
 			DaemonSet: otelcollector.DaemonSetConfig{
@@ -620,10 +772,25 @@ func createMetricPipelineController(client client.Client, reconcileTriggerChan <
 				MemoryRequest:     resource.MustParse("50Mi"),
 			},
 		},
+		ClusterCollector: otelcollector.ClusterCollectorConfig{
+			Config: otelcollector.Config{
+				Namespace:               telemetryNamespace,
+				BaseName:                metricClusterName,
+				ObserveBySelfMonitoring: enableSelfMonitor,
+			},
+			Deployment: otelcollector.ClusterCollectorDeploymentConfig{
+				Image:             metricGatewayImage,
+				PriorityClassName: metricGatewayPriorityClass,
+				CPULimit:          resource.MustParse("500m"),
+				MemoryLimit:       resource.MustParse("500Mi"),
+				CPURequest:        resource.MustParse("15m"),
+				MemoryRequest:     resource.MustParse("50Mi"),
+			},
+		},
 		Gateway: otelcollector.GatewayConfig{
 			Config: otelcollector.Config{
 				Namespace:               telemetryNamespace,
-				BaseName:                "telemetry-metric-gateway",
+				BaseName:                metricGatewayName,
 				ObserveBySelfMonitoring: enableSelfMonitor,
 			},
 			Deployment: otelcollector.DeploymentConfig{
@@ -642,6 +809,7 @@ func createMetricPipelineController(client client.Client, reconcileTriggerChan <
 		},
 		OverridesConfigMapName: types.NamespacedName{Name: overridesConfigMapName, Namespace: telemetryNamespace},
 		MaxPipelines:           maxMetricPipelines,
+		TapEndpoint:            createTapEndpoint(),
 	}
 
 	return telemetrycontrollers.NewMetricPipelineController(
@@ -650,14 +818,18 @@ func createMetricPipelineController(client client.Client, reconcileTriggerChan <
 		metricpipeline.NewReconciler(
 			client,
 			config,
-			&k8sutils.DeploymentProber{Client: client},
+			&k8sutils.GatewayProber{Client: client},
 			&k8sutils.DaemonSetProber{Client: client},
 			enableSelfMonitor,
 			flowHealthProber,
+			dataFlowStatsProber,
 			overridesHandler))
 }
 
 func createSelfMonitoringConfig() telemetry.SelfMonitorConfig {
+	// The flag is validated by validateFlags
+	fluentBitFsBufferLimitBytes, _ := fluentbitconfig.ParseSize(fluentBitFsBufferLimit)
+
 	return telemetry.SelfMonitorConfig{
 		Enabled: enableSelfMonitor,
 		Config: selfmonitor.Config{
@@ -673,8 +845,25 @@ func createSelfMonitoringConfig() telemetry.SelfMonitorConfig {
 				MemoryRequest:     resource.MustParse(selfMonitorMemoryRequest),
 			},
 		},
-		WebhookScheme: "https",
-		WebhookURL:    fmt.Sprintf("%s.%s.svc", webhookServiceName, telemetryNamespace),
+		WebhookScheme:          "https",
+		WebhookURL:             fmt.Sprintf("%s.%s.svc", webhookServiceName, telemetryNamespace),
+		FluentBitFsBufferLimit: fluentBitFsBufferLimitBytes,
+	}
+}
+
+// createTapEndpoint returns the endpoint of the tap handler, which is served by the webhook server. Without the webhook server, the tap is disabled.
+func createTapEndpoint() tap.Endpoint {
+	if !enableWebhook {
+		return tap.Endpoint{}
+	}
+
+	return tap.Endpoint{
+		Host: fmt.Sprintf("%s.%s.svc", webhookServiceName, telemetryNamespace),
+		Port: webhookServicePort,
+		CASecretName: types.NamespacedName{
+			Name:      webhookCertSecret,
+			Namespace: telemetryNamespace,
+		},
 	}
 }
 
@@ -703,10 +892,17 @@ func createTelemetryController(client client.Client, scheme *runtime.Scheme, web
 		Traces: telemetry.TracesConfig{
 			OTLPServiceName: traceOTLPServiceName,
 			Namespace:       telemetryNamespace,
+			GatewayName:     traceGatewayName,
 		},
 		Metrics: telemetry.MetricsConfig{
 			OTLPServiceName: metricOTLPServiceName,
 			Namespace:       telemetryNamespace,
+			GatewayName:     metricGatewayName,
+			AgentName:       metricAgentName,
+		},
+		Logs: telemetry.LogsConfig{
+			Namespace:     telemetryNamespace,
+			FluentBitName: fluentBitDaemonSet,
 		},
 		Webhook:                webhookConfig,
 		OverridesConfigMapName: types.NamespacedName{Name: overridesConfigMapName, Namespace: telemetryNamespace},
@@ -726,7 +922,7 @@ func createWebhookConfig() telemetry.WebhookConfig {
 				Namespace: telemetryNamespace,
 			},
 			CASecretName: types.NamespacedName{
-				Name:      "telemetry-webhook-cert",
+				Name:      webhookCertSecret,
 				Namespace: telemetryNamespace,
 			},
 			WebhookName: types.NamespacedName{
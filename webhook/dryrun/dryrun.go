@@ -14,6 +14,8 @@ import (
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/internal/fluentbit/config/builder"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/log/gateway"
+	"github.com/kyma-project/telemetry-manager/internal/secretref"
 )
 
 func dryRunArgs() []string {
@@ -30,6 +32,7 @@ type Config struct {
 }
 
 type DryRunner struct {
+	client        client.Reader
 	fileWriter    fileWriter
 	commandRunner commandRunner
 	config        Config
@@ -37,6 +40,7 @@ type DryRunner struct {
 
 func NewDryRunner(c client.Client, config Config) *DryRunner {
 	return &DryRunner{
+		client:        c,
 		fileWriter:    &fileWriterImpl{client: c, config: config},
 		commandRunner: &commandRunnerImpl{},
 		config:        config,
@@ -57,6 +61,10 @@ func (d *DryRunner) RunParser(ctx context.Context, parser *telemetryv1alpha1.Log
 }
 
 func (d *DryRunner) RunPipeline(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) error {
+	if pipeline.Spec.Output.IsOtlpDefined() {
+		return d.runGatewayPipeline(ctx, pipeline)
+	}
+
 	workDir := newWorkDirPath()
 	cleanup, err := d.fileWriter.PreparePipelineDryRun(ctx, workDir, pipeline)
 	if err != nil {
@@ -71,6 +79,21 @@ func (d *DryRunner) RunPipeline(ctx context.Context, pipeline *telemetryv1alpha1
 	return d.runCmd(ctx, args)
 }
 
+// runGatewayPipeline validates a pipeline with OTLP output, which is rendered into the log gateway configuration instead of Fluent Bit.
+// The OTel Collector binary is not part of the manager image, so the configuration is rendered, but not run.
+// Pipelines referencing missing Secrets are accepted, because the Secrets can be created later, and the reconciler reports them in the status.
+func (d *DryRunner) runGatewayPipeline(ctx context.Context, pipeline *telemetryv1alpha1.LogPipeline) error {
+	if secretref.ReferencesNonExistentSecret(ctx, d.client, pipeline) {
+		return nil
+	}
+
+	if _, _, err := gateway.MakeConfig(ctx, d.client, []telemetryv1alpha1.LogPipeline{*pipeline}); err != nil {
+		return fmt.Errorf("error validating the supplied configuration: %w", err)
+	}
+
+	return nil
+}
+
 func (d *DryRunner) runCmd(ctx context.Context, args []string) error {
 	outBytes, err := d.commandRunner.Run(ctx, fluentBitPath, args...)
 	out := string(outBytes)
@@ -8,6 +8,9 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
 	"github.com/kyma-project/telemetry-manager/webhook/dryrun/mocks"
@@ -188,3 +191,65 @@ func TestDryRunner_RunParser(t *testing.T) {
 		})
 	}
 }
+
+func TestDryRunner_RunPipelineWithOTLPOutput(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+		Data:       map[string][]byte{"endpoint": []byte("https://backend:4317")},
+	}
+
+	testCases := []struct {
+		name        string
+		endpoint    telemetryv1alpha1.ValueType
+		expectedErr string
+	}{
+		{
+			name:     "endpoint value",
+			endpoint: telemetryv1alpha1.ValueType{Value: "https://backend:4317"},
+		},
+		{
+			name: "endpoint from existing secret",
+			endpoint: telemetryv1alpha1.ValueType{ValueFrom: &telemetryv1alpha1.ValueFromSource{
+				SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{Name: "backend", Namespace: "default", Key: "endpoint"},
+			}},
+		},
+		{
+			name: "endpoint from missing secret is validated by the reconciler",
+			endpoint: telemetryv1alpha1.ValueType{ValueFrom: &telemetryv1alpha1.ValueFromSource{
+				SecretKeyRef: &telemetryv1alpha1.SecretKeyRef{Name: "missing", Namespace: "default", Key: "endpoint"},
+			}},
+		},
+		{
+			name:        "empty endpoint",
+			expectedErr: "error validating the supplied configuration",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockFileWriter := &mocks.FileWriter{}
+			mockCommandRunner := &mocks.CommandRunner{}
+			dryRunner := &DryRunner{
+				client:        fake.NewClientBuilder().WithObjects(secret).Build(),
+				fileWriter:    mockFileWriter,
+				commandRunner: mockCommandRunner,
+			}
+
+			pipeline := &telemetryv1alpha1.LogPipeline{
+				ObjectMeta: metav1.ObjectMeta{Name: "otlp"},
+				Spec: telemetryv1alpha1.LogPipelineSpec{
+					Output: telemetryv1alpha1.Output{Otlp: &telemetryv1alpha1.OtlpOutput{Endpoint: tc.endpoint}},
+				},
+			}
+
+			err := dryRunner.RunPipeline(context.Background(), pipeline)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			mockFileWriter.AssertNotCalled(t, "PreparePipelineDryRun", mock.Anything, mock.Anything, mock.Anything)
+			mockCommandRunner.AssertNotCalled(t, "Run", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -142,7 +142,7 @@ func (v *ValidatingWebhookHandler) validateLogPipeline(ctx context.Context, logP
 	}
 
 	if err := v.dryRunner.RunPipeline(ctx, logPipeline); err != nil {
-		log.Error(err, "Failed to validate Fluent Bit config")
+		log.Error(err, "Failed to validate pipeline config")
 		return err
 	}
 
@@ -0,0 +1,80 @@
+package metricpipeline
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	logpipelinewebhook "github.com/kyma-project/telemetry-manager/webhook/logpipeline"
+)
+
+// +kubebuilder:webhook:path=/validate-metricpipeline,mutating=false,failurePolicy=fail,sideEffects=None,groups=telemetry.kyma-project.io,resources=metricpipelines,verbs=create;update,versions=v1alpha1,name=vmetricpipeline.kb.io,admissionReviewVersions=v1
+type ValidatingWebhookHandler struct {
+	decoder admission.Decoder
+}
+
+func NewValidatingWebhookHandler(decoder admission.Decoder) *ValidatingWebhookHandler {
+	return &ValidatingWebhookHandler{
+		decoder: decoder,
+	}
+}
+
+func (v *ValidatingWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := logf.FromContext(ctx)
+
+	metricPipeline := &telemetryv1alpha1.MetricPipeline{}
+	if err := v.decoder.Decode(req, metricPipeline); err != nil {
+		log.Error(err, "Failed to decode MetricPipeline")
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := validateFilters(metricPipeline.Spec.Filters); err != nil {
+		log.Error(err, "MetricPipeline rejected")
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Code:    int32(http.StatusForbidden),
+					Reason:  logpipelinewebhook.StatusReasonConfigurationError,
+					Message: err.Error(),
+				},
+			},
+		}
+	}
+	return admission.Allowed("MetricPipeline validation successful")
+}
+
+func validateFilters(filters *telemetryv1alpha1.MetricPipelineFilters) error {
+	if filters == nil {
+		return nil
+	}
+
+	for i, pattern := range filters.Include {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regular expression in include filter %d: %w", i, err)
+		}
+	}
+
+	for i, pattern := range filters.Exclude {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regular expression in exclude filter %d: %w", i, err)
+		}
+	}
+
+	for i, filter := range filters.DataPoints {
+		if filter.Regex != "" {
+			if _, err := regexp.Compile(filter.Regex); err != nil {
+				return fmt.Errorf("invalid regular expression in data point filter %d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
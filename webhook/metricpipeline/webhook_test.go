@@ -0,0 +1,86 @@
+package metricpipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, telemetryv1alpha1.AddToScheme(scheme))
+
+	sut := NewValidatingWebhookHandler(admission.NewDecoder(scheme))
+
+	tests := []struct {
+		name     string
+		pipeline telemetryv1alpha1.MetricPipeline
+		allowed  bool
+	}{
+		{
+			name:     "no filters",
+			pipeline: testutils.NewMetricPipelineBuilder().Build(),
+			allowed:  true,
+		},
+		{
+			name: "valid filters",
+			pipeline: testutils.NewMetricPipelineBuilder().
+				WithIncludeMetrics("http_server_.*").
+				WithExcludeMetrics(".*_bucket").
+				WithDataPointFilter(telemetryv1alpha1.MetricPipelineAttributeFilter{Key: "http.route", Regex: "/internal/.*"}).
+				Build(),
+			allowed: true,
+		},
+		{
+			name: "invalid include pattern",
+			pipeline: testutils.NewMetricPipelineBuilder().
+				WithIncludeMetrics("(http_server").
+				Build(),
+			allowed: false,
+		},
+		{
+			name: "invalid exclude pattern",
+			pipeline: testutils.NewMetricPipelineBuilder().
+				WithExcludeMetrics("*_bucket").
+				Build(),
+			allowed: false,
+		},
+		{
+			name: "invalid data point regex",
+			pipeline: testutils.NewMetricPipelineBuilder().
+				WithDataPointFilter(telemetryv1alpha1.MetricPipelineAttributeFilter{Key: "http.route", Regex: "[internal"}).
+				Build(),
+			allowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.pipeline)
+			require.NoError(t, err)
+
+			response := sut.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+
+			require.Equal(t, tt.allowed, response.Allowed)
+			if !tt.allowed {
+				require.Equal(t, int32(http.StatusForbidden), response.Result.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package tracepipeline
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/otelcollector/config/trace/gateway"
+	logpipelinewebhook "github.com/kyma-project/telemetry-manager/webhook/logpipeline"
+)
+
+// +kubebuilder:webhook:path=/validate-tracepipeline,mutating=false,failurePolicy=fail,sideEffects=None,groups=telemetry.kyma-project.io,resources=tracepipelines,verbs=create;update,versions=v1alpha1,name=vtracepipeline.kb.io,admissionReviewVersions=v1
+type ValidatingWebhookHandler struct {
+	decoder admission.Decoder
+}
+
+func NewValidatingWebhookHandler(decoder admission.Decoder) *ValidatingWebhookHandler {
+	return &ValidatingWebhookHandler{
+		decoder: decoder,
+	}
+}
+
+func (v *ValidatingWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := logf.FromContext(ctx)
+
+	tracePipeline := &telemetryv1alpha1.TracePipeline{}
+	if err := v.decoder.Decode(req, tracePipeline); err != nil {
+		log.Error(err, "Failed to decode TracePipeline")
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := gateway.ValidateFilters(tracePipeline.Spec.Filters); err != nil {
+		log.Error(err, "TracePipeline rejected")
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Code:    int32(http.StatusForbidden),
+					Reason:  logpipelinewebhook.StatusReasonConfigurationError,
+					Message: err.Error(),
+				},
+			},
+		}
+	}
+	return admission.Allowed("TracePipeline validation successful")
+}
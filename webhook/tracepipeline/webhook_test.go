@@ -0,0 +1,92 @@
+package tracepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	telemetryv1alpha1 "github.com/kyma-project/telemetry-manager/apis/telemetry/v1alpha1"
+	"github.com/kyma-project/telemetry-manager/internal/testutils"
+)
+
+func TestHandle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, telemetryv1alpha1.AddToScheme(scheme))
+
+	sut := NewValidatingWebhookHandler(admission.NewDecoder(scheme))
+
+	tests := []struct {
+		name     string
+		pipeline telemetryv1alpha1.TracePipeline
+		allowed  bool
+	}{
+		{
+			name:     "no filters",
+			pipeline: testutils.NewTracePipelineBuilder().Build(),
+			allowed:  true,
+		},
+		{
+			name: "valid filters",
+			pipeline: testutils.NewTracePipelineBuilder().
+				WithFilterCondition(`attributes["http.route"] == "/healthz"`).
+				WithAttributeFilter(telemetryv1alpha1.TracePipelineAttributeFilter{Key: "http.url", Regex: ".*/ready"}).
+				Build(),
+			allowed: true,
+		},
+		{
+			name: "invalid condition",
+			pipeline: testutils.NewTracePipelineBuilder().
+				WithFilterCondition(`attributes["http.route"] == "/healthz`).
+				Build(),
+			allowed: false,
+		},
+		{
+			name: "condition without an expression",
+			pipeline: testutils.NewTracePipelineBuilder().
+				WithFilterCondition(`foo bar baz`).
+				Build(),
+			allowed: false,
+		},
+		{
+			name: "incomplete condition",
+			pipeline: testutils.NewTracePipelineBuilder().
+				WithFilterCondition(`attributes["x"] ==`).
+				Build(),
+			allowed: false,
+		},
+		{
+			name: "invalid regex",
+			pipeline: testutils.NewTracePipelineBuilder().
+				WithAttributeFilter(telemetryv1alpha1.TracePipelineAttributeFilter{Key: "http.url", Regex: "(ready"}).
+				Build(),
+			allowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.pipeline)
+			require.NoError(t, err)
+
+			response := sut.Handle(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+
+			require.Equal(t, tt.allowed, response.Allowed)
+			if !tt.allowed {
+				require.Equal(t, int32(http.StatusForbidden), response.Result.Code)
+			}
+		})
+	}
+}